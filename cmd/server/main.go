@@ -1,14 +1,24 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"log"
+	"os"
 	"time"
 
 	"github.com/sukryu/pAuth/internal/config"
+	"github.com/sukryu/pAuth/internal/store/dynamic"
+	"github.com/sukryu/pAuth/internal/store/factory"
+	"github.com/sukryu/pAuth/internal/store/manager"
+	"github.com/sukryu/pAuth/internal/store/schema"
 	"github.com/sukryu/pAuth/pkg/apis/handlers"
 	"github.com/sukryu/pAuth/pkg/apis/router"
 	"github.com/sukryu/pAuth/pkg/controllers"
+	"github.com/sukryu/pAuth/pkg/events"
+	"github.com/sukryu/pAuth/pkg/mail"
+	"github.com/sukryu/pAuth/pkg/middleware"
 	"github.com/sukryu/pAuth/pkg/utils/jwt"
 )
 
@@ -19,24 +29,194 @@ func main() {
 		log.Fatalf("Failed to load config: %v", err)
 	}
 
-	// 스토어 초기화
-	store := memory.NewMemoryStore()
+	// core 테이블 마이그레이션. 테이블이 암묵적으로 생성되기를 기대하지 않음
+	storeFactory := factory.NewStoreFactory(&manager.SQLManagerFactory{})
+	defer storeFactory.Close()
+	dynStore, err := storeFactory.NewDynamicStore(&cfg.Database)
+	if err != nil {
+		log.Fatalf("Failed to open database: %v", err)
+	}
+	if err := dynStore.RunCoreMigrations(context.Background()); err != nil {
+		log.Fatalf("Failed to run core migrations: %v", err)
+	}
+
+	// 스토어 초기화. 모든 리소스 store가 같은 DB를 사용하므로 컨트롤러의 트랜잭션이 실제로 롤백됨
+	store, err := storeFactory.NewStore(&cfg.Database)
+	if err != nil {
+		log.Fatalf("Failed to create store: %v", err)
+	}
+
+	// 변경 이벤트 버스 (users:watch SSE 스트림)
+	eventBus := events.NewMemoryBus(0)
+
+	// 토큰 폐기, 요청 제한, Idempotency-Key, 권한 판정 캐시가 공유하는 cache.
+	// 여러 인스턴스를 운영하면 redis를 사용해야 상태가 공유됨
+	sharedCache, err := storeFactory.NewCache(&cfg.Cache)
+	if err != nil {
+		log.Fatalf("Failed to create cache: %v", err)
+	}
+	accessCache := sharedCache
+	if cfg.Cache.AccessTTLSeconds < 0 {
+		accessCache = nil
+	}
+	accessCacheTTL := time.Duration(cfg.Cache.AccessTTLSeconds) * time.Second
+
+	loginIdentifiers := make([]controllers.LoginIdentifier, 0, len(cfg.Auth.LoginIdentifiers))
+	for _, identifier := range cfg.Auth.LoginIdentifiers {
+		switch id := controllers.LoginIdentifier(identifier); id {
+		case controllers.LoginByName, controllers.LoginByEmail:
+			loginIdentifiers = append(loginIdentifiers, id)
+		default:
+			log.Fatalf("Invalid login identifier: %q", identifier)
+		}
+	}
 
 	// 컨트롤러 초기화
-	authController := controllers.NewAuthController(store)
-	rbacController := controllers.NewRBACController(store)
+	namePolicy := controllers.NamePolicy{
+		MaxLength:      cfg.Auth.Names.MaxLength,
+		AllowUppercase: cfg.Auth.Names.AllowUppercase,
+	}
+	// 이메일 확인 토큰 발송. 미설정 상태로 가입 확인을 요구하면 모든 가입이 실패하므로 시작 시 거부
+	var emailSender controllers.EmailChangeSender
+	if cfg.Email.SMTPHost != "" {
+		sender, err := mail.NewSMTPSender(mail.SMTPConfig{
+			Host:      cfg.Email.SMTPHost,
+			Port:      cfg.Email.SMTPPort,
+			Username:  cfg.Email.Username,
+			Password:  cfg.Email.Password,
+			From:      cfg.Email.From,
+			VerifyURL: cfg.Email.VerifyURL,
+		})
+		if err != nil {
+			log.Fatalf("Invalid email config: %v", err)
+		}
+		emailSender = sender
+	}
+	if cfg.Auth.RequireEmailVerification && emailSender == nil {
+		log.Fatalf("auth.requireEmailVerification requires email.smtpHost to be configured")
+	}
+
+	authController := controllers.NewAuthControllerWithConfig(store, controllers.AuthControllerConfig{
+		LoginHistoryLimit:   cfg.Auth.LoginHistoryLimit,
+		LoginIdentifiers:    loginIdentifiers,
+		PasswordHistorySize: cfg.Auth.PasswordHistorySize,
+		DefaultRoles:        cfg.Auth.DefaultRoles,
+
+		AllowSelfRegistration:    cfg.Auth.AllowSelfRegistration,
+		RequireEmailVerification: cfg.Auth.RequireEmailVerification,
+		EmailChangeSender:        emailSender,
+		MinPasswordLength:        cfg.Auth.MinPasswordLength,
+
+		AllowRemovingLastAdmin: cfg.Auth.AllowRemovingLastAdmin,
+		Names:                  namePolicy,
+		MaxSubjectsPerBinding:  cfg.Auth.MaxSubjectsPerBinding,
+		Events:                 eventBus,
+		AccessCache:            accessCache,
+	})
+	rbacController := controllers.NewRBACControllerWithConfig(store, controllers.RBACControllerConfig{
+		AllowRemovingLastAdmin: cfg.Auth.AllowRemovingLastAdmin,
+		AllowedVerbs:           cfg.Auth.AllowedVerbs,
+		AllowUnknownVerbs:      cfg.Auth.AllowUnknownVerbs,
+		MaxSubjectsPerBinding:  cfg.Auth.MaxSubjectsPerBinding,
+		Names:                  namePolicy,
+		Events:                 eventBus,
+		AccessCache:            accessCache,
+		AccessCacheTTL:         accessCacheTTL,
+	})
 
 	// JWT 매니저 초기화
-	jwtManager := jwt.NewJWTManager(
-		cfg.Auth.JWTSecret,
-		time.Duration(cfg.Auth.TokenExpiration)*time.Hour,
-	)
+	previousKeys := make([]jwt.SigningKey, 0, len(cfg.Auth.PreviousJWTSecrets))
+	for _, prev := range cfg.Auth.PreviousJWTSecrets {
+		key := jwt.SigningKey{ID: prev.KeyID, Secret: prev.Secret}
+		if prev.Sunset != "" {
+			if key.Sunset, err = time.Parse(time.RFC3339, prev.Sunset); err != nil {
+				log.Fatalf("Invalid sunset for previous JWT secret: %v", err)
+			}
+		}
+		previousKeys = append(previousKeys, key)
+	}
+	jwtManager := jwt.NewJWTManagerWithConfig(jwt.Config{
+		SecretKey: cfg.Auth.JWTSecret,
+		Expiry:    time.Duration(cfg.Auth.TokenExpiration) * time.Hour,
+		Issuer:    cfg.Auth.Issuer,
+		Audience:  cfg.Auth.Audience,
+		Leeway:    time.Duration(cfg.Auth.ClockSkewSeconds) * time.Second,
+
+		KeyID:        cfg.Auth.JWTKeyID,
+		PreviousKeys: previousKeys,
+
+		RefreshExpiry:    time.Duration(cfg.Auth.RefreshTokenExpiration) * time.Hour,
+		RememberMeExpiry: time.Duration(cfg.Auth.RememberMeExpiration) * time.Hour,
+
+		Revocations: sharedCache,
+	})
 
 	// 핸들러 초기화
 	authHandler := handlers.NewAuthHandler(authController, jwtManager, rbacController)
+	authHandler.SetEventBus(eventBus)
+	authHandler.SetPaginationConfig(handlers.PaginationConfig{
+		DefaultPageSize: cfg.Server.DefaultPageSize,
+		MaxPageSize:     cfg.Server.MaxPageSize,
+		Envelope:        cfg.Server.ListEnvelope,
+	})
+
+	// 동적 엔티티 API는 스키마 파일이 설정된 경우에만 노출
+	var dynamicHandler *handlers.DynamicHandler
+	if cfg.Dynamic.SchemaFile != "" {
+		schemas, err := loadDynamicSchemas(context.Background(), dynStore, cfg.Dynamic.SchemaFile)
+		if err != nil {
+			log.Fatalf("Failed to load dynamic schemas: %v", err)
+		}
+		dynamicHandler = handlers.NewDynamicHandler(dynStore, schemas...)
+		dynamicHandler.SetPaginationConfig(handlers.PaginationConfig{
+			DefaultPageSize: cfg.Server.DefaultPageSize,
+			MaxPageSize:     cfg.Server.MaxPageSize,
+			Envelope:        cfg.Server.ListEnvelope,
+		})
+	}
+
+	// 경로별 CORS 정책은 설정된 것만으로 활성화 (origin 목록이 비어 있으면 모두 거부)
+	defaultCORS := corsConfig(cfg.Server.CORS.Enabled, cfg.Server.CORS.CORSPolicy)
+	if err := defaultCORS.Validate(); err != nil {
+		log.Fatalf("Invalid CORS config: %v", err)
+	}
+	corsRoutes := make([]middleware.CORSRoute, 0, len(cfg.Server.CORS.Routes))
+	for _, route := range cfg.Server.CORS.Routes {
+		routeCORS := corsConfig(true, route.CORSPolicy)
+		if err := routeCORS.Validate(); err != nil {
+			log.Fatalf("Invalid CORS config for %s: %v", route.PathPrefix, err)
+		}
+		corsRoutes = append(corsRoutes, middleware.CORSRoute{
+			PathPrefix: route.PathPrefix,
+			Config:     routeCORS,
+		})
+	}
 
 	// 라우터 초기화
-	r := router.NewRouter(authHandler, jwtManager, rbacController)
+	r := router.NewRouterWithConfig(authHandler, jwtManager, rbacController, router.Config{
+		CSRF: middleware.CSRFConfig{
+			Enabled:           cfg.Server.CSRF.Enabled,
+			CookieName:        cfg.Server.CSRF.CookieName,
+			HeaderName:        cfg.Server.CSRF.HeaderName,
+			SessionCookieName: cfg.Server.CSRF.SessionCookieName,
+			Secure:            cfg.Server.CSRF.Secure,
+		},
+		Gzip: middleware.GzipConfig{
+			Enabled: cfg.Server.Gzip.Enabled,
+			MinSize: cfg.Server.Gzip.MinSize,
+		},
+		CORS:       defaultCORS,
+		CORSRoutes: corsRoutes,
+		Idempotency: middleware.IdempotencyConfig{
+			Cache: sharedCache,
+		},
+		RateLimit: middleware.RateLimitConfig{
+			Cache:    sharedCache,
+			Requests: cfg.Server.RateLimit.Requests,
+			Window:   time.Duration(cfg.Server.RateLimit.WindowSeconds) * time.Second,
+		},
+		Dynamic: dynamicHandler,
+	})
 	engine := r.Setup()
 
 	// 서버 시작
@@ -45,3 +225,49 @@ func main() {
 		log.Fatalf("Failed to start server: %v", err)
 	}
 }
+
+// corsConfig converts a configured CORS policy to the middleware config
+func corsConfig(enabled bool, policy config.CORSPolicy) middleware.CORSConfig {
+	return middleware.CORSConfig{
+		Enabled:          enabled,
+		AllowedOrigins:   policy.AllowedOrigins,
+		AllowedMethods:   policy.AllowedMethods,
+		AllowedHeaders:   policy.AllowedHeaders,
+		AllowCredentials: policy.AllowCredentials,
+		MaxAge:           time.Duration(policy.MaxAgeSeconds) * time.Second,
+	}
+}
+
+// loadDynamicSchemas reads the entity schemas exposed by the dynamic API and creates their missing tables.
+// core 테이블은 전용 API와 RBAC 리소스를 가지므로 거부
+func loadDynamicSchemas(ctx context.Context, store *dynamic.DynamicStore, path string) ([]schema.EntitySchema, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var schemas []schema.EntitySchema
+	if err := json.Unmarshal(data, &schemas); err != nil {
+		return nil, fmt.Errorf("invalid schema file %s: %w", path, err)
+	}
+
+	core := make(map[string]bool, len(schema.CoreSchemas))
+	for _, s := range schema.CoreSchemas {
+		core[s.Name] = true
+	}
+	for _, s := range schemas {
+		if core[s.Name] {
+			return nil, fmt.Errorf("core table %q cannot be exposed as a dynamic entity", s.Name)
+		}
+		exists, err := store.TableExists(ctx, s.Name)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			continue
+		}
+		if err := store.CreateDynamicTable(ctx, s.Name, s.TableOptions()); err != nil {
+			return nil, fmt.Errorf("failed to create table %s: %w", s.Name, err)
+		}
+	}
+	return schemas, nil
+}
@@ -2,14 +2,19 @@ package config
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/spf13/viper"
+	"github.com/sukryu/pAuth/internal/store/schema"
 )
 
 type Config struct {
 	Database DatabaseConfig `mapstructure:"database"`
 	Server   ServerConfig   `mapstructure:"server"`
 	Auth     AuthConfig     `mapstructure:"auth"`
+	Cache    CacheConfig    `mapstructure:"cache"`
+	Email    EmailConfig    `mapstructure:"email"`
+	Dynamic  DynamicConfig  `mapstructure:"dynamic"`
 }
 
 type DatabaseConfig struct {
@@ -20,16 +25,199 @@ type DatabaseConfig struct {
 	Username string `mapstructure:"username"`
 	Password string `mapstructure:"password"`
 	SSLMode  string `mapstructure:"sslmode"`
+
+	// ReadReplicas are DSNs of read-only replicas. 조회(Get/List/Find/Count)는 replica로, 쓰기는 위 primary로 보냄.
+	// 비어 있으면 모든 쿼리가 primary를 사용. replication 지연 동안에는 방금 쓴 데이터가 보이지 않을 수 있음
+	ReadReplicas []string `mapstructure:"readReplicas"`
+
+	// TablePrefix is prepended to every pAuth table (e.g. "pauth_") when the database is shared
+	TablePrefix string `mapstructure:"tablePrefix"`
+
+	// role_rules 테이블에 역할 규칙을 정규화해 저장 (FindByVerb 등 정확 일치 조회)
+	NormalizedRoleRules bool `mapstructure:"normalizedRoleRules"`
+
+	// users 테이블에 추가할 배포별 컬럼 (예: employee_id). 값은 사용자 profile의 같은 키로 노출
+	UserExtraFields []schema.FieldDef `mapstructure:"userExtraFields"`
+	// 중복을 허용하지 않을 users 컬럼 (예: phone). core 컬럼 또는 userExtraFields 이름
+	UserUniqueFields []string `mapstructure:"userUniqueFields"`
+
+	// Connection pool 설정 (0이면 드라이버 기본값)
+	MaxOpenConns           int `mapstructure:"maxOpenConns"`
+	MaxIdleConns           int `mapstructure:"maxIdleConns"`
+	ConnMaxLifetimeSeconds int `mapstructure:"connMaxLifetimeSeconds"`
+
+	// SQLite busy_timeout (ms). 0이면 드라이버 기본값
+	BusyTimeoutMs int `mapstructure:"busyTimeoutMs"`
+
+	// SQLite PRAGMA 설정. 트레이드오프는 manager.Config 참고
+	JournalMode string `mapstructure:"journalMode"` // "WAL", "DELETE", ...
+	Synchronous string `mapstructure:"synchronous"` // "NORMAL", "FULL", ...
+	ForeignKeys bool   `mapstructure:"foreignKeys"`
+
+	// 이 시간(ms)보다 오래 걸린 쿼리를 로그로 남김. 0이면 비활성화
+	SlowQueryThresholdMs int `mapstructure:"slowQueryThresholdMs"`
+
+	// NOT NULL/UNIQUE/CHECK 제약 위반을 필드 단위 ValidationError로 반환 (기본값은 원본 DB 에러)
+	ConstraintErrors bool `mapstructure:"constraintErrors"`
+
+	// 소프트 삭제된 행의 보존 기간 (예: "720h"). 0이면 영구 보존
+	RetentionPeriod time.Duration `mapstructure:"retentionPeriod"`
+	// purge 실행 주기. 0이면 dynamic.DefaultPurgeInterval
+	PurgeInterval time.Duration `mapstructure:"purgeInterval"`
 }
 
 type ServerConfig struct {
 	Host string `mapstructure:"host"`
 	Port int    `mapstructure:"port"`
+
+	// 목록 API 페이지 크기. 0이면 handlers.DefaultPageSize / handlers.DefaultMaxPageSize
+	DefaultPageSize int `mapstructure:"defaultPageSize"`
+	MaxPageSize     int `mapstructure:"maxPageSize"`
+	// 목록 응답을 {items, metadata}로 감쌈. false이면 Accept: application/vnd.pauth.list+json 요청에만 적용
+	ListEnvelope bool `mapstructure:"listEnvelope"`
+
+	CSRF CSRFConfig `mapstructure:"csrf"`
+	Gzip GzipConfig `mapstructure:"gzip"`
+	CORS CORSConfig `mapstructure:"cors"`
+
+	// 로그인/토큰 갱신/가입 요청 제한. 카운터는 cache에 저장됨
+	RateLimit RateLimitConfig `mapstructure:"rateLimit"`
+}
+
+// RateLimitConfig limits public authentication requests per client IP
+type RateLimitConfig struct {
+	// window당 허용 요청 수. 0이면 비활성화
+	Requests int `mapstructure:"requests"`
+	// 0이면 60초
+	WindowSeconds int `mapstructure:"windowSeconds"`
+}
+
+// CORSConfig is the default cross-origin policy plus per path prefix overrides
+type CORSConfig struct {
+	Enabled    bool `mapstructure:"enabled"`
+	CORSPolicy `mapstructure:",squash"`
+
+	// 경로 prefix별 정책 (예: 관리자 경로는 내부 origin만). 가장 긴 prefix가 적용됨
+	Routes []CORSRouteConfig `mapstructure:"routes"`
+}
+
+// CORSPolicy lists what cross-origin callers may do
+type CORSPolicy struct {
+	AllowedOrigins   []string `mapstructure:"allowedOrigins"`   // "*"이면 모든 origin
+	AllowedMethods   []string `mapstructure:"allowedMethods"`   // 비어 있으면 middleware.DefaultCORSMethods
+	AllowedHeaders   []string `mapstructure:"allowedHeaders"`   // 비어 있으면 middleware.DefaultCORSHeaders
+	AllowCredentials bool     `mapstructure:"allowCredentials"` // allowedOrigins의 "*"와 함께 쓸 수 없음
+	MaxAgeSeconds    int      `mapstructure:"maxAgeSeconds"`
+}
+
+// CORSRouteConfig overrides the CORS policy for paths under PathPrefix
+type CORSRouteConfig struct {
+	PathPrefix string `mapstructure:"pathPrefix"`
+	CORSPolicy `mapstructure:",squash"`
+}
+
+// GzipConfig enables gzip compression of responses
+type GzipConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// 이보다 작은 응답은 압축하지 않음. 0이면 middleware.DefaultGzipMinSize
+	MinSize int `mapstructure:"minSize"`
+}
+
+// CSRFConfig enables double-submit CSRF protection for cookie-based sessions
+type CSRFConfig struct {
+	Enabled           bool   `mapstructure:"enabled"`
+	CookieName        string `mapstructure:"cookieName"`
+	HeaderName        string `mapstructure:"headerName"`
+	SessionCookieName string `mapstructure:"sessionCookieName"`
+	Secure            bool   `mapstructure:"secure"`
 }
 
 type AuthConfig struct {
-	JWTSecret       string `mapstructure:"jwtSecret"`
-	TokenExpiration int    `mapstructure:"tokenExpiration"`
+	JWTSecret         string `mapstructure:"jwtSecret"`
+	TokenExpiration   int    `mapstructure:"tokenExpiration"`
+	LoginHistoryLimit int    `mapstructure:"loginHistoryLimit"`
+	Issuer            string `mapstructure:"issuer"`
+	Audience          string `mapstructure:"audience"`
+	ClockSkewSeconds  int    `mapstructure:"clockSkewSeconds"`
+
+	// 발급 토큰의 kid 헤더. secret 교체 시 함께 바꾸면 검증할 키를 바로 찾을 수 있음
+	JWTKeyID string `mapstructure:"jwtKeyId"`
+	// 교체 전 secret 목록. sunset까지 이 secret으로 서명된 토큰도 허용
+	PreviousJWTSecrets []PreviousJWTSecret `mapstructure:"previousJwtSecrets"`
+
+	// 로그인 시 발급하는 refresh token 만료 시간 (hours). 0이면 refresh token 비활성화
+	RefreshTokenExpiration int `mapstructure:"refreshTokenExpiration"`
+	// rememberMe 로그인의 refresh token 만료 시간 (hours). 0이면 refreshTokenExpiration
+	RememberMeExpiration int `mapstructure:"rememberMeExpiration"`
+
+	// DefaultRoles are assigned to users created without any roles
+	DefaultRoles []string `mapstructure:"defaultRoles"`
+
+	// 마지막 admin(*/*/*) 사용자/바인딩 삭제 허용. 장애 복구 시에만 true로 설정
+	AllowRemovingLastAdmin bool `mapstructure:"allowRemovingLastAdmin"`
+
+	// 역할 rule에 허용되는 verb 목록. 비어 있으면 get,list,create,update,delete,watch,*
+	AllowedVerbs []string `mapstructure:"allowedVerbs"`
+	// true이면 목록에 없는 verb도 허용 (strict=false)
+	AllowUnknownVerbs bool `mapstructure:"allowUnknownVerbs"`
+	// 바인딩 하나에 허용되는 subject 수. 0이면 제한 없음 (많은 구성원은 Group subject 사용 권장)
+	MaxSubjectsPerBinding int `mapstructure:"maxSubjectsPerBinding"`
+
+	// 사용자/역할/바인딩 이름 정책. 생성과 이름 변경 시에만 적용됨
+	Names NamesConfig `mapstructure:"names"`
+
+	// 재사용할 수 없는 이전 비밀번호 수. 0이면 기본값(5), 음수이면 현재 비밀번호만 거부
+	PasswordHistorySize int `mapstructure:"passwordHistorySize"`
+
+	// POST /api/v1/auth/register 공개 가입 허용. 가입자는 defaultRoles만 부여받음
+	AllowSelfRegistration bool `mapstructure:"allowSelfRegistration"`
+	// true이면 가입자는 이메일 확인 전까지 비활성 상태
+	RequireEmailVerification bool `mapstructure:"requireEmailVerification"`
+	// 가입 시 최소 비밀번호 길이. 0이면 기본값(8)
+	MinPasswordLength int `mapstructure:"minPasswordLength"`
+
+	// 로그인 식별자로 허용할 종류와 시도 순서 ("name", "email"). 비어 있으면 name, email
+	LoginIdentifiers []string `mapstructure:"loginIdentifiers"`
+}
+
+// NamesConfig configures the names accepted for new users, roles and role bindings
+type NamesConfig struct {
+	// 0이면 63 (DNS label 길이)
+	MaxLength int `mapstructure:"maxLength"`
+	// true이면 대문자도 허용
+	AllowUppercase bool `mapstructure:"allowUppercase"`
+}
+
+// PreviousJWTSecret is a rotated-out JWT secret that is still accepted until Sunset
+type PreviousJWTSecret struct {
+	Secret string `mapstructure:"secret"`
+	KeyID  string `mapstructure:"keyId"`
+	// RFC3339 시각 (예: "2024-07-01T00:00:00Z"). 비어 있으면 제한 없음
+	Sunset string `mapstructure:"sunset"`
+}
+
+type CacheConfig struct {
+	Type     string `mapstructure:"type"` // "memory", "redis"
+	RedisURL string `mapstructure:"redisUrl"`
+
+	// 권한 판정 캐시 TTL (초). 0이면 controllers.DefaultAccessCacheTTL, 음수이면 캐시하지 않음
+	AccessTTLSeconds int `mapstructure:"accessTtlSeconds"`
+}
+
+// EmailConfig 이메일 변경/가입 확인 토큰 발송용 SMTP 설정. smtpHost가 비어 있으면 발송하지 않음
+type EmailConfig struct {
+	SMTPHost  string `mapstructure:"smtpHost"`
+	SMTPPort  int    `mapstructure:"smtpPort"` // 0이면 587
+	Username  string `mapstructure:"username"`
+	Password  string `mapstructure:"password"`
+	From      string `mapstructure:"from"`
+	VerifyURL string `mapstructure:"verifyUrl"` // 설정 시 ?token=... 링크를 메일에 포함
+}
+
+// DynamicConfig 동적 엔티티 API (/api/v1/dynamic/:table) 설정
+type DynamicConfig struct {
+	// 노출할 엔티티 스키마 목록(JSON 배열) 파일 경로. 비어 있으면 API를 등록하지 않음
+	SchemaFile string `mapstructure:"schemaFile"`
 }
 
 func LoadConfig() (*Config, error) {
@@ -38,6 +226,8 @@ func LoadConfig() (*Config, error) {
 	viper.SetDefault("database.type", "sqlite")
 	viper.SetDefault("database.database", "auth.db")
 	viper.SetDefault("auth.tokenExpiration", 24) // 24 hours
+	viper.SetDefault("auth.loginHistoryLimit", 10)
+	viper.SetDefault("cache.type", "memory")
 
 	viper.SetConfigFile("./config.yaml")
 
@@ -0,0 +1,399 @@
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/sukryu/pAuth/internal/store/interfaces"
+	"github.com/sukryu/pAuth/pkg/apis/auth/v1alpha1"
+	"github.com/sukryu/pAuth/pkg/errors"
+	"github.com/sukryu/pAuth/pkg/utils/namespace"
+)
+
+// DocumentVersion is the format version written by ExportAll.
+// 버전 1 문서(cluster 객체 없음)도 ImportAll로 읽을 수 있음
+const DocumentVersion = 2
+
+// Document is the serialized form of the auth dataset of one namespace together with
+// the cluster-scoped roles and bindings
+type Document struct {
+	Version             int                            `json:"version"`
+	ExportedAt          time.Time                      `json:"exportedAt"`
+	Namespace           string                         `json:"namespace"`
+	Users               []*v1alpha1.User               `json:"users"`
+	Roles               []*v1alpha1.Role               `json:"roles"`
+	RoleBindings        []*v1alpha1.RoleBinding        `json:"roleBindings"`
+	ClusterRoles        []*v1alpha1.ClusterRole        `json:"clusterRoles"`
+	ClusterRoleBindings []*v1alpha1.ClusterRoleBinding `json:"clusterRoleBindings"`
+}
+
+// ImportMode controls what ImportAll does when an object already exists
+type ImportMode string
+
+const (
+	// ImportModeFailOnConflict aborts before writing anything if any object already exists
+	ImportModeFailOnConflict ImportMode = "fail-on-conflict"
+	// ImportModeUpsert overwrites existing objects
+	ImportModeUpsert ImportMode = "upsert"
+	// ImportModeSkipExisting leaves existing objects untouched
+	ImportModeSkipExisting ImportMode = "skip-existing"
+)
+
+// ParseImportMode validates a mode given on the command line
+func ParseImportMode(s string) (ImportMode, error) {
+	switch mode := ImportMode(s); mode {
+	case ImportModeFailOnConflict, ImportModeUpsert, ImportModeSkipExisting:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("unknown import mode %q", s)
+	}
+}
+
+// Stores are the stores read by ExportAll and written by ImportAll.
+// 모든 store는 같은 데이터베이스를 사용해야 ImportAll이 하나의 트랜잭션으로 기록됨
+type Stores struct {
+	Users        interfaces.UserStore
+	Roles        interfaces.RoleStore
+	RoleBindings interfaces.RoleBindingStore
+
+	// ClusterRoles/ClusterRoleBindings가 nil이면 cluster 객체는 내보내지 않으며, 이를 포함한 문서는 가져올 수 없음
+	ClusterRoles        interfaces.ClusterRoleStore
+	ClusterRoleBindings interfaces.ClusterRoleBindingStore
+}
+
+// Backup exports and imports users, roles, role bindings and the cluster-scoped roles and bindings.
+// 저장소를 직접 사용하므로 사용자의 password hash는 다시 해싱되지 않고 그대로 보존됨.
+type Backup struct {
+	stores Stores
+}
+
+// New creates a Backup over stores
+func New(stores Stores) *Backup {
+	return &Backup{stores: stores}
+}
+
+// ExportAll writes every user, role and role binding of the request namespace, and every
+// cluster role and cluster role binding, to w.
+// 사용자는 StreamUsers로 배치 단위로 읽어 바로 기록하므로 전체 사용자를 메모리에 올리지 않음.
+func (b *Backup) ExportAll(ctx context.Context, w io.Writer) error {
+	roles, err := b.stores.Roles.List(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list roles: %w", err)
+	}
+	bindings, err := b.stores.RoleBindings.List(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list role bindings: %w", err)
+	}
+	var clusterRoles []*v1alpha1.ClusterRole
+	if b.stores.ClusterRoles != nil {
+		if clusterRoles, err = b.stores.ClusterRoles.List(ctx); err != nil {
+			return fmt.Errorf("failed to list cluster roles: %w", err)
+		}
+	}
+	var clusterBindings []*v1alpha1.ClusterRoleBinding
+	if b.stores.ClusterRoleBindings != nil {
+		if clusterBindings, err = b.stores.ClusterRoleBindings.List(ctx); err != nil {
+			return fmt.Errorf("failed to list cluster role bindings: %w", err)
+		}
+	}
+	// 결과를 비교/diff 하기 쉽도록 이름순 정렬 (사용자는 StreamUsers가 이름순으로 전달)
+	sort.Slice(roles, func(i, j int) bool { return roles[i].Name < roles[j].Name })
+	sort.Slice(bindings, func(i, j int) bool { return bindings[i].Name < bindings[j].Name })
+	sort.Slice(clusterRoles, func(i, j int) bool { return clusterRoles[i].Name < clusterRoles[j].Name })
+	sort.Slice(clusterBindings, func(i, j int) bool { return clusterBindings[i].Name < clusterBindings[j].Name })
+
+	// Document와 같은 형식을 필드 단위로 기록
+	dw := &documentWriter{w: w}
+	dw.raw("{")
+	dw.field("version", DocumentVersion, false)
+	dw.field("exportedAt", time.Now().UTC(), false)
+	dw.field("namespace", namespace.FromContext(ctx), false)
+	dw.raw("\n  \"users\": [")
+	count := 0
+	err = b.stores.Users.StreamUsers(ctx, func(user *v1alpha1.User) error {
+		if count > 0 {
+			dw.raw(",")
+		}
+		count++
+		dw.raw("\n    ")
+		dw.value(user, "    ")
+		return dw.err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list users: %w", err)
+	}
+	if count > 0 {
+		dw.raw("\n  ")
+	}
+	dw.raw("],")
+	dw.field("roles", nonNil(roles), false)
+	dw.field("roleBindings", nonNil(bindings), false)
+	dw.field("clusterRoles", nonNil(clusterRoles), false)
+	dw.field("clusterRoleBindings", nonNil(clusterBindings), true)
+	dw.raw("\n}\n")
+	return dw.err
+}
+
+// documentWriter writes an indented Document piece by piece, keeping the first error
+type documentWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (d *documentWriter) raw(s string) {
+	if d.err == nil {
+		_, d.err = io.WriteString(d.w, s)
+	}
+}
+
+func (d *documentWriter) value(v interface{}, prefix string) {
+	if d.err != nil {
+		return
+	}
+	data, err := json.MarshalIndent(v, prefix, "  ")
+	if err != nil {
+		d.err = err
+		return
+	}
+	_, d.err = d.w.Write(data)
+}
+
+func (d *documentWriter) field(name string, v interface{}, last bool) {
+	d.raw("\n  \"" + name + "\": ")
+	d.value(v, "  ")
+	if !last {
+		d.raw(",")
+	}
+}
+
+// nonNil encodes empty lists as [] rather than null
+func nonNil[T any](items []T) []T {
+	if items == nil {
+		return []T{}
+	}
+	return items
+}
+
+// ImportAll reads a document written by ExportAll and stores its objects according to mode.
+// 모든 객체는 하나의 트랜잭션에서 기록되므로 실패하면 아무것도 남지 않음.
+// 사용자, 역할, 바인딩은 문서의 namespace와 관계없이 요청 namespace로 가져오므로 다른 namespace로 복사할 수 있음.
+// ClusterRole → Role → User → RoleBinding → ClusterRoleBinding 순서로 기록하여 바인딩이 항상 존재하는 역할을 참조하도록 함.
+func (b *Backup) ImportAll(ctx context.Context, r io.Reader, mode ImportMode) error {
+	if _, err := ParseImportMode(string(mode)); err != nil {
+		return err
+	}
+
+	var doc Document
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return fmt.Errorf("failed to decode document: %w", err)
+	}
+	if doc.Version < 1 || doc.Version > DocumentVersion {
+		return fmt.Errorf("unsupported document version %d", doc.Version)
+	}
+	if len(doc.ClusterRoles) > 0 && b.stores.ClusterRoles == nil {
+		return fmt.Errorf("document contains cluster roles but no cluster role store is configured")
+	}
+	if len(doc.ClusterRoleBindings) > 0 && b.stores.ClusterRoleBindings == nil {
+		return fmt.Errorf("document contains cluster role bindings but no cluster role binding store is configured")
+	}
+	remapNamespace(&doc, namespace.FromContext(ctx))
+
+	return b.stores.Users.InTransaction(ctx, func(ctx context.Context) error {
+		// fail-on-conflict는 기록 전에 충돌 여부를 모두 확인해 어떤 객체가 충돌했는지 바로 알림
+		if mode == ImportModeFailOnConflict {
+			if err := b.checkConflicts(ctx, &doc); err != nil {
+				return err
+			}
+		}
+
+		for _, role := range doc.ClusterRoles {
+			if err := b.importClusterRole(ctx, role, mode); err != nil {
+				return fmt.Errorf("failed to import cluster role %s: %w", role.Name, err)
+			}
+		}
+		for _, role := range doc.Roles {
+			if err := b.importRole(ctx, role, mode); err != nil {
+				return fmt.Errorf("failed to import role %s: %w", role.Name, err)
+			}
+		}
+		for _, user := range doc.Users {
+			if err := b.importUser(ctx, user, mode); err != nil {
+				return fmt.Errorf("failed to import user %s: %w", user.Name, err)
+			}
+		}
+		for _, binding := range doc.RoleBindings {
+			if err := b.importRoleBinding(ctx, binding, mode); err != nil {
+				return fmt.Errorf("failed to import role binding %s: %w", binding.Name, err)
+			}
+		}
+		for _, binding := range doc.ClusterRoleBindings {
+			if err := b.importClusterRoleBinding(ctx, binding, mode); err != nil {
+				return fmt.Errorf("failed to import cluster role binding %s: %w", binding.Name, err)
+			}
+		}
+		return nil
+	})
+}
+
+// remapNamespace moves the namespaced objects of doc into ns; cluster 객체는 namespace가 없으므로 그대로 둠
+func remapNamespace(doc *Document, ns string) {
+	for _, user := range doc.Users {
+		user.Namespace = ns
+	}
+	for _, role := range doc.Roles {
+		role.Namespace = ns
+	}
+	for _, binding := range doc.RoleBindings {
+		binding.Namespace = ns
+	}
+	doc.Namespace = ns
+}
+
+func (b *Backup) checkConflicts(ctx context.Context, doc *Document) error {
+	for _, role := range doc.ClusterRoles {
+		if exists, err := found(b.stores.ClusterRoles.Get(ctx, role.Name)); err != nil || exists {
+			return conflict("cluster role", role.Name, err)
+		}
+	}
+	for _, role := range doc.Roles {
+		if exists, err := found(b.stores.Roles.Get(ctx, role.Name)); err != nil || exists {
+			return conflict("role", role.Name, err)
+		}
+	}
+	for _, user := range doc.Users {
+		if exists, err := found(b.stores.Users.Get(ctx, user.Name)); err != nil || exists {
+			return conflict("user", user.Name, err)
+		}
+	}
+	for _, binding := range doc.RoleBindings {
+		if exists, err := found(b.stores.RoleBindings.Get(ctx, binding.Name)); err != nil || exists {
+			return conflict("role binding", binding.Name, err)
+		}
+	}
+	for _, binding := range doc.ClusterRoleBindings {
+		if exists, err := found(b.stores.ClusterRoleBindings.Get(ctx, binding.Name)); err != nil || exists {
+			return conflict("cluster role binding", binding.Name, err)
+		}
+	}
+	return nil
+}
+
+func (b *Backup) importRole(ctx context.Context, role *v1alpha1.Role, mode ImportMode) error {
+	exists, err := found(b.stores.Roles.Get(ctx, role.Name))
+	if err != nil {
+		return err
+	}
+	switch {
+	case !exists:
+		return b.stores.Roles.Create(ctx, role)
+	case mode == ImportModeUpsert:
+		return b.stores.Roles.Update(ctx, role)
+	case mode == ImportModeSkipExisting:
+		return nil
+	default:
+		return conflict("role", role.Name, nil)
+	}
+}
+
+func (b *Backup) importUser(ctx context.Context, user *v1alpha1.User, mode ImportMode) error {
+	exists, err := found(b.stores.Users.Get(ctx, user.Name))
+	if err != nil {
+		return err
+	}
+	switch {
+	case !exists:
+		return b.stores.Users.Create(ctx, user)
+	case mode == ImportModeUpsert:
+		// Update는 password hash와 활성 상태를 기록하지 않으므로 별도로 반영
+		if err := b.stores.Users.Update(ctx, user); err != nil {
+			return err
+		}
+		if err := b.stores.Users.UpdatePassword(ctx, user.Name, user.Spec.PasswordHash); err != nil {
+			return err
+		}
+		return b.stores.Users.UpdateStatus(ctx, user.Name, user.Status.Active)
+	case mode == ImportModeSkipExisting:
+		return nil
+	default:
+		return conflict("user", user.Name, nil)
+	}
+}
+
+func (b *Backup) importRoleBinding(ctx context.Context, binding *v1alpha1.RoleBinding, mode ImportMode) error {
+	exists, err := found(b.stores.RoleBindings.Get(ctx, binding.Name))
+	if err != nil {
+		return err
+	}
+	switch {
+	case !exists:
+		return b.stores.RoleBindings.Create(ctx, binding)
+	case mode == ImportModeUpsert:
+		return b.stores.RoleBindings.Update(ctx, binding)
+	case mode == ImportModeSkipExisting:
+		return nil
+	default:
+		return conflict("role binding", binding.Name, nil)
+	}
+}
+
+func (b *Backup) importClusterRole(ctx context.Context, role *v1alpha1.ClusterRole, mode ImportMode) error {
+	exists, err := found(b.stores.ClusterRoles.Get(ctx, role.Name))
+	if err != nil {
+		return err
+	}
+	switch {
+	case !exists:
+		return b.stores.ClusterRoles.Create(ctx, role)
+	case mode == ImportModeUpsert:
+		return b.stores.ClusterRoles.Update(ctx, role)
+	case mode == ImportModeSkipExisting:
+		return nil
+	default:
+		return conflict("cluster role", role.Name, nil)
+	}
+}
+
+func (b *Backup) importClusterRoleBinding(ctx context.Context, binding *v1alpha1.ClusterRoleBinding, mode ImportMode) error {
+	exists, err := found(b.stores.ClusterRoleBindings.Get(ctx, binding.Name))
+	if err != nil {
+		return err
+	}
+	switch {
+	case !exists:
+		return b.stores.ClusterRoleBindings.Create(ctx, binding)
+	case mode == ImportModeUpsert:
+		return b.stores.ClusterRoleBindings.Update(ctx, binding)
+	case mode == ImportModeSkipExisting:
+		return nil
+	default:
+		return conflict("cluster role binding", binding.Name, nil)
+	}
+}
+
+// found converts the result of a store Get into an existence check
+func found[T any](_ T, err error) (bool, error) {
+	if err == nil {
+		return true, nil
+	}
+	for _, notFound := range []error{
+		errors.ErrUserNotFound, errors.ErrRoleNotFound, errors.ErrRoleBindingNotFound,
+		errors.ErrClusterRoleNotFound, errors.ErrClusterRoleBindingNotFound,
+	} {
+		if stderrors.Is(err, notFound) {
+			return false, nil
+		}
+	}
+	return false, err
+}
+
+func conflict(kind, name string, err error) error {
+	if err != nil {
+		return err
+	}
+	return errors.ErrAlreadyExists.WithReason(fmt.Sprintf("%s %s already exists", kind, name))
+}
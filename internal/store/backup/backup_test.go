@@ -0,0 +1,357 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	clusterrole "github.com/sukryu/pAuth/internal/store/cluster_role"
+	clusterrolebinding "github.com/sukryu/pAuth/internal/store/cluster_role_binding"
+	"github.com/sukryu/pAuth/internal/store/dynamic"
+	"github.com/sukryu/pAuth/internal/store/manager"
+	"github.com/sukryu/pAuth/internal/store/role"
+	rolebinding "github.com/sukryu/pAuth/internal/store/role_binding"
+	"github.com/sukryu/pAuth/internal/store/user"
+	"github.com/sukryu/pAuth/pkg/apis/auth/v1alpha1"
+	"github.com/sukryu/pAuth/pkg/utils/namespace"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func setupTestStores(t *testing.T) Stores {
+	manager, err := manager.NewSQLManager(manager.Config{
+		Type: "sqlite3",
+		DSN:  ":memory:",
+	})
+	require.NoError(t, err)
+
+	dbConn := manager.GetDB()
+	t.Cleanup(func() { dbConn.Close() })
+
+	_, err = dbConn.Exec(`
+        CREATE TABLE IF NOT EXISTS entity_schemas (
+            id TEXT PRIMARY KEY,
+            name TEXT UNIQUE NOT NULL,
+            description TEXT,
+            fields TEXT NOT NULL,
+            indexes TEXT,
+            annotations TEXT,
+            created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+            updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+            deleted_at TIMESTAMP
+        );
+        CREATE TABLE IF NOT EXISTS users (
+            id TEXT PRIMARY KEY,
+            namespace TEXT NOT NULL DEFAULT 'default',
+            username TEXT UNIQUE NOT NULL,
+            email TEXT UNIQUE NOT NULL,
+            password_hash TEXT NOT NULL,
+            display_name TEXT,
+            profile TEXT,
+            roles TEXT,
+            is_active BOOLEAN DEFAULT true,
+            last_login TIMESTAMP,
+            login_history TEXT,
+            password_history TEXT,
+            password_changed_at TIMESTAMP,
+            pending_email TEXT,
+            email_change_token TEXT,
+            email_change_expires TIMESTAMP,
+            activation_time TIMESTAMP,
+            created_by TEXT,
+            updated_by TEXT,
+            annotations TEXT,
+            created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+            updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+            deleted_at TIMESTAMP
+        );
+        CREATE TABLE IF NOT EXISTS roles (
+            id TEXT PRIMARY KEY,
+            name TEXT UNIQUE NOT NULL,
+            namespace TEXT NOT NULL DEFAULT 'default',
+            description TEXT,
+            rules TEXT NOT NULL,
+            inherits TEXT,
+            created_by TEXT,
+            updated_by TEXT,
+            annotations TEXT,
+            created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+            updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+            deleted_at TIMESTAMP
+        );
+        CREATE TABLE IF NOT EXISTS role_bindings (
+            id TEXT PRIMARY KEY,
+            name TEXT UNIQUE NOT NULL,
+            namespace TEXT NOT NULL DEFAULT 'default',
+            role_ref TEXT NOT NULL,
+            role_refs TEXT,
+            subjects TEXT NOT NULL,
+            created_by TEXT,
+            updated_by TEXT,
+            annotations TEXT,
+            created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+            updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+            deleted_at TIMESTAMP
+        );
+        CREATE TABLE IF NOT EXISTS cluster_roles (
+            id TEXT PRIMARY KEY,
+            name TEXT UNIQUE NOT NULL,
+            rules TEXT,
+            annotations TEXT,
+            created_by TEXT,
+            updated_by TEXT,
+            created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+            updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+            deleted_at TIMESTAMP
+        );
+        CREATE TABLE IF NOT EXISTS cluster_role_bindings (
+            id TEXT PRIMARY KEY,
+            name TEXT UNIQUE NOT NULL,
+            role_ref TEXT NOT NULL,
+            subjects TEXT,
+            annotations TEXT,
+            created_by TEXT,
+            updated_by TEXT,
+            created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+            updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+            deleted_at TIMESTAMP
+        )
+    `)
+	require.NoError(t, err)
+
+	dynStore, err := dynamic.NewDynamicStore(manager)
+	require.NoError(t, err)
+
+	users, err := user.NewStore(dynStore, user.Config{DatabaseType: "sqlite"})
+	require.NoError(t, err)
+	roles, err := role.NewStore(dynStore, role.Config{DatabaseType: "sqlite"})
+	require.NoError(t, err)
+	bindings, err := rolebinding.NewStore(dynStore, rolebinding.Config{DatabaseType: "sqlite"})
+	require.NoError(t, err)
+	clusterRoles, err := clusterrole.NewStore(dynStore, clusterrole.Config{DatabaseType: "sqlite"})
+	require.NoError(t, err)
+	clusterBindings, err := clusterrolebinding.NewStore(dynStore, clusterrolebinding.Config{DatabaseType: "sqlite"})
+	require.NoError(t, err)
+
+	return Stores{
+		Users:               users,
+		Roles:               roles,
+		RoleBindings:        bindings,
+		ClusterRoles:        clusterRoles,
+		ClusterRoleBindings: clusterBindings,
+	}
+}
+
+func populate(t *testing.T, ctx context.Context, stores Stores) {
+	created := metav1.NewTime(time.Now().Add(-time.Hour).Truncate(time.Second))
+	lastLogin := metav1.NewTime(time.Now().Add(-time.Minute).Truncate(time.Second))
+
+	require.NoError(t, stores.Roles.Create(ctx, &v1alpha1.Role{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "reader",
+			CreationTimestamp: created,
+			Annotations:       map[string]string{"description": "read only"},
+		},
+		Rules: []v1alpha1.PolicyRule{{
+			Verbs:     []string{"get", "list"},
+			Resources: []string{"users"},
+			APIGroups: []string{"auth.service"},
+		}},
+	}))
+	require.NoError(t, stores.Users.Create(ctx, &v1alpha1.User{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "alice",
+			CreationTimestamp: created,
+			Annotations:       map[string]string{"team": "platform"},
+		},
+		Spec: v1alpha1.UserSpec{
+			Username:     "alice",
+			Email:        "alice@example.com",
+			PasswordHash: "$2a$10$existinghashvalue",
+			DisplayName:  "Alice",
+			Profile:      map[string]string{"title": "engineer"},
+			Roles:        []string{"reader"},
+		},
+		Status: v1alpha1.UserStatus{Active: true, LastLogin: &lastLogin},
+	}))
+	require.NoError(t, stores.Users.Create(ctx, &v1alpha1.User{
+		ObjectMeta: metav1.ObjectMeta{Name: "bob", CreationTimestamp: created},
+		Spec: v1alpha1.UserSpec{
+			Username:     "bob",
+			Email:        "bob@example.com",
+			PasswordHash: "$2a$10$anotherhashvalue",
+		},
+	}))
+	require.NoError(t, stores.RoleBindings.Create(ctx, &v1alpha1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "alice-reader", CreationTimestamp: created},
+		RoleRef:    v1alpha1.RoleRef{Kind: "Role", Name: "reader"},
+		Subjects:   []v1alpha1.Subject{{Kind: v1alpha1.SubjectKindUser, Name: "alice"}},
+	}))
+	require.NoError(t, stores.ClusterRoles.Create(ctx, &v1alpha1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{Name: "auditor", CreationTimestamp: created},
+		Rules:      []v1alpha1.PolicyRule{{Verbs: []string{"get"}, Resources: []string{"*"}, APIGroups: []string{"*"}}},
+	}))
+	require.NoError(t, stores.ClusterRoleBindings.Create(ctx, &v1alpha1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "bob-auditor", CreationTimestamp: created},
+		RoleRef:    v1alpha1.RoleRef{Kind: v1alpha1.RoleRefKindClusterRole, Name: "auditor"},
+		Subjects:   []v1alpha1.Subject{{Kind: v1alpha1.SubjectKindUser, Name: "bob"}},
+	}))
+}
+
+// exportDocument exports stores and clears the export timestamp for comparison
+func exportDocument(t *testing.T, ctx context.Context, stores Stores) (Document, []byte) {
+	var buf bytes.Buffer
+	require.NoError(t, New(stores).ExportAll(ctx, &buf))
+
+	var doc Document
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &doc))
+	doc.ExportedAt = time.Time{}
+	return doc, buf.Bytes()
+}
+
+func TestBackup_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	source := setupTestStores(t)
+	populate(t, ctx, source)
+
+	exported, data := exportDocument(t, ctx, source)
+	assert.Equal(t, DocumentVersion, exported.Version)
+	assert.Len(t, exported.Users, 2)
+	assert.Len(t, exported.Roles, 1)
+	assert.Len(t, exported.RoleBindings, 1)
+	assert.Len(t, exported.ClusterRoles, 1)
+	assert.Len(t, exported.ClusterRoleBindings, 1)
+
+	target := setupTestStores(t)
+	require.NoError(t, New(target).ImportAll(ctx, bytes.NewReader(data), ImportModeFailOnConflict))
+
+	imported, _ := exportDocument(t, ctx, target)
+	assert.Equal(t, exported, imported)
+
+	// password hash는 다시 해싱되지 않아야 함
+	alice, err := target.Users.Get(ctx, "alice")
+	require.NoError(t, err)
+	assert.Equal(t, "$2a$10$existinghashvalue", alice.Spec.PasswordHash)
+	assert.Equal(t, "platform", alice.Annotations["team"])
+}
+
+func TestBackup_ImportModes(t *testing.T) {
+	ctx := context.Background()
+	source := setupTestStores(t)
+	populate(t, ctx, source)
+	_, data := exportDocument(t, ctx, source)
+
+	t.Run("fail-on-conflict writes nothing when any object exists", func(t *testing.T) {
+		target := setupTestStores(t)
+		require.NoError(t, target.Users.Create(ctx, &v1alpha1.User{
+			ObjectMeta: metav1.ObjectMeta{Name: "bob"},
+			Spec:       v1alpha1.UserSpec{Username: "bob", Email: "bob@example.com", PasswordHash: "local"},
+		}))
+
+		err := New(target).ImportAll(ctx, bytes.NewReader(data), ImportModeFailOnConflict)
+		assert.Error(t, err)
+
+		_, err = target.Roles.Get(ctx, "reader")
+		assert.Error(t, err)
+	})
+
+	t.Run("skip-existing keeps local objects", func(t *testing.T) {
+		target := setupTestStores(t)
+		require.NoError(t, target.Users.Create(ctx, &v1alpha1.User{
+			ObjectMeta: metav1.ObjectMeta{Name: "bob"},
+			Spec:       v1alpha1.UserSpec{Username: "bob", Email: "bob@example.com", PasswordHash: "local"},
+		}))
+
+		require.NoError(t, New(target).ImportAll(ctx, bytes.NewReader(data), ImportModeSkipExisting))
+
+		bob, err := target.Users.Get(ctx, "bob")
+		require.NoError(t, err)
+		assert.Equal(t, "local", bob.Spec.PasswordHash)
+		_, err = target.Users.Get(ctx, "alice")
+		assert.NoError(t, err)
+	})
+
+	t.Run("upsert overwrites local objects", func(t *testing.T) {
+		target := setupTestStores(t)
+		require.NoError(t, target.Users.Create(ctx, &v1alpha1.User{
+			ObjectMeta: metav1.ObjectMeta{Name: "bob"},
+			Spec:       v1alpha1.UserSpec{Username: "bob", Email: "bob@example.com", PasswordHash: "local"},
+			Status:     v1alpha1.UserStatus{Active: true},
+		}))
+
+		require.NoError(t, New(target).ImportAll(ctx, bytes.NewReader(data), ImportModeUpsert))
+
+		bob, err := target.Users.Get(ctx, "bob")
+		require.NoError(t, err)
+		assert.Equal(t, "$2a$10$anotherhashvalue", bob.Spec.PasswordHash)
+		assert.False(t, bob.Status.Active)
+	})
+
+	t.Run("a failed import writes nothing", func(t *testing.T) {
+		var doc Document
+		require.NoError(t, json.Unmarshal(data, &doc))
+		// 두 번째 사용자가 첫 번째와 같은 이메일을 사용해 기록 중에 실패
+		doc.Users[1].Spec.Email = doc.Users[0].Spec.Email
+		broken, err := json.Marshal(doc)
+		require.NoError(t, err)
+
+		target := setupTestStores(t)
+		assert.Error(t, New(target).ImportAll(ctx, bytes.NewReader(broken), ImportModeSkipExisting))
+
+		_, err = target.Roles.Get(ctx, "reader")
+		assert.Error(t, err)
+		_, err = target.ClusterRoles.Get(ctx, "auditor")
+		assert.Error(t, err)
+		_, err = target.Users.Get(ctx, "alice")
+		assert.Error(t, err)
+	})
+
+	t.Run("rejects unknown mode and version", func(t *testing.T) {
+		target := setupTestStores(t)
+		assert.Error(t, New(target).ImportAll(ctx, bytes.NewReader(data), ImportMode("merge")))
+		assert.Error(t, New(target).ImportAll(ctx, bytes.NewReader([]byte(`{"version":99}`)), ImportModeUpsert))
+	})
+}
+
+func TestBackup_ImportIntoOtherNamespace(t *testing.T) {
+	ctx := context.Background()
+	source := setupTestStores(t)
+	populate(t, ctx, source)
+	_, data := exportDocument(t, ctx, source)
+
+	target := setupTestStores(t)
+	teamA := namespace.WithNamespace(ctx, "team-a")
+	require.NoError(t, New(target).ImportAll(teamA, bytes.NewReader(data), ImportModeFailOnConflict))
+
+	alice, err := target.Users.Get(teamA, "alice")
+	require.NoError(t, err)
+	assert.Equal(t, "team-a", alice.Namespace)
+	reader, err := target.Roles.Get(teamA, "reader")
+	require.NoError(t, err)
+	assert.Equal(t, "team-a", reader.Namespace)
+	binding, err := target.RoleBindings.Get(teamA, "alice-reader")
+	require.NoError(t, err)
+	assert.Equal(t, "team-a", binding.Namespace)
+
+	_, err = target.Users.Get(ctx, "alice")
+	assert.Error(t, err, "nothing is imported into the source namespace")
+
+	exported, _ := exportDocument(t, teamA, target)
+	assert.Equal(t, "team-a", exported.Namespace)
+	assert.Len(t, exported.ClusterRoles, 1)
+}
+
+func TestBackup_ImportVersion1(t *testing.T) {
+	ctx := context.Background()
+	target := setupTestStores(t)
+
+	doc := `{"version": 1, "namespace": "default", "users": [], "roles": [{"metadata": {"name": "reader"}, "rules": []}], "roleBindings": []}`
+	require.NoError(t, New(target).ImportAll(ctx, bytes.NewReader([]byte(doc)), ImportModeFailOnConflict))
+
+	_, err := target.Roles.Get(ctx, "reader")
+	assert.NoError(t, err)
+}
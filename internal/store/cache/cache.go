@@ -0,0 +1,44 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Cache defines the key-value operations shared by session, rate limit and permission caches.
+// 여러 서버 인스턴스가 상태를 공유해야 하는 경우 Redis 구현을 사용해야 함.
+type Cache interface {
+	// Get returns the value for key and whether it was found
+	Get(ctx context.Context, key string) (string, bool, error)
+
+	// Set stores value under key; a zero ttl means the entry never expires
+	Set(ctx context.Context, key, value string, ttl time.Duration) error
+
+	// Del removes key; deleting a missing key is not an error
+	Del(ctx context.Context, key string) error
+
+	// Incr atomically increments the counter at key, applying ttl when the counter is created
+	Incr(ctx context.Context, key string, ttl time.Duration) (int64, error)
+
+	// Close releases any underlying connections
+	Close() error
+}
+
+// Config holds cache backend configuration
+type Config struct {
+	Type     string // "memory", "redis"
+	RedisURL string
+}
+
+// New creates a Cache for the configured backend, defaulting to in-memory
+func New(cfg Config) (Cache, error) {
+	switch cfg.Type {
+	case "", "memory":
+		return NewMemoryCache(), nil
+	case "redis":
+		return NewRedisCache(cfg.RedisURL)
+	default:
+		return nil, fmt.Errorf("unsupported cache type: %s", cfg.Type)
+	}
+}
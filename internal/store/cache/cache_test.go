@@ -0,0 +1,138 @@
+package cache
+
+import (
+	"context"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func runCacheTests(t *testing.T, c Cache) {
+	ctx := context.Background()
+
+	t.Run("Set and Get", func(t *testing.T) {
+		err := c.Set(ctx, "test:key", "value", time.Minute)
+		assert.NoError(t, err)
+
+		value, found, err := c.Get(ctx, "test:key")
+		assert.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, "value", value)
+	})
+
+	t.Run("Get missing key", func(t *testing.T) {
+		_, found, err := c.Get(ctx, "test:missing")
+		assert.NoError(t, err)
+		assert.False(t, found)
+	})
+
+	t.Run("Del", func(t *testing.T) {
+		assert.NoError(t, c.Set(ctx, "test:del", "value", 0))
+		assert.NoError(t, c.Del(ctx, "test:del"))
+
+		_, found, err := c.Get(ctx, "test:del")
+		assert.NoError(t, err)
+		assert.False(t, found)
+
+		// 없는 키 삭제는 에러가 아님
+		assert.NoError(t, c.Del(ctx, "test:del"))
+	})
+
+	t.Run("Incr", func(t *testing.T) {
+		defer c.Del(ctx, "test:counter")
+
+		for i := int64(1); i <= 3; i++ {
+			value, err := c.Incr(ctx, "test:counter", time.Minute)
+			assert.NoError(t, err)
+			assert.Equal(t, i, value)
+		}
+	})
+
+	t.Run("TTL expiry", func(t *testing.T) {
+		assert.NoError(t, c.Set(ctx, "test:ttl", "value", 1100*time.Millisecond))
+		time.Sleep(1500 * time.Millisecond)
+
+		_, found, err := c.Get(ctx, "test:ttl")
+		assert.NoError(t, err)
+		assert.False(t, found)
+	})
+}
+
+func TestMemoryCache(t *testing.T) {
+	c := NewMemoryCache()
+	defer c.Close()
+
+	runCacheTests(t, c)
+
+	t.Run("Incr keeps original expiry", func(t *testing.T) {
+		ctx := context.Background()
+		_, err := c.Incr(ctx, "test:window", time.Second)
+		assert.NoError(t, err)
+		_, err = c.Incr(ctx, "test:window", time.Hour)
+		assert.NoError(t, err)
+
+		_, expiresAt, found := c.items.GetWithExpiration("test:window")
+		assert.True(t, found)
+		assert.WithinDuration(t, time.Now().Add(time.Second), expiresAt, time.Second)
+	})
+
+	t.Run("concurrent Incr and Set", func(t *testing.T) {
+		ctx := context.Background()
+		var wg sync.WaitGroup
+		for i := 0; i < 50; i++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				_, err := c.Incr(ctx, "test:concurrent", time.Minute)
+				assert.NoError(t, err)
+			}()
+			go func() {
+				defer wg.Done()
+				assert.NoError(t, c.Set(ctx, "test:other", "x", time.Minute))
+			}()
+		}
+		wg.Wait()
+
+		value, found, err := c.Get(ctx, "test:concurrent")
+		assert.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, "50", value)
+	})
+
+	t.Run("Incr non-integer value", func(t *testing.T) {
+		ctx := context.Background()
+		assert.NoError(t, c.Set(ctx, "test:text", "abc", 0))
+		_, err := c.Incr(ctx, "test:text", 0)
+		assert.Error(t, err)
+	})
+}
+
+func TestRedisCache(t *testing.T) {
+	url := os.Getenv("PAUTH_TEST_REDIS_URL")
+	if url == "" {
+		t.Skip("PAUTH_TEST_REDIS_URL not set; skipping Redis integration test")
+	}
+
+	c, err := NewRedisCache(url)
+	if err != nil {
+		t.Fatalf("failed to create redis cache: %v", err)
+	}
+	defer c.Close()
+
+	runCacheTests(t, c)
+}
+
+func TestNew(t *testing.T) {
+	c, err := New(Config{})
+	assert.NoError(t, err)
+	assert.IsType(t, &MemoryCache{}, c)
+
+	_, err = New(Config{Type: "redis"})
+	assert.Error(t, err)
+
+	_, err = New(Config{Type: "memcached"})
+	assert.Error(t, err)
+}
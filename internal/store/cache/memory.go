@@ -0,0 +1,93 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	gocache "github.com/patrickmn/go-cache"
+)
+
+// MemoryCache implements Cache in process memory.
+// 단일 인스턴스 배포나 테스트 용도로 사용.
+type MemoryCache struct {
+	// mu serializes writes so Set/Del cannot interleave with the read-modify-write in Incr
+	mu    sync.Mutex
+	items *gocache.Cache
+}
+
+// NewMemoryCache creates a new MemoryCache
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{
+		items: gocache.New(gocache.NoExpiration, 10*time.Minute),
+	}
+}
+
+var _ Cache = (*MemoryCache)(nil)
+
+// Get returns the value for key and whether it was found
+func (c *MemoryCache) Get(ctx context.Context, key string) (string, bool, error) {
+	value, found := c.items.Get(key)
+	if !found {
+		return "", false, nil
+	}
+	return value.(string), true, nil
+}
+
+// Set stores value under key
+func (c *MemoryCache) Set(ctx context.Context, key, value string, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.items.Set(key, value, expiration(ttl))
+	return nil
+}
+
+// Del removes key
+func (c *MemoryCache) Del(ctx context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.items.Delete(key)
+	return nil
+}
+
+// Incr increments the counter at key, applying ttl when the counter is created
+func (c *MemoryCache) Incr(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	value, expiresAt, found := c.items.GetWithExpiration(key)
+	if !found {
+		c.items.Set(key, "1", expiration(ttl))
+		return 1, nil
+	}
+
+	current, err := strconv.ParseInt(value.(string), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("value at key %s is not an integer", key)
+	}
+	current++
+
+	// 기존 만료 시각 유지
+	remaining := gocache.NoExpiration
+	if !expiresAt.IsZero() {
+		remaining = time.Until(expiresAt)
+	}
+	c.items.Set(key, strconv.FormatInt(current, 10), remaining)
+	return current, nil
+}
+
+// Close is a no-op for the in-memory cache
+func (c *MemoryCache) Close() error {
+	return nil
+}
+
+func expiration(ttl time.Duration) time.Duration {
+	if ttl <= 0 {
+		return gocache.NoExpiration
+	}
+	return ttl
+}
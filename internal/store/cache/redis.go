@@ -0,0 +1,81 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisCache implements Cache on top of Redis so state is shared across server instances
+type RedisCache struct {
+	client *redis.Client
+}
+
+// NewRedisCache creates a RedisCache from a redis:// URL
+func NewRedisCache(url string) (*RedisCache, error) {
+	if url == "" {
+		return nil, fmt.Errorf("redis URL is required")
+	}
+
+	opts, err := redis.ParseURL(url)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redis URL: %w", err)
+	}
+
+	return &RedisCache{
+		client: redis.NewClient(opts),
+	}, nil
+}
+
+var _ Cache = (*RedisCache)(nil)
+
+// Get returns the value for key and whether it was found
+func (c *RedisCache) Get(ctx context.Context, key string) (string, bool, error) {
+	value, err := c.client.Get(ctx, key).Result()
+	if errors.Is(err, redis.Nil) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return value, true, nil
+}
+
+// Set stores value under key
+func (c *RedisCache) Set(ctx context.Context, key, value string, ttl time.Duration) error {
+	if ttl < 0 {
+		ttl = 0
+	}
+	return c.client.Set(ctx, key, value, ttl).Err()
+}
+
+// Del removes key
+func (c *RedisCache) Del(ctx context.Context, key string) error {
+	return c.client.Del(ctx, key).Err()
+}
+
+// incrScript increments KEYS[1] and sets its TTL (ARGV[1] ms) only when the counter was created.
+// INCR과 PEXPIRE를 한 번에 실행하므로 그 사이에 연결이 끊겨 만료 없는 카운터가 남지 않음
+var incrScript = redis.NewScript(`
+local value = redis.call("INCR", KEYS[1])
+if value == 1 and tonumber(ARGV[1]) > 0 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[1])
+end
+return value
+`)
+
+// Incr increments the counter at key, applying ttl when the counter is created (고정 윈도우)
+func (c *RedisCache) Incr(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	if ttl < 0 {
+		ttl = 0
+	}
+	return incrScript.Run(ctx, c.client, []string{key}, ttl.Milliseconds()).Int64()
+}
+
+// Close closes the Redis client
+func (c *RedisCache) Close() error {
+	return c.client.Close()
+}
@@ -0,0 +1,138 @@
+package clusterrole
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/sukryu/pAuth/internal/store/dynamic"
+	"github.com/sukryu/pAuth/internal/store/interfaces"
+	"github.com/sukryu/pAuth/internal/store/repository"
+	"github.com/sukryu/pAuth/pkg/apis/auth/v1alpha1"
+	"github.com/sukryu/pAuth/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type Config struct {
+	DatabaseType string
+}
+
+// Store persists cluster-scoped roles. Role과 달리 namespace로 범위가 제한되지 않음
+type Store struct {
+	dynamicStore *dynamic.DynamicStore
+	config       Config
+}
+
+var _ interfaces.ClusterRoleStore = (*Store)(nil)
+
+func NewStore(dynStore *dynamic.DynamicStore, cfg Config) (interfaces.ClusterRoleStore, error) {
+	return &Store{
+		dynamicStore: dynStore,
+		config:       cfg,
+	}, nil
+}
+
+// HealthCheck checks the database holding the cluster-scoped cluster_roles table
+func (s *Store) HealthCheck(ctx context.Context) error {
+	return s.dynamicStore.HealthCheck(ctx)
+}
+
+// clusterRoles returns the generic repository backing the CRUD operations
+func (s *Store) clusterRoles() *repository.Repository[*v1alpha1.ClusterRole] {
+	return repository.New(s.dynamicStore, repository.Config[*v1alpha1.ClusterRole]{
+		Table:    "cluster_roles",
+		Key:      func(role *v1alpha1.ClusterRole) string { return role.Name },
+		ToRow:    clusterRoleToRow,
+		FromRow:  mapToClusterRole,
+		NotFound: errors.ErrClusterRoleNotFound,
+	})
+}
+
+func (s *Store) Create(ctx context.Context, role *v1alpha1.ClusterRole) error {
+	if role.CreationTimestamp.IsZero() {
+		role.CreationTimestamp = metav1.NewTime(time.Now())
+	}
+
+	return s.clusterRoles().Create(ctx, role)
+}
+
+func (s *Store) Get(ctx context.Context, name string) (*v1alpha1.ClusterRole, error) {
+	return s.clusterRoles().Get(ctx, name)
+}
+
+func (s *Store) Update(ctx context.Context, role *v1alpha1.ClusterRole) error {
+	return s.clusterRoles().Update(ctx, role)
+}
+
+func (s *Store) Delete(ctx context.Context, name string) error {
+	return s.clusterRoles().Delete(ctx, name)
+}
+
+func (s *Store) List(ctx context.Context) ([]*v1alpha1.ClusterRole, error) {
+	return s.clusterRoles().List(ctx)
+}
+
+// clusterRoleToRow converts role into cluster_roles table columns
+func clusterRoleToRow(role *v1alpha1.ClusterRole) (map[string]interface{}, error) {
+	rulesJSON, err := json.Marshal(role.Rules)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal rules: %w", err)
+	}
+
+	row := map[string]interface{}{
+		"name":       role.Name,
+		"rules":      string(rulesJSON),
+		"created_at": role.CreationTimestamp.Time,
+	}
+
+	if len(role.Annotations) > 0 {
+		annotationsJSON, err := json.Marshal(role.Annotations)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal annotations: %w", err)
+		}
+		row["annotations"] = string(annotationsJSON)
+	}
+
+	return row, nil
+}
+
+func mapToClusterRole(data map[string]interface{}) (*v1alpha1.ClusterRole, error) {
+	role := &v1alpha1.ClusterRole{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ClusterRole",
+			APIVersion: "auth.service/v1alpha1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              data["name"].(string),
+			CreationTimestamp: metav1.Time{Time: data["created_at"].(time.Time)},
+			Annotations:       make(map[string]string),
+		},
+	}
+
+	if rulesJSON, ok := data["rules"].(string); ok {
+		var rules []v1alpha1.PolicyRule
+		if err := json.Unmarshal([]byte(rulesJSON), &rules); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal rules: %w", err)
+		}
+		role.Rules = rules
+	}
+
+	if annotations, ok := data["annotations"].(string); ok && annotations != "" {
+		var parsedAnnotations map[string]string
+		if err := json.Unmarshal([]byte(annotations), &parsedAnnotations); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal annotations: %w", err)
+		}
+		role.Annotations = parsedAnnotations
+	}
+
+	// 생성자/수정자는 컬럼 값을 기준으로 annotation에 노출
+	if createdBy, ok := data["created_by"].(string); ok && createdBy != "" {
+		metav1.SetMetaDataAnnotation(&role.ObjectMeta, v1alpha1.AnnotationCreatedBy, createdBy)
+	}
+	if updatedBy, ok := data["updated_by"].(string); ok && updatedBy != "" {
+		metav1.SetMetaDataAnnotation(&role.ObjectMeta, v1alpha1.AnnotationUpdatedBy, updatedBy)
+	}
+
+	return role, nil
+}
@@ -0,0 +1,109 @@
+package clusterrole
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/sukryu/pAuth/internal/store/dynamic"
+	"github.com/sukryu/pAuth/internal/store/manager"
+	"github.com/sukryu/pAuth/pkg/apis/auth/v1alpha1"
+	"github.com/sukryu/pAuth/pkg/errors"
+	"github.com/sukryu/pAuth/pkg/utils/namespace"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func setupTestDB(t *testing.T) (*sql.DB, *dynamic.DynamicStore) {
+	manager, err := manager.NewSQLManager(manager.Config{
+		Type: "sqlite3",
+		DSN:  ":memory:",
+	})
+	if err != nil {
+		t.Fatalf("failed to create SQLManager: %v", err)
+	}
+
+	dbConn := manager.GetDB()
+
+	_, err = dbConn.Exec(`
+       CREATE TABLE IF NOT EXISTS entity_schemas (
+           id TEXT PRIMARY KEY,
+           name TEXT UNIQUE NOT NULL,
+           description TEXT,
+           fields TEXT NOT NULL,
+           indexes TEXT,
+           annotations TEXT,
+           created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+           updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+           deleted_at TIMESTAMP
+       );
+       CREATE TABLE IF NOT EXISTS cluster_roles (
+           id TEXT PRIMARY KEY,
+           name TEXT UNIQUE NOT NULL,
+           rules TEXT NOT NULL,
+           annotations TEXT,
+           created_by TEXT,
+           updated_by TEXT,
+           created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+           updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+           deleted_at TIMESTAMP
+       )
+   `)
+	if err != nil {
+		t.Fatalf("failed to create tables: %v", err)
+	}
+
+	store, err := dynamic.NewDynamicStore(manager)
+	if err != nil {
+		t.Fatalf("failed to create dynamic store: %v", err)
+	}
+
+	return dbConn, store
+}
+
+func setupTestStore(t *testing.T) (*Store, func()) {
+	dbConn, dynStore := setupTestDB(t)
+	store := &Store{
+		dynamicStore: dynStore,
+		config:       Config{DatabaseType: "sqlite"},
+	}
+
+	return store, func() { dbConn.Close() }
+}
+
+func TestClusterRoleStore_CRUD(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	role := &v1alpha1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-reader"},
+		Rules: []v1alpha1.PolicyRule{{
+			Verbs:     []string{"get", "list"},
+			Resources: []string{"users"},
+			APIGroups: []string{"auth.service"},
+		}},
+	}
+	assert.NoError(t, store.Create(ctx, role))
+
+	// ClusterRole은 요청 namespace와 무관하게 조회됨
+	got, err := store.Get(namespace.WithNamespace(ctx, "tenant-a"), "cluster-reader")
+	assert.NoError(t, err)
+	assert.Equal(t, "ClusterRole", got.Kind)
+	assert.Empty(t, got.Namespace)
+	assert.Equal(t, role.Rules, got.Rules)
+
+	got.Rules[0].Verbs = []string{"get"}
+	assert.NoError(t, store.Update(ctx, got))
+
+	roles, err := store.List(namespace.WithNamespace(ctx, "tenant-b"))
+	assert.NoError(t, err)
+	if assert.Len(t, roles, 1) {
+		assert.Equal(t, []string{"get"}, roles[0].Rules[0].Verbs)
+	}
+
+	assert.NoError(t, store.Delete(ctx, "cluster-reader"))
+	_, err = store.Get(ctx, "cluster-reader")
+	assert.ErrorIs(t, err, errors.ErrClusterRoleNotFound)
+}
@@ -0,0 +1,162 @@
+package clusterrolebinding
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/sukryu/pAuth/internal/store/dynamic"
+	"github.com/sukryu/pAuth/internal/store/interfaces"
+	"github.com/sukryu/pAuth/internal/store/repository"
+	"github.com/sukryu/pAuth/pkg/apis/auth/v1alpha1"
+	"github.com/sukryu/pAuth/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type Config struct {
+	DatabaseType string
+}
+
+// Store persists cluster-scoped role bindings. RoleBinding과 달리 namespace로 범위가 제한되지 않음
+type Store struct {
+	dynamicStore *dynamic.DynamicStore
+	config       Config
+}
+
+var _ interfaces.ClusterRoleBindingStore = (*Store)(nil)
+
+func NewStore(dynStore *dynamic.DynamicStore, cfg Config) (interfaces.ClusterRoleBindingStore, error) {
+	return &Store{
+		dynamicStore: dynStore,
+		config:       cfg,
+	}, nil
+}
+
+// HealthCheck checks the database holding the cluster_role_bindings table
+func (s *Store) HealthCheck(ctx context.Context) error {
+	return s.dynamicStore.HealthCheck(ctx)
+}
+
+// bindings returns the generic repository backing the CRUD operations
+func (s *Store) bindings() *repository.Repository[*v1alpha1.ClusterRoleBinding] {
+	return repository.New(s.dynamicStore, repository.Config[*v1alpha1.ClusterRoleBinding]{
+		Table:    "cluster_role_bindings",
+		Key:      func(binding *v1alpha1.ClusterRoleBinding) string { return binding.Name },
+		ToRow:    clusterRoleBindingToRow,
+		FromRow:  mapToClusterRoleBinding,
+		NotFound: errors.ErrClusterRoleBindingNotFound,
+	})
+}
+
+func (s *Store) Create(ctx context.Context, binding *v1alpha1.ClusterRoleBinding) error {
+	if binding.CreationTimestamp.IsZero() {
+		binding.CreationTimestamp = metav1.NewTime(time.Now())
+	}
+
+	return s.bindings().Create(ctx, binding)
+}
+
+func (s *Store) Get(ctx context.Context, name string) (*v1alpha1.ClusterRoleBinding, error) {
+	return s.bindings().Get(ctx, name)
+}
+
+func (s *Store) Update(ctx context.Context, binding *v1alpha1.ClusterRoleBinding) error {
+	return s.bindings().Update(ctx, binding)
+}
+
+func (s *Store) Delete(ctx context.Context, name string) error {
+	return s.bindings().Delete(ctx, name)
+}
+
+func (s *Store) List(ctx context.Context) ([]*v1alpha1.ClusterRoleBinding, error) {
+	return s.bindings().List(ctx)
+}
+
+func (s *Store) FindBySubject(ctx context.Context, subjectKind, subjectName string) ([]*v1alpha1.ClusterRoleBinding, error) {
+	bindings, err := s.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var filtered []*v1alpha1.ClusterRoleBinding
+	for _, binding := range bindings {
+		for _, subject := range binding.Subjects {
+			if subject.Kind == subjectKind && subject.Name == subjectName {
+				filtered = append(filtered, binding)
+				break
+			}
+		}
+	}
+
+	return filtered, nil
+}
+
+// clusterRoleBindingToRow converts binding into cluster_role_bindings table columns
+func clusterRoleBindingToRow(binding *v1alpha1.ClusterRoleBinding) (map[string]interface{}, error) {
+	subjectsJSON, err := json.Marshal(binding.Subjects)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal subjects: %w", err)
+	}
+
+	row := map[string]interface{}{
+		"name":       binding.Name,
+		"role_ref":   binding.RoleRef.Name,
+		"subjects":   string(subjectsJSON),
+		"created_at": binding.CreationTimestamp.Time,
+	}
+
+	if len(binding.Annotations) > 0 {
+		annotationsJSON, err := json.Marshal(binding.Annotations)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal annotations: %w", err)
+		}
+		row["annotations"] = string(annotationsJSON)
+	}
+
+	return row, nil
+}
+
+func mapToClusterRoleBinding(data map[string]interface{}) (*v1alpha1.ClusterRoleBinding, error) {
+	binding := &v1alpha1.ClusterRoleBinding{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ClusterRoleBinding",
+			APIVersion: "auth.service/v1alpha1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              data["name"].(string),
+			CreationTimestamp: metav1.Time{Time: data["created_at"].(time.Time)},
+			Annotations:       make(map[string]string),
+		},
+		RoleRef: v1alpha1.RoleRef{
+			Kind: v1alpha1.RoleRefKindClusterRole,
+			Name: data["role_ref"].(string),
+		},
+	}
+
+	if subjectsJSON, ok := data["subjects"].(string); ok && subjectsJSON != "" {
+		var subjects []v1alpha1.Subject
+		if err := json.Unmarshal([]byte(subjectsJSON), &subjects); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal subjects: %w", err)
+		}
+		binding.Subjects = subjects
+	}
+
+	if annotations, ok := data["annotations"].(string); ok && annotations != "" {
+		var parsedAnnotations map[string]string
+		if err := json.Unmarshal([]byte(annotations), &parsedAnnotations); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal annotations: %w", err)
+		}
+		binding.Annotations = parsedAnnotations
+	}
+
+	// 생성자/수정자는 컬럼 값을 기준으로 annotation에 노출
+	if createdBy, ok := data["created_by"].(string); ok && createdBy != "" {
+		metav1.SetMetaDataAnnotation(&binding.ObjectMeta, v1alpha1.AnnotationCreatedBy, createdBy)
+	}
+	if updatedBy, ok := data["updated_by"].(string); ok && updatedBy != "" {
+		metav1.SetMetaDataAnnotation(&binding.ObjectMeta, v1alpha1.AnnotationUpdatedBy, updatedBy)
+	}
+
+	return binding, nil
+}
@@ -0,0 +1,108 @@
+package clusterrolebinding
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/sukryu/pAuth/internal/store/dynamic"
+	"github.com/sukryu/pAuth/internal/store/manager"
+	"github.com/sukryu/pAuth/pkg/apis/auth/v1alpha1"
+	"github.com/sukryu/pAuth/pkg/errors"
+	"github.com/sukryu/pAuth/pkg/utils/namespace"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func setupTestDB(t *testing.T) (*sql.DB, *dynamic.DynamicStore) {
+	manager, err := manager.NewSQLManager(manager.Config{
+		Type: "sqlite3",
+		DSN:  ":memory:",
+	})
+	if err != nil {
+		t.Fatalf("failed to create SQLManager: %v", err)
+	}
+
+	dbConn := manager.GetDB()
+
+	_, err = dbConn.Exec(`
+       CREATE TABLE IF NOT EXISTS entity_schemas (
+           id TEXT PRIMARY KEY,
+           name TEXT UNIQUE NOT NULL,
+           description TEXT,
+           fields TEXT NOT NULL,
+           indexes TEXT,
+           annotations TEXT,
+           created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+           updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+           deleted_at TIMESTAMP
+       );
+       CREATE TABLE IF NOT EXISTS cluster_role_bindings (
+           id TEXT PRIMARY KEY,
+           name TEXT UNIQUE NOT NULL,
+           role_ref TEXT NOT NULL,
+           subjects TEXT,
+           annotations TEXT,
+           created_by TEXT,
+           updated_by TEXT,
+           created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+           updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+           deleted_at TIMESTAMP
+       )
+   `)
+	if err != nil {
+		t.Fatalf("failed to create tables: %v", err)
+	}
+
+	store, err := dynamic.NewDynamicStore(manager)
+	if err != nil {
+		t.Fatalf("failed to create dynamic store: %v", err)
+	}
+
+	return dbConn, store
+}
+
+func setupTestStore(t *testing.T) (*Store, func()) {
+	dbConn, dynStore := setupTestDB(t)
+	store := &Store{
+		dynamicStore: dynStore,
+		config:       Config{DatabaseType: "sqlite"},
+	}
+
+	return store, func() { dbConn.Close() }
+}
+
+func TestClusterRoleBindingStore_CRUD(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	binding := &v1alpha1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-reader-binding"},
+		RoleRef:    v1alpha1.RoleRef{Kind: v1alpha1.RoleRefKindClusterRole, Name: "cluster-reader"},
+		Subjects:   []v1alpha1.Subject{{Kind: v1alpha1.SubjectKindUser, Name: "alice"}},
+	}
+	assert.NoError(t, store.Create(ctx, binding))
+
+	// ClusterRoleBinding은 요청 namespace와 무관하게 조회됨
+	got, err := store.Get(namespace.WithNamespace(ctx, "tenant-a"), binding.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, binding.RoleRef, got.RoleRef)
+	assert.Equal(t, binding.Subjects, got.Subjects)
+
+	got.Subjects = append(got.Subjects, v1alpha1.Subject{Kind: v1alpha1.SubjectKindUser, Name: "bob"})
+	assert.NoError(t, store.Update(ctx, got))
+
+	found, err := store.FindBySubject(namespace.WithNamespace(ctx, "tenant-b"), v1alpha1.SubjectKindUser, "bob")
+	assert.NoError(t, err)
+	assert.Len(t, found, 1)
+
+	found, err = store.FindBySubject(ctx, v1alpha1.SubjectKindUser, "carol")
+	assert.NoError(t, err)
+	assert.Empty(t, found)
+
+	assert.NoError(t, store.Delete(ctx, binding.Name))
+	_, err = store.Get(ctx, binding.Name)
+	assert.ErrorIs(t, err, errors.ErrClusterRoleBindingNotFound)
+}
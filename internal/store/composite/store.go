@@ -0,0 +1,187 @@
+// Package composite adapts the per-resource SQL stores to the controllers.Store interface
+package composite
+
+import (
+	"context"
+
+	"github.com/sukryu/pAuth/internal/store/dynamic"
+	"github.com/sukryu/pAuth/internal/store/interfaces"
+	"github.com/sukryu/pAuth/pkg/apis/auth/v1alpha1"
+	"github.com/sukryu/pAuth/pkg/controllers"
+)
+
+// Stores are the per-resource stores combined by Store
+type Stores struct {
+	Users               interfaces.UserStore
+	Roles               interfaces.RoleStore
+	RoleBindings        interfaces.RoleBindingStore
+	ClusterRoles        interfaces.ClusterRoleStore
+	ClusterRoleBindings interfaces.ClusterRoleBindingStore
+	ServiceAccounts     interfaces.ServiceAccountStore
+}
+
+// Store implements controllers.Store over the SQL stores.
+// 모든 store가 dynStore와 같은 데이터베이스를 사용해야 InTransaction이 여러 store의 연산을 함께 묶음
+type Store struct {
+	stores  Stores
+	dynamic *dynamic.DynamicStore
+}
+
+var (
+	_ controllers.Store      = (*Store)(nil)
+	_ controllers.Transactor = (*Store)(nil)
+)
+
+// New combines stores into a controllers.Store whose transactions run on dynStore
+func New(dynStore *dynamic.DynamicStore, stores Stores) *Store {
+	return &Store{stores: stores, dynamic: dynStore}
+}
+
+// InTransaction runs fn in a database transaction shared by every store, rolling back when fn fails
+func (s *Store) InTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return s.dynamic.InTransaction(ctx, fn)
+}
+
+// User operations
+
+func (s *Store) CreateUser(ctx context.Context, user *v1alpha1.User) error {
+	return s.stores.Users.Create(ctx, user)
+}
+
+func (s *Store) GetUser(ctx context.Context, name string) (*v1alpha1.User, error) {
+	return s.stores.Users.Get(ctx, name)
+}
+
+func (s *Store) UpdateUser(ctx context.Context, user *v1alpha1.User) error {
+	return s.stores.Users.Update(ctx, user)
+}
+
+func (s *Store) DeleteUser(ctx context.Context, name string) error {
+	return s.stores.Users.Delete(ctx, name)
+}
+
+func (s *Store) ListUsers(ctx context.Context) (*v1alpha1.UserList, error) {
+	return s.stores.Users.List(ctx)
+}
+
+func (s *Store) ListUsersWithOptions(ctx context.Context, opts v1alpha1.ListOptions) (*v1alpha1.UserList, error) {
+	return s.stores.Users.ListWithOptions(ctx, opts)
+}
+
+func (s *Store) FindUserByEmail(ctx context.Context, email string) (*v1alpha1.User, error) {
+	return s.stores.Users.FindByEmail(ctx, email)
+}
+
+func (s *Store) FindUserByEmailChangeToken(ctx context.Context, tokenHash string) (*v1alpha1.User, error) {
+	return s.stores.Users.FindByEmailChangeToken(ctx, tokenHash)
+}
+
+func (s *Store) RenameUser(ctx context.Context, oldName, newName string) error {
+	return s.stores.Users.Rename(ctx, oldName, newName)
+}
+
+// Role operations
+
+func (s *Store) CreateRole(ctx context.Context, role *v1alpha1.Role) error {
+	return s.stores.Roles.Create(ctx, role)
+}
+
+func (s *Store) GetRole(ctx context.Context, name string) (*v1alpha1.Role, error) {
+	return s.stores.Roles.Get(ctx, name)
+}
+
+func (s *Store) UpdateRole(ctx context.Context, role *v1alpha1.Role) error {
+	return s.stores.Roles.Update(ctx, role)
+}
+
+func (s *Store) DeleteRole(ctx context.Context, name string) error {
+	return s.stores.Roles.Delete(ctx, name)
+}
+
+func (s *Store) ListRoles(ctx context.Context) ([]*v1alpha1.Role, error) {
+	return s.stores.Roles.List(ctx)
+}
+
+func (s *Store) ListRolesPaged(ctx context.Context, limit, offset int) ([]*v1alpha1.Role, int, error) {
+	return s.stores.Roles.ListPaged(ctx, limit, offset)
+}
+
+func (s *Store) ListRolesWithCursor(ctx context.Context, limit int, next string) ([]*v1alpha1.Role, string, error) {
+	return s.stores.Roles.ListWithCursor(ctx, limit, next)
+}
+
+// RoleBinding operations
+
+func (s *Store) CreateRoleBinding(ctx context.Context, binding *v1alpha1.RoleBinding) error {
+	return s.stores.RoleBindings.Create(ctx, binding)
+}
+
+func (s *Store) GetRoleBinding(ctx context.Context, name string) (*v1alpha1.RoleBinding, error) {
+	return s.stores.RoleBindings.Get(ctx, name)
+}
+
+func (s *Store) UpdateRoleBinding(ctx context.Context, binding *v1alpha1.RoleBinding) error {
+	return s.stores.RoleBindings.Update(ctx, binding)
+}
+
+func (s *Store) DeleteRoleBinding(ctx context.Context, name string) error {
+	return s.stores.RoleBindings.Delete(ctx, name)
+}
+
+func (s *Store) ListRoleBindings(ctx context.Context) ([]*v1alpha1.RoleBinding, error) {
+	return s.stores.RoleBindings.List(ctx)
+}
+
+func (s *Store) ListRoleBindingsPaged(ctx context.Context, limit, offset int) ([]*v1alpha1.RoleBinding, int, error) {
+	return s.stores.RoleBindings.ListPaged(ctx, limit, offset)
+}
+
+func (s *Store) ListRoleBindingsWithCursor(ctx context.Context, limit int, next string) ([]*v1alpha1.RoleBinding, string, error) {
+	return s.stores.RoleBindings.ListWithCursor(ctx, limit, next)
+}
+
+// ClusterRole operations
+
+func (s *Store) CreateClusterRole(ctx context.Context, role *v1alpha1.ClusterRole) error {
+	return s.stores.ClusterRoles.Create(ctx, role)
+}
+
+func (s *Store) GetClusterRole(ctx context.Context, name string) (*v1alpha1.ClusterRole, error) {
+	return s.stores.ClusterRoles.Get(ctx, name)
+}
+
+func (s *Store) DeleteClusterRole(ctx context.Context, name string) error {
+	return s.stores.ClusterRoles.Delete(ctx, name)
+}
+
+func (s *Store) ListClusterRoles(ctx context.Context) ([]*v1alpha1.ClusterRole, error) {
+	return s.stores.ClusterRoles.List(ctx)
+}
+
+// ClusterRoleBinding operations
+
+func (s *Store) CreateClusterRoleBinding(ctx context.Context, binding *v1alpha1.ClusterRoleBinding) error {
+	return s.stores.ClusterRoleBindings.Create(ctx, binding)
+}
+
+func (s *Store) GetClusterRoleBinding(ctx context.Context, name string) (*v1alpha1.ClusterRoleBinding, error) {
+	return s.stores.ClusterRoleBindings.Get(ctx, name)
+}
+
+func (s *Store) DeleteClusterRoleBinding(ctx context.Context, name string) error {
+	return s.stores.ClusterRoleBindings.Delete(ctx, name)
+}
+
+func (s *Store) ListClusterRoleBindings(ctx context.Context) ([]*v1alpha1.ClusterRoleBinding, error) {
+	return s.stores.ClusterRoleBindings.List(ctx)
+}
+
+// ServiceAccount operations
+
+func (s *Store) CreateServiceAccount(ctx context.Context, sa *v1alpha1.ServiceAccount) error {
+	return s.stores.ServiceAccounts.Create(ctx, sa)
+}
+
+func (s *Store) GetServiceAccount(ctx context.Context, name string) (*v1alpha1.ServiceAccount, error) {
+	return s.stores.ServiceAccounts.Get(ctx, name)
+}
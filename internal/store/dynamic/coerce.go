@@ -0,0 +1,140 @@
+package dynamic
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sukryu/pAuth/internal/store/schema"
+)
+
+// timestampLayouts are the textual timestamp formats SQLite drivers may return
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// baseTimestampColumns are the timestamp columns CreateDynamicTable adds to every table
+var baseTimestampColumns = []string{"created_at", "updated_at", "deleted_at"}
+
+// coerceRows converts scanned values in place so each column has a deterministic Go type
+// determined by its FieldDef.Type, regardless of how the driver returned it.
+// TEXT → string, INTEGER → int64, NUMERIC → float64, BOOLEAN → bool,
+// TIMESTAMP → time.Time, JSON → unmarshaled value. NULL은 nil로 유지
+func coerceRows(rows []map[string]interface{}, fields []schema.FieldDef) error {
+	types := make(map[string]schema.FieldType, len(fields)+len(baseTimestampColumns))
+	for _, col := range baseTimestampColumns {
+		types[col] = schema.FieldTypeTimestamp
+	}
+	for _, field := range fields {
+		types[field.Name] = field.Type
+	}
+
+	for _, row := range rows {
+		for col, val := range row {
+			fieldType, ok := types[col]
+			if !ok || val == nil {
+				continue
+			}
+			converted, err := coerceValue(val, fieldType)
+			if err != nil {
+				return fmt.Errorf("column %s: %w", col, err)
+			}
+			row[col] = converted
+		}
+	}
+	return nil
+}
+
+func coerceValue(val interface{}, fieldType schema.FieldType) (interface{}, error) {
+	if b, ok := val.([]byte); ok {
+		val = string(b)
+	}
+
+	switch fieldType {
+	case schema.FieldTypeString:
+		switch v := val.(type) {
+		case string:
+			return v, nil
+		case time.Time:
+			return v.Format(time.RFC3339Nano), nil
+		default:
+			return fmt.Sprint(v), nil
+		}
+
+	case schema.FieldTypeInteger:
+		switch v := val.(type) {
+		case int64:
+			return v, nil
+		case float64:
+			return int64(v), nil
+		case bool:
+			if v {
+				return int64(1), nil
+			}
+			return int64(0), nil
+		case string:
+			return strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+		}
+
+	case schema.FieldTypeNumber:
+		switch v := val.(type) {
+		case float64:
+			return v, nil
+		case int64:
+			return float64(v), nil
+		case string:
+			return strconv.ParseFloat(strings.TrimSpace(v), 64)
+		}
+
+	case schema.FieldTypeBoolean:
+		switch v := val.(type) {
+		case bool:
+			return v, nil
+		case int64:
+			return v != 0, nil
+		case float64:
+			return v != 0, nil
+		case string:
+			return strconv.ParseBool(strings.TrimSpace(v))
+		}
+
+	case schema.FieldTypeTimestamp:
+		switch v := val.(type) {
+		case time.Time:
+			return v.UTC(), nil
+		case int64:
+			return time.Unix(v, 0).UTC(), nil
+		case string:
+			for _, layout := range timestampLayouts {
+				if t, err := time.Parse(layout, v); err == nil {
+					return t.UTC(), nil
+				}
+			}
+			return nil, fmt.Errorf("unrecognized timestamp %q", v)
+		}
+
+	case schema.FieldTypeJSON:
+		s, ok := val.(string)
+		if !ok {
+			// 드라이버가 이미 디코딩한 값은 그대로 사용
+			return val, nil
+		}
+		var decoded interface{}
+		if err := json.Unmarshal([]byte(s), &decoded); err != nil {
+			return nil, fmt.Errorf("invalid JSON: %w", err)
+		}
+		return decoded, nil
+
+	default:
+		return val, nil
+	}
+
+	return nil, fmt.Errorf("cannot convert %T to %s", val, fieldType)
+}
@@ -0,0 +1,76 @@
+package dynamic
+
+import (
+	"strings"
+
+	"github.com/sukryu/pAuth/pkg/errors"
+)
+
+// SetConstraintErrors makes DynamicInsert/DynamicUpdate report NOT NULL, UNIQUE and CHECK
+// constraint failures as field-scoped *errors.ValidationError. 기본값은 비활성화이며,
+// 해석할 수 없는 에러는 설정과 무관하게 그대로 반환됨
+func (s *DynamicStore) SetConstraintErrors(enabled bool) {
+	s.constraintErrors = enabled
+}
+
+// constraintError converts err into a ValidationError when enabled and err is a recognised constraint failure
+func (s *DynamicStore) constraintError(err error) error {
+	if err == nil || !s.constraintErrors {
+		return err
+	}
+	if verr, ok := parseConstraintError(err); ok {
+		return verr
+	}
+	return err
+}
+
+// ConstraintViolation reports whether err is a SQLite constraint failure. 실패한 컬럼을 알 수 있으면
+// SetConstraintErrors와 같은 필드 단위 ValidationError도 반환하며, 그렇지 않으면 (FOREIGN KEY 등) nil을 반환함.
+// 호출자는 SQL 메시지를 그대로 노출하지 않고 4xx로 보고할 수 있음
+func ConstraintViolation(err error) (*errors.ValidationError, bool) {
+	if err == nil || !strings.Contains(err.Error(), "constraint failed") {
+		return nil, false
+	}
+	if verr, ok := parseConstraintError(err); ok {
+		return verr, true
+	}
+	return nil, true
+}
+
+// constraintMessages maps the SQLite constraint failure prefix to the message reported per field
+var constraintMessages = []struct {
+	prefix  string
+	message string
+}{
+	{"NOT NULL constraint failed: ", "is required"},
+	{"UNIQUE constraint failed: ", "already exists"},
+	{"CHECK constraint failed: ", "violates a check constraint"},
+}
+
+// parseConstraintError extracts the failed columns from a SQLite constraint message such as
+// "NOT NULL constraint failed: users.email" or "UNIQUE constraint failed: users.a, users.b".
+// CHECK 제약은 컬럼 대신 제약 이름이 field로 사용됨
+func parseConstraintError(err error) (*errors.ValidationError, bool) {
+	msg := err.Error()
+	for _, c := range constraintMessages {
+		i := strings.Index(msg, c.prefix)
+		if i < 0 {
+			continue
+		}
+
+		verr := errors.NewValidationError()
+		for _, target := range strings.Split(msg[i+len(c.prefix):], ",") {
+			target = strings.TrimSpace(target)
+			// "table.column"에서 테이블(접두사 포함) 부분 제거
+			if dot := strings.LastIndex(target, "."); dot >= 0 {
+				target = target[dot+1:]
+			}
+			if !isValidIdentifier(target) {
+				return nil, false
+			}
+			verr.Add(target, c.message)
+		}
+		return verr, true
+	}
+	return nil, false
+}
@@ -1,20 +1,30 @@
 package dynamic
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
+	"database/sql/driver"
+	stderrors "errors"
 	"fmt"
+	"io"
+	"log"
+	"path/filepath"
+	"strings"
 	"sync"
 	"testing"
 	"time"
 
-	_ "github.com/mattn/go-sqlite3"
+	"github.com/mattn/go-sqlite3"
 	"github.com/patrickmn/go-cache"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/sukryu/pAuth/internal/db"
 	"github.com/sukryu/pAuth/internal/store/dynamic/query"
 	"github.com/sukryu/pAuth/internal/store/manager"
 	"github.com/sukryu/pAuth/internal/store/schema"
+	"github.com/sukryu/pAuth/pkg/errors"
+	"github.com/sukryu/pAuth/pkg/utils/requestid"
 )
 
 func setupTestDB(t *testing.T) (*sql.DB, *DynamicStore) {
@@ -299,6 +309,158 @@ func TestDynamicStore_ComplexQuery(t *testing.T) {
 	assert.Equal(t, "Product 3", results[0]["title"])
 }
 
+func TestDynamicStore_DynamicQueryStream(t *testing.T) {
+	dbConn, store := setupTestDB(t)
+	defer dbConn.Close()
+	ctx := context.Background()
+
+	opts := schema.TableOptions{
+		Fields: []schema.FieldDef{
+			{Name: "seq", Type: schema.FieldTypeInteger},
+			{Name: "category", Type: schema.FieldTypeString, Nullable: true},
+		},
+	}
+	assert.NoError(t, store.CreateDynamicTable(ctx, "test_events", opts))
+
+	const total = 5000
+	err := store.InTransaction(ctx, func(ctx context.Context) error {
+		for i := 0; i < total; i++ {
+			category := "even"
+			if i%2 == 1 {
+				category = "odd"
+			}
+			row := map[string]interface{}{"id": fmt.Sprintf("e%05d", i), "seq": i, "category": category}
+			if err := store.DynamicInsert(ctx, "test_events", row); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+
+	params := query.QueryParams{
+		Where:   []query.WhereCondition{{Column: "category", Operator: "=", Value: "odd"}},
+		OrderBy: []query.OrderByClause{{Column: "seq"}},
+	}
+
+	t.Run("callback sees every matching row once", func(t *testing.T) {
+		seen := make(map[string]int)
+		err := store.DynamicQueryStream(ctx, "test_events", params, func(row map[string]interface{}) error {
+			assert.Equal(t, "odd", row["category"])
+			seen[row["id"].(string)]++
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Len(t, seen, total/2)
+		for id, count := range seen {
+			assert.Equal(t, 1, count, id)
+		}
+	})
+
+	t.Run("callback error stops iteration", func(t *testing.T) {
+		stop := fmt.Errorf("stop")
+		calls := 0
+		err := store.DynamicQueryStream(ctx, "test_events", params, func(row map[string]interface{}) error {
+			calls++
+			if calls == 10 {
+				return stop
+			}
+			return nil
+		})
+		assert.Equal(t, stop, err)
+		assert.Equal(t, 10, calls)
+	})
+
+	t.Run("cancelled context stops iteration", func(t *testing.T) {
+		cctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		calls := 0
+		err := store.DynamicQueryStream(cctx, "test_events", params, func(row map[string]interface{}) error {
+			calls++
+			if calls == 5 {
+				cancel()
+			}
+			return nil
+		})
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, 5, calls)
+	})
+
+	t.Run("table is still usable after an early stop", func(t *testing.T) {
+		results, err := store.DynamicQuery(ctx, "test_events", params)
+		assert.NoError(t, err)
+		assert.Len(t, results, total/2)
+	})
+}
+
+func TestDynamicStore_DynamicQueryTiebreaker(t *testing.T) {
+	dbConn, store := setupTestDB(t)
+	defer dbConn.Close()
+	ctx := context.Background()
+
+	opts := schema.TableOptions{
+		Fields: []schema.FieldDef{
+			{Name: "category", Type: schema.FieldTypeString},
+		},
+	}
+	assert.NoError(t, store.CreateDynamicTable(ctx, "test_ties", opts))
+
+	// id 순서와 다르게 삽입하여 삽입 순서에 의존하지 않는지 확인
+	ids := []string{"i07", "i02", "i09", "i04", "i01", "i08", "i03", "i06", "i05", "i10"}
+	for i, id := range ids {
+		category := []string{"a", "b"}[i%2]
+		assert.NoError(t, store.DynamicInsert(ctx, "test_ties", map[string]interface{}{"id": id, "category": category}))
+	}
+
+	pageIDs := func() []string {
+		var got []string
+		for offset := 0; offset < len(ids); offset += 3 {
+			params := query.QueryParams{
+				OrderBy: []query.OrderByClause{{Column: "category"}},
+				Limit:   3,
+				Offset:  offset,
+			}
+			rows, err := store.DynamicQuery(ctx, "test_ties", params)
+			assert.NoError(t, err)
+			for _, row := range rows {
+				got = append(got, row["id"].(string))
+			}
+		}
+		return got
+	}
+
+	want := []string{"i01", "i03", "i05", "i07", "i09", "i02", "i04", "i06", "i08", "i10"}
+	for run := 0; run < 3; run++ {
+		assert.Equal(t, want, pageIDs())
+	}
+
+	t.Run("defaults to id order", func(t *testing.T) {
+		rows, err := store.DynamicQuery(ctx, "test_ties", query.QueryParams{Limit: 2})
+		assert.NoError(t, err)
+		assert.Equal(t, "i01", rows[0]["id"])
+		assert.Equal(t, "i02", rows[1]["id"])
+	})
+
+	t.Run("explicit id order is not duplicated", func(t *testing.T) {
+		params := query.QueryParams{OrderBy: []query.OrderByClause{{Column: "id", Desc: true}}}
+		assert.Equal(t, params.OrderBy, withTiebreaker(params))
+
+		rows, err := store.DynamicQuery(ctx, "test_ties", params)
+		assert.NoError(t, err)
+		assert.Equal(t, "i10", rows[0]["id"])
+	})
+
+	t.Run("grouped queries are left unordered", func(t *testing.T) {
+		params := query.QueryParams{GroupBy: []string{"category"}}
+		params.AddAggregate("COUNT", "*", "total")
+		assert.Empty(t, withTiebreaker(params))
+
+		rows, err := store.DynamicQuery(ctx, "test_ties", params)
+		assert.NoError(t, err)
+		assert.Len(t, rows, 2)
+	})
+}
+
 func TestDynamicStore_ComplexQueryVariations(t *testing.T) {
 	dbConn, store := setupTestDB(t)
 	defer dbConn.Close()
@@ -460,6 +622,12 @@ func TestDynamicStore_Caching(t *testing.T) {
 	assert.Len(t, versionsCached, 1)
 	assert.Equal(t, versions, versionsCached)
 
+	// 반환된 슬라이스를 수정해도 캐시된 버전은 그대로 유지됨
+	versionsCached[0].Changes = "mutated"
+	versionsCached, err = store.GetSchemaVersions(ctx, "test_schema")
+	assert.NoError(t, err)
+	assert.Equal(t, "Initial version", versionsCached[0].Changes)
+
 	// 캐시 강제 만료
 	time.Sleep(60 * time.Millisecond) // 테스트용 짧은 만료 시간 사용
 	_, found := store.versionCache.Get("test_schema")
@@ -636,13 +804,27 @@ func TestDynamicStore_DropDynamicTable(t *testing.T) {
 	assert.NoError(t, err)
 
 	// 테이블 삭제
-	err = store.DropDynamicTable("test_users")
+	err = store.DropDynamicTable(ctx, "test_users")
 	assert.NoError(t, err)
 
 	// 삭제된 테이블 확인
 	exists, err := store.TableExists(ctx, "test_users")
 	assert.NoError(t, err)
 	assert.False(t, exists)
+
+	// 트랜잭션 안의 삭제는 롤백되어야 함
+	assert.NoError(t, store.CreateDynamicTable(ctx, "test_users", opts))
+	err = store.InTransaction(ctx, func(ctx context.Context) error {
+		if err := store.DropDynamicTable(ctx, "test_users"); err != nil {
+			return err
+		}
+		return fmt.Errorf("abort")
+	})
+	assert.EqualError(t, err, "abort")
+
+	exists, err = store.TableExists(ctx, "test_users")
+	assert.NoError(t, err)
+	assert.True(t, exists)
 }
 
 func TestDynamicStore_Concurrency(t *testing.T) {
@@ -738,3 +920,1198 @@ func TestDynamicStore_Concurrency(t *testing.T) {
 // 		assert.NotContains(t, row, "email", "Column 'email' should not exist")
 // 	}
 // }
+
+func createParentChildTables(t *testing.T, ctx context.Context, store *DynamicStore) {
+	err := store.CreateDynamicTable(ctx, "teams", schema.TableOptions{
+		Fields: []schema.FieldDef{
+			{Name: "name", Type: schema.FieldTypeString},
+		},
+	})
+	assert.NoError(t, err)
+
+	err = store.CreateDynamicTable(ctx, "members", schema.TableOptions{
+		Fields: []schema.FieldDef{
+			{Name: "name", Type: schema.FieldTypeString},
+			{Name: "team_id", Type: schema.FieldTypeString},
+		},
+		ForeignKeys: []schema.ForeignKeyDef{
+			{Column: "team_id", RefTable: "teams", RefColumn: "id", OnDelete: "CASCADE"},
+		},
+	})
+	assert.NoError(t, err)
+}
+
+func TestDynamicStore_ForeignKeys(t *testing.T) {
+	mgr, err := manager.NewSQLManager(manager.Config{
+		Type:        "sqlite3",
+		DSN:         ":memory:",
+		ForeignKeys: true,
+	})
+	if err != nil {
+		t.Fatalf("failed to create SQLManager: %v", err)
+	}
+	defer mgr.Close()
+
+	store, err := NewDynamicStore(mgr)
+	assert.NoError(t, err)
+	ctx := context.Background()
+
+	createParentChildTables(t, ctx, store)
+
+	t.Run("Insert child with existing parent", func(t *testing.T) {
+		err := store.DynamicInsert(ctx, "teams", map[string]interface{}{"id": "team-1", "name": "core"})
+		assert.NoError(t, err)
+
+		err = store.DynamicInsert(ctx, "members", map[string]interface{}{"id": "m-1", "name": "alice", "team_id": "team-1"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Insert child with missing parent fails", func(t *testing.T) {
+		err := store.DynamicInsert(ctx, "members", map[string]interface{}{"id": "m-2", "name": "bob", "team_id": "missing"})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "FOREIGN KEY")
+	})
+
+	t.Run("Invalid foreign key definition", func(t *testing.T) {
+		err := store.CreateDynamicTable(ctx, "broken", schema.TableOptions{
+			ForeignKeys: []schema.ForeignKeyDef{
+				{Column: "team_id", RefTable: "teams; DROP TABLE teams"},
+			},
+		})
+		assert.Error(t, err)
+	})
+}
+
+func TestDynamicStore_ValidateReferentialIntegrity(t *testing.T) {
+	dbConn, store := setupTestDB(t)
+	defer dbConn.Close()
+	ctx := context.Background()
+
+	createParentChildTables(t, ctx, store)
+	// setupTestDB는 ForeignKeys 없이 열리므로 외래 키가 강제되지 않음
+	assert.NoError(t, store.AddSchemaDependency(ctx, "teams", "members", string(schema.DependencyOneToMany)))
+
+	err := store.DynamicInsert(ctx, "teams", map[string]interface{}{"id": "team-1", "name": "core"})
+	assert.NoError(t, err)
+	err = store.DynamicInsert(ctx, "members", map[string]interface{}{"id": "m-1", "name": "alice", "team_id": "team-1"})
+	assert.NoError(t, err)
+
+	violations, err := store.ValidateReferentialIntegrity(ctx, "teams")
+	assert.NoError(t, err)
+	assert.Empty(t, violations)
+
+	// 외래 키 강제가 꺼져 있으므로 고아 행이 삽입됨
+	err = store.DynamicInsert(ctx, "members", map[string]interface{}{"id": "m-2", "name": "bob", "team_id": "missing"})
+	assert.NoError(t, err)
+
+	violations, err = store.ValidateReferentialIntegrity(ctx, "teams")
+	assert.NoError(t, err)
+	if assert.Len(t, violations, 1) {
+		assert.Equal(t, "members", violations[0].Table)
+		assert.Equal(t, "teams", violations[0].Parent)
+	}
+}
+
+func TestDynamicStore_ValidateJSONReferences(t *testing.T) {
+	mgr, err := manager.NewSQLManager(manager.Config{Type: "sqlite3", DSN: ":memory:"})
+	require.NoError(t, err)
+	defer mgr.Close()
+	store, err := NewDynamicStore(mgr)
+	require.NoError(t, err)
+	ctx := context.Background()
+	require.NoError(t, store.RunCoreMigrations(ctx))
+
+	insert := func(table string, row map[string]interface{}) {
+		row["created_at"] = time.Now()
+		row["updated_at"] = time.Now()
+		require.NoError(t, store.DynamicInsert(ctx, table, row))
+	}
+	insert("roles", map[string]interface{}{"id": "reader", "name": "reader", "namespace": "default"})
+	insert("roles", map[string]interface{}{"id": "other-reader", "name": "other-reader", "namespace": "tenant-a"})
+	insert("users", map[string]interface{}{"id": "alice", "username": "alice", "email": "alice@example.com", "password_hash": "x", "namespace": "default"})
+
+	insert("role_bindings", map[string]interface{}{
+		"id": "ok", "name": "ok", "namespace": "default", "role_ref": "reader",
+		"subjects": `[{"kind":"User","name":"alice"},{"kind":"Group","name":"auditors"}]`,
+	})
+	insert("role_bindings", map[string]interface{}{
+		"id": "orphan-role", "name": "orphan-role", "namespace": "default", "role_ref": "other-reader",
+		"subjects": `[{"kind":"User","name":"alice"}]`,
+	})
+	insert("role_bindings", map[string]interface{}{
+		"id": "orphan-subject", "name": "orphan-subject", "namespace": "default", "role_ref": "reader",
+		"role_refs": `[{"kind":"Role","name":"reader"}]`, "subjects": `[{"kind":"User","name":"ghost"}]`,
+	})
+	insert("cluster_role_bindings", map[string]interface{}{
+		"id": "cluster", "name": "cluster", "role_ref": "missing", "subjects": `[{"kind":"User","name":"alice"}]`,
+	})
+
+	type ref struct{ table, column, reference string }
+	refs := func(violations []ReferentialViolation) []ref {
+		out := make([]ref, len(violations))
+		for i, v := range violations {
+			out[i] = ref{v.Table, v.Column, v.Reference}
+		}
+		return out
+	}
+
+	// 다른 namespace의 Role은 참조할 수 없음
+	violations, err := store.ValidateReferentialIntegrity(ctx, "roles")
+	require.NoError(t, err)
+	assert.Equal(t, []ref{{"role_bindings", "role_ref", "other-reader"}}, refs(violations))
+
+	violations, err = store.ValidateReferentialIntegrity(ctx, "role_bindings")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []ref{
+		{"role_bindings", "role_ref", "other-reader"},
+		{"role_bindings", "subjects", "ghost"},
+	}, refs(violations))
+
+	violations, err = store.ValidateReferentialIntegrity(ctx, "cluster_role_bindings")
+	require.NoError(t, err)
+	assert.Equal(t, []ref{{"cluster_role_bindings", "role_ref", "missing"}}, refs(violations))
+
+	// 소프트 삭제된 부모는 없는 것으로 취급
+	require.NoError(t, store.DynamicDelete(ctx, "users", "alice"))
+	violations, err = store.ValidateReferentialIntegrity(ctx, "users")
+	require.NoError(t, err)
+	assert.Len(t, violations, 4)
+}
+
+func TestDynamicStore_DynamicSelectOrdered(t *testing.T) {
+	dbConn, store := setupTestDB(t)
+	defer dbConn.Close()
+	ctx := context.Background()
+
+	opts := schema.TableOptions{
+		Fields: []schema.FieldDef{
+			{Name: "title", Type: schema.FieldTypeString},
+			{Name: "price", Type: schema.FieldTypeInteger},
+			{Name: "category", Type: schema.FieldTypeString},
+		},
+	}
+	err := store.CreateDynamicTable(ctx, "test_products", opts)
+	assert.NoError(t, err)
+
+	testProducts := []map[string]interface{}{
+		{"id": "p1", "title": "Product 1", "price": 200, "category": "B"},
+		{"id": "p2", "title": "Product 2", "price": 100, "category": "A"},
+		{"id": "p3", "title": "Product 3", "price": 300, "category": "A"},
+		{"id": "p4", "title": "Product 4", "price": 150, "category": "B"},
+	}
+	for _, product := range testProducts {
+		assert.NoError(t, store.DynamicInsert(ctx, "test_products", product))
+	}
+
+	ids := func(results []map[string]interface{}) []string {
+		out := make([]string, len(results))
+		for i, r := range results {
+			out[i] = r["id"].(string)
+		}
+		return out
+	}
+
+	tests := []struct {
+		name    string
+		orderBy []query.OrderByClause
+		want    []string
+	}{
+		{
+			name:    "single column ascending",
+			orderBy: []query.OrderByClause{{Column: "price"}},
+			want:    []string{"p2", "p4", "p1", "p3"},
+		},
+		{
+			name:    "single column descending",
+			orderBy: []query.OrderByClause{{Column: "price", Desc: true}},
+			want:    []string{"p3", "p1", "p4", "p2"},
+		},
+		{
+			name: "two columns",
+			orderBy: []query.OrderByClause{
+				{Column: "category"},
+				{Column: "price", Desc: true},
+			},
+			want: []string{"p3", "p2", "p1", "p4"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := store.DynamicSelectOrdered(ctx, "test_products", nil, tt.orderBy)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, ids(results))
+		})
+	}
+
+	t.Run("with conditions", func(t *testing.T) {
+		results, err := store.DynamicSelectOrdered(ctx, "test_products",
+			map[string]interface{}{"category": "B"},
+			[]query.OrderByClause{{Column: "price"}})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"p4", "p1"}, ids(results))
+	})
+
+	t.Run("invalid column", func(t *testing.T) {
+		_, err := store.DynamicSelectOrdered(ctx, "test_products", nil,
+			[]query.OrderByClause{{Column: "price; DROP TABLE test_products"}})
+		assert.Error(t, err)
+	})
+}
+
+func TestDynamicStore_QueryOrGroups(t *testing.T) {
+	dbConn, store := setupTestDB(t)
+	defer dbConn.Close()
+	ctx := context.Background()
+
+	opts := schema.TableOptions{
+		Fields: []schema.FieldDef{
+			{Name: "title", Type: schema.FieldTypeString},
+			{Name: "price", Type: schema.FieldTypeInteger},
+			{Name: "category", Type: schema.FieldTypeString},
+		},
+	}
+	assert.NoError(t, store.CreateDynamicTable(ctx, "test_products", opts))
+
+	testProducts := []map[string]interface{}{
+		{"id": "p1", "title": "Product 1", "price": 50, "category": "A"},
+		{"id": "p2", "title": "Product 2", "price": 150, "category": "A"},
+		{"id": "p3", "title": "Product 3", "price": 200, "category": "B"},
+		{"id": "p4", "title": "Product 4", "price": 300, "category": "C"},
+	}
+	for _, product := range testProducts {
+		assert.NoError(t, store.DynamicInsert(ctx, "test_products", product))
+	}
+
+	t.Run("single OR group", func(t *testing.T) {
+		params := query.QueryParams{
+			SelectColumns: []string{"id"},
+			OrderBy:       []query.OrderByClause{{Column: "id"}},
+		}
+		params.AddOrGroup(
+			query.WhereCondition{Column: "category", Operator: "=", Value: "A"},
+			query.WhereCondition{Column: "category", Operator: "=", Value: "B"},
+		)
+
+		results, err := store.DynamicQuery(ctx, "test_products", params)
+		assert.NoError(t, err)
+		assert.Len(t, results, 3)
+	})
+
+	t.Run("OR group combined with AND condition", func(t *testing.T) {
+		params := query.QueryParams{
+			SelectColumns: []string{"id"},
+			Where: []query.WhereCondition{
+				{Column: "price", Operator: ">", Value: 100},
+			},
+			OrGroups: [][]query.WhereCondition{{
+				{Column: "category", Operator: "=", Value: "A"},
+				{Column: "category", Operator: "=", Value: "B"},
+			}},
+			OrderBy: []query.OrderByClause{{Column: "id"}},
+		}
+
+		clause := params.GetWhereClause()
+		assert.Equal(t, "price > ? AND (category = ? OR category = ?)", clause)
+		assert.Equal(t, []interface{}{100, "A", "B"}, params.GetArgs())
+
+		results, err := store.DynamicQuery(ctx, "test_products", query.QueryParams{
+			SelectColumns: params.SelectColumns,
+			Where:         params.Where,
+			OrGroups:      params.OrGroups,
+			OrderBy:       params.OrderBy,
+		})
+		assert.NoError(t, err)
+		if assert.Len(t, results, 2) {
+			assert.Equal(t, "p2", results[0]["id"])
+			assert.Equal(t, "p3", results[1]["id"])
+		}
+	})
+	t.Run("invalid columns and operators are rejected", func(t *testing.T) {
+		invalid := []query.QueryParams{
+			{Where: []query.WhereCondition{{Column: "1=1 OR category", Operator: "=", Value: "A"}}},
+			{Where: []query.WhereCondition{{Column: "category", Operator: "= 'A' OR 1 =", Value: "A"}}},
+			{OrGroups: [][]query.WhereCondition{{
+				{Column: "category", Operator: "=", Value: "A"},
+				{Column: "category) OR (1", Operator: "=", Value: 1},
+			}}},
+			{OrGroups: [][]query.WhereCondition{{{Column: "price", Operator: "IN", Value: 100}}}},
+			{OrderBy: []query.OrderByClause{{Column: "price; DROP TABLE test_products"}}},
+		}
+		for _, params := range invalid {
+			_, err := store.DynamicQuery(ctx, "test_products", params)
+			assert.Error(t, err)
+		}
+
+		// 연산자는 대소문자를 구분하지 않음
+		results, err := store.DynamicQuery(ctx, "test_products", query.QueryParams{
+			Where: []query.WhereCondition{{Column: "title", Operator: "like", Value: "Product%"}},
+		})
+		assert.NoError(t, err)
+		assert.Len(t, results, 4)
+	})
+}
+
+func TestDynamicStore_QueryAggregates(t *testing.T) {
+	dbConn, store := setupTestDB(t)
+	defer dbConn.Close()
+	ctx := context.Background()
+
+	opts := schema.TableOptions{
+		Fields: []schema.FieldDef{
+			{Name: "title", Type: schema.FieldTypeString},
+			{Name: "price", Type: schema.FieldTypeInteger},
+			{Name: "category", Type: schema.FieldTypeString},
+		},
+	}
+	assert.NoError(t, store.CreateDynamicTable(ctx, "test_products", opts))
+
+	testProducts := []map[string]interface{}{
+		{"id": "p1", "title": "Product 1", "price": 100, "category": "A"},
+		{"id": "p2", "title": "Product 2", "price": 200, "category": "A"},
+		{"id": "p3", "title": "Product 3", "price": 300, "category": "A"},
+		{"id": "p4", "title": "Product 4", "price": 400, "category": "B"},
+	}
+	for _, product := range testProducts {
+		assert.NoError(t, store.DynamicInsert(ctx, "test_products", product))
+	}
+
+	t.Run("COUNT grouped by category", func(t *testing.T) {
+		params := query.QueryParams{
+			SelectColumns: []string{"category"},
+			GroupBy:       []string{"category"},
+			OrderBy:       []query.OrderByClause{{Column: "category"}},
+		}
+		params.AddAggregate("COUNT", "*", "total")
+
+		results, err := store.DynamicQuery(ctx, "test_products", params)
+		assert.NoError(t, err)
+		if assert.Len(t, results, 2) {
+			assert.Equal(t, "A", results[0]["category"])
+			assert.Equal(t, int64(3), results[0]["total"])
+			assert.Equal(t, "B", results[1]["category"])
+			assert.Equal(t, int64(1), results[1]["total"])
+		}
+	})
+
+	t.Run("AVG over filtered set", func(t *testing.T) {
+		params := query.QueryParams{
+			Where: []query.WhereCondition{
+				{Column: "category", Operator: "=", Value: "A"},
+				{Column: "price", Operator: ">", Value: 100},
+			},
+			Aggregates: []query.AggregateClause{
+				{Function: "AVG", Column: "price", Alias: "avg_price"},
+			},
+		}
+
+		results, err := store.DynamicQuery(ctx, "test_products", params)
+		assert.NoError(t, err)
+		if assert.Len(t, results, 1) {
+			assert.Equal(t, float64(250), results[0]["avg_price"])
+		}
+	})
+
+	t.Run("invalid aggregates", func(t *testing.T) {
+		invalid := []query.QueryParams{
+			{Aggregates: []query.AggregateClause{{Function: "DROP", Column: "price", Alias: "x"}}},
+			{Aggregates: []query.AggregateClause{{Function: "SUM", Column: "*", Alias: "x"}}},
+			{Aggregates: []query.AggregateClause{{Function: "SUM", Column: "price", Alias: "x; --"}}},
+			{GroupBy: []string{"category; DROP TABLE test_products"}},
+		}
+		for _, params := range invalid {
+			_, err := store.DynamicQuery(ctx, "test_products", params)
+			assert.Error(t, err)
+		}
+	})
+}
+
+func TestDynamicStore_RetryOnBusy(t *testing.T) {
+	// :memory:는 커넥션마다 별도 DB이므로 파일 DB로 잠금 경합을 재현
+	mgr, err := manager.NewSQLManager(manager.Config{
+		Type:        "sqlite3",
+		DSN:         filepath.Join(t.TempDir(), "busy.db"),
+		BusyTimeout: time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("failed to create SQLManager: %v", err)
+	}
+	defer mgr.Close()
+
+	store, err := NewDynamicStore(mgr)
+	assert.NoError(t, err)
+	ctx := context.Background()
+
+	opts := schema.TableOptions{
+		Fields: []schema.FieldDef{{Name: "value", Type: schema.FieldTypeInteger}},
+	}
+	assert.NoError(t, store.CreateDynamicTable(ctx, "busy_items", opts))
+
+	const writers, perWriter = 8, 25
+	var wg sync.WaitGroup
+	errCh := make(chan error, writers*perWriter)
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < perWriter; i++ {
+				id := fmt.Sprintf("item-%d-%d", w, i)
+				if err := store.DynamicInsert(ctx, "busy_items", map[string]interface{}{"id": id, "value": i}); err != nil {
+					errCh <- err
+					continue
+				}
+				if err := store.DynamicUpdate(ctx, "busy_items", id, map[string]interface{}{"value": i + 1}); err != nil {
+					errCh <- err
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+	close(errCh)
+
+	for err := range errCh {
+		t.Errorf("unexpected write error: %v", err)
+	}
+
+	count, err := store.CountActive(ctx, "busy_items")
+	assert.NoError(t, err)
+	assert.Equal(t, writers*perWriter, count)
+}
+
+func TestIsBusyError(t *testing.T) {
+	assert.True(t, isBusyError(sqlite3.Error{Code: sqlite3.ErrBusy}))
+	assert.True(t, isBusyError(sqlite3.Error{Code: sqlite3.ErrLocked}))
+	assert.True(t, isBusyError(fmt.Errorf("wrapped: %w", sqlite3.Error{Code: sqlite3.ErrBusy})))
+	assert.False(t, isBusyError(sqlite3.Error{Code: sqlite3.ErrConstraint}))
+	assert.False(t, isBusyError(nil))
+}
+
+func TestDynamicStore_TableSchemaCache(t *testing.T) {
+	dbConn, store := setupTestDB(t)
+	defer dbConn.Close()
+	ctx := context.Background()
+
+	err := store.CreateDynamicTable(ctx, "cached_items", schema.TableOptions{
+		Fields: []schema.FieldDef{{Name: "title", Type: schema.FieldTypeString}},
+	})
+	assert.NoError(t, err)
+
+	columns, err := store.GetTableSchema(ctx, "cached_items")
+	assert.NoError(t, err)
+	assert.Contains(t, columns, "title TEXT")
+
+	// 스토어를 우회한 변경은 TTL 동안 보이지 않아야 함 (PRAGMA를 다시 읽지 않음)
+	_, err = dbConn.Exec("ALTER TABLE cached_items ADD COLUMN hidden TEXT")
+	assert.NoError(t, err)
+	cached, err := store.GetTableSchema(ctx, "cached_items")
+	assert.NoError(t, err)
+	assert.Equal(t, columns, cached)
+
+	t.Run("alter invalidates", func(t *testing.T) {
+		assert.NoError(t, store.AlterDynamicTable(ctx, "cached_items", map[string]string{"price INTEGER": "ADD"}))
+
+		columns, err := store.GetTableSchema(ctx, "cached_items")
+		assert.NoError(t, err)
+		assert.Contains(t, columns, "hidden TEXT")
+		assert.Contains(t, columns, "price INTEGER")
+	})
+
+	t.Run("drop column invalidates", func(t *testing.T) {
+		assert.NoError(t, store.DropColumn(ctx, "cached_items", "hidden", 100))
+
+		columns, err := store.GetTableSchema(ctx, "cached_items")
+		assert.NoError(t, err)
+		assert.NotContains(t, columns, "hidden TEXT")
+	})
+
+	t.Run("drop table invalidates", func(t *testing.T) {
+		assert.NoError(t, store.DropDynamicTable(ctx, "cached_items"))
+
+		columns, err := store.GetTableSchema(ctx, "cached_items")
+		assert.NoError(t, err)
+		assert.Empty(t, columns)
+	})
+
+	t.Run("returned slice is a copy", func(t *testing.T) {
+		assert.NoError(t, store.CreateDynamicTable(ctx, "copy_items", schema.TableOptions{}))
+
+		columns, err := store.GetTableSchema(ctx, "copy_items")
+		assert.NoError(t, err)
+		columns[0] = "mutated"
+
+		again, err := store.GetTableSchema(ctx, "copy_items")
+		assert.NoError(t, err)
+		assert.NotEqual(t, "mutated", again[0])
+	})
+}
+
+func TestDynamicStore_SlowQueryLog(t *testing.T) {
+	dbConn, store := setupTestDB(t)
+	defer dbConn.Close()
+	ctx := context.Background()
+
+	assert.NoError(t, store.CreateDynamicTable(ctx, "slow_items", schema.TableOptions{
+		Fields: []schema.FieldDef{{Name: "secret", Type: schema.FieldTypeString}},
+	}))
+
+	var buf bytes.Buffer
+	logger := log.New(&buf, "", 0)
+
+	// 기본값은 비활성화
+	store.SetSlowQueryConfig(SlowQueryConfig{Logger: logger})
+	assert.NoError(t, store.DynamicInsert(ctx, "slow_items", map[string]interface{}{"id": "a", "secret": "hunter2"}))
+	assert.Empty(t, buf.String())
+
+	store.SetSlowQueryConfig(SlowQueryConfig{Threshold: time.Nanosecond, Logger: logger})
+	assert.NoError(t, store.DynamicInsert(ctx, "slow_items", map[string]interface{}{"id": "b", "secret": "hunter2"}))
+	_, err := store.DynamicSelect(ctx, "slow_items", map[string]interface{}{"secret": "hunter2"})
+	assert.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if assert.Len(t, lines, 2) {
+		assert.Contains(t, lines[0], "slow query: table=slow_items")
+		assert.Contains(t, lines[0], "INSERT INTO slow_items")
+		assert.Contains(t, lines[1], "secret = ?")
+	}
+	// 파라미터 값은 기록되지 않아야 함
+	assert.NotContains(t, buf.String(), "hunter2")
+}
+
+func TestDynamicStore_OrderedAndPartialIndexes(t *testing.T) {
+	dbConn, store := setupTestDB(t)
+	defer dbConn.Close()
+	ctx := context.Background()
+
+	err := store.CreateDynamicTable(ctx, "events", schema.TableOptions{
+		Fields: []schema.FieldDef{
+			{Name: "owner", Type: schema.FieldTypeString},
+			{Name: "happened_at", Type: schema.FieldTypeTimestamp},
+			{Name: "kind", Type: schema.FieldTypeString},
+		},
+		Indexes: []schema.IndexDef{
+			{
+				Name:       "idx_events_owner_recent",
+				Columns:    []string{"owner", "happened_at"},
+				Directions: []schema.IndexDirection{schema.IndexAsc, schema.IndexDesc},
+			},
+			{
+				Name:    "idx_events_active_kind",
+				Columns: []string{"kind"},
+				Where:   []schema.IndexCondition{{Column: "deleted_at", Operator: "IS", Value: nil}, {Column: "kind", Operator: "!=", Value: "it's"}},
+			},
+		},
+	})
+	assert.NoError(t, err)
+
+	indexSQL := func(name string) string {
+		var sql string
+		err := dbConn.QueryRow("SELECT sql FROM sqlite_master WHERE type = 'index' AND name = ?", name).Scan(&sql)
+		assert.NoError(t, err)
+		return sql
+	}
+
+	assert.Contains(t, indexSQL("idx_events_owner_recent"), "(owner, happened_at DESC)")
+	assert.Contains(t, indexSQL("idx_events_active_kind"), "WHERE deleted_at IS NULL AND kind != 'it''s'")
+
+	t.Run("invalid definitions are rejected", func(t *testing.T) {
+		invalid := []schema.IndexDef{
+			{Name: "idx_bad_column", Columns: []string{"owner; DROP TABLE events"}},
+			{Name: "idx_bad_direction", Columns: []string{"owner"}, Directions: []schema.IndexDirection{"SIDEWAYS"}},
+			{Name: "idx_bad_operator", Columns: []string{"owner"}, Where: []schema.IndexCondition{{Column: "kind", Operator: "LIKE", Value: "a%"}}},
+			{Name: "idx_bad_predicate_column", Columns: []string{"owner"}, Where: []schema.IndexCondition{{Column: "1=1 OR kind", Operator: "=", Value: 1}}},
+		}
+		for _, idx := range invalid {
+			assert.Error(t, CreateIndex(ctx, dbConn, "events", idx), idx.Name)
+		}
+	})
+}
+
+func TestDynamicStore_PurgeDeleted(t *testing.T) {
+	dbConn, store := setupTestDB(t)
+	defer dbConn.Close()
+	ctx := context.Background()
+
+	assert.NoError(t, store.CreateDynamicTable(ctx, "purge_items", schema.TableOptions{}))
+
+	seed := func() {
+		_, err := dbConn.Exec(`DELETE FROM purge_items;
+            INSERT INTO purge_items (id, deleted_at) VALUES
+                ('old1', datetime('now', '-3 days')),
+                ('old2', datetime('now', '-2 days')),
+                ('old3', datetime('now', '-49 hours')),
+                ('recent', datetime('now', '-1 hour')),
+                ('live', NULL)`)
+		assert.NoError(t, err)
+	}
+	remaining := func() []string {
+		rows, err := dbConn.Query("SELECT id FROM purge_items ORDER BY id")
+		assert.NoError(t, err)
+		defer rows.Close()
+		var ids []string
+		for rows.Next() {
+			var id string
+			assert.NoError(t, rows.Scan(&id))
+			ids = append(ids, id)
+		}
+		return ids
+	}
+
+	seed()
+	n, err := store.PurgeDeleted(ctx, "purge_items", 24*time.Hour)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), n)
+	assert.Equal(t, []string{"live", "recent"}, remaining())
+
+	t.Run("batched", func(t *testing.T) {
+		seed()
+		n, err := store.purgeDeleted(ctx, "purge_items", 24*time.Hour, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), n)
+		assert.Equal(t, []string{"live", "recent"}, remaining())
+	})
+
+	t.Run("background job", func(t *testing.T) {
+		seed()
+		stop := store.StartPurgeJob(PurgeConfig{
+			Tables:          []string{"missing_table", "purge_items"},
+			RetentionPeriod: 24 * time.Hour,
+			PurgeInterval:   time.Hour,
+			Logger:          log.New(io.Discard, "", 0),
+		})
+		assert.Eventually(t, func() bool { return len(remaining()) == 2 }, time.Second, 10*time.Millisecond)
+		stop()
+	})
+
+	_, err = store.PurgeDeleted(ctx, "purge_items; DROP TABLE users", time.Hour)
+	assert.Error(t, err)
+}
+
+func TestNewDynamicStoreFromDB(t *testing.T) {
+	dbConn, err := sql.Open("sqlite3", ":memory:")
+	assert.NoError(t, err)
+	defer dbConn.Close()
+	dbConn.SetMaxOpenConns(1)
+
+	store := NewDynamicStoreFromDB(dbConn)
+	ctx := context.Background()
+	assert.NoError(t, store.HealthCheck(ctx))
+
+	_, err = dbConn.Exec(`CREATE TABLE notes (id TEXT PRIMARY KEY, body TEXT, created_at TIMESTAMP, updated_at TIMESTAMP, deleted_at TIMESTAMP)`)
+	assert.NoError(t, err)
+
+	assert.NoError(t, store.DynamicInsert(ctx, "notes", map[string]interface{}{"id": "n1", "body": "hello"}))
+	rows, err := store.DynamicSelect(ctx, "notes", map[string]interface{}{"id": "n1"})
+	assert.NoError(t, err)
+	if assert.Len(t, rows, 1) {
+		assert.Equal(t, "hello", rows[0]["body"])
+	}
+}
+
+func TestQueryParams_NullOperators(t *testing.T) {
+	params := query.QueryParams{
+		Where: []query.WhereCondition{
+			{Column: "last_login", Operator: query.OpIsNull},
+			{Column: "namespace", Operator: "=", Value: "default"},
+		},
+	}
+	params.AddOrGroup(
+		query.WhereCondition{Column: "email", Operator: query.OpIsNotNull},
+		query.WhereCondition{Column: "username", Operator: "LIKE", Value: "a%"},
+	)
+
+	assert.Equal(t, "last_login IS NULL AND namespace = ? AND (email IS NOT NULL OR username LIKE ?)", params.GetWhereClause())
+	assert.Equal(t, []interface{}{"default", "a%"}, params.GetArgs())
+}
+
+func TestDynamicStore_SlowQueryLogRequestID(t *testing.T) {
+	dbConn, store := setupTestDB(t)
+	defer dbConn.Close()
+	ctx := requestid.WithRequestID(context.Background(), "req-42")
+
+	var buf bytes.Buffer
+	store.SetSlowQueryConfig(SlowQueryConfig{Threshold: time.Nanosecond, Logger: log.New(&buf, "", 0)})
+	assert.NoError(t, store.CreateDynamicTable(context.Background(), "traced", schema.TableOptions{
+		Fields: []schema.FieldDef{{Name: "label", Type: schema.FieldTypeString}},
+	}))
+	_, err := store.DynamicSelect(ctx, "traced", nil)
+	assert.NoError(t, err)
+
+	assert.Contains(t, buf.String(), "slow query: request_id=req-42 table=traced")
+}
+
+func TestDynamicStore_SchemaVersionHelpers(t *testing.T) {
+	dbConn, store := setupTestDB(t)
+	defer dbConn.Close()
+	ctx := context.Background()
+
+	current, err := store.GetCurrentSchemaVersion(ctx, "versioned")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, current)
+
+	for _, change := range []string{"create table", "add column email", "add index email"} {
+		assert.NoError(t, store.TrackSchemaVersion(ctx, "versioned", change))
+		// 조회로 캐시를 채워도 다음 버전이 반영되어야 함
+		_, err := store.GetSchemaVersions(ctx, "versioned")
+		assert.NoError(t, err)
+	}
+
+	current, err = store.GetCurrentSchemaVersion(ctx, "versioned")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, current)
+
+	changes, err := store.GetSchemaChangesSince(ctx, "versioned", 1)
+	assert.NoError(t, err)
+	if assert.Len(t, changes, 2) {
+		assert.Equal(t, int64(2), changes[0].Version)
+		assert.Equal(t, "add column email", changes[0].Changes)
+		assert.Equal(t, int64(3), changes[1].Version)
+		assert.Equal(t, "add index email", changes[1].Changes)
+	}
+
+	changes, err = store.GetSchemaChangesSince(ctx, "versioned", 3)
+	assert.NoError(t, err)
+	assert.Empty(t, changes)
+}
+
+func TestDynamicStore_CreateTableFieldTypes(t *testing.T) {
+	dbConn, store := setupTestDB(t)
+	defer dbConn.Close()
+	ctx := context.Background()
+
+	for _, fieldType := range schema.AllowedFieldTypes {
+		t.Run(string(fieldType), func(t *testing.T) {
+			table := "typed_" + strings.ToLower(string(fieldType))
+			err := store.CreateDynamicTable(ctx, table, schema.TableOptions{
+				Fields: []schema.FieldDef{{Name: "value", Type: fieldType, Nullable: true}},
+			})
+			assert.NoError(t, err)
+		})
+	}
+
+	t.Run("unknown type", func(t *testing.T) {
+		t.Cleanup(func() { errors.ErrInvalidInput.Reason = "" })
+
+		err := store.CreateDynamicTable(ctx, "typed_unknown", schema.TableOptions{
+			Fields: []schema.FieldDef{{Name: "value", Type: "VARCHAR(10); DROP TABLE users", Nullable: true}},
+		})
+		assert.Equal(t, errors.ErrInvalidInput, err)
+		assert.ErrorContains(t, err, "unsupported type")
+
+		exists, err := store.TableExists(ctx, "typed_unknown")
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+}
+
+// cancelAfterChecks cancels itself once Err has been consulted n times, i.e. after n-1 DropColumn batches
+type cancelAfterChecks struct {
+	context.Context
+	cancel context.CancelFunc
+	n      int
+}
+
+func (c *cancelAfterChecks) Err() error {
+	if c.n--; c.n == 0 {
+		c.cancel()
+	}
+	return c.Context.Err()
+}
+
+func TestDynamicStore_DropColumnCancelled(t *testing.T) {
+	dbConn, store := setupTestDB(t)
+	defer dbConn.Close()
+	ctx := context.Background()
+
+	err := store.CreateDynamicTable(ctx, "big_table", schema.TableOptions{
+		Fields: []schema.FieldDef{
+			{Name: "name", Type: schema.FieldTypeString, Nullable: true},
+			{Name: "age", Type: schema.FieldTypeInteger, Nullable: true},
+		},
+	})
+	assert.NoError(t, err)
+
+	const rows = 5000
+	_, err = dbConn.Exec(`WITH RECURSIVE seq(n) AS (SELECT 1 UNION ALL SELECT n + 1 FROM seq WHERE n < ?)
+		INSERT INTO big_table (id, name, age) SELECT 'row-' || n, 'name-' || n, n FROM seq`, rows)
+	assert.NoError(t, err)
+
+	cancelCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	err = store.DropColumn(&cancelAfterChecks{Context: cancelCtx, cancel: cancel, n: 3}, "big_table", "age", 100)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	// 원본 테이블은 그대로, 임시 테이블은 정리됨
+	columns, err := store.GetTableSchema(ctx, "big_table")
+	assert.NoError(t, err)
+	assert.Contains(t, columns, "age INTEGER")
+
+	var count int
+	assert.NoError(t, dbConn.QueryRow("SELECT COUNT(*) FROM big_table").Scan(&count))
+	assert.Equal(t, rows, count)
+
+	exists, err := store.TableExists(ctx, "big_table_temp")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+}
+
+// recordedStatement is one statement captured by recordingDB
+type recordedStatement struct {
+	query string
+	args  []interface{}
+}
+
+// recordingDB implements manager.DB by recording statements instead of executing them.
+// QueryContext는 항상 errNotExecuted를, QueryRowContext는 nil을 반환하므로 SQL 생성 검증에만 사용
+type recordingDB struct {
+	statements []recordedStatement
+}
+
+var errNotExecuted = fmt.Errorf("recordingDB: statement not executed")
+
+var _ manager.DB = (*recordingDB)(nil)
+
+func (r *recordingDB) record(query string, args []interface{}) {
+	r.statements = append(r.statements, recordedStatement{query: query, args: args})
+}
+
+func (r *recordingDB) ExecContext(_ context.Context, query string, args ...interface{}) (sql.Result, error) {
+	r.record(query, args)
+	return driver.RowsAffected(1), nil
+}
+
+func (r *recordingDB) QueryContext(_ context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	r.record(query, args)
+	return nil, errNotExecuted
+}
+
+func (r *recordingDB) QueryRowContext(_ context.Context, query string, args ...interface{}) *sql.Row {
+	r.record(query, args)
+	return nil
+}
+
+func TestDynamicStore_GeneratedSQL(t *testing.T) {
+	ctx := context.Background()
+	rec := &recordingDB{}
+	store := newDynamicStore(nil, nil)
+	store.conn = rec
+
+	last := func() recordedStatement {
+		if len(rec.statements) == 0 {
+			t.Fatal("no statement recorded")
+		}
+		return rec.statements[len(rec.statements)-1]
+	}
+
+	t.Run("DynamicSelect", func(t *testing.T) {
+		_, err := store.DynamicSelectOrdered(ctx, "users", map[string]interface{}{"email": "a@example.com"},
+			[]query.OrderByClause{{Column: "created_at", Desc: true}})
+		assert.ErrorIs(t, err, errNotExecuted)
+		assert.Equal(t, "SELECT * FROM users WHERE deleted_at IS NULL AND email = ? ORDER BY created_at DESC", last().query)
+		assert.Equal(t, []interface{}{"a@example.com"}, last().args)
+	})
+
+	t.Run("DynamicUpdate", func(t *testing.T) {
+		err := store.DynamicUpdate(ctx, "users", "user-1", map[string]interface{}{"display_name": "Alice"})
+		assert.NoError(t, err)
+		assert.Equal(t, "UPDATE users SET display_name = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?", last().query)
+		assert.Equal(t, []interface{}{"Alice", "user-1"}, last().args)
+	})
+
+	t.Run("DynamicQuery", func(t *testing.T) {
+		_, err := store.DynamicQuery(ctx, "users", query.QueryParams{
+			SelectColumns: []string{"id", "email"},
+			Where: []query.WhereCondition{
+				{Column: "namespace", Operator: "=", Value: "default"},
+				{Column: "last_login", Operator: query.OpIsNull},
+			},
+			OrderBy: []query.OrderByClause{{Column: "id"}},
+			Limit:   10,
+		})
+		assert.ErrorIs(t, err, errNotExecuted)
+		assert.Equal(t, "SELECT id, email FROM users WHERE namespace = ? AND last_login IS NULL ORDER BY id LIMIT 10", last().query)
+		assert.Equal(t, []interface{}{"default"}, last().args)
+	})
+
+	t.Run("table prefix", func(t *testing.T) {
+		assert.NoError(t, store.SetTablePrefix("pauth_"))
+		t.Cleanup(func() { _ = store.SetTablePrefix("") })
+
+		_, err := store.DynamicSelect(ctx, "roles", nil)
+		assert.ErrorIs(t, err, errNotExecuted)
+		assert.Equal(t, "SELECT * FROM pauth_roles WHERE deleted_at IS NULL", last().query)
+		assert.Empty(t, last().args)
+	})
+}
+
+func TestDynamicStore_EmptyResultVsFailure(t *testing.T) {
+	dbConn, store := setupTestDB(t)
+	defer dbConn.Close()
+	ctx := context.Background()
+
+	err := store.CreateDynamicTable(ctx, "things", schema.TableOptions{
+		Fields: []schema.FieldDef{{Name: "name", Type: schema.FieldTypeString, Nullable: true}},
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, store.DynamicInsert(ctx, "things", map[string]interface{}{"id": "1", "name": "a"}))
+
+	t.Run("no rows is an empty result", func(t *testing.T) {
+		results, err := store.DynamicQuery(ctx, "things", query.QueryParams{
+			Where: []query.WhereCondition{{Column: "name", Operator: "=", Value: "missing"}},
+		})
+		assert.NoError(t, err)
+		assert.NotNil(t, results)
+		assert.Empty(t, results)
+
+		results, err = store.DynamicSelect(ctx, "things", map[string]interface{}{"name": "missing"})
+		assert.NoError(t, err)
+		assert.NotNil(t, results)
+		assert.Empty(t, results)
+
+		count, err := store.CountActiveWhere(ctx, "things", map[string]interface{}{"name": "missing"})
+		assert.NoError(t, err)
+		assert.Equal(t, 0, count)
+	})
+
+	t.Run("broken query is an error", func(t *testing.T) {
+		_, err := store.DynamicQuery(ctx, "things", query.QueryParams{
+			Where: []query.WhereCondition{{Column: "no_such_column", Operator: "=", Value: "x"}},
+		})
+		assert.Error(t, err)
+
+		_, err = store.DynamicSelect(ctx, "missing_table", nil)
+		assert.Error(t, err)
+	})
+}
+
+func TestDynamicStore_DynamicSelectWithOptions(t *testing.T) {
+	dbConn, store := setupTestDB(t)
+	defer dbConn.Close()
+	ctx := context.Background()
+
+	assert.NoError(t, store.CreateDynamicTable(ctx, "tombstones", schema.TableOptions{}))
+	_, err := dbConn.Exec(`INSERT INTO tombstones (id, deleted_at) VALUES
+        ('old', datetime('now', '-3 days')),
+        ('recent', datetime('now', '-1 hour')),
+        ('live', NULL)`)
+	assert.NoError(t, err)
+
+	ids := func(opts SelectOptions) []string {
+		opts.OrderBy = []query.OrderByClause{{Column: "id"}}
+		rows, err := store.DynamicSelectWithOptions(ctx, "tombstones", nil, opts)
+		assert.NoError(t, err)
+		result := make([]string, 0, len(rows))
+		for _, row := range rows {
+			result = append(result, row["id"].(string))
+		}
+		return result
+	}
+
+	t.Run("deleted rows are excluded by default", func(t *testing.T) {
+		assert.Equal(t, []string{"live"}, ids(SelectOptions{}))
+	})
+
+	t.Run("include deleted", func(t *testing.T) {
+		assert.Equal(t, []string{"live", "old", "recent"}, ids(SelectOptions{IncludeDeleted: true}))
+	})
+
+	t.Run("deleted before cutoff", func(t *testing.T) {
+		cutoff := time.Now().Add(-24 * time.Hour)
+		assert.Equal(t, []string{"old"}, ids(SelectOptions{IncludeDeleted: true, DeletedBefore: &cutoff}))
+		assert.Equal(t, []string{"recent"}, ids(SelectOptions{IncludeDeleted: true, DeletedAfter: &cutoff}))
+	})
+
+	t.Run("invalid combinations", func(t *testing.T) {
+		before, after := time.Now(), time.Now().Add(time.Hour)
+
+		_, err := store.DynamicSelectWithOptions(ctx, "tombstones", nil, SelectOptions{DeletedBefore: &before})
+		assert.Error(t, err)
+		_, err = store.DynamicSelectWithOptions(ctx, "tombstones", nil, SelectOptions{
+			IncludeDeleted: true, DeletedBefore: &before, DeletedAfter: &after,
+		})
+		assert.Error(t, err)
+	})
+}
+
+func TestDynamicStore_DynamicSelectCoercesFieldTypes(t *testing.T) {
+	dbConn, store := setupTestDB(t)
+	defer dbConn.Close()
+	ctx := context.Background()
+
+	fields := []schema.FieldDef{
+		{Name: "name", Type: schema.FieldTypeString, Nullable: true},
+		{Name: "quantity", Type: schema.FieldTypeInteger, Nullable: true},
+		{Name: "price", Type: schema.FieldTypeNumber, Nullable: true},
+		{Name: "active", Type: schema.FieldTypeBoolean, Nullable: true},
+		{Name: "seen_at", Type: schema.FieldTypeTimestamp, Nullable: true},
+		{Name: "meta", Type: schema.FieldTypeJSON, Nullable: true},
+	}
+	assert.NoError(t, store.CreateDynamicTable(ctx, "typed_rows", schema.TableOptions{Fields: fields}))
+
+	// 드라이버마다 다르게 돌려주는 표현을 섞어서 저장
+	_, err := dbConn.Exec(`INSERT INTO typed_rows (id, name, quantity, price, active, seen_at, meta) VALUES
+        ('a', 'widget', '7', 3, 1, '2024-05-01 10:00:00', '{"tags":["x"],"level":2}'),
+        ('b', 'gadget', 9, '2.5', 'false', '2024-05-02T11:30:00Z', NULL)`)
+	assert.NoError(t, err)
+
+	rows, err := store.DynamicSelectWithOptions(ctx, "typed_rows", nil, SelectOptions{
+		OrderBy: []query.OrderByClause{{Column: "id"}},
+		Fields:  fields,
+	})
+	assert.NoError(t, err)
+	if !assert.Len(t, rows, 2) {
+		return
+	}
+
+	for _, row := range rows {
+		assert.IsType(t, "", row["name"])
+		assert.IsType(t, int64(0), row["quantity"])
+		assert.IsType(t, float64(0), row["price"])
+		assert.IsType(t, false, row["active"])
+		assert.IsType(t, time.Time{}, row["seen_at"])
+		assert.IsType(t, time.Time{}, row["created_at"])
+		assert.Nil(t, row["deleted_at"])
+	}
+
+	assert.Equal(t, int64(7), rows[0]["quantity"])
+	assert.Equal(t, float64(3), rows[0]["price"])
+	assert.Equal(t, true, rows[0]["active"])
+	assert.Equal(t, time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC), rows[0]["seen_at"])
+	assert.Equal(t, map[string]interface{}{"tags": []interface{}{"x"}, "level": float64(2)}, rows[0]["meta"])
+
+	assert.Equal(t, 2.5, rows[1]["price"])
+	assert.Equal(t, false, rows[1]["active"])
+	assert.Equal(t, time.Date(2024, 5, 2, 11, 30, 0, 0, time.UTC), rows[1]["seen_at"])
+	assert.Nil(t, rows[1]["meta"])
+
+	t.Run("invalid JSON is an error", func(t *testing.T) {
+		_, err := dbConn.Exec(`INSERT INTO typed_rows (id, meta) VALUES ('c', '{broken')`)
+		assert.NoError(t, err)
+		_, err = store.DynamicSelectWithOptions(ctx, "typed_rows", map[string]interface{}{"id": "c"}, SelectOptions{Fields: fields})
+		assert.Error(t, err)
+	})
+}
+
+func TestDynamicStore_EnsureDynamicTable(t *testing.T) {
+	dbConn, store := setupTestDB(t)
+	defer dbConn.Close()
+	ctx := context.Background()
+
+	opts := schema.TableOptions{
+		Fields: []schema.FieldDef{
+			{Name: "name", Type: schema.FieldTypeString},
+		},
+	}
+	result, err := store.EnsureDynamicTable(ctx, "widgets", opts)
+	assert.NoError(t, err)
+	assert.True(t, result.Created)
+
+	t.Run("adds missing fields and indexes", func(t *testing.T) {
+		opts.Fields = append(opts.Fields, schema.FieldDef{Name: "color", Type: schema.FieldTypeString, Nullable: true})
+		opts.Indexes = []schema.IndexDef{{Name: "idx_widgets_color", Columns: []string{"color"}}}
+
+		result, err := store.EnsureDynamicTable(ctx, "widgets", opts)
+		assert.NoError(t, err)
+		assert.False(t, result.Created)
+		assert.Equal(t, []string{"color"}, result.AddedColumns)
+		assert.Equal(t, []string{"idx_widgets_color"}, result.CreatedIndexes)
+		assert.NoError(t, store.ValidateSchema(ctx, "widgets", opts.Fields))
+
+		// 두 번째 호출은 변경 없음
+		result, err = store.EnsureDynamicTable(ctx, "widgets", opts)
+		assert.NoError(t, err)
+		assert.Empty(t, result.AddedColumns)
+		assert.Empty(t, result.CreatedIndexes)
+	})
+
+	t.Run("reports conflicting type changes", func(t *testing.T) {
+		changed := schema.TableOptions{Fields: []schema.FieldDef{
+			{Name: "name", Type: schema.FieldTypeInteger},
+		}}
+
+		result, err := store.EnsureDynamicTable(ctx, "widgets", changed)
+		assert.Error(t, err)
+		assert.Equal(t, []SchemaConflict{{Column: "name", Reason: "existing type TEXT differs from requested INTEGER"}}, result.Conflicts)
+	})
+}
+
+func TestDynamicStore_ConstraintErrors(t *testing.T) {
+	dbConn, store := setupTestDB(t)
+	defer dbConn.Close()
+	ctx := context.Background()
+
+	assert.NoError(t, store.SetTablePrefix("pauth_"))
+	assert.NoError(t, store.CreateDynamicTable(ctx, "members", schema.TableOptions{
+		Fields: []schema.FieldDef{
+			{Name: "email", Type: schema.FieldTypeString},
+			{Name: "nickname", Type: schema.FieldTypeString, Nullable: true},
+		},
+		Indexes: []schema.IndexDef{{Name: "idx_members_email", Columns: []string{"email"}, Unique: true}},
+	}))
+	assert.NoError(t, store.DynamicInsert(ctx, "members", map[string]interface{}{"id": "m1", "email": "a@example.com"}))
+
+	// 기본값은 원본 에러
+	err := store.DynamicInsert(ctx, "members", map[string]interface{}{"id": "m2"})
+	assert.Contains(t, err.Error(), "NOT NULL constraint failed: pauth_members.email")
+
+	store.SetConstraintErrors(true)
+	fieldErrors := func(err error) []errors.FieldError {
+		var verr *errors.ValidationError
+		if !assert.ErrorAs(t, err, &verr) {
+			return nil
+		}
+		assert.Equal(t, 400, verr.Code())
+		return verr.Errors
+	}
+
+	t.Run("not null", func(t *testing.T) {
+		err := store.DynamicInsert(ctx, "members", map[string]interface{}{"id": "m2", "nickname": "b"})
+		assert.Equal(t, []errors.FieldError{{Field: "email", Message: "is required"}}, fieldErrors(err))
+
+		err = store.DynamicUpdate(ctx, "members", "m1", map[string]interface{}{"email": nil})
+		assert.Equal(t, []errors.FieldError{{Field: "email", Message: "is required"}}, fieldErrors(err))
+	})
+
+	t.Run("unique", func(t *testing.T) {
+		err := store.DynamicInsert(ctx, "members", map[string]interface{}{"id": "m2", "email": "a@example.com"})
+		assert.Equal(t, []errors.FieldError{{Field: "email", Message: "already exists"}}, fieldErrors(err))
+
+		err = store.DynamicInsert(ctx, "members", map[string]interface{}{"id": "m1", "email": "c@example.com"})
+		assert.Equal(t, []errors.FieldError{{Field: "id", Message: "already exists"}}, fieldErrors(err))
+	})
+
+	t.Run("other errors unchanged", func(t *testing.T) {
+		err := store.DynamicInsert(ctx, "members", map[string]interface{}{"id": "m3", "missing": "x"})
+		assert.Error(t, err)
+		var verr *errors.ValidationError
+		assert.False(t, stderrors.As(err, &verr))
+	})
+}
+
+func TestParseConstraintError(t *testing.T) {
+	tests := []struct {
+		msg  string
+		want []errors.FieldError
+	}{
+		{"NOT NULL constraint failed: users.email", []errors.FieldError{{Field: "email", Message: "is required"}}},
+		{"UNIQUE constraint failed: users.namespace, users.username", []errors.FieldError{
+			{Field: "namespace", Message: "already exists"},
+			{Field: "username", Message: "already exists"},
+		}},
+		{"CHECK constraint failed: positive_price", []errors.FieldError{{Field: "positive_price", Message: "violates a check constraint"}}},
+		{"CHECK constraint failed: price > 0", nil},
+		{"no such table: users", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.msg, func(t *testing.T) {
+			verr, ok := parseConstraintError(stderrors.New(tt.msg))
+			if tt.want == nil {
+				assert.False(t, ok)
+				return
+			}
+			if assert.True(t, ok) {
+				assert.Equal(t, tt.want, verr.Errors)
+			}
+		})
+	}
+}
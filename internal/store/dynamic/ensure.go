@@ -0,0 +1,134 @@
+package dynamic
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/sukryu/pAuth/internal/store/schema"
+)
+
+// SchemaConflict is a difference between an existing table and the requested
+// TableOptions that EnsureDynamicTable cannot resolve automatically
+type SchemaConflict struct {
+	Column string
+	Reason string
+}
+
+func (c SchemaConflict) String() string {
+	return fmt.Sprintf("%s: %s", c.Column, c.Reason)
+}
+
+// EnsureResult reports what EnsureDynamicTable changed
+type EnsureResult struct {
+	Created        bool
+	AddedColumns   []string
+	CreatedIndexes []string
+	// Conflicts are left untouched; 타입 변경 등은 마이그레이션으로 직접 처리해야 함
+	Conflicts []SchemaConflict
+}
+
+// EnsureDynamicTable creates tableName if it is missing, otherwise reconciles it with opts
+// by adding missing columns and indexes. 컬럼 타입 불일치처럼 자동으로 해결할 수 없는 차이는
+// Conflicts에 기록하고 error로도 반환하므로 스키마가 조용히 어긋나지 않음
+func (s *DynamicStore) EnsureDynamicTable(ctx context.Context, tableName string, opts schema.TableOptions) (*EnsureResult, error) {
+	exists, err := s.TableExists(ctx, tableName)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		if err := s.CreateDynamicTable(ctx, tableName, opts); err != nil {
+			return nil, err
+		}
+		return &EnsureResult{Created: true}, nil
+	}
+
+	result := &EnsureResult{}
+	fullName := s.TableName(tableName)
+
+	// 캐시가 오래된 스키마를 돌려주지 않도록 직접 조회
+	columns, err := s.loadTableSchema(ctx, fullName)
+	if err != nil {
+		return nil, err
+	}
+	existing := make(map[string]string, len(columns))
+	for _, col := range columns {
+		parts := strings.SplitN(col, " ", 2)
+		if len(parts) == 2 {
+			existing[parts[0]] = parts[1]
+		} else {
+			existing[parts[0]] = ""
+		}
+	}
+
+	for _, field := range opts.Fields {
+		if !field.Type.IsValid() {
+			return nil, fmt.Errorf("unsupported type %q for field %s", field.Type, field.Name)
+		}
+		currentType, ok := existing[field.Name]
+		if ok {
+			if !strings.EqualFold(currentType, string(field.Type)) {
+				result.Conflicts = append(result.Conflicts, SchemaConflict{
+					Column: field.Name,
+					Reason: fmt.Sprintf("existing type %s differs from requested %s", currentType, field.Type),
+				})
+			}
+			continue
+		}
+		// SQLite는 기본값 없는 NOT NULL 컬럼을 기존 테이블에 추가할 수 없음
+		if !field.Nullable && field.DefaultValue == nil {
+			result.Conflicts = append(result.Conflicts, SchemaConflict{
+				Column: field.Name,
+				Reason: "cannot add a NOT NULL column without a default value",
+			})
+			continue
+		}
+		if err := s.AddColumn(ctx, tableName, field.GenerateColumnDef()); err != nil {
+			return result, fmt.Errorf("failed to add column %s: %w", field.Name, err)
+		}
+		result.AddedColumns = append(result.AddedColumns, field.Name)
+	}
+
+	indexes, err := s.existingIndexes(ctx, fullName)
+	if err != nil {
+		return result, err
+	}
+	for _, idx := range opts.Indexes {
+		idx.Name = s.TableName(idx.Name)
+		if indexes[idx.Name] {
+			continue
+		}
+		if err := CreateIndex(ctx, s.db(ctx), fullName, idx); err != nil {
+			return result, fmt.Errorf("failed to create index %s: %w", idx.Name, err)
+		}
+		result.CreatedIndexes = append(result.CreatedIndexes, idx.Name)
+	}
+
+	if len(result.Conflicts) > 0 {
+		reasons := make([]string, 0, len(result.Conflicts))
+		for _, c := range result.Conflicts {
+			reasons = append(reasons, c.String())
+		}
+		return result, fmt.Errorf("table %s has incompatible schema: %s", fullName, strings.Join(reasons, "; "))
+	}
+	return result, nil
+}
+
+// existingIndexes returns the names of the indexes defined on tableName
+func (s *DynamicStore) existingIndexes(ctx context.Context, tableName string) (map[string]bool, error) {
+	rows, err := s.db(ctx).QueryContext(ctx, "SELECT name FROM sqlite_master WHERE type='index' AND tbl_name=?", tableName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	names := map[string]bool{}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		names[name] = true
+	}
+	return names, rows.Err()
+}
@@ -8,15 +8,31 @@ import (
 	"strings"
 
 	"github.com/sukryu/pAuth/internal/db"
+	"github.com/sukryu/pAuth/internal/store/manager"
 	"github.com/sukryu/pAuth/internal/store/schema"
 )
 
-// scanRows converts sql.Rows to []map[string]interface{}
+// scanRows converts sql.Rows to []map[string]interface{}.
+// 결과가 없으면 DynamicSelect와 같이 nil이 아닌 빈 슬라이스를 반환
 func scanRows(rows *sql.Rows) ([]map[string]interface{}, error) {
-	columns, err := rows.Columns()
+	results := make([]map[string]interface{}, 0)
+	err := scanEach(context.Background(), rows, func(row map[string]interface{}) error {
+		results = append(results, row)
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
+	return results, nil
+}
+
+// scanEach scans rows one at a time into a new map and passes it to fn.
+// fn의 에러나 ctx 취소 시 즉시 중단하며, rows를 닫는 것은 호출자의 책임
+func scanEach(ctx context.Context, rows *sql.Rows, fn func(map[string]interface{}) error) error {
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
 
 	values := make([]interface{}, len(columns))
 	valuePtrs := make([]interface{}, len(columns))
@@ -24,14 +40,15 @@ func scanRows(rows *sql.Rows) ([]map[string]interface{}, error) {
 		valuePtrs[i] = &values[i]
 	}
 
-	var results []map[string]interface{}
 	for rows.Next() {
-		err := rows.Scan(valuePtrs...)
-		if err != nil {
-			return nil, err
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := rows.Scan(valuePtrs...); err != nil {
+			return err
 		}
 
-		row := make(map[string]interface{})
+		row := make(map[string]interface{}, len(columns))
 		for i, col := range columns {
 			val := values[i]
 			if b, ok := val.([]byte); ok {
@@ -40,30 +57,24 @@ func scanRows(rows *sql.Rows) ([]map[string]interface{}, error) {
 				row[col] = val
 			}
 		}
-		results = append(results, row)
-	}
-
-	if err = rows.Err(); err != nil {
-		return nil, err
+		if err := fn(row); err != nil {
+			return err
+		}
 	}
 
-	return results, nil
+	return rows.Err()
 }
 
-// createIndex creates a new index on the specified table
-func CreateIndex(ctx context.Context, db db.DBTX, tableName string, index schema.IndexDef) error {
-	uniqueStr := ""
-	if index.Unique {
-		uniqueStr = "UNIQUE"
+// CreateIndex creates index on the specified table, including sort directions and partial index predicates
+func CreateIndex(ctx context.Context, db manager.Execer, tableName string, index schema.IndexDef) error {
+	if !isValidIdentifier(tableName) {
+		return fmt.Errorf("invalid table name: %s", tableName)
+	}
+	if err := validateIndex(index); err != nil {
+		return err
 	}
 
-	query := fmt.Sprintf("CREATE %s INDEX IF NOT EXISTS %s ON %s (%s)",
-		uniqueStr,
-		index.Name,
-		tableName,
-		strings.Join(index.Columns, ", "))
-
-	_, err := db.ExecContext(ctx, query)
+	_, err := db.ExecContext(ctx, index.GenerateCreateIndexSQL(tableName))
 	return err
 }
 
@@ -73,9 +84,8 @@ func (s *DynamicStore) ValidateSchemaDefinition(fields map[string]string) error
 			return fmt.Errorf("invalid field definition: field name or type is empty")
 		}
 
-		// 허용된 데이터 타입 확인
-		validTypes := []string{"TEXT", "INTEGER", "REAL", "BLOB", "BOOLEAN", "DATETIME"}
-		if !contains(validTypes, fieldType) {
+		// 허용된 데이터 타입 확인 (schema.AllowedFieldTypes)
+		if !schema.FieldType(fieldType).IsValid() {
 			return fmt.Errorf("invalid field type: %s is not supported", fieldType)
 		}
 	}
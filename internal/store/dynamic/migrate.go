@@ -0,0 +1,263 @@
+package dynamic
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/sukryu/pAuth/internal/store/schema"
+)
+
+// migration is one step of the core schema, applied at most once per database
+type migration struct {
+	Version     int
+	Description string
+	Apply       func(ctx context.Context, s *DynamicStore) error
+}
+
+// coreMigrations provision the tables the stores rely on, in version order.
+// 적용된 migration은 수정하지 말고 새 버전을 추가해야 함.
+// v2, v3은 버전별 스냅샷이 아닌 현재의 schema.CoreSchemas를 읽으므로, 나중에 추가된 컬럼/인덱스도
+// 처음부터 만들어질 수 있음. 이미 적용된 DB에는 RunCoreMigrations가 매 시작 시 같은 추가분을 반영함.
+// 컬럼 제거나 타입 변경처럼 추가가 아닌 변경은 반드시 새 migration으로 처리해야 함
+var coreMigrations = []migration{
+	{Version: 1, Description: "schema metadata tables", Apply: createMetadataTables},
+	{Version: 2, Description: "core entity tables", Apply: ensureCoreTables},
+	{Version: 3, Description: "namespace-scoped keys", Apply: scopeKeysToNamespace},
+}
+
+// CoreMigrationVersion is the version RunCoreMigrations migrates to
+var CoreMigrationVersion = coreMigrations[len(coreMigrations)-1].Version
+
+// RunCoreMigrations creates the schema metadata tables and the core entity tables
+// (schema.CoreSchemas) that are missing, recording each applied version in schema_migrations.
+// 이후 매번 core 테이블을 CoreSchemas와 다시 맞추므로 (ensureCoreTables) v2 적용 뒤에 추가된
+// 컬럼과 인덱스도 기존 DB에 생성됨
+func (s *DynamicStore) RunCoreMigrations(ctx context.Context) error {
+	if err := s.MigrateToVersion(ctx, CoreMigrationVersion); err != nil {
+		return err
+	}
+	if err := s.InTransaction(ctx, func(ctx context.Context) error {
+		return ensureCoreTables(ctx, s)
+	}); err != nil {
+		return fmt.Errorf("failed to reconcile core tables: %w", err)
+	}
+	return nil
+}
+
+// MigrateToVersion applies the core migrations newer than the recorded version up to target.
+// 각 migration은 기록과 함께 하나의 트랜잭션에서 적용되므로 실패 시 다음 실행에서 다시 시도됨
+func (s *DynamicStore) MigrateToVersion(ctx context.Context, target int) error {
+	if target < 0 || target > CoreMigrationVersion {
+		return fmt.Errorf("unknown migration version %d (latest is %d)", target, CoreMigrationVersion)
+	}
+
+	query := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+        version INTEGER PRIMARY KEY,
+        description TEXT NOT NULL,
+        applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+    )`, s.TableName("schema_migrations"))
+	if _, err := s.db(ctx).ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("failed to create schema_migrations table: %w", err)
+	}
+
+	current, err := s.MigrationVersion(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range coreMigrations {
+		if m.Version <= current || m.Version > target {
+			continue
+		}
+		err := s.InTransaction(ctx, func(ctx context.Context) error {
+			if err := m.Apply(ctx, s); err != nil {
+				return err
+			}
+			insert := fmt.Sprintf("INSERT INTO %s (version, description) VALUES (?, ?)", s.TableName("schema_migrations"))
+			_, err := s.db(ctx).ExecContext(ctx, insert, m.Version, m.Description)
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("migration %d (%s) failed: %w", m.Version, m.Description, err)
+		}
+	}
+	return nil
+}
+
+// MigrationVersion returns the latest applied core migration, or 0 if none was applied
+func (s *DynamicStore) MigrationVersion(ctx context.Context) (int, error) {
+	query := fmt.Sprintf("SELECT MAX(version) FROM %s", s.TableName("schema_migrations"))
+	var version sql.NullInt64
+	if err := s.db(ctx).QueryRowContext(ctx, query).Scan(&version); err != nil {
+		return 0, fmt.Errorf("failed to read migration version: %w", err)
+	}
+	return int(version.Int64), nil
+}
+
+// createMetadataTables creates the tables that describe dynamic schemas (sql/schema.sql)
+func createMetadataTables(ctx context.Context, s *DynamicStore) error {
+	statements := []string{
+		fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+            id TEXT PRIMARY KEY,
+            name TEXT UNIQUE NOT NULL,
+            description TEXT,
+            fields TEXT NOT NULL,
+            indexes TEXT,
+            annotations TEXT,
+            created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+            updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+            deleted_at TIMESTAMP
+        )`, s.TableName("entity_schemas")),
+		fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+            id INTEGER PRIMARY KEY AUTOINCREMENT,
+            schema_name TEXT NOT NULL,
+            version INTEGER NOT NULL,
+            changes TEXT NOT NULL,
+            created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+        )`, s.TableName("schema_versions")),
+		fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+            id INTEGER PRIMARY KEY AUTOINCREMENT,
+            parent_schema TEXT NOT NULL,
+            child_schema TEXT NOT NULL,
+            dependency_type TEXT NOT NULL,
+            created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+        )`, s.TableName("schema_dependencies")),
+	}
+	for _, stmt := range statements {
+		if _, err := s.db(ctx).ExecContext(ctx, stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ensureCoreTables creates or reconciles the table of every schema.CoreSchemas entry
+func ensureCoreTables(ctx context.Context, s *DynamicStore) error {
+	for _, entity := range schema.CoreSchemas {
+		if _, err := s.EnsureDynamicTable(ctx, entity.Name, entity.TableOptions()); err != nil {
+			return fmt.Errorf("table %s: %w", entity.Name, err)
+		}
+	}
+	return nil
+}
+
+// scopeKeysToNamespace rebuilds the core tables with KeyColumns that were created with a global
+// id primary key, so that names only need to be unique within a namespace.
+// SQLite는 primary key를 변경할 수 없으므로 새 테이블을 만들고 행을 복사함
+func scopeKeysToNamespace(ctx context.Context, s *DynamicStore) error {
+	for _, entity := range schema.CoreSchemas {
+		if len(entity.KeyColumns) == 0 {
+			continue
+		}
+		exists, err := s.TableExists(ctx, entity.Name)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			continue
+		}
+		keys, err := s.primaryKeyColumns(ctx, s.TableName(entity.Name))
+		if err != nil {
+			return fmt.Errorf("table %s: %w", entity.Name, err)
+		}
+		if len(keys) > 1 {
+			continue // 이미 복합 키로 생성됨
+		}
+		if err := s.rebuildTable(ctx, entity.Name, entity.TableOptions()); err != nil {
+			return fmt.Errorf("table %s: %w", entity.Name, err)
+		}
+	}
+	return nil
+}
+
+// primaryKeyColumns returns the primary key columns of tableName in key order
+func (s *DynamicStore) primaryKeyColumns(ctx context.Context, tableName string) ([]string, error) {
+	rows, err := s.db(ctx).QueryContext(ctx, fmt.Sprintf("PRAGMA table_info(%s)", tableName))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	keys := map[int]string{}
+	for rows.Next() {
+		var (
+			cid, notNull, pk int
+			name, colType    string
+			defaultValue     sql.NullString
+		)
+		if err := rows.Scan(&cid, &name, &colType, &notNull, &defaultValue, &pk); err != nil {
+			return nil, err
+		}
+		if pk > 0 {
+			keys[pk] = name
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	columns := make([]string, 0, len(keys))
+	for i := 1; i <= len(keys); i++ {
+		columns = append(columns, keys[i])
+	}
+	return columns, nil
+}
+
+// rebuildTable recreates tableName from opts and copies the rows of the columns both tables share.
+// 기존 인덱스는 이름이 겹치지 않도록 먼저 삭제하고 opts.Indexes로 다시 생성됨
+func (s *DynamicStore) rebuildTable(ctx context.Context, tableName string, opts schema.TableOptions) error {
+	fullName := s.TableName(tableName)
+	oldName := fullName + "_rebuild"
+	defer s.invalidateTableSchema(fullName)
+
+	oldColumns, err := s.loadTableSchema(ctx, fullName)
+	if err != nil {
+		return err
+	}
+	if _, err := s.db(ctx).ExecContext(ctx, fmt.Sprintf("ALTER TABLE %s RENAME TO %s", fullName, oldName)); err != nil {
+		return fmt.Errorf("failed to rename table: %w", err)
+	}
+
+	indexes, err := s.existingIndexes(ctx, oldName)
+	if err != nil {
+		return err
+	}
+	for name := range indexes {
+		if strings.HasPrefix(name, "sqlite_") {
+			continue // 제약 조건이 만든 자동 인덱스는 테이블과 함께 삭제됨
+		}
+		if _, err := s.db(ctx).ExecContext(ctx, fmt.Sprintf("DROP INDEX %s", name)); err != nil {
+			return fmt.Errorf("failed to drop index %s: %w", name, err)
+		}
+	}
+
+	if err := s.CreateDynamicTable(ctx, tableName, opts); err != nil {
+		return err
+	}
+	newColumns, err := s.loadTableSchema(ctx, fullName)
+	if err != nil {
+		return err
+	}
+	shared := make(map[string]bool, len(newColumns))
+	for _, name := range getColumnNames(newColumns) {
+		shared[name] = true
+	}
+	var columns []string
+	for _, name := range getColumnNames(oldColumns) {
+		if shared[name] {
+			columns = append(columns, name)
+		}
+	}
+
+	copySQL := fmt.Sprintf("INSERT INTO %s (%s) SELECT %s FROM %s",
+		fullName, strings.Join(columns, ", "), strings.Join(columns, ", "), oldName)
+	if _, err := s.db(ctx).ExecContext(ctx, copySQL); err != nil {
+		return fmt.Errorf("failed to copy rows: %w", err)
+	}
+	if _, err := s.db(ctx).ExecContext(ctx, fmt.Sprintf("DROP TABLE %s", oldName)); err != nil {
+		return fmt.Errorf("failed to drop old table: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+package dynamic
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sukryu/pAuth/internal/store/manager"
+	"github.com/sukryu/pAuth/internal/store/schema"
+)
+
+func TestDynamicStore_RunCoreMigrations(t *testing.T) {
+	mgr, err := manager.NewSQLManager(manager.Config{Type: "sqlite3", DSN: ":memory:"})
+	require.NoError(t, err)
+	defer mgr.Close()
+	store, err := NewDynamicStore(mgr)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	require.NoError(t, store.RunCoreMigrations(ctx))
+
+	tables := []string{"schema_migrations", "entity_schemas", "schema_versions", "schema_dependencies"}
+	for _, entity := range schema.CoreSchemas {
+		tables = append(tables, entity.Name)
+	}
+	for _, table := range tables {
+		exists, err := store.TableExists(ctx, table)
+		assert.NoError(t, err)
+		assert.True(t, exists, table)
+	}
+
+	version, err := store.MigrationVersion(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, CoreMigrationVersion, version)
+
+	// 필수가 아닌 필드는 생략해도 삽입 가능해야 함
+	assert.NoError(t, store.DynamicInsert(ctx, "users", map[string]interface{}{
+		"id":            "alice",
+		"username":      "alice",
+		"email":         "alice@example.com",
+		"password_hash": "hash",
+	}))
+	rows, err := store.DynamicSelect(ctx, "users", map[string]interface{}{"id": "alice"})
+	assert.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, "default", rows[0]["namespace"])
+
+	t.Run("re-running is a no-op", func(t *testing.T) {
+		require.NoError(t, store.RunCoreMigrations(ctx))
+
+		var applied int
+		require.NoError(t, mgr.GetDB().QueryRow("SELECT COUNT(*) FROM schema_migrations").Scan(&applied))
+		assert.Equal(t, len(coreMigrations), applied)
+	})
+
+	t.Run("core schema additions are reconciled on start", func(t *testing.T) {
+		original := schema.CoreSchemas
+		defer func() { schema.CoreSchemas = original }()
+
+		extended := make([]schema.EntitySchema, len(original))
+		copy(extended, original)
+		users := &extended[0]
+		require.Equal(t, "users", users.Name)
+		users.Fields = append(append([]schema.FieldDef{}, users.Fields...),
+			schema.FieldDef{Name: "nickname", Type: schema.FieldTypeString, Nullable: true})
+		users.Indexes = append(append([]schema.IndexDef{}, users.Indexes...),
+			schema.IndexDef{Name: "idx_users_nickname", Columns: []string{"nickname"}})
+		schema.CoreSchemas = extended
+
+		require.NoError(t, store.RunCoreMigrations(ctx))
+
+		columns, err := store.GetTableSchema(ctx, "users")
+		require.NoError(t, err)
+		assert.Contains(t, getColumnNames(columns), "nickname")
+		indexes, err := store.existingIndexes(ctx, "users")
+		require.NoError(t, err)
+		assert.True(t, indexes["idx_users_nickname"])
+	})
+
+	t.Run("unknown version is rejected", func(t *testing.T) {
+		assert.Error(t, store.MigrateToVersion(ctx, CoreMigrationVersion+1))
+	})
+}
+
+func TestDynamicStore_MigrateToVersion(t *testing.T) {
+	mgr, err := manager.NewSQLManager(manager.Config{Type: "sqlite3", DSN: ":memory:"})
+	require.NoError(t, err)
+	defer mgr.Close()
+	store, err := NewDynamicStore(mgr)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	require.NoError(t, store.MigrateToVersion(ctx, 1))
+
+	exists, err := store.TableExists(ctx, "entity_schemas")
+	assert.NoError(t, err)
+	assert.True(t, exists)
+	exists, err = store.TableExists(ctx, "users")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	require.NoError(t, store.RunCoreMigrations(ctx))
+	exists, err = store.TableExists(ctx, "users")
+	assert.NoError(t, err)
+	assert.True(t, exists)
+}
+
+func TestDynamicStore_ScopeKeysToNamespace(t *testing.T) {
+	mgr, err := manager.NewSQLManager(manager.Config{Type: "sqlite3", DSN: ":memory:"})
+	require.NoError(t, err)
+	defer mgr.Close()
+	store, err := NewDynamicStore(mgr)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	// 버전 2까지 적용된 데이터베이스: id가 전역 primary key이고 username이 전역 UNIQUE
+	require.NoError(t, store.MigrateToVersion(ctx, 1))
+	var users schema.EntitySchema
+	for _, entity := range schema.CoreSchemas {
+		if entity.Name == "users" {
+			users = entity
+		}
+	}
+	legacy := users.TableOptions()
+	legacy.KeyColumns = nil
+	legacy.Indexes = []schema.IndexDef{{Name: "idx_users_username", Columns: []string{"username"}, Unique: true}}
+	require.NoError(t, store.CreateDynamicTable(ctx, "users", legacy))
+	_, err = mgr.GetDB().Exec("INSERT INTO schema_migrations (version, description) VALUES (2, 'core entity tables')")
+	require.NoError(t, err)
+
+	alice := func(ns string) map[string]interface{} {
+		return map[string]interface{}{
+			"id":            "alice",
+			"namespace":     ns,
+			"username":      "alice",
+			"email":         "alice@example.com",
+			"password_hash": "hash",
+		}
+	}
+	require.NoError(t, store.DynamicInsert(ctx, "users", alice("default")))
+	assert.Error(t, store.DynamicInsert(ctx, "users", alice("team-a")))
+
+	require.NoError(t, store.RunCoreMigrations(ctx))
+
+	keys, err := store.primaryKeyColumns(ctx, "users")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"namespace", "id"}, keys)
+
+	// 기존 행은 유지되고 같은 이름을 다른 namespace에 만들 수 있음
+	rows, err := store.DynamicSelect(ctx, "users", map[string]interface{}{"id": "alice"})
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, "alice@example.com", rows[0]["email"])
+
+	assert.NoError(t, store.DynamicInsert(ctx, "users", alice("team-a")))
+	assert.Error(t, store.DynamicInsert(ctx, "users", alice("team-a")))
+
+	// 수정과 삭제는 scope의 namespace에만 적용됨
+	require.NoError(t, store.DynamicDeleteScoped(ctx, "users", "alice", map[string]interface{}{"namespace": "team-a"}))
+	rows, err = store.DynamicSelect(ctx, "users", map[string]interface{}{"id": "alice"})
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, "default", rows[0]["namespace"])
+}
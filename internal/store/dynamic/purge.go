@@ -0,0 +1,117 @@
+package dynamic
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// DefaultPurgeBatchSize is the number of rows removed per DELETE statement
+const DefaultPurgeBatchSize = 500
+
+// DefaultPurgeInterval is how often the purge job runs when no interval is configured
+const DefaultPurgeInterval = time.Hour
+
+// PurgeConfig configures the background purge of soft-deleted rows
+type PurgeConfig struct {
+	Tables []string
+
+	// RetentionPeriod is how long soft-deleted rows are kept. 0이면 purge job 비활성화
+	RetentionPeriod time.Duration
+	PurgeInterval   time.Duration
+	BatchSize       int
+	Logger          *log.Logger // nil이면 log.Default()
+}
+
+// PurgeDeleted hard-deletes rows of tableName soft-deleted more than olderThan ago
+// and returns the number of rows removed
+func (s *DynamicStore) PurgeDeleted(ctx context.Context, tableName string, olderThan time.Duration) (int64, error) {
+	return s.purgeDeleted(ctx, tableName, olderThan, DefaultPurgeBatchSize)
+}
+
+// purgeDeleted deletes in batches so a large purge does not hold the write lock for long
+func (s *DynamicStore) purgeDeleted(ctx context.Context, tableName string, olderThan time.Duration, batchSize int) (int64, error) {
+	tableName = s.TableName(tableName)
+	if !isValidIdentifier(tableName) {
+		return 0, fmt.Errorf("invalid table name: %s", tableName)
+	}
+	if batchSize <= 0 {
+		batchSize = DefaultPurgeBatchSize
+	}
+
+	// CURRENT_TIMESTAMP(UTC)와 드라이버가 쓴 time.Time 형식을 모두 비교할 수 있도록 datetime()으로 정규화
+	cutoff := time.Now().Add(-olderThan).UTC().Format("2006-01-02 15:04:05")
+	query := fmt.Sprintf(`DELETE FROM %s WHERE id IN (
+        SELECT id FROM %s WHERE deleted_at IS NOT NULL AND datetime(deleted_at) < datetime(?) LIMIT ?)`,
+		tableName, tableName)
+
+	var total int64
+	for {
+		result, err := s.execWithRetry(ctx, tableName, query, cutoff, batchSize)
+		if err != nil {
+			return total, err
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return total, err
+		}
+		total += affected
+
+		if affected < int64(batchSize) {
+			return total, nil
+		}
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+	}
+}
+
+// StartPurgeJob periodically purges soft-deleted rows of cfg.Tables until the returned stop function is called
+func (s *DynamicStore) StartPurgeJob(cfg PurgeConfig) (stop func()) {
+	if cfg.RetentionPeriod <= 0 || len(cfg.Tables) == 0 {
+		return func() {}
+	}
+	if cfg.PurgeInterval <= 0 {
+		cfg.PurgeInterval = DefaultPurgeInterval
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = log.Default()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(cfg.PurgeInterval)
+		defer ticker.Stop()
+
+		for {
+			for _, table := range cfg.Tables {
+				// 아직 생성되지 않은 테이블은 건너뜀
+				if columns, err := s.GetTableSchema(ctx, table); err != nil || len(columns) == 0 {
+					continue
+				}
+				n, err := s.purgeDeleted(ctx, table, cfg.RetentionPeriod, cfg.BatchSize)
+				if err != nil && ctx.Err() == nil {
+					cfg.Logger.Printf("Failed to purge deleted rows from %s: %v", table, err)
+				} else if n > 0 {
+					cfg.Logger.Printf("Purged %d deleted rows from %s", n, table)
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return func() {
+		cancel()
+		wg.Wait()
+	}
+}
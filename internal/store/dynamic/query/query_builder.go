@@ -7,7 +7,10 @@ import (
 
 type QueryParams struct {
 	SelectColumns []string
+	Aggregates    []AggregateClause
+	GroupBy       []string
 	Where         []WhereCondition
+	OrGroups      [][]WhereCondition // 각 그룹은 괄호로 묶인 OR 조건이며 Where와 AND로 결합
 	OrderBy       []OrderByClause
 	Limit         int
 	Offset        int
@@ -20,30 +23,110 @@ type WhereCondition struct {
 	Value    interface{}
 }
 
+// Null-check operators render as "col IS NULL" / "col IS NOT NULL" and bind no value
+const (
+	OpIsNull    = "ISNULL"
+	OpIsNotNull = "ISNOTNULL"
+)
+
+// comparisonOperators are the operators a WhereCondition may bind a value with
+var comparisonOperators = map[string]bool{
+	"=": true, "!=": true, "<>": true, "<": true, "<=": true, ">": true, ">=": true,
+	"LIKE": true, "NOT LIKE": true, "IS": true, "IS NOT": true,
+	OpIsNull: true, OpIsNotNull: true,
+}
+
+// IsValidOperator reports whether op is a supported WHERE operator; 대소문자는 구분하지 않음
+func IsValidOperator(op string) bool {
+	return comparisonOperators[strings.ToUpper(op)]
+}
+
+// render returns the SQL fragment for w and whether it binds w.Value
+func (w WhereCondition) render() (string, bool) {
+	switch strings.ToUpper(w.Operator) {
+	case OpIsNull:
+		return w.Column + " IS NULL", false
+	case OpIsNotNull:
+		return w.Column + " IS NOT NULL", false
+	}
+	return fmt.Sprintf("%s %s ?", w.Column, w.Operator), true
+}
+
 type OrderByClause struct {
 	Column string
 	Desc   bool
 }
 
+// AggregateClause renders as FUNCTION(column) AS alias in the SELECT clause
+type AggregateClause struct {
+	Function string // COUNT, SUM, AVG, MIN, MAX
+	Column   string // COUNT의 경우 "*" 허용
+	Alias    string
+}
+
+var aggregateFunctions = map[string]bool{
+	"COUNT": true,
+	"SUM":   true,
+	"AVG":   true,
+	"MIN":   true,
+	"MAX":   true,
+}
+
+// IsValidAggregateFunction reports whether fn is a supported aggregate function
+func IsValidAggregateFunction(fn string) bool {
+	return aggregateFunctions[strings.ToUpper(fn)]
+}
+
+func (a AggregateClause) String() string {
+	return fmt.Sprintf("%s(%s) AS %s", strings.ToUpper(a.Function), a.Column, a.Alias)
+}
+
 func (p *QueryParams) GetSelectClause() string {
-	if len(p.SelectColumns) == 0 {
+	if len(p.SelectColumns) == 0 && len(p.Aggregates) == 0 {
 		return "*"
 	}
-	return strings.Join(p.SelectColumns, ", ")
+	parts := make([]string, 0, len(p.SelectColumns)+len(p.Aggregates))
+	parts = append(parts, p.SelectColumns...)
+	for _, a := range p.Aggregates {
+		parts = append(parts, a.String())
+	}
+	return strings.Join(parts, ", ")
+}
+
+func (p *QueryParams) GetGroupByClause() string {
+	return strings.Join(p.GroupBy, ", ")
 }
 
 func (p *QueryParams) GetWhereClause() string {
-	if len(p.Where) == 0 {
+	if len(p.Where) == 0 && len(p.OrGroups) == 0 {
 		return ""
 	}
-	conditions := make([]string, len(p.Where))
-	for i, w := range p.Where {
-		conditions[i] = fmt.Sprintf("%s %s ?", w.Column, w.Operator)
-		p.Args = append(p.Args, w.Value)
+	conditions := make([]string, 0, len(p.Where)+len(p.OrGroups))
+	for _, w := range p.Where {
+		conditions = append(conditions, p.addCondition(w))
+	}
+	for _, group := range p.OrGroups {
+		if len(group) == 0 {
+			continue
+		}
+		parts := make([]string, len(group))
+		for i, w := range group {
+			parts[i] = p.addCondition(w)
+		}
+		conditions = append(conditions, "("+strings.Join(parts, " OR ")+")")
 	}
 	return strings.Join(conditions, " AND ")
 }
 
+// addCondition renders w and appends its bound value, if any, to Args
+func (p *QueryParams) addCondition(w WhereCondition) string {
+	clause, bound := w.render()
+	if bound {
+		p.Args = append(p.Args, w.Value)
+	}
+	return clause
+}
+
 func (p *QueryParams) GetOrderByClause() string {
 	if len(p.OrderBy) == 0 {
 		return ""
@@ -87,3 +170,15 @@ func (p *QueryParams) AddOrderBy(column string, desc bool) {
 		Desc:   desc,
 	})
 }
+
+func (p *QueryParams) AddOrGroup(conditions ...WhereCondition) {
+	p.OrGroups = append(p.OrGroups, conditions)
+}
+
+func (p *QueryParams) AddAggregate(function, column, alias string) {
+	p.Aggregates = append(p.Aggregates, AggregateClause{
+		Function: function,
+		Column:   column,
+		Alias:    alias,
+	})
+}
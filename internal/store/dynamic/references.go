@@ -0,0 +1,139 @@
+package dynamic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// jsonReference is a reference a core table stores by name or inside a JSON array of {kind, name}
+// instead of a FOREIGN KEY, so PRAGMA foreign_key_check cannot see it
+type jsonReference struct {
+	table  string // 참조하는 테이블
+	column string
+	list   bool   // column이 {kind, name} JSON 배열인지 여부
+	kind   string // list 항목 중 이 kind만 검사 (""이면 모두)
+
+	parent       string // 참조되는 테이블
+	parentColumn string
+	namespaced   bool // 부모 행이 참조하는 행과 같은 namespace에 있어야 함
+}
+
+// coreJSONReferences lists the RBAC references checked by ValidateReferentialIntegrity.
+// Group subject는 저장된 객체가 아니므로 검사하지 않으며, ClusterRoleBinding의 User subject는
+// 모든 namespace의 같은 이름 사용자에게 적용되므로 namespace와 무관하게 검사함
+var coreJSONReferences = []jsonReference{
+	{table: "role_bindings", column: "role_ref", parent: "roles", parentColumn: "name", namespaced: true},
+	{table: "role_bindings", column: "role_refs", list: true, parent: "roles", parentColumn: "name", namespaced: true},
+	{table: "role_bindings", column: "subjects", list: true, kind: "User", parent: "users", parentColumn: "id", namespaced: true},
+	{table: "role_bindings", column: "subjects", list: true, kind: "ServiceAccount", parent: "service_accounts", parentColumn: "name"},
+	{table: "cluster_role_bindings", column: "role_ref", parent: "cluster_roles", parentColumn: "name"},
+	{table: "cluster_role_bindings", column: "subjects", list: true, kind: "User", parent: "users", parentColumn: "id"},
+	{table: "cluster_role_bindings", column: "subjects", list: true, kind: "ServiceAccount", parent: "service_accounts", parentColumn: "name"},
+}
+
+// validateJSONReferences reports rows of schemaName, or rows referencing schemaName, whose
+// coreJSONReferences point at a missing or soft-deleted parent
+func (s *DynamicStore) validateJSONReferences(ctx context.Context, schemaName string) ([]ReferentialViolation, error) {
+	var violations []ReferentialViolation
+	for _, ref := range coreJSONReferences {
+		if ref.table != schemaName && ref.parent != schemaName {
+			continue
+		}
+		found, err := s.checkJSONReference(ctx, ref)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check %s.%s: %w", ref.table, ref.column, err)
+		}
+		violations = append(violations, found...)
+	}
+	return violations, nil
+}
+
+// checkJSONReference scans every live row of ref.table for names missing from ref.parent
+func (s *DynamicStore) checkJSONReference(ctx context.Context, ref jsonReference) ([]ReferentialViolation, error) {
+	for _, table := range []string{ref.table, ref.parent} {
+		exists, err := s.TableExists(ctx, table)
+		if err != nil || !exists {
+			return nil, err
+		}
+	}
+
+	namespaceColumn := "''"
+	if ref.namespaced {
+		namespaceColumn = "namespace"
+	}
+	parents, err := s.referenceKeys(ctx, fmt.Sprintf("SELECT %s, %s FROM %s WHERE deleted_at IS NULL",
+		namespaceColumn, ref.parentColumn, s.TableName(ref.parent)))
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db(ctx).QueryContext(ctx, fmt.Sprintf("SELECT rowid, %s, %s FROM %s WHERE deleted_at IS NULL",
+		namespaceColumn, ref.column, s.TableName(ref.table)))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var violations []ReferentialViolation
+	for rows.Next() {
+		var rowID int64
+		var ns string
+		var value *string
+		if err := rows.Scan(&rowID, &ns, &value); err != nil {
+			return nil, err
+		}
+		if value == nil || *value == "" {
+			continue
+		}
+
+		names := []string{*value}
+		if ref.list {
+			var entries []struct {
+				Kind string `json:"kind"`
+				Name string `json:"name"`
+			}
+			if err := json.Unmarshal([]byte(*value), &entries); err != nil {
+				return nil, fmt.Errorf("row %d: %w", rowID, err)
+			}
+			names = names[:0]
+			for _, entry := range entries {
+				if ref.kind == "" || entry.Kind == ref.kind {
+					names = append(names, entry.Name)
+				}
+			}
+		}
+
+		for _, name := range names {
+			if !parents[ns+"\x00"+name] {
+				violations = append(violations, ReferentialViolation{
+					Table:     ref.table,
+					RowID:     rowID,
+					Parent:    ref.parent,
+					Column:    ref.column,
+					Reference: name,
+				})
+			}
+		}
+	}
+	return violations, rows.Err()
+}
+
+// referenceKeys returns the set of "namespace\x00name" keys selected by query
+func (s *DynamicStore) referenceKeys(ctx context.Context, query string) (map[string]bool, error) {
+	rows, err := s.db(ctx).QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	keys := make(map[string]bool)
+	for rows.Next() {
+		var ns, name string
+		if err := rows.Scan(&ns, &name); err != nil {
+			return nil, err
+		}
+		keys[ns+"\x00"+name] = true
+	}
+	return keys, rows.Err()
+}
@@ -0,0 +1,91 @@
+package dynamic
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// RetryConfig controls retries of statements that fail with SQLITE_BUSY/SQLITE_LOCKED
+type RetryConfig struct {
+	MaxRetries     int           // 0이면 재시도 없음
+	InitialBackoff time.Duration // 첫 재시도 대기 시간, 이후 2배씩 증가
+	MaxBackoff     time.Duration
+}
+
+// DefaultRetryConfig returns the retry policy used by NewDynamicStore
+func DefaultRetryConfig() RetryConfig {
+	return RetryConfig{
+		MaxRetries:     10,
+		InitialBackoff: 5 * time.Millisecond,
+		MaxBackoff:     500 * time.Millisecond,
+	}
+}
+
+// SetRetryConfig replaces the busy/locked retry policy
+func (s *DynamicStore) SetRetryConfig(cfg RetryConfig) {
+	s.retry = cfg
+}
+
+// isBusyError reports whether err is a transient SQLite lock error
+func isBusyError(err error) bool {
+	if err == nil {
+		return false
+	}
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) {
+		return sqliteErr.Code == sqlite3.ErrBusy || sqliteErr.Code == sqlite3.ErrLocked
+	}
+	msg := err.Error()
+	return strings.Contains(msg, "database is locked") || strings.Contains(msg, "database table is locked")
+}
+
+// withRetry runs fn, retrying with exponential backoff while it returns a busy/locked error
+func (s *DynamicStore) withRetry(ctx context.Context, fn func() error) error {
+	backoff := s.retry.InitialBackoff
+	for attempt := 0; ; attempt++ {
+		err := fn()
+		if !isBusyError(err) || attempt >= s.retry.MaxRetries {
+			return err
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+
+		backoff *= 2
+		if s.retry.MaxBackoff > 0 && backoff > s.retry.MaxBackoff {
+			backoff = s.retry.MaxBackoff
+		}
+	}
+}
+
+func (s *DynamicStore) execWithRetry(ctx context.Context, table, query string, args ...interface{}) (sql.Result, error) {
+	var result sql.Result
+	err := s.withRetry(ctx, func() error {
+		defer s.observe(ctx, table, query, time.Now())
+		var err error
+		result, err = s.db(ctx).ExecContext(ctx, query, args...)
+		return err
+	})
+	return result, err
+}
+
+func (s *DynamicStore) queryWithRetry(ctx context.Context, table, query string, args ...interface{}) (*sql.Rows, error) {
+	var rows *sql.Rows
+	err := s.withRetry(ctx, func() error {
+		defer s.observe(ctx, table, query, time.Now())
+		var err error
+		rows, err = s.reader(ctx).QueryContext(ctx, query, args...)
+		return err
+	})
+	return rows, err
+}
@@ -0,0 +1,40 @@
+package dynamic
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/sukryu/pAuth/pkg/utils/requestid"
+)
+
+// SlowQueryConfig controls logging of statements that take longer than Threshold
+type SlowQueryConfig struct {
+	Threshold time.Duration // 0이면 비활성화
+	Logger    *log.Logger   // nil이면 log.Default()
+}
+
+// SetSlowQueryConfig enables slow query logging. 기본값은 비활성화
+func (s *DynamicStore) SetSlowQueryConfig(cfg SlowQueryConfig) {
+	if cfg.Logger == nil {
+		cfg.Logger = log.Default()
+	}
+	s.slowQuery = cfg
+}
+
+// observe logs query when it ran longer than the configured threshold.
+// 값이 아닌 placeholder가 포함된 SQL만 기록하므로 파라미터는 노출되지 않음
+func (s *DynamicStore) observe(ctx context.Context, table, query string, start time.Time) {
+	if s.slowQuery.Threshold <= 0 {
+		return
+	}
+	elapsed := time.Since(start)
+	if elapsed < s.slowQuery.Threshold {
+		return
+	}
+	if id := requestid.FromContext(ctx); id != "" {
+		s.slowQuery.Logger.Printf("slow query: request_id=%s table=%s duration=%s sql=%q", id, table, elapsed, query)
+		return
+	}
+	s.slowQuery.Logger.Printf("slow query: table=%s duration=%s sql=%q", table, elapsed, query)
+}
@@ -11,16 +11,31 @@ import (
 	"github.com/patrickmn/go-cache"
 	"github.com/sukryu/pAuth/internal/db"
 	"github.com/sukryu/pAuth/internal/store/dynamic/query"
+	"github.com/sukryu/pAuth/internal/store/interfaces"
 	"github.com/sukryu/pAuth/internal/store/manager"
 	"github.com/sukryu/pAuth/internal/store/schema"
+	"github.com/sukryu/pAuth/pkg/errors"
 )
 
+// schemaCacheTTL bounds how long a table schema is served without re-reading PRAGMA table_info
+const schemaCacheTTL = 30 * time.Second
+
 type DynamicStore struct {
 	manager      manager.Manager
+	conn         manager.DB // 모든 SQL 실행 경로. 테스트에서 fake로 대체 가능
+	replica      manager.Queryer
 	queries      *db.Queries
 	versionCache *cache.Cache
+	schemaCache  *cache.Cache
+	retry        RetryConfig
+	slowQuery    SlowQueryConfig
+	tablePrefix  string
+
+	constraintErrors bool // SetConstraintErrors 참고
 }
 
+var _ interfaces.DynamicStore = (*DynamicStore)(nil)
+
 // NewDynamicStore initializes a new DynamicStore instance
 func NewDynamicStore(mgr manager.Manager) (*DynamicStore, error) {
 	// Get DB connection from manager
@@ -29,26 +44,88 @@ func NewDynamicStore(mgr manager.Manager) (*DynamicStore, error) {
 		return nil, fmt.Errorf("failed to initialize DynamicStore: no valid database connection")
 	}
 
-	// Create queries instance using the db.New function
-	queries := db.New(dbConn)
+	return newDynamicStore(mgr, dbConn), nil
+}
+
+// NewDynamicStoreFromDB initializes a DynamicStore over an existing connection.
+// 테스트나 이미 *sql.DB를 가진 호출자용이며, db는 nil이 아니어야 함
+func NewDynamicStoreFromDB(dbConn *sql.DB) *DynamicStore {
+	return newDynamicStore(manager.NewSQLManagerFromDB(dbConn), dbConn)
+}
 
+func newDynamicStore(mgr manager.Manager, dbConn *sql.DB) *DynamicStore {
 	return &DynamicStore{
 		manager:      mgr,
-		queries:      queries,
+		conn:         mgr,
+		queries:      db.New(dbConn),
 		versionCache: cache.New(5*time.Minute, 10*time.Minute),
-	}, nil
+		schemaCache:  cache.New(schemaCacheTTL, time.Minute),
+		retry:        DefaultRetryConfig(),
+	}
+}
+
+// SetReadReplica sends row reads (DynamicSelect, DynamicQuery, CountActive) to replica.
+// 쓰기와 스키마 조회는 계속 primary를 사용하며, nil이면 모든 쿼리가 primary로 감
+func (s *DynamicStore) SetReadReplica(replica manager.Queryer) {
+	s.replica = replica
+}
+
+// reader returns the connection used for row reads.
+// 트랜잭션 안에서는 자신의 쓰기를 볼 수 있도록 replica 대신 트랜잭션을 사용
+func (s *DynamicStore) reader(ctx context.Context) manager.Queryer {
+	if tx, ok := txFromContext(ctx); ok {
+		return tx
+	}
+	if s.replica != nil {
+		return s.replica
+	}
+	return s.conn
+}
+
+// SetTablePrefix makes every table the store touches use prefix (e.g. "pauth_" -> pauth_users).
+// 호출자는 계속 논리적 테이블 이름을 사용하며, 데이터베이스를 다른 앱과 공유할 때 이름 충돌을 막음
+func (s *DynamicStore) SetTablePrefix(prefix string) error {
+	if prefix != "" && !isValidIdentifier(prefix) {
+		return fmt.Errorf("invalid table prefix: %s", prefix)
+	}
+	s.tablePrefix = prefix
+	s.schemaCache.Flush()
+	s.versionCache.Flush()
+	return nil
+}
+
+// TableName returns the physical name of the logical table name
+func (s *DynamicStore) TableName(name string) string {
+	return s.tablePrefix + name
+}
+
+// HealthCheck reports whether the underlying database is reachable
+func (s *DynamicStore) HealthCheck(ctx context.Context) error {
+	return s.manager.HealthCheck(ctx)
 }
 
 // 동적 테이블 생성
 func (s *DynamicStore) CreateDynamicTable(ctx context.Context, tableName string, opts schema.TableOptions) error {
+	tableName = s.TableName(tableName)
+
 	// Validate table name
 	if !isValidIdentifier(tableName) {
 		return fmt.Errorf("invalid table name: %s", tableName)
 	}
 
+	// 잘못된 DDL이 생성되지 않도록 DB 접근 전에 필드 타입 검증
+	for _, field := range opts.Fields {
+		if !field.Type.IsValid() {
+			return errors.ErrInvalidInput.WithReason(fmt.Sprintf("unsupported type %q for field %s", field.Type, field.Name))
+		}
+	}
+
 	// 테이블 기본 컬럼과 추가 필드 설정
-	baseColumns := `
-        id TEXT PRIMARY KEY,
+	idColumn := "id TEXT PRIMARY KEY"
+	if len(opts.KeyColumns) > 0 {
+		idColumn = "id TEXT NOT NULL"
+	}
+	baseColumns := idColumn + `,
         created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
         updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
         deleted_at TIMESTAMP`
@@ -58,16 +135,38 @@ func (s *DynamicStore) CreateDynamicTable(ctx context.Context, tableName string,
 		columnDefs = append(columnDefs, field.GenerateColumnDef())
 	}
 
+	// 복합 primary key (예: namespace별로 id가 유일)
+	if len(opts.KeyColumns) > 0 {
+		for _, column := range opts.KeyColumns {
+			if !isValidIdentifier(column) {
+				return fmt.Errorf("invalid key column: %s", column)
+			}
+		}
+		columnDefs = append(columnDefs, fmt.Sprintf("PRIMARY KEY (%s, id)", strings.Join(opts.KeyColumns, ", ")))
+	}
+
+	// 외래 키 제약 조건 (PRAGMA foreign_keys = ON 일 때만 강제됨)
+	for _, fk := range opts.ForeignKeys {
+		if err := validateForeignKey(fk); err != nil {
+			return err
+		}
+		fk.RefTable = s.TableName(fk.RefTable)
+		columnDefs = append(columnDefs, fk.GenerateConstraintDef())
+	}
+
 	query := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (%s)",
 		tableName, strings.Join(columnDefs, ", "))
-	_, err := s.manager.GetDB().ExecContext(ctx, query)
+	_, err := s.db(ctx).ExecContext(ctx, query)
 	if err != nil {
 		return err
 	}
+	s.invalidateTableSchema(tableName)
 
 	// 인덱스 생성
 	for _, idx := range opts.Indexes {
-		if err := s.CreateDynamicIndex(ctx, idx.Name, tableName, strings.Join(idx.Columns, ", ")); err != nil {
+		// 인덱스 이름도 데이터베이스 전역이므로 접두사 적용
+		idx.Name = s.TableName(idx.Name)
+		if err := CreateIndex(ctx, s.db(ctx), tableName, idx); err != nil {
 			return err
 		}
 	}
@@ -81,6 +180,9 @@ func (s *DynamicStore) AlterDynamicTable(ctx context.Context, tableName string,
 		return fmt.Errorf("invalid table name: %s", tableName)
 	}
 
+	// 일부 변경만 적용된 경우에도 캐시가 남지 않도록 항상 무효화
+	defer s.invalidateTableSchema(s.TableName(tableName))
+
 	// 변경 사항(action) 검증 및 처리
 	for column, action := range changes {
 		if err := validateChangeAction(action); err != nil {
@@ -119,9 +221,10 @@ func (s *DynamicStore) AlterDynamicTable(ctx context.Context, tableName string,
 
 // CreateDynamicIndex 인덱스 생성
 func (s *DynamicStore) CreateDynamicIndex(ctx context.Context, indexName, tableName string, columns string) error {
+	indexName, tableName = s.TableName(indexName), s.TableName(tableName)
 	query := fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s ON %s (%s)",
 		indexName, tableName, columns)
-	_, err := s.manager.GetDB().ExecContext(ctx, query)
+	_, err := s.db(ctx).ExecContext(ctx, query)
 	return err
 }
 
@@ -151,6 +254,7 @@ func (s *DynamicStore) ValidateSchema(ctx context.Context, tableName string, fie
 
 // DynamicInsert 동적 테이블에 데이터 삽입
 func (s *DynamicStore) DynamicInsert(ctx context.Context, tableName string, data map[string]interface{}) error {
+	tableName = s.TableName(tableName)
 	columns := make([]string, 0, len(data))
 	values := make([]interface{}, 0, len(data))
 	placeholders := make([]string, 0, len(data))
@@ -166,14 +270,90 @@ func (s *DynamicStore) DynamicInsert(ctx context.Context, tableName string, data
 		strings.Join(columns, ", "),
 		strings.Join(placeholders, ", "))
 
-	_, err := s.manager.GetDB().ExecContext(ctx, query, values...)
-	return err
+	_, err := s.execWithRetry(ctx, tableName, query, values...)
+	return s.constraintError(err)
 }
 
+// DefaultListOrder is the ORDER BY used by the stores' List methods so results are stable.
+// id는 created_at이 같은 행 사이의 순서를 결정
+var DefaultListOrder = []query.OrderByClause{{Column: "created_at"}, {Column: "id"}}
+
 // DynamicSelect 동적 테이블에서 데이터 조회
 func (s *DynamicStore) DynamicSelect(ctx context.Context, tableName string, conditions map[string]interface{}) ([]map[string]interface{}, error) {
-	clauses := []string{"deleted_at IS NULL"} // 기본 조건
-	values := make([]interface{}, 0)
+	return s.DynamicSelectOrdered(ctx, tableName, conditions, nil)
+}
+
+// DynamicSelectOrdered DynamicSelect와 동일하되 ORDER BY 절로 결과를 정렬
+func (s *DynamicStore) DynamicSelectOrdered(ctx context.Context, tableName string, conditions map[string]interface{}, orderBy []query.OrderByClause) ([]map[string]interface{}, error) {
+	return s.DynamicSelectWithOptions(ctx, tableName, conditions, SelectOptions{OrderBy: orderBy})
+}
+
+// SelectOptions controls sorting and soft-delete filtering of DynamicSelectWithOptions.
+// zero value는 삭제되지 않은 행만 조회 (DynamicSelect와 동일)
+type SelectOptions struct {
+	OrderBy []query.OrderByClause
+
+	// IncludeDeleted returns soft-deleted rows together with live rows
+	IncludeDeleted bool
+	// DeletedBefore/DeletedAfter restrict the result to rows deleted in the range (경계 미포함).
+	// 삭제된 행만 반환하므로 IncludeDeleted와 함께 지정해야 함
+	DeletedBefore *time.Time
+	DeletedAfter  *time.Time
+
+	// Fields, when set, coerces scanned values to the Go type of each FieldDef.Type
+	// (created_at/updated_at/deleted_at은 항상 time.Time). 지정되지 않은 컬럼은 드라이버 값 그대로
+	Fields []schema.FieldDef
+}
+
+// validate rejects option combinations that cannot match anything
+func (o SelectOptions) validate() error {
+	for _, clause := range o.OrderBy {
+		if !isValidIdentifier(clause.Column) {
+			return fmt.Errorf("invalid order by column: %s", clause.Column)
+		}
+	}
+	if (o.DeletedBefore != nil || o.DeletedAfter != nil) && !o.IncludeDeleted {
+		return fmt.Errorf("deleted time range requires IncludeDeleted")
+	}
+	if o.DeletedBefore != nil && o.DeletedAfter != nil && !o.DeletedAfter.Before(*o.DeletedBefore) {
+		return fmt.Errorf("DeletedAfter must be before DeletedBefore")
+	}
+	return nil
+}
+
+// whereClauses returns the soft-delete conditions for opts
+func (o SelectOptions) whereClauses() ([]string, []interface{}) {
+	if !o.IncludeDeleted {
+		return []string{"deleted_at IS NULL"}, nil
+	}
+
+	if o.DeletedBefore == nil && o.DeletedAfter == nil {
+		return nil, nil
+	}
+
+	// CURRENT_TIMESTAMP(UTC)로 기록된 값과 비교할 수 있도록 datetime()으로 정규화 (purgeDeleted와 동일)
+	clauses := []string{"deleted_at IS NOT NULL"}
+	var values []interface{}
+	if o.DeletedAfter != nil {
+		clauses = append(clauses, "datetime(deleted_at) > datetime(?)")
+		values = append(values, o.DeletedAfter.UTC().Format("2006-01-02 15:04:05"))
+	}
+	if o.DeletedBefore != nil {
+		clauses = append(clauses, "datetime(deleted_at) < datetime(?)")
+		values = append(values, o.DeletedBefore.UTC().Format("2006-01-02 15:04:05"))
+	}
+	return clauses, values
+}
+
+// DynamicSelectWithOptions DynamicSelect와 동일하되 정렬과 소프트 삭제 행의 포함 여부를 opts로 지정
+func (s *DynamicStore) DynamicSelectWithOptions(ctx context.Context, tableName string, conditions map[string]interface{}, opts SelectOptions) ([]map[string]interface{}, error) {
+	tableName = s.TableName(tableName)
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+	orderBy := opts.OrderBy
+
+	clauses, values := opts.whereClauses() // 기본 조건
 
 	// 추가 조건이 있는 경우
 	if len(conditions) > 0 {
@@ -184,11 +364,18 @@ func (s *DynamicStore) DynamicSelect(ctx context.Context, tableName string, cond
 	}
 
 	// WHERE 절 구성
-	query := fmt.Sprintf("SELECT * FROM %s WHERE %s",
-		tableName,
-		strings.Join(clauses, " AND "))
+	selectSQL := fmt.Sprintf("SELECT * FROM %s", tableName)
+	if len(clauses) > 0 {
+		selectSQL += " WHERE " + strings.Join(clauses, " AND ")
+	}
+
+	// ORDER BY 절 구성
+	if len(orderBy) > 0 {
+		params := query.QueryParams{OrderBy: orderBy}
+		selectSQL += " ORDER BY " + params.GetOrderByClause()
+	}
 
-	rows, err := s.manager.GetDB().QueryContext(ctx, query, values...)
+	rows, err := s.queryWithRetry(ctx, tableName, selectSQL, values...)
 	if err != nil {
 		return nil, err
 	}
@@ -218,28 +405,82 @@ func (s *DynamicStore) DynamicSelect(ctx context.Context, tableName string, cond
 		}
 		results = append(results, row)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
 
+	if opts.Fields != nil {
+		if err := coerceRows(results, opts.Fields); err != nil {
+			return nil, err
+		}
+	}
 	return results, nil
 }
 
+// CountActive 소프트 삭제되지 않은 행의 수를 반환
+func (s *DynamicStore) CountActive(ctx context.Context, tableName string) (int, error) {
+	return s.CountActiveWhere(ctx, tableName, nil)
+}
+
+// CountActiveWhere CountActive와 동일하되 컬럼 값이 일치하는 행만 셈
+func (s *DynamicStore) CountActiveWhere(ctx context.Context, tableName string, conditions map[string]interface{}) (int, error) {
+	tableName = s.TableName(tableName)
+	if !isValidIdentifier(tableName) {
+		return 0, fmt.Errorf("invalid table name: %s", tableName)
+	}
+
+	clauses := []string{"deleted_at IS NULL"}
+	values := make([]interface{}, 0, len(conditions))
+	for col, val := range conditions {
+		if !isValidIdentifier(col) {
+			return 0, fmt.Errorf("invalid column name: %s", col)
+		}
+		clauses = append(clauses, fmt.Sprintf("%s = ?", col))
+		values = append(values, val)
+	}
+
+	var count int
+	countSQL := fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE %s", tableName, strings.Join(clauses, " AND "))
+	err := s.withRetry(ctx, func() error {
+		defer s.observe(ctx, tableName, countSQL, time.Now())
+		return s.reader(ctx).QueryRowContext(ctx, countSQL, values...).Scan(&count)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
 // DynamicUpdate 동적 테이블의 데이터 업데이트
 func (s *DynamicStore) DynamicUpdate(ctx context.Context, tableName string, id string, data map[string]interface{}) error {
+	return s.DynamicUpdateScoped(ctx, tableName, id, nil, data)
+}
+
+// DynamicUpdateScoped DynamicUpdate와 동일하되 scope의 컬럼 값도 일치하는 행만 수정.
+// namespace처럼 id와 함께 primary key를 이루는 컬럼을 지정하는 데 사용
+func (s *DynamicStore) DynamicUpdateScoped(ctx context.Context, tableName string, id string, scope, data map[string]interface{}) error {
+	tableName = s.TableName(tableName)
 	setParts := make([]string, 0, len(data))
-	values := make([]interface{}, 0, len(data)+1)
+	values := make([]interface{}, 0, len(data)+len(scope)+1)
 
 	for col, val := range data {
 		setParts = append(setParts, fmt.Sprintf("%s = ?", col))
 		values = append(values, val)
 	}
-	values = append(values, id) // WHERE id = ? 조건을 위한 값
+	where, whereValues, err := scopedIDClause(id, scope)
+	if err != nil {
+		return err
+	}
+	values = append(values, whereValues...)
 
-	query := fmt.Sprintf("UPDATE %s SET %s, updated_at = CURRENT_TIMESTAMP WHERE id = ?",
+	query := fmt.Sprintf("UPDATE %s SET %s, updated_at = CURRENT_TIMESTAMP WHERE %s",
 		tableName,
-		strings.Join(setParts, ", "))
+		strings.Join(setParts, ", "),
+		where)
 
-	result, err := s.manager.GetDB().ExecContext(ctx, query, values...)
+	result, err := s.execWithRetry(ctx, tableName, query, values...)
 	if err != nil {
-		return err
+		return s.constraintError(err)
 	}
 
 	affected, err := result.RowsAffected()
@@ -255,10 +496,20 @@ func (s *DynamicStore) DynamicUpdate(ctx context.Context, tableName string, id s
 
 // DynamicDelete 동적 테이블의 데이터 삭제 (소프트 삭제)
 func (s *DynamicStore) DynamicDelete(ctx context.Context, tableName string, id string) error {
-	query := fmt.Sprintf("UPDATE %s SET deleted_at = CURRENT_TIMESTAMP WHERE id = ? AND deleted_at IS NULL",
-		tableName)
+	return s.DynamicDeleteScoped(ctx, tableName, id, nil)
+}
 
-	result, err := s.manager.GetDB().ExecContext(ctx, query, id)
+// DynamicDeleteScoped DynamicDelete와 동일하되 scope의 컬럼 값도 일치하는 행만 삭제
+func (s *DynamicStore) DynamicDeleteScoped(ctx context.Context, tableName string, id string, scope map[string]interface{}) error {
+	tableName = s.TableName(tableName)
+	where, values, err := scopedIDClause(id, scope)
+	if err != nil {
+		return err
+	}
+	query := fmt.Sprintf("UPDATE %s SET deleted_at = CURRENT_TIMESTAMP WHERE %s AND deleted_at IS NULL",
+		tableName, where)
+
+	result, err := s.execWithRetry(ctx, tableName, query, values...)
 	if err != nil {
 		return err
 	}
@@ -274,8 +525,51 @@ func (s *DynamicStore) DynamicDelete(ctx context.Context, tableName string, id s
 	return nil
 }
 
+// scopedIDClause builds the "id = ? AND col = ?..." condition of a scoped update or delete
+func scopedIDClause(id string, scope map[string]interface{}) (string, []interface{}, error) {
+	clauses := []string{"id = ?"}
+	values := []interface{}{id}
+	for col, val := range scope {
+		if !isValidIdentifier(col) {
+			return "", nil, fmt.Errorf("invalid column name: %s", col)
+		}
+		clauses = append(clauses, fmt.Sprintf("%s = ?", col))
+		values = append(values, val)
+	}
+	return strings.Join(clauses, " AND "), values, nil
+}
+
 // DynamicQuery 동적 테이블의 복잡한 쿼리 실행
 func (s *DynamicStore) DynamicQuery(ctx context.Context, tableName string, queryParams query.QueryParams) ([]map[string]interface{}, error) {
+	rows, err := s.runQuery(ctx, tableName, queryParams)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanRows(rows)
+}
+
+// DynamicQueryStream runs the query like DynamicQuery but passes each row to fn as it is scanned
+// instead of collecting the whole result. fn이 에러를 반환하거나 ctx가 취소되면 중단하고 그 에러를 반환함.
+// 트랜잭션 안에서는 fn이 끝날 때까지 연결을 점유하므로 fn에서 같은 트랜잭션으로 다른 쿼리를 실행하면 안 됨
+func (s *DynamicStore) DynamicQueryStream(ctx context.Context, tableName string, queryParams query.QueryParams, fn func(map[string]interface{}) error) error {
+	rows, err := s.runQuery(ctx, tableName, queryParams)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	return scanEach(ctx, rows, fn)
+}
+
+// runQuery builds the SELECT for queryParams and executes it against tableName
+func (s *DynamicStore) runQuery(ctx context.Context, tableName string, queryParams query.QueryParams) (*sql.Rows, error) {
+	tableName = s.TableName(tableName)
+	if err := validateQueryParams(queryParams); err != nil {
+		return nil, err
+	}
+
 	query := fmt.Sprintf("SELECT %s FROM %s",
 		queryParams.GetSelectClause(),
 		tableName)
@@ -284,6 +578,11 @@ func (s *DynamicStore) DynamicQuery(ctx context.Context, tableName string, query
 		query += " WHERE " + whereClause
 	}
 
+	if groupBy := queryParams.GetGroupByClause(); groupBy != "" {
+		query += " GROUP BY " + groupBy
+	}
+
+	queryParams.OrderBy = withTiebreaker(queryParams)
 	if orderBy := queryParams.GetOrderByClause(); orderBy != "" {
 		query += " ORDER BY " + orderBy
 	}
@@ -292,19 +591,33 @@ func (s *DynamicStore) DynamicQuery(ctx context.Context, tableName string, query
 		query += " " + limit
 	}
 
-	rows, err := s.manager.GetDB().QueryContext(ctx, query, queryParams.GetArgs()...)
-	if err != nil {
-		return nil, err
+	return s.queryWithRetry(ctx, tableName, query, queryParams.GetArgs()...)
+}
+
+// tiebreakerColumn orders rows whose ORDER BY columns are equal; SQLite는 동률 행의 순서를 보장하지 않음
+const tiebreakerColumn = "id"
+
+// withTiebreaker returns params.OrderBy with tiebreakerColumn appended unless it is already present,
+// so pages of a LIMIT/OFFSET query never overlap. 그룹/집계 쿼리의 결과 행에는 id가 없으므로 그대로 반환
+func withTiebreaker(params query.QueryParams) []query.OrderByClause {
+	if len(params.GroupBy) > 0 || len(params.Aggregates) > 0 {
+		return params.OrderBy
+	}
+	for _, clause := range params.OrderBy {
+		if strings.EqualFold(clause.Column, tiebreakerColumn) {
+			return params.OrderBy
+		}
 	}
-	defer rows.Close()
 
-	return scanRows(rows)
+	orderBy := make([]query.OrderByClause, 0, len(params.OrderBy)+1)
+	orderBy = append(orderBy, params.OrderBy...)
+	return append(orderBy, query.OrderByClause{Column: tiebreakerColumn})
 }
 
 // 테이블 존재 여부 확인
 func (s *DynamicStore) TableExists(ctx context.Context, tableName string) (bool, error) {
 	query := "SELECT name FROM sqlite_master WHERE type='table' AND name=?"
-	row := s.manager.GetDB().QueryRowContext(ctx, query, tableName)
+	row := s.db(ctx).QueryRowContext(ctx, query, s.TableName(tableName))
 
 	var name string
 	err := row.Scan(&name)
@@ -319,15 +632,20 @@ func (s *DynamicStore) TableExists(ctx context.Context, tableName string) (bool,
 
 // 테이블 컬럼 추가
 func (s *DynamicStore) AddColumn(ctx context.Context, tableName, columnDef string) error {
+	tableName = s.TableName(tableName)
 	query := fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s", tableName, columnDef)
-	_, err := s.manager.GetDB().ExecContext(ctx, query)
+	_, err := s.db(ctx).ExecContext(ctx, query)
+	s.invalidateTableSchema(tableName)
 	return err
 }
 
 // 테이블 컬럼 삭제 (SQLite는 지원하지 않으므로 우회 방법 필요)
 func (s *DynamicStore) DropColumn(ctx context.Context, tableName, columnName string, batchSize int) error {
-	// 1. 기존 테이블의 스키마 조회
-	columns, err := s.GetTableSchema(ctx, tableName)
+	tableName = s.TableName(tableName)
+
+	// 1. 기존 테이블의 스키마 조회 (파괴적 작업이므로 캐시를 사용하지 않음)
+	defer s.invalidateTableSchema(tableName)
+	columns, err := s.loadTableSchema(ctx, tableName)
 	if err != nil {
 		return fmt.Errorf("failed to get schema for table %s: %w", tableName, err)
 	}
@@ -351,13 +669,26 @@ func (s *DynamicStore) DropColumn(ctx context.Context, tableName, columnName str
 
 	// 4. 새 테이블 생성
 	createSQL := fmt.Sprintf("CREATE TABLE %s (%s)", tempTable, strings.Join(newColumns, ", "))
-	if _, err := s.manager.GetDB().ExecContext(ctx, createSQL); err != nil {
+	if _, err := s.db(ctx).ExecContext(ctx, createSQL); err != nil {
 		return fmt.Errorf("failed to create temp table: %w", err)
 	}
 
+	// 중단 시 임시 테이블 정리. ctx가 이미 취소되었을 수 있으므로 취소만 제거하고
+	// 트랜잭션 안이라면 같은 트랜잭션에서 삭제
+	dropTemp := func() {
+		cleanupCtx := context.WithoutCancel(ctx)
+		_, _ = s.db(cleanupCtx).ExecContext(cleanupCtx, fmt.Sprintf("DROP TABLE IF EXISTS %s", tempTable))
+	}
+
 	// 5. 데이터 배치 복사
 	offset := 0
 	for {
+		// 오래 걸리는 삭제를 중단할 수 있도록 배치마다 취소 여부 확인
+		if err := ctx.Err(); err != nil {
+			dropTemp()
+			return err
+		}
+
 		// 데이터 복사 쿼리: 배치 단위로 처리
 		copySQL := fmt.Sprintf(
 			"INSERT INTO %s SELECT %s FROM %s LIMIT %d OFFSET %d",
@@ -369,14 +700,19 @@ func (s *DynamicStore) DropColumn(ctx context.Context, tableName, columnName str
 		)
 
 		// 복사 실행
-		result, err := s.manager.GetDB().ExecContext(ctx, copySQL)
+		result, err := s.db(ctx).ExecContext(ctx, copySQL)
 		if err != nil {
+			dropTemp()
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return ctxErr
+			}
 			return fmt.Errorf("failed to copy data in batches: %w", err)
 		}
 
 		// 처리된 행 수 확인
 		rowsAffected, err := result.RowsAffected()
 		if err != nil {
+			dropTemp()
 			return fmt.Errorf("failed to get rows affected: %w", err)
 		}
 
@@ -389,22 +725,46 @@ func (s *DynamicStore) DropColumn(ctx context.Context, tableName, columnName str
 
 	// 6. 기존 테이블 삭제 및 교체
 	dropSQL := fmt.Sprintf("DROP TABLE IF EXISTS %s", tableName)
-	if _, err := s.manager.GetDB().ExecContext(ctx, dropSQL); err != nil {
+	if _, err := s.db(ctx).ExecContext(ctx, dropSQL); err != nil {
 		return fmt.Errorf("failed to drop original table: %w", err)
 	}
 
 	renameSQL := fmt.Sprintf("ALTER TABLE %s RENAME TO %s", tempTable, tableName)
-	if _, err := s.manager.GetDB().ExecContext(ctx, renameSQL); err != nil {
+	if _, err := s.db(ctx).ExecContext(ctx, renameSQL); err != nil {
 		return fmt.Errorf("failed to rename temp table: %w", err)
 	}
 
 	return nil
 }
 
-// 테이블의 현재 스키마 조회
+// 테이블의 현재 스키마 조회. 결과는 schemaCacheTTL 동안 캐시됨
 func (s *DynamicStore) GetTableSchema(ctx context.Context, tableName string) ([]string, error) {
+	tableName = s.TableName(tableName)
+	if cached, found := s.schemaCache.Get(tableName); found {
+		return append([]string(nil), cached.([]string)...), nil
+	}
+
+	columns, err := s.loadTableSchema(ctx, tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	// 존재하지 않는 테이블(빈 결과)은 캐시하지 않음 - 이후 생성되면 바로 보여야 함
+	if len(columns) > 0 {
+		s.schemaCache.Set(tableName, append([]string(nil), columns...), cache.DefaultExpiration)
+	}
+	return columns, nil
+}
+
+// invalidateTableSchema drops the cached schema of tableName after DDL
+func (s *DynamicStore) invalidateTableSchema(tableName string) {
+	s.schemaCache.Delete(tableName)
+}
+
+// loadTableSchema reads the table's columns via PRAGMA table_info
+func (s *DynamicStore) loadTableSchema(ctx context.Context, tableName string) ([]string, error) {
 	query := fmt.Sprintf("PRAGMA table_info(%s)", tableName)
-	rows, err := s.manager.GetDB().QueryContext(ctx, query)
+	rows, err := s.db(ctx).QueryContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -426,11 +786,13 @@ func (s *DynamicStore) GetTableSchema(ctx context.Context, tableName string) ([]
 	return columns, nil
 }
 
-// 테이블 삭제
-func (s *DynamicStore) DropDynamicTable(tableName string) error {
+// 테이블 삭제. ctx에 트랜잭션이 있으면 그 트랜잭션에서 실행됨
+func (s *DynamicStore) DropDynamicTable(ctx context.Context, tableName string) error {
+	tableName = s.TableName(tableName)
 	sql := fmt.Sprintf("DROP TABLE IF EXISTS %s", tableName)
 
-	_, err := s.manager.GetDB().ExecContext(context.Background(), sql)
+	_, err := s.db(ctx).ExecContext(ctx, sql)
+	s.invalidateTableSchema(tableName)
 	if err != nil {
 		return fmt.Errorf("failed to drop table %s: %w", tableName, err)
 	}
@@ -439,21 +801,27 @@ func (s *DynamicStore) DropDynamicTable(tableName string) error {
 }
 
 func (s *DynamicStore) TrackSchemaVersion(ctx context.Context, schemaName string, changes string) error {
-	query := `INSERT INTO schema_versions (schema_name, version, changes, created_at)
-              VALUES (?, (SELECT COALESCE(MAX(version), 0) + 1 FROM schema_versions WHERE schema_name = ?), ?, CURRENT_TIMESTAMP)`
-	_, err := s.manager.GetDB().ExecContext(ctx, query, schemaName, schemaName, changes)
-	return err
+	query := fmt.Sprintf(`INSERT INTO %[1]s (schema_name, version, changes, created_at)
+              VALUES (?, (SELECT COALESCE(MAX(version), 0) + 1 FROM %[1]s WHERE schema_name = ?), ?, CURRENT_TIMESTAMP)`,
+		s.TableName("schema_versions"))
+	if _, err := s.db(ctx).ExecContext(ctx, query, schemaName, schemaName, changes); err != nil {
+		return err
+	}
+	// 새 버전이 바로 보이도록 캐시 무효화
+	s.versionCache.Delete(schemaName)
+	return nil
 }
 
 func (s *DynamicStore) GetSchemaVersions(ctx context.Context, schemaName string) ([]db.SchemaVersion, error) {
-	// 캐시 확인.
+	// 캐시 확인. 호출자가 결과를 수정해도 캐시에 영향이 없도록 복사본을 반환
 	if cached, found := s.versionCache.Get(schemaName); found {
-		return cached.([]db.SchemaVersion), nil
+		return append([]db.SchemaVersion(nil), cached.([]db.SchemaVersion)...), nil
 	}
 
 	// DB에서 조회.
-	query := `SELECT id, schema_name, version, changes, created_at FROM schema_versions WHERE schema_name = ? ORDER BY version DESC`
-	rows, err := s.manager.GetDB().QueryContext(ctx, query, schemaName)
+	query := fmt.Sprintf(`SELECT id, schema_name, version, changes, created_at FROM %s WHERE schema_name = ? ORDER BY version DESC`,
+		s.TableName("schema_versions"))
+	rows, err := s.db(ctx).QueryContext(ctx, query, schemaName)
 	if err != nil {
 		return nil, err
 	}
@@ -469,21 +837,50 @@ func (s *DynamicStore) GetSchemaVersions(ctx context.Context, schemaName string)
 	}
 
 	// 캐시에 저장
-	s.versionCache.Set(schemaName, versions, cache.DefaultExpiration)
+	s.versionCache.Set(schemaName, append([]db.SchemaVersion(nil), versions...), cache.DefaultExpiration)
 	return versions, nil
 }
 
+// GetCurrentSchemaVersion returns the latest tracked version of schemaName, or 0 if none was tracked
+func (s *DynamicStore) GetCurrentSchemaVersion(ctx context.Context, schemaName string) (int, error) {
+	versions, err := s.GetSchemaVersions(ctx, schemaName)
+	if err != nil {
+		return 0, err
+	}
+	if len(versions) == 0 {
+		return 0, nil
+	}
+	return int(versions[0].Version), nil // 버전 내림차순 정렬
+}
+
+// GetSchemaChangesSince returns the versions of schemaName newer than sinceVersion, oldest first
+func (s *DynamicStore) GetSchemaChangesSince(ctx context.Context, schemaName string, sinceVersion int) ([]db.SchemaVersion, error) {
+	versions, err := s.GetSchemaVersions(ctx, schemaName)
+	if err != nil {
+		return nil, err
+	}
+
+	// 캐시된 슬라이스를 변경하지 않도록 새 슬라이스에 역순으로 담음
+	changes := []db.SchemaVersion{}
+	for i := len(versions) - 1; i >= 0; i-- {
+		if versions[i].Version > int64(sinceVersion) {
+			changes = append(changes, versions[i])
+		}
+	}
+	return changes, nil
+}
+
 func (s *DynamicStore) AddSchemaDependency(ctx context.Context, parent, child, dependencyType string) error {
-	query := `INSERT INTO schema_dependencies (parent_schema, child_schema, dependency_type, created_at)
-              VALUES (?, ?, ?, CURRENT_TIMESTAMP)`
-	_, err := s.manager.GetDB().ExecContext(ctx, query, parent, child, dependencyType)
+	query := fmt.Sprintf(`INSERT INTO %s (parent_schema, child_schema, dependency_type, created_at)
+              VALUES (?, ?, ?, CURRENT_TIMESTAMP)`, s.TableName("schema_dependencies"))
+	_, err := s.db(ctx).ExecContext(ctx, query, parent, child, dependencyType)
 	return err
 }
 
 func (s *DynamicStore) GetSchemaDependencies(ctx context.Context, schemaName string) ([]db.SchemaDependency, error) {
-	query := `SELECT id, parent_schema, child_schema, dependency_type, created_at FROM schema_dependencies
-              WHERE parent_schema = ? OR child_schema = ?`
-	rows, err := s.manager.GetDB().QueryContext(ctx, query, schemaName, schemaName)
+	query := fmt.Sprintf(`SELECT id, parent_schema, child_schema, dependency_type, created_at FROM %s
+              WHERE parent_schema = ? OR child_schema = ?`, s.TableName("schema_dependencies"))
+	rows, err := s.db(ctx).QueryContext(ctx, query, schemaName, schemaName)
 	if err != nil {
 		return nil, err
 	}
@@ -500,6 +897,78 @@ func (s *DynamicStore) GetSchemaDependencies(ctx context.Context, schemaName str
 	return dependencies, nil
 }
 
+// ReferentialViolation describes a child row whose foreign key points at a missing parent row
+type ReferentialViolation struct {
+	Table  string
+	RowID  int64
+	Parent string
+
+	// Column and Reference are set for references stored by name or as JSON (role_ref, subjects)
+	Column    string
+	Reference string
+}
+
+// ValidateReferentialIntegrity scans the schema and its ONE_TO_MANY/MANY_TO_ONE children for orphaned rows.
+// PRAGMA foreign_key_check는 foreign_keys 설정과 무관하게 동작하므로 강제가 꺼진 상태에서 들어간 데이터도 검출함.
+// 외래 키를 강제하려면 manager.Config.ForeignKeys로 모든 커넥션에 적용해야 함.
+// RBAC 테이블의 JSON 참조 (role_ref, role_refs, subjects)도 schemaName이 참조하거나 참조되는 쪽이면 함께 검사함
+func (s *DynamicStore) ValidateReferentialIntegrity(ctx context.Context, schemaName string) ([]ReferentialViolation, error) {
+	if !isValidIdentifier(schemaName) {
+		return nil, fmt.Errorf("invalid table name: %s", schemaName)
+	}
+
+	dependencies, err := s.GetSchemaDependencies(ctx, schemaName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get schema dependencies: %w", err)
+	}
+
+	tables := []string{schemaName}
+	for _, dep := range dependencies {
+		if dep.ParentSchema != schemaName || !schema.DependencyType(dep.DependencyType).IsReferential() {
+			continue
+		}
+		if isValidIdentifier(dep.ChildSchema) && !contains(tables, dep.ChildSchema) {
+			tables = append(tables, dep.ChildSchema)
+		}
+	}
+
+	var violations []ReferentialViolation
+	for _, table := range tables {
+		rows, err := s.db(ctx).QueryContext(ctx, fmt.Sprintf("PRAGMA foreign_key_check(%s)", s.TableName(table)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to check foreign keys for %s: %w", table, err)
+		}
+
+		for rows.Next() {
+			var violation ReferentialViolation
+			var rowID sql.NullInt64
+			var fkID int
+			if err := rows.Scan(&violation.Table, &rowID, &violation.Parent, &fkID); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			violation.RowID = rowID.Int64
+			violations = append(violations, violation)
+		}
+		if err := rows.Err(); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		rows.Close()
+	}
+
+	jsonViolations, err := s.validateJSONReferences(ctx, schemaName)
+	if err != nil {
+		return nil, err
+	}
+	return append(violations, jsonViolations...), nil
+}
+
+// IsValidIdentifier reports whether identifier can be used as a table or column name
+func IsValidIdentifier(identifier string) bool {
+	return isValidIdentifier(identifier)
+}
+
 // isValidIdentifier checks if the given identifier (e.g., table name) is valid
 func isValidIdentifier(identifier string) bool {
 	// Define regex for valid identifiers
@@ -518,6 +987,131 @@ var allowedActions = map[string]bool{
 	"MODIFY": true,
 }
 
+var allowedOnDeleteActions = map[string]bool{
+	"":            true,
+	"CASCADE":     true,
+	"SET NULL":    true,
+	"SET DEFAULT": true,
+	"RESTRICT":    true,
+	"NO ACTION":   true,
+}
+
+// validateForeignKey checks identifiers and the ON DELETE action of a foreign key definition
+func validateForeignKey(fk schema.ForeignKeyDef) error {
+	if !isValidIdentifier(fk.Column) {
+		return fmt.Errorf("invalid foreign key column: %s", fk.Column)
+	}
+	if !isValidIdentifier(fk.RefTable) {
+		return fmt.Errorf("invalid referenced table: %s", fk.RefTable)
+	}
+	if fk.RefColumn != "" && !isValidIdentifier(fk.RefColumn) {
+		return fmt.Errorf("invalid referenced column: %s", fk.RefColumn)
+	}
+	if !allowedOnDeleteActions[fk.OnDelete] {
+		return fmt.Errorf("unsupported ON DELETE action: %s", fk.OnDelete)
+	}
+	return nil
+}
+
+// allowedIndexOperators are the comparison operators accepted in partial index predicates
+var allowedIndexOperators = map[string]bool{
+	"=": true, "!=": true, "<": true, "<=": true, ">": true, ">=": true, "IS": true, "IS NOT": true,
+}
+
+// validateIndex checks the index name, columns, directions and partial index predicate
+func validateIndex(idx schema.IndexDef) error {
+	if !isValidIdentifier(idx.Name) {
+		return fmt.Errorf("invalid index name: %s", idx.Name)
+	}
+	if len(idx.Columns) == 0 {
+		return fmt.Errorf("index %s has no columns", idx.Name)
+	}
+	for _, col := range idx.Columns {
+		if !isValidIdentifier(col) {
+			return fmt.Errorf("invalid index column: %s", col)
+		}
+	}
+	if len(idx.Directions) > len(idx.Columns) {
+		return fmt.Errorf("index %s has more directions than columns", idx.Name)
+	}
+	for _, dir := range idx.Directions {
+		if dir != "" && dir != schema.IndexAsc && dir != schema.IndexDesc {
+			return fmt.Errorf("invalid index direction: %s", dir)
+		}
+	}
+	for _, c := range idx.Where {
+		if !isValidIdentifier(c.Column) {
+			return fmt.Errorf("invalid index predicate column: %s", c.Column)
+		}
+		if !allowedIndexOperators[c.Operator] {
+			return fmt.Errorf("unsupported index predicate operator: %s", c.Operator)
+		}
+		switch c.Value.(type) {
+		case nil, bool, string, int, int32, int64, float32, float64:
+		default:
+			return fmt.Errorf("unsupported index predicate value for %s: %T", c.Column, c.Value)
+		}
+	}
+	return nil
+}
+
+// validateQueryParams checks selected columns, aggregate functions, their columns and aliases,
+// WHERE columns and operators (OrGroups 포함), GROUP BY and ORDER BY columns
+func validateQueryParams(params query.QueryParams) error {
+	for _, col := range params.SelectColumns {
+		if !isValidIdentifier(col) {
+			return fmt.Errorf("invalid select column: %s", col)
+		}
+	}
+	for _, a := range params.Aggregates {
+		if !query.IsValidAggregateFunction(a.Function) {
+			return fmt.Errorf("unsupported aggregate function: %s", a.Function)
+		}
+		if a.Column == "*" {
+			if !strings.EqualFold(a.Function, "COUNT") {
+				return fmt.Errorf("%s does not support *", a.Function)
+			}
+		} else if !isValidIdentifier(a.Column) {
+			return fmt.Errorf("invalid aggregate column: %s", a.Column)
+		}
+		if !isValidIdentifier(a.Alias) {
+			return fmt.Errorf("invalid aggregate alias: %s", a.Alias)
+		}
+	}
+	for _, col := range params.GroupBy {
+		if !isValidIdentifier(col) {
+			return fmt.Errorf("invalid group by column: %s", col)
+		}
+	}
+	if err := validateWhereConditions(params.Where); err != nil {
+		return err
+	}
+	for _, group := range params.OrGroups {
+		if err := validateWhereConditions(group); err != nil {
+			return err
+		}
+	}
+	for _, o := range params.OrderBy {
+		if !isValidIdentifier(o.Column) {
+			return fmt.Errorf("invalid order by column: %s", o.Column)
+		}
+	}
+	return nil
+}
+
+// validateWhereConditions checks the column and operator of each condition; 값은 항상 바인딩되므로 검사하지 않음
+func validateWhereConditions(conditions []query.WhereCondition) error {
+	for _, w := range conditions {
+		if !isValidIdentifier(w.Column) {
+			return fmt.Errorf("invalid where column: %s", w.Column)
+		}
+		if !query.IsValidOperator(w.Operator) {
+			return fmt.Errorf("unsupported where operator: %s", w.Operator)
+		}
+	}
+	return nil
+}
+
 // validateChangeAction checks if the provided action is valid
 func validateChangeAction(action string) error {
 	if !allowedActions[action] {
@@ -536,7 +1130,7 @@ func (s *DynamicStore) batchCopy(ctx context.Context, copySQL string, sourceTabl
 		query := fmt.Sprintf("%s LIMIT %d OFFSET %d", copySQL, batchSize, offset)
 
 		// Execute batch copy
-		_, err := s.manager.GetDB().ExecContext(ctx, query)
+		_, err := s.db(ctx).ExecContext(ctx, query)
 		if err != nil {
 			return totalRows, err
 		}
@@ -557,7 +1151,7 @@ func (s *DynamicStore) batchCopy(ctx context.Context, copySQL string, sourceTabl
 // getRowCount gets the count of rows processed in the batch
 func (s *DynamicStore) getRowCount(ctx context.Context, tableName string, batchSize, offset int) int {
 	query := fmt.Sprintf("SELECT COUNT(*) FROM %s LIMIT %d OFFSET %d", tableName, batchSize, offset)
-	row := s.manager.GetDB().QueryRowContext(ctx, query)
+	row := s.db(ctx).QueryRowContext(ctx, query)
 
 	var count int
 	if err := row.Scan(&count); err != nil {
@@ -0,0 +1,53 @@
+package dynamic
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/sukryu/pAuth/internal/store/manager"
+)
+
+type txKey struct{}
+
+// txFromContext returns the transaction started by InTransaction, if any
+func txFromContext(ctx context.Context) (*sql.Tx, bool) {
+	tx, ok := ctx.Value(txKey{}).(*sql.Tx)
+	return tx, ok
+}
+
+// db returns the connection statements run on: the current transaction, or the primary connection
+func (s *DynamicStore) db(ctx context.Context) manager.DB {
+	if tx, ok := txFromContext(ctx); ok {
+		return tx
+	}
+	return s.conn
+}
+
+// InTransaction runs fn in a database transaction. fn에 전달된 ctx로 실행되는 모든 쿼리는
+// 같은 트랜잭션을 사용하며, fn이 에러를 반환하거나 panic이 발생하면 롤백됨.
+// 이미 트랜잭션 안에서 호출되면 새 트랜잭션을 열지 않고 바깥 트랜잭션에 참여함
+func (s *DynamicStore) InTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := txFromContext(ctx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := s.manager.GetDB().BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("%w (rollback failed: %v)", err, rbErr)
+		}
+		return err
+	}
+	return tx.Commit()
+}
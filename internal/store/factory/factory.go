@@ -1,30 +1,49 @@
 package factory
 
 import (
+	"context"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/sukryu/pAuth/internal/config"
+	"github.com/sukryu/pAuth/internal/store/cache"
+	clusterrole "github.com/sukryu/pAuth/internal/store/cluster_role"
+	clusterrolebinding "github.com/sukryu/pAuth/internal/store/cluster_role_binding"
+	"github.com/sukryu/pAuth/internal/store/composite"
 	"github.com/sukryu/pAuth/internal/store/dynamic"
 	"github.com/sukryu/pAuth/internal/store/interfaces"
 	"github.com/sukryu/pAuth/internal/store/manager"
 	"github.com/sukryu/pAuth/internal/store/role"
 	rolebinding "github.com/sukryu/pAuth/internal/store/role_binding"
+	"github.com/sukryu/pAuth/internal/store/schema"
+	serviceaccount "github.com/sukryu/pAuth/internal/store/service_account"
 	"github.com/sukryu/pAuth/internal/store/user"
+	"github.com/sukryu/pAuth/pkg/controllers"
 )
 
 type StoreFactory interface {
 	NewUserStore(cfg *config.DatabaseConfig) (interfaces.UserStore, error)
 	NewRoleStore(cfg *config.DatabaseConfig) (interfaces.RoleStore, error)
 	NewRoleBindingStore(cfg *config.DatabaseConfig) (interfaces.RoleBindingStore, error)
+	NewClusterRoleStore(cfg *config.DatabaseConfig) (interfaces.ClusterRoleStore, error)
+	NewClusterRoleBindingStore(cfg *config.DatabaseConfig) (interfaces.ClusterRoleBindingStore, error)
+	NewServiceAccountStore(cfg *config.DatabaseConfig) (interfaces.ServiceAccountStore, error)
 	NewDynamicStore(cfg *config.DatabaseConfig) (*dynamic.DynamicStore, error)
+	// NewStore combines every resource store on cfg into a controllers.Store with real transactions
+	NewStore(cfg *config.DatabaseConfig) (controllers.Store, error)
+	NewCache(cfg *config.CacheConfig) (cache.Cache, error)
 	Close() error
 	GetStats() map[string]interface{}
+	// HealthCheck checks every open manager and returns the first failure
+	HealthCheck(ctx context.Context) error
 }
 
 type storeFactory struct {
 	mu             sync.RWMutex
 	managers       map[string]manager.Manager
+	caches         []cache.Cache
+	purgeStops     []func()
 	managerFactory manager.ManagerFactory
 }
 
@@ -36,8 +55,46 @@ func NewStoreFactory(managerFactory manager.ManagerFactory) StoreFactory {
 }
 
 func (f *storeFactory) getManager(cfg *config.DatabaseConfig) (manager.Manager, error) {
+	return f.openManager(cfg, cfg.GetDSN(), func(mgr manager.Manager) error {
+		// 보존 기간이 설정된 경우 DSN당 하나의 purge job 실행
+		if cfg.RetentionPeriod <= 0 {
+			return nil
+		}
+		dynStore, err := newDynamicStore(mgr, cfg)
+		if err != nil {
+			return err
+		}
+		tables := make([]string, 0, len(schema.CoreSchemas))
+		for _, s := range schema.CoreSchemas {
+			tables = append(tables, s.Name)
+		}
+		f.purgeStops = append(f.purgeStops, dynStore.StartPurgeJob(dynamic.PurgeConfig{
+			Tables:          tables,
+			RetentionPeriod: cfg.RetentionPeriod,
+			PurgeInterval:   cfg.PurgeInterval,
+		}))
+		return nil
+	})
+}
+
+// getReplicas returns a manager for each of cfg.ReadReplicas
+func (f *storeFactory) getReplicas(cfg *config.DatabaseConfig) ([]manager.Manager, error) {
+	replicas := make([]manager.Manager, 0, len(cfg.ReadReplicas))
+	for _, dsn := range cfg.ReadReplicas {
+		mgr, err := f.openManager(cfg, dsn, nil)
+		if err != nil {
+			return nil, fmt.Errorf("read replica: %w", err)
+		}
+		replicas = append(replicas, mgr)
+	}
+	return replicas, nil
+}
+
+// openManager returns the manager for dsn, creating it with cfg's pool settings on first use.
+// onCreate은 새로 만든 경우에만 lock을 잡은 채로 호출됨
+func (f *storeFactory) openManager(cfg *config.DatabaseConfig, dsn string, onCreate func(manager.Manager) error) (manager.Manager, error) {
 	f.mu.RLock()
-	mgr, exists := f.managers[cfg.GetDSN()]
+	mgr, exists := f.managers[dsn]
 	f.mu.RUnlock()
 
 	if exists {
@@ -48,14 +105,21 @@ func (f *storeFactory) getManager(cfg *config.DatabaseConfig) (manager.Manager,
 	defer f.mu.Unlock()
 
 	// Double-check after acquiring write lock
-	if mgr, exists = f.managers[cfg.GetDSN()]; exists {
+	if mgr, exists = f.managers[dsn]; exists {
 		return mgr, nil
 	}
 
 	// Create new manager
 	mgr, err := f.managerFactory.NewManager(manager.Config{
-		Type: cfg.Type,
-		DSN:  cfg.GetDSN(),
+		Type:            cfg.Type,
+		DSN:             dsn,
+		MaxOpenConns:    cfg.MaxOpenConns,
+		MaxIdleConns:    cfg.MaxIdleConns,
+		ConnMaxLifetime: time.Duration(cfg.ConnMaxLifetimeSeconds) * time.Second,
+		BusyTimeout:     time.Duration(cfg.BusyTimeoutMs) * time.Millisecond,
+		JournalMode:     cfg.JournalMode,
+		Synchronous:     cfg.Synchronous,
+		ForeignKeys:     cfg.ForeignKeys,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create database manager: %v", err)
@@ -66,7 +130,13 @@ func (f *storeFactory) getManager(cfg *config.DatabaseConfig) (manager.Manager,
 		return nil, fmt.Errorf("failed to initialize database: %v", err)
 	}
 
-	f.managers[cfg.GetDSN()] = mgr
+	if onCreate != nil {
+		if err := onCreate(mgr); err != nil {
+			return nil, err
+		}
+	}
+
+	f.managers[dsn] = mgr
 	return mgr, nil
 }
 
@@ -77,11 +147,35 @@ func (f *storeFactory) NewDynamicStore(cfg *config.DatabaseConfig) (*dynamic.Dyn
 		return nil, err
 	}
 
-	// DynamicStore 생성
-	store, err := dynamic.NewDynamicStore(manager)
+	store, err := newDynamicStore(manager, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	replicas, err := f.getReplicas(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if len(replicas) > 0 {
+		store.SetReadReplica(newReplicaSet(replicas))
+	}
+
+	return store, nil
+}
+
+// newDynamicStore creates a DynamicStore on mgr configured from cfg
+func newDynamicStore(mgr manager.Manager, cfg *config.DatabaseConfig) (*dynamic.DynamicStore, error) {
+	store, err := dynamic.NewDynamicStore(mgr)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create dynamic store: %w", err)
 	}
+	if err := store.SetTablePrefix(cfg.TablePrefix); err != nil {
+		return nil, err
+	}
+	store.SetSlowQueryConfig(dynamic.SlowQueryConfig{
+		Threshold: time.Duration(cfg.SlowQueryThresholdMs) * time.Millisecond,
+	})
+	store.SetConstraintErrors(cfg.ConstraintErrors)
 
 	return store, nil
 }
@@ -94,6 +188,8 @@ func (f *storeFactory) NewUserStore(cfg *config.DatabaseConfig) (interfaces.User
 
 	return user.NewStore(dynStore, user.Config{
 		DatabaseType: cfg.Type,
+		ExtraFields:  cfg.UserExtraFields,
+		UniqueFields: cfg.UserUniqueFields,
 	})
 }
 
@@ -104,7 +200,8 @@ func (f *storeFactory) NewRoleStore(cfg *config.DatabaseConfig) (interfaces.Role
 	}
 
 	return role.NewStore(dynStore, role.Config{
-		DatabaseType: cfg.Type,
+		DatabaseType:    cfg.Type,
+		NormalizedRules: cfg.NormalizedRoleRules,
 	})
 }
 
@@ -119,10 +216,94 @@ func (f *storeFactory) NewRoleBindingStore(cfg *config.DatabaseConfig) (interfac
 	})
 }
 
+func (f *storeFactory) NewClusterRoleStore(cfg *config.DatabaseConfig) (interfaces.ClusterRoleStore, error) {
+	dynStore, err := f.NewDynamicStore(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return clusterrole.NewStore(dynStore, clusterrole.Config{
+		DatabaseType: cfg.Type,
+	})
+}
+
+func (f *storeFactory) NewClusterRoleBindingStore(cfg *config.DatabaseConfig) (interfaces.ClusterRoleBindingStore, error) {
+	dynStore, err := f.NewDynamicStore(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return clusterrolebinding.NewStore(dynStore, clusterrolebinding.Config{
+		DatabaseType: cfg.Type,
+	})
+}
+
+func (f *storeFactory) NewServiceAccountStore(cfg *config.DatabaseConfig) (interfaces.ServiceAccountStore, error) {
+	dynStore, err := f.NewDynamicStore(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return serviceaccount.NewStore(dynStore, serviceaccount.Config{
+		DatabaseType: cfg.Type,
+	})
+}
+
+func (f *storeFactory) NewStore(cfg *config.DatabaseConfig) (controllers.Store, error) {
+	dynStore, err := f.NewDynamicStore(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	var stores composite.Stores
+	if stores.Users, err = f.NewUserStore(cfg); err != nil {
+		return nil, err
+	}
+	if stores.Roles, err = f.NewRoleStore(cfg); err != nil {
+		return nil, err
+	}
+	if stores.RoleBindings, err = f.NewRoleBindingStore(cfg); err != nil {
+		return nil, err
+	}
+	if stores.ClusterRoles, err = f.NewClusterRoleStore(cfg); err != nil {
+		return nil, err
+	}
+	if stores.ClusterRoleBindings, err = f.NewClusterRoleBindingStore(cfg); err != nil {
+		return nil, err
+	}
+	if stores.ServiceAccounts, err = f.NewServiceAccountStore(cfg); err != nil {
+		return nil, err
+	}
+
+	return composite.New(dynStore, stores), nil
+}
+
+func (f *storeFactory) NewCache(cfg *config.CacheConfig) (cache.Cache, error) {
+	c, err := cache.New(cache.Config{
+		Type:     cfg.Type,
+		RedisURL: cfg.RedisURL,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cache: %w", err)
+	}
+
+	f.mu.Lock()
+	f.caches = append(f.caches, c)
+	f.mu.Unlock()
+
+	return c, nil
+}
+
 func (f *storeFactory) Close() error {
 	f.mu.Lock()
 	defer f.mu.Unlock()
 
+	// 연결을 닫기 전에 purge job 종료
+	for _, stop := range f.purgeStops {
+		stop()
+	}
+	f.purgeStops = nil
+
 	var errs []error
 	for dsn, manager := range f.managers {
 		if err := manager.Close(); err != nil {
@@ -130,6 +311,12 @@ func (f *storeFactory) Close() error {
 		}
 		delete(f.managers, dsn)
 	}
+	for _, c := range f.caches {
+		if err := c.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	f.caches = nil
 
 	if len(errs) > 0 {
 		return fmt.Errorf("errors closing managers: %v", errs)
@@ -147,3 +334,15 @@ func (f *storeFactory) GetStats() map[string]interface{} {
 	}
 	return stats
 }
+
+func (f *storeFactory) HealthCheck(ctx context.Context) error {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	for _, manager := range f.managers {
+		if err := manager.HealthCheck(ctx); err != nil {
+			return fmt.Errorf("database is unhealthy: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,371 @@
+package factory
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sukryu/pAuth/internal/config"
+	"github.com/sukryu/pAuth/internal/store/manager"
+	"github.com/sukryu/pAuth/internal/store/schema"
+	"github.com/sukryu/pAuth/pkg/apis/auth/v1alpha1"
+	"github.com/sukryu/pAuth/pkg/controllers"
+	"github.com/sukryu/pAuth/pkg/utils/namespace"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// sqlite3ManagerFactory opens every manager with the sqlite3 driver
+type sqlite3ManagerFactory struct{}
+
+func (sqlite3ManagerFactory) NewManager(cfg manager.Config) (manager.Manager, error) {
+	cfg.Type = "sqlite3"
+	return manager.NewSQLManager(cfg)
+}
+
+func TestStoreFactory_HealthCheck(t *testing.T) {
+	f := NewStoreFactory(sqlite3ManagerFactory{})
+	cfg := &config.DatabaseConfig{Type: "sqlite", Database: ":memory:"}
+
+	userStore, err := f.NewUserStore(cfg)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	assert.NoError(t, userStore.HealthCheck(ctx))
+	assert.NoError(t, f.HealthCheck(ctx))
+
+	// 같은 DSN의 manager를 닫으면 store와 factory 모두 비정상으로 보고
+	mgr, err := f.(*storeFactory).getManager(cfg)
+	require.NoError(t, err)
+	require.NoError(t, mgr.Close())
+
+	assert.Error(t, userStore.HealthCheck(ctx))
+	assert.Error(t, f.HealthCheck(ctx))
+}
+
+func TestStoreFactory_TablePrefix(t *testing.T) {
+	f := NewStoreFactory(sqlite3ManagerFactory{})
+	defer f.Close()
+	cfg := &config.DatabaseConfig{
+		Type:        "sqlite",
+		Database:    filepath.Join(t.TempDir(), "shared.db"),
+		TablePrefix: "pauth_",
+	}
+	ctx := context.Background()
+
+	dynStore, err := f.NewDynamicStore(cfg)
+	require.NoError(t, err)
+	require.NoError(t, dynStore.CreateDynamicTable(ctx, "roles", schema.TableOptions{
+		Fields: []schema.FieldDef{
+			{Name: "name", Type: schema.FieldTypeString},
+			{Name: "namespace", Type: schema.FieldTypeString, DefaultValue: "'default'"},
+			{Name: "description", Type: schema.FieldTypeString, Nullable: true},
+			{Name: "rules", Type: schema.FieldTypeJSON, Nullable: true},
+			{Name: "inherits", Type: schema.FieldTypeJSON, Nullable: true},
+			{Name: "created_by", Type: schema.FieldTypeString, Nullable: true},
+			{Name: "updated_by", Type: schema.FieldTypeString, Nullable: true},
+		},
+		Indexes: []schema.IndexDef{{Name: "idx_roles_name", Columns: []string{"name"}, Unique: true}},
+	}))
+
+	roleStore, err := f.NewRoleStore(cfg)
+	require.NoError(t, err)
+
+	reader := &v1alpha1.Role{
+		ObjectMeta: metav1.ObjectMeta{Name: "reader"},
+		Rules:      []v1alpha1.PolicyRule{{Verbs: []string{"get"}, Resources: []string{"users"}, APIGroups: []string{"auth.service"}}},
+	}
+	require.NoError(t, roleStore.Create(ctx, reader))
+
+	got, err := roleStore.Get(ctx, "reader")
+	require.NoError(t, err)
+	assert.Equal(t, reader.Rules, got.Rules)
+
+	roles, err := roleStore.List(ctx)
+	require.NoError(t, err)
+	assert.Len(t, roles, 1)
+
+	require.NoError(t, roleStore.Delete(ctx, "reader"))
+	_, err = roleStore.Get(ctx, "reader")
+	assert.Error(t, err)
+
+	// 테이블과 인덱스 모두 접두사가 붙어야 함
+	mgr, err := f.(*storeFactory).getManager(cfg)
+	require.NoError(t, err)
+	rows, err := mgr.GetDB().QueryContext(ctx, "SELECT name FROM sqlite_master WHERE type IN ('table', 'index') AND name NOT LIKE 'sqlite_%'")
+	require.NoError(t, err)
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		require.NoError(t, rows.Scan(&name))
+		names = append(names, name)
+	}
+	assert.ElementsMatch(t, []string{"pauth_roles", "pauth_idx_roles_name"}, names)
+}
+
+func TestStoreFactory_ReadReplicas(t *testing.T) {
+	f := NewStoreFactory(sqlite3ManagerFactory{})
+	defer f.Close()
+	dir := t.TempDir()
+	primaryCfg := &config.DatabaseConfig{Type: "sqlite", Database: filepath.Join(dir, "primary.db")}
+	replicaCfg := &config.DatabaseConfig{Type: "sqlite", Database: filepath.Join(dir, "replica.db")}
+	splitCfg := &config.DatabaseConfig{Type: "sqlite", Database: primaryCfg.Database, ReadReplicas: []string{replicaCfg.Database}}
+	ctx := context.Background()
+
+	newUser := func(name string) *v1alpha1.User {
+		return &v1alpha1.User{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       v1alpha1.UserSpec{Username: name, Email: name + "@example.com", PasswordHash: "hashed_password"},
+		}
+	}
+
+	// 두 파일 모두 core 테이블을 생성
+	for _, cfg := range []*config.DatabaseConfig{primaryCfg, replicaCfg} {
+		dynStore, err := f.NewDynamicStore(cfg)
+		require.NoError(t, err)
+		require.NoError(t, dynStore.RunCoreMigrations(ctx))
+	}
+
+	primary, err := f.NewUserStore(primaryCfg)
+	require.NoError(t, err)
+	replica, err := f.NewUserStore(replicaCfg)
+	require.NoError(t, err)
+	split, err := f.NewUserStore(splitCfg)
+	require.NoError(t, err)
+
+	// 쓰기는 primary로 가므로 replica를 읽는 split store에서는 보이지 않음
+	require.NoError(t, split.Create(ctx, newUser("alice")))
+	_, err = primary.Get(ctx, "alice")
+	assert.NoError(t, err)
+	_, err = split.Get(ctx, "alice")
+	assert.Error(t, err)
+
+	// replica에만 있는 행은 split store의 조회 결과에 나타남
+	require.NoError(t, replica.Create(ctx, newUser("bob")))
+	got, err := split.Get(ctx, "bob")
+	require.NoError(t, err)
+	assert.Equal(t, "bob@example.com", got.Spec.Email)
+
+	list, err := split.List(ctx)
+	require.NoError(t, err)
+	if assert.Len(t, list.Items, 1) {
+		assert.Equal(t, "bob", list.Items[0].Name)
+	}
+
+	// replica manager도 factory가 관리하므로 통계/헬스체크 대상에 포함
+	assert.Contains(t, f.GetStats(), replicaCfg.Database)
+}
+
+func TestStoreFactory_CoreMigrations(t *testing.T) {
+	f := NewStoreFactory(sqlite3ManagerFactory{})
+	defer f.Close()
+	cfg := &config.DatabaseConfig{Type: "sqlite", Database: filepath.Join(t.TempDir(), "core.db")}
+	ctx := context.Background()
+
+	dynStore, err := f.NewDynamicStore(cfg)
+	require.NoError(t, err)
+	require.NoError(t, dynStore.RunCoreMigrations(ctx))
+
+	// 마이그레이션으로 만든 테이블만으로 각 store가 동작해야 함
+	userStore, err := f.NewUserStore(cfg)
+	require.NoError(t, err)
+	require.NoError(t, userStore.Create(ctx, &v1alpha1.User{
+		ObjectMeta: metav1.ObjectMeta{Name: "alice"},
+		Spec:       v1alpha1.UserSpec{Username: "alice", Email: "alice@example.com", PasswordHash: "hashed_password"},
+	}))
+
+	roleStore, err := f.NewRoleStore(cfg)
+	require.NoError(t, err)
+	require.NoError(t, roleStore.Create(ctx, &v1alpha1.Role{
+		ObjectMeta: metav1.ObjectMeta{Name: "reader", Annotations: map[string]string{"description": "read only"}},
+		Rules:      []v1alpha1.PolicyRule{{Verbs: []string{"get"}, Resources: []string{"users"}, APIGroups: []string{"auth.service"}}},
+	}))
+
+	bindingStore, err := f.NewRoleBindingStore(cfg)
+	require.NoError(t, err)
+	require.NoError(t, bindingStore.Create(ctx, &v1alpha1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "alice-reader"},
+		Subjects:   []v1alpha1.Subject{{Kind: v1alpha1.SubjectKindUser, Name: "alice"}},
+		RoleRef:    v1alpha1.RoleRef{Kind: v1alpha1.RoleRefKindRole, Name: "reader"},
+	}))
+
+	bindings, err := bindingStore.FindBySubject(ctx, v1alpha1.SubjectKindUser, "alice")
+	require.NoError(t, err)
+	assert.Len(t, bindings, 1)
+}
+
+func TestStoreFactory_SharedTransaction(t *testing.T) {
+	f := NewStoreFactory(sqlite3ManagerFactory{})
+	defer f.Close()
+	cfg := &config.DatabaseConfig{Type: "sqlite", Database: filepath.Join(t.TempDir(), "tx.db")}
+	ctx := context.Background()
+
+	dynStore, err := f.NewDynamicStore(cfg)
+	require.NoError(t, err)
+	require.NoError(t, dynStore.RunCoreMigrations(ctx))
+
+	userStore, err := f.NewUserStore(cfg)
+	require.NoError(t, err)
+	bindingStore, err := f.NewRoleBindingStore(cfg)
+	require.NoError(t, err)
+
+	existing := &v1alpha1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "alice-reader"},
+		Subjects:   []v1alpha1.Subject{{Kind: v1alpha1.SubjectKindUser, Name: "bob"}},
+		RoleRef:    v1alpha1.RoleRef{Kind: v1alpha1.RoleRefKindRole, Name: "reader"},
+	}
+	require.NoError(t, bindingStore.Create(ctx, existing))
+
+	// 같은 설정으로 만든 store는 하나의 트랜잭션에 참여하므로 binding 실패 시 user도 롤백됨
+	err = userStore.InTransaction(ctx, func(ctx context.Context) error {
+		if err := userStore.Create(ctx, &v1alpha1.User{
+			ObjectMeta: metav1.ObjectMeta{Name: "alice"},
+			Spec:       v1alpha1.UserSpec{Username: "alice", PasswordHash: "hashed_password"},
+		}); err != nil {
+			return err
+		}
+		return bindingStore.Create(ctx, existing.DeepCopy())
+	})
+	require.Error(t, err)
+
+	_, err = userStore.Get(ctx, "alice")
+	assert.Error(t, err)
+}
+
+func TestStoreFactory_NewStore(t *testing.T) {
+	f := NewStoreFactory(sqlite3ManagerFactory{})
+	defer f.Close()
+	cfg := &config.DatabaseConfig{Type: "sqlite", Database: filepath.Join(t.TempDir(), "store.db")}
+	ctx := context.Background()
+
+	dynStore, err := f.NewDynamicStore(cfg)
+	require.NoError(t, err)
+	require.NoError(t, dynStore.RunCoreMigrations(ctx))
+
+	store, err := f.NewStore(cfg)
+	require.NoError(t, err)
+	_, ok := store.(controllers.Transactor)
+	require.True(t, ok, "store must implement controllers.Transactor")
+
+	require.NoError(t, store.CreateRole(ctx, &v1alpha1.Role{
+		ObjectMeta: metav1.ObjectMeta{Name: "reader"},
+		Rules:      []v1alpha1.PolicyRule{{Verbs: []string{"get"}, Resources: []string{"users"}}},
+	}))
+	require.NoError(t, store.CreateRoleBinding(ctx, &v1alpha1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "alice-reader"},
+		Subjects:   []v1alpha1.Subject{{Kind: v1alpha1.SubjectKindUser, Name: "bob"}},
+		RoleRef:    v1alpha1.RoleRef{Kind: v1alpha1.RoleRefKindRole, Name: "reader"},
+	}))
+
+	newUser := func(name string) *v1alpha1.User {
+		return &v1alpha1.User{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: v1alpha1.UserSpec{
+				Username:     name,
+				Email:        name + "@example.com",
+				PasswordHash: "Password123!",
+			},
+		}
+	}
+	auth := controllers.NewAuthController(store)
+
+	t.Run("rolls back the user when a binding fails", func(t *testing.T) {
+		_, err := auth.CreateUserWithBindings(ctx, newUser("alice"), []string{"reader"})
+		require.Error(t, err)
+
+		_, err = store.GetUser(ctx, "alice")
+		assert.Error(t, err)
+	})
+
+	t.Run("commits the user and its bindings", func(t *testing.T) {
+		_, err := auth.CreateUserWithBindings(ctx, newUser("carol"), []string{"reader"})
+		require.NoError(t, err)
+
+		_, err = store.GetUser(ctx, "carol")
+		assert.NoError(t, err)
+		binding, err := store.GetRoleBinding(ctx, "carol-reader")
+		require.NoError(t, err)
+		assert.Equal(t, "carol", binding.Subjects[0].Name)
+	})
+
+	t.Run("rename updates binding subjects in place", func(t *testing.T) {
+		before, err := store.GetRoleBinding(ctx, "carol-reader")
+		require.NoError(t, err)
+
+		_, err = auth.RenameUser(ctx, "carol", "dave")
+		require.NoError(t, err)
+
+		after, err := store.GetRoleBinding(ctx, "carol-reader")
+		require.NoError(t, err)
+		assert.Equal(t, "dave", after.Subjects[0].Name)
+		assert.True(t, before.CreationTimestamp.Equal(&after.CreationTimestamp))
+	})
+
+	t.Run("names are unique per namespace", func(t *testing.T) {
+		teamCtx := namespace.WithNamespace(ctx, "team-a")
+		require.NoError(t, store.CreateUser(teamCtx, newUser("dave")))
+		require.NoError(t, store.CreateRole(teamCtx, &v1alpha1.Role{ObjectMeta: metav1.ObjectMeta{Name: "reader"}}))
+		assert.Error(t, store.CreateUser(teamCtx, newUser("dave")))
+
+		// 다른 namespace의 같은 이름은 수정/삭제 대상이 아님
+		require.NoError(t, store.DeleteUser(teamCtx, "dave"))
+		_, err := store.GetUser(ctx, "dave")
+		assert.NoError(t, err)
+
+		found, err := store.FindUserByEmail(teamCtx, "dave@example.com")
+		assert.Error(t, err)
+		assert.Nil(t, found)
+	})
+}
+
+// tokenRecorder keeps the last verification token sent by the auth controller
+type tokenRecorder struct{ token string }
+
+func (r *tokenRecorder) SendEmailChangeVerification(_ context.Context, _ *v1alpha1.User, _, token string) error {
+	r.token = token
+	return nil
+}
+
+func TestStoreFactory_RegistrationVerification(t *testing.T) {
+	f := NewStoreFactory(sqlite3ManagerFactory{})
+	defer f.Close()
+	cfg := &config.DatabaseConfig{Type: "sqlite", Database: filepath.Join(t.TempDir(), "store.db")}
+	ctx := context.Background()
+
+	dynStore, err := f.NewDynamicStore(cfg)
+	require.NoError(t, err)
+	require.NoError(t, dynStore.RunCoreMigrations(ctx))
+	store, err := f.NewStore(cfg)
+	require.NoError(t, err)
+
+	sender := &tokenRecorder{}
+	auth := controllers.NewAuthControllerWithConfig(store, controllers.AuthControllerConfig{
+		AllowSelfRegistration:    true,
+		RequireEmailVerification: true,
+		EmailChangeSender:        sender,
+	})
+
+	_, err = auth.RegisterUser(ctx, controllers.Registration{
+		Name: "erin", Email: "erin@example.com", Password: "correct-horse",
+	})
+	require.NoError(t, err)
+	pending, err := store.GetUser(ctx, "erin")
+	require.NoError(t, err)
+	assert.False(t, pending.Status.Active)
+
+	_, err = auth.VerifyRegistration(ctx, sender.token)
+	require.NoError(t, err)
+
+	// 활성화가 DB에 저장되어야 다시 읽은 사용자도 활성 상태이고 refresh가 허용됨
+	verified, err := store.GetUser(ctx, "erin")
+	require.NoError(t, err)
+	assert.True(t, verified.Status.Active)
+	assert.Equal(t, "erin@example.com", verified.Spec.Email)
+
+	_, err = auth.RefreshUser(ctx, "erin", time.Now())
+	assert.NoError(t, err)
+}
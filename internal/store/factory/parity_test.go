@@ -0,0 +1,80 @@
+package factory
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/sukryu/pAuth/internal/store/cache"
+	clusterrole "github.com/sukryu/pAuth/internal/store/cluster_role"
+	clusterrolebinding "github.com/sukryu/pAuth/internal/store/cluster_role_binding"
+	"github.com/sukryu/pAuth/internal/store/interfaces"
+	"github.com/sukryu/pAuth/internal/store/role"
+	rolebinding "github.com/sukryu/pAuth/internal/store/role_binding"
+	serviceaccount "github.com/sukryu/pAuth/internal/store/service_account"
+	"github.com/sukryu/pAuth/internal/store/user"
+)
+
+// methodSet returns the exported method names and signatures of t
+func methodSet(t reflect.Type) map[string]string {
+	methods := make(map[string]string, t.NumMethod())
+	for i := 0; i < t.NumMethod(); i++ {
+		m := t.Method(i)
+		sig := m.Type
+		if t.Kind() != reflect.Interface {
+			// receiver 제거 후 비교
+			in := make([]reflect.Type, 0, sig.NumIn()-1)
+			for j := 1; j < sig.NumIn(); j++ {
+				in = append(in, sig.In(j))
+			}
+			out := make([]reflect.Type, 0, sig.NumOut())
+			for j := 0; j < sig.NumOut(); j++ {
+				out = append(out, sig.Out(j))
+			}
+			sig = reflect.FuncOf(in, out, sig.IsVariadic())
+		}
+		methods[m.Name] = sig.String()
+	}
+	return methods
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// TestStoreInterfaceParity fails when an implementation grows exported methods that its
+// interface does not declare (or vice versa), so backends cannot silently drift apart.
+func TestStoreInterfaceParity(t *testing.T) {
+	tests := []struct {
+		name  string
+		iface reflect.Type
+		impls []reflect.Type
+	}{
+		{"UserStore", reflect.TypeOf((*interfaces.UserStore)(nil)).Elem(), []reflect.Type{reflect.TypeOf(&user.Store{})}},
+		{"RoleStore", reflect.TypeOf((*interfaces.RoleStore)(nil)).Elem(), []reflect.Type{reflect.TypeOf(&role.Store{})}},
+		{"RoleBindingStore", reflect.TypeOf((*interfaces.RoleBindingStore)(nil)).Elem(), []reflect.Type{reflect.TypeOf(&rolebinding.Store{})}},
+		{"ClusterRoleStore", reflect.TypeOf((*interfaces.ClusterRoleStore)(nil)).Elem(), []reflect.Type{reflect.TypeOf(&clusterrole.Store{})}},
+		{"ClusterRoleBindingStore", reflect.TypeOf((*interfaces.ClusterRoleBindingStore)(nil)).Elem(), []reflect.Type{reflect.TypeOf(&clusterrolebinding.Store{})}},
+		{"ServiceAccountStore", reflect.TypeOf((*interfaces.ServiceAccountStore)(nil)).Elem(), []reflect.Type{reflect.TypeOf(&serviceaccount.Store{})}},
+		{"Cache", reflect.TypeOf((*cache.Cache)(nil)).Elem(), []reflect.Type{reflect.TypeOf(&cache.MemoryCache{}), reflect.TypeOf(&cache.RedisCache{})}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := methodSet(tt.iface)
+			for _, impl := range tt.impls {
+				got := methodSet(impl)
+				assert.Equal(t, sortedKeys(want), sortedKeys(got), "%s method set differs from %s", impl, tt.name)
+				for name, sig := range want {
+					assert.Equal(t, sig, got[name], "%s.%s signature", impl, name)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package factory
+
+import (
+	"context"
+	"database/sql"
+	"sync/atomic"
+
+	"github.com/sukryu/pAuth/internal/store/manager"
+)
+
+// replicaSet spreads reads over the read replicas in round-robin order
+type replicaSet struct {
+	replicas []manager.Manager
+	next     atomic.Uint32
+}
+
+func newReplicaSet(replicas []manager.Manager) *replicaSet {
+	return &replicaSet{replicas: replicas}
+}
+
+func (r *replicaSet) pick() manager.Manager {
+	return r.replicas[int(r.next.Add(1)-1)%len(r.replicas)]
+}
+
+func (r *replicaSet) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return r.pick().QueryContext(ctx, query, args...)
+}
+
+func (r *replicaSet) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return r.pick().QueryRowContext(ctx, query, args...)
+}
@@ -0,0 +1,18 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/sukryu/pAuth/pkg/apis/auth/v1alpha1"
+)
+
+type ClusterRoleBindingStore interface {
+	Create(ctx context.Context, binding *v1alpha1.ClusterRoleBinding) error
+	Get(ctx context.Context, name string) (*v1alpha1.ClusterRoleBinding, error)
+	Update(ctx context.Context, binding *v1alpha1.ClusterRoleBinding) error
+	Delete(ctx context.Context, name string) error
+	List(ctx context.Context) ([]*v1alpha1.ClusterRoleBinding, error)
+	FindBySubject(ctx context.Context, subjectKind, subjectName string) ([]*v1alpha1.ClusterRoleBinding, error)
+
+	HealthChecker
+}
@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/sukryu/pAuth/pkg/apis/auth/v1alpha1"
+)
+
+type ClusterRoleStore interface {
+	Create(ctx context.Context, role *v1alpha1.ClusterRole) error
+	Get(ctx context.Context, name string) (*v1alpha1.ClusterRole, error)
+	Update(ctx context.Context, role *v1alpha1.ClusterRole) error
+	Delete(ctx context.Context, name string) error
+	List(ctx context.Context) ([]*v1alpha1.ClusterRole, error)
+
+	HealthChecker
+}
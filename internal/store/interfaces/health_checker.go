@@ -0,0 +1,9 @@
+package interfaces
+
+import "context"
+
+// HealthChecker is embedded by every store interface. HealthCheck reports whether the
+// backing database is reachable; 모든 store는 같은 DynamicStore를 통해 manager에 위임함
+type HealthChecker interface {
+	HealthCheck(ctx context.Context) error
+}
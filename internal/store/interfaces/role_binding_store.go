@@ -12,10 +12,18 @@ type RoleBindingStore interface {
 	Update(ctx context.Context, binding *v1alpha1.RoleBinding) error
 	Delete(ctx context.Context, name string) error
 	List(ctx context.Context) ([]*v1alpha1.RoleBinding, error)
+	ListPaged(ctx context.Context, limit, offset int) ([]*v1alpha1.RoleBinding, int, error)
+	ListWithCursor(ctx context.Context, limit int, next string) ([]*v1alpha1.RoleBinding, string, error)
 
 	FindBySubject(ctx context.Context, subjectKind, subjectName string) ([]*v1alpha1.RoleBinding, error)
 	FindByRole(ctx context.Context, roleName string) ([]*v1alpha1.RoleBinding, error)
 	AddSubject(ctx context.Context, name string, subject v1alpha1.Subject) error
 	RemoveSubject(ctx context.Context, name string, subject v1alpha1.Subject) error
+
+	// InTransaction runs fn atomically; fn에 전달된 ctx로 수행한 연산만 트랜잭션에 포함됨.
+	// controllers.Transactor와 같은 이름이므로 store를 그대로 controller에 전달할 수 있음
+	InTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+
+	HealthChecker
 	//ListByNamespace(ctx context.Context, namespace string) ([]*v1alpha1.RoleBinding, error)
 }
@@ -12,10 +12,18 @@ type RoleStore interface {
 	Update(ctx context.Context, role *v1alpha1.Role) error
 	Delete(ctx context.Context, name string) error
 	List(ctx context.Context) ([]*v1alpha1.Role, error)
+	ListPaged(ctx context.Context, limit, offset int) ([]*v1alpha1.Role, int, error)
+	ListWithCursor(ctx context.Context, limit int, next string) ([]*v1alpha1.Role, string, error)
 
 	FindByVerb(ctx context.Context, verb string) ([]*v1alpha1.Role, error)
 	FindByResource(ctx context.Context, resource string) ([]*v1alpha1.Role, error)
 	FindByAPIGroup(ctx context.Context, apiGroup string) ([]*v1alpha1.Role, error)
 	UpdateRules(ctx context.Context, name string, rules []v1alpha1.PolicyRule) error
 	ListBySubject(ctx context.Context, subjectKind, subjectName string) ([]*v1alpha1.Role, error)
+
+	// InTransaction runs fn atomically; fn에 전달된 ctx로 수행한 연산만 트랜잭션에 포함됨.
+	// controllers.Transactor와 같은 이름이므로 store를 그대로 controller에 전달할 수 있음
+	InTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+
+	HealthChecker
 }
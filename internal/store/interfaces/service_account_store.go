@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/sukryu/pAuth/pkg/apis/auth/v1alpha1"
+)
+
+type ServiceAccountStore interface {
+	Create(ctx context.Context, sa *v1alpha1.ServiceAccount) error
+	Get(ctx context.Context, name string) (*v1alpha1.ServiceAccount, error)
+	Delete(ctx context.Context, name string) error
+	List(ctx context.Context) ([]*v1alpha1.ServiceAccount, error)
+
+	HealthChecker
+}
@@ -12,10 +12,23 @@ type UserStore interface {
 	Update(ctx context.Context, user *v1alpha1.User) error
 	Delete(ctx context.Context, name string) error
 	List(ctx context.Context) (*v1alpha1.UserList, error)
+	ListWithOptions(ctx context.Context, opts v1alpha1.ListOptions) (*v1alpha1.UserList, error)
+	// StreamUsers calls fn for each user in batches instead of loading every user into memory
+	StreamUsers(ctx context.Context, fn func(*v1alpha1.User) error) error
 
 	FindByEmail(ctx context.Context, email string) (*v1alpha1.User, error)
 	FindByUsername(ctx context.Context, username string) (*v1alpha1.User, error)
+	FindByEmailChangeToken(ctx context.Context, tokenHash string) (*v1alpha1.User, error)
+	// Rename changes the user's name (id); username도 이전 이름과 같았다면 함께 변경
+	Rename(ctx context.Context, oldName, newName string) error
 	UpdatePassword(ctx context.Context, name string, hashedPassword string) error
 	UpdateStatus(ctx context.Context, name string, active bool) error
 	ListByRole(ctx context.Context, roleName string) (*v1alpha1.UserList, error)
+	FindByDisplayNamePrefix(ctx context.Context, prefix string, limit int) (*v1alpha1.UserList, error)
+
+	// InTransaction runs fn atomically; fn에 전달된 ctx로 수행한 연산만 트랜잭션에 포함됨.
+	// controllers.Transactor와 같은 이름이므로 store를 그대로 controller에 전달할 수 있음
+	InTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+
+	HealthChecker
 }
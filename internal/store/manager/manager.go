@@ -4,13 +4,36 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	_ "github.com/lib/pq"           // PostgreSQL driver
 	_ "github.com/mattn/go-sqlite3" // SQLite driver
 )
 
+// Execer runs statements that do not return rows
+type Execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// Queryer runs statements that return rows
+type Queryer interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// DB is the narrow connection interface stores run their SQL through.
+// *sql.DB, *sql.Tx, SQLManager 모두 만족하므로 테스트에서는 SQL을 기록하는 fake로 대체할 수 있음
+type DB interface {
+	Execer
+	Queryer
+}
+
 // Manager defines the interface for database operations
 type Manager interface {
+	DB
+
 	// Initialize performs any necessary database setup
 	Initialize(ctx context.Context) error
 
@@ -25,15 +48,43 @@ type Manager interface {
 
 	// EnsureSchemaExists ensures the existence of a specific schema
 	EnsureSchemaExists(ctx context.Context, schemaName string, schemaSQL string) error
+
+	// HealthCheck verifies the database is reachable and can execute queries
+	HealthCheck(ctx context.Context) error
 }
 
 // Config holds database configuration
 type Config struct {
 	Type     string
 	DSN      string
-	MaxConns int
+	MaxConns int // Deprecated: MaxOpenConns/MaxIdleConns 사용
+
+	// Connection pool tuning. 0이면 database/sql 기본값 유지
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+
+	// ForeignKeys enables SQLite foreign key enforcement on every pooled connection
+	ForeignKeys bool
+
+	// BusyTimeout sets SQLite busy_timeout on connection open, so a locked
+	// database is waited on instead of failing immediately. 0이면 드라이버 기본값
+	BusyTimeout time.Duration
+
+	// JournalMode sets SQLite journal_mode (e.g. "WAL"). WAL은 writer가 reader를
+	// 막지 않아 동시성이 좋아지지만 -wal/-shm 파일이 생기고 네트워크 파일시스템에서는 쓸 수 없음
+	JournalMode string
+
+	// Synchronous sets SQLite synchronous (e.g. "NORMAL"). WAL + NORMAL은 프로세스
+	// 크래시에는 안전하지만, 전원 장애/OS 크래시 시 마지막 커밋 일부가 유실될 수 있음
+	Synchronous string
 }
 
+var (
+	sqliteJournalModes = map[string]bool{"DELETE": true, "TRUNCATE": true, "PERSIST": true, "MEMORY": true, "WAL": true, "OFF": true}
+	sqliteSynchronous  = map[string]bool{"OFF": true, "NORMAL": true, "FULL": true, "EXTRA": true}
+)
+
 // SQLManager implements the Manager interface using sql.DB
 type SQLManager struct {
 	db       *sql.DB
@@ -44,15 +95,20 @@ type SQLManager struct {
 
 // NewSQLManager creates a new SQLManager
 func NewSQLManager(cfg Config) (*SQLManager, error) {
-	db, err := sql.Open(cfg.Type, cfg.DSN)
+	dsn := cfg.DSN
+	if isSQLite(cfg.Type) {
+		var err error
+		if dsn, err = sqliteDSN(cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	db, err := sql.Open(cfg.Type, dsn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
-	if cfg.MaxConns > 0 {
-		db.SetMaxOpenConns(cfg.MaxConns)
-		db.SetMaxIdleConns(cfg.MaxConns / 2)
-	}
+	applyPoolConfig(db, cfg)
 
 	return &SQLManager{
 		db:       db,
@@ -62,6 +118,12 @@ func NewSQLManager(cfg Config) (*SQLManager, error) {
 	}, nil
 }
 
+// NewSQLManagerFromDB wraps an already opened connection.
+// 연결 설정(DSN, 풀 설정)은 호출자가 이미 적용했다고 가정함
+func NewSQLManagerFromDB(db *sql.DB) *SQLManager {
+	return &SQLManager{db: db}
+}
+
 // Initialize performs any necessary database setup
 func (m *SQLManager) Initialize(ctx context.Context) error {
 	// Example: Run migrations or other setup tasks
@@ -73,24 +135,52 @@ func (m *SQLManager) GetDB() *sql.DB {
 	return m.db
 }
 
+// ExecContext runs query on the managed connection
+func (m *SQLManager) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return m.db.ExecContext(ctx, query, args...)
+}
+
+// QueryContext runs query on the managed connection
+func (m *SQLManager) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return m.db.QueryContext(ctx, query, args...)
+}
+
+// QueryRowContext runs query on the managed connection
+func (m *SQLManager) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return m.db.QueryRowContext(ctx, query, args...)
+}
+
 // Close closes the database connection
 func (m *SQLManager) Close() error {
 	return m.db.Close()
 }
 
+// HealthCheck pings the database and runs SELECT 1
+func (m *SQLManager) HealthCheck(ctx context.Context) error {
+	if err := m.db.PingContext(ctx); err != nil {
+		return fmt.Errorf("database ping failed: %w", err)
+	}
+	var one int
+	if err := m.db.QueryRowContext(ctx, "SELECT 1").Scan(&one); err != nil {
+		return fmt.Errorf("database query failed: %w", err)
+	}
+	return nil
+}
+
 // GetStats returns database statistics
 func (m *SQLManager) GetStats() map[string]interface{} {
 	stats := m.db.Stats()
-	healthy := true
-	if err := m.db.Ping(); err != nil {
-		healthy = false
-	}
+	healthy := m.HealthCheck(context.Background()) == nil
 	return map[string]interface{}{
-		"max_open_conns": stats.MaxOpenConnections,
-		"open_conns":     stats.OpenConnections,
-		"in_use":         stats.InUse,
-		"idle":           stats.Idle,
-		"healthy":        healthy,
+		"max_open_conns":      stats.MaxOpenConnections,
+		"open_conns":          stats.OpenConnections,
+		"in_use":              stats.InUse,
+		"idle":                stats.Idle,
+		"wait_count":          stats.WaitCount,
+		"wait_duration":       stats.WaitDuration.String(),
+		"max_idle_closed":     stats.MaxIdleClosed,
+		"max_lifetime_closed": stats.MaxLifetimeClosed,
+		"healthy":             healthy,
 	}
 }
 
@@ -103,6 +193,66 @@ func (m *SQLManager) EnsureSchemaExists(ctx context.Context, schemaName string,
 	return nil
 }
 
+// applyPoolConfig applies connection pool settings to db.
+// MaxOpenConns/MaxIdleConns가 지정되면 레거시 MaxConns보다 우선함.
+func applyPoolConfig(db *sql.DB, cfg Config) {
+	if cfg.MaxConns > 0 {
+		db.SetMaxOpenConns(cfg.MaxConns)
+		db.SetMaxIdleConns(cfg.MaxConns / 2)
+	}
+	if cfg.MaxOpenConns > 0 {
+		db.SetMaxOpenConns(cfg.MaxOpenConns)
+	}
+	if cfg.MaxIdleConns > 0 {
+		db.SetMaxIdleConns(cfg.MaxIdleConns)
+	}
+	if cfg.ConnMaxLifetime > 0 {
+		db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	}
+}
+
+// isSQLite reports whether the driver type refers to SQLite
+func isSQLite(dbType string) bool {
+	return dbType == "sqlite" || dbType == "sqlite3"
+}
+
+// sqliteDSN appends per-connection PRAGMA settings to the DSN.
+// 드라이버가 커넥션을 열 때마다 적용하므로 connection init hook 역할을 함
+func sqliteDSN(cfg Config) (string, error) {
+	dsn := cfg.DSN
+	if cfg.ForeignKeys {
+		dsn = withDSNParam(dsn, "_foreign_keys", "1")
+	}
+	if cfg.BusyTimeout > 0 {
+		dsn = withDSNParam(dsn, "_busy_timeout", strconv.FormatInt(cfg.BusyTimeout.Milliseconds(), 10))
+	}
+	if cfg.JournalMode != "" {
+		mode := strings.ToUpper(cfg.JournalMode)
+		if !sqliteJournalModes[mode] {
+			return "", fmt.Errorf("unsupported journal mode: %s", cfg.JournalMode)
+		}
+		dsn = withDSNParam(dsn, "_journal_mode", mode)
+	}
+	if cfg.Synchronous != "" {
+		mode := strings.ToUpper(cfg.Synchronous)
+		if !sqliteSynchronous[mode] {
+			return "", fmt.Errorf("unsupported synchronous mode: %s", cfg.Synchronous)
+		}
+		dsn = withDSNParam(dsn, "_synchronous", mode)
+	}
+	return dsn, nil
+}
+
+// withDSNParam appends a query parameter to a SQLite DSN.
+// PRAGMA는 커넥션 단위로 적용되므로 DSN에 넣어야 풀의 모든 커넥션에 반영됨.
+func withDSNParam(dsn, key, value string) string {
+	sep := "?"
+	if strings.Contains(dsn, "?") {
+		sep = "&"
+	}
+	return dsn + sep + key + "=" + value
+}
+
 // ManagerFactory creates database managers
 type ManagerFactory interface {
 	NewManager(cfg Config) (Manager, error)
@@ -0,0 +1,91 @@
+package manager
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSQLManager_PoolConfig(t *testing.T) {
+	mgr, err := NewSQLManager(Config{
+		Type:            "sqlite3",
+		DSN:             ":memory:",
+		MaxOpenConns:    3,
+		MaxIdleConns:    1,
+		ConnMaxLifetime: time.Minute,
+	})
+	require.NoError(t, err)
+	defer mgr.Close()
+
+	assert.Equal(t, 3, mgr.GetDB().Stats().MaxOpenConnections)
+
+	stats := mgr.GetStats()
+	assert.Equal(t, 3, stats["max_open_conns"])
+	assert.Equal(t, true, stats["healthy"])
+	assert.Contains(t, stats, "wait_count")
+}
+
+func TestNewSQLManager_LegacyMaxConns(t *testing.T) {
+	mgr, err := NewSQLManager(Config{Type: "sqlite3", DSN: ":memory:", MaxConns: 4})
+	require.NoError(t, err)
+	defer mgr.Close()
+
+	assert.Equal(t, 4, mgr.GetDB().Stats().MaxOpenConnections)
+}
+
+func TestNewSQLManager_SQLitePragmas(t *testing.T) {
+	// :memory: DB는 journal_mode가 항상 "memory"이므로 파일 DB 사용
+	mgr, err := NewSQLManager(Config{
+		Type:        "sqlite3",
+		DSN:         filepath.Join(t.TempDir(), "wal.db"),
+		JournalMode: "wal",
+		Synchronous: "NORMAL",
+		ForeignKeys: true,
+	})
+	require.NoError(t, err)
+	defer mgr.Close()
+	require.NoError(t, mgr.Initialize(context.Background()))
+
+	var journalMode string
+	require.NoError(t, mgr.GetDB().QueryRow("PRAGMA journal_mode").Scan(&journalMode))
+	assert.Equal(t, "wal", journalMode)
+
+	var synchronous, foreignKeys int
+	require.NoError(t, mgr.GetDB().QueryRow("PRAGMA synchronous").Scan(&synchronous))
+	assert.Equal(t, 1, synchronous) // NORMAL
+	require.NoError(t, mgr.GetDB().QueryRow("PRAGMA foreign_keys").Scan(&foreignKeys))
+	assert.Equal(t, 1, foreignKeys)
+
+	// DSN으로 설정하므로 풀의 모든 커넥션에 적용됨
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		conn, err := mgr.GetDB().Conn(ctx)
+		require.NoError(t, err)
+		defer conn.Close()
+		require.NoError(t, conn.QueryRowContext(ctx, "PRAGMA foreign_keys").Scan(&foreignKeys))
+		assert.Equal(t, 1, foreignKeys, "connection %d", i)
+	}
+}
+
+func TestNewSQLManager_InvalidPragma(t *testing.T) {
+	_, err := NewSQLManager(Config{Type: "sqlite3", DSN: ":memory:", JournalMode: "bogus"})
+	assert.Error(t, err)
+
+	_, err = NewSQLManager(Config{Type: "sqlite3", DSN: ":memory:", Synchronous: "sometimes"})
+	assert.Error(t, err)
+}
+
+func TestSQLManager_HealthCheck(t *testing.T) {
+	mgr, err := NewSQLManager(Config{Type: "sqlite3", DSN: ":memory:"})
+	require.NoError(t, err)
+
+	assert.NoError(t, mgr.HealthCheck(context.Background()))
+
+	require.NoError(t, mgr.Close())
+	assert.Error(t, mgr.HealthCheck(context.Background()))
+	assert.Equal(t, false, mgr.GetStats()["healthy"])
+}
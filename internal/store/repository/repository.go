@@ -0,0 +1,188 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sukryu/pAuth/internal/store/dynamic"
+	"github.com/sukryu/pAuth/internal/store/dynamic/query"
+	"github.com/sukryu/pAuth/pkg/utils/actor"
+)
+
+// Config describes how a type T is stored in a DynamicStore table
+type Config[T any] struct {
+	// Table is the name of the backing table
+	Table string
+
+	// Key returns the primary key (id column) of item
+	Key func(item T) string
+
+	// ToRow converts item into column values. id, namespace, created_* 컬럼은 Update 시 무시됨
+	ToRow func(item T) (map[string]interface{}, error)
+
+	// FromRow converts a selected row back into T
+	FromRow func(row map[string]interface{}) (T, error)
+
+	// NotFound is returned by Get and Update when no row matches the key
+	NotFound error
+
+	// Scope returns extra column conditions applied to every read and write (e.g. namespace).
+	// nil이면 테이블 전체가 대상
+	Scope func(ctx context.Context) map[string]interface{}
+
+	// OrderBy sorts the result of List; 비어 있으면 dynamic.DefaultListOrder
+	OrderBy []query.OrderByClause
+}
+
+// Repository implements generic CRUD for T on top of DynamicStore.
+// updated_at과 created_by/updated_by 컬럼은 저장소가 직접 채움.
+type Repository[T any] struct {
+	dynamicStore *dynamic.DynamicStore
+	config       Config[T]
+}
+
+// immutableColumns are never overwritten by Update
+var immutableColumns = []string{"id", "namespace", "created_at", "created_by"}
+
+// New creates a Repository for T
+func New[T any](dynStore *dynamic.DynamicStore, cfg Config[T]) *Repository[T] {
+	if cfg.NotFound == nil {
+		cfg.NotFound = fmt.Errorf("%s: not found", cfg.Table)
+	}
+	if len(cfg.OrderBy) == 0 {
+		cfg.OrderBy = dynamic.DefaultListOrder
+	}
+	return &Repository[T]{
+		dynamicStore: dynStore,
+		config:       cfg,
+	}
+}
+
+// Create inserts item
+func (r *Repository[T]) Create(ctx context.Context, item T) error {
+	// 테이블이 존재하는지 확인 (schema 검증용)
+	if _, err := r.dynamicStore.GetTableSchema(ctx, r.config.Table); err != nil {
+		return err
+	}
+
+	row, err := r.config.ToRow(item)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	row["id"] = r.config.Key(item)
+	if _, ok := row["created_at"]; !ok {
+		row["created_at"] = now
+	}
+	row["updated_at"] = now
+	row["created_by"] = actor.FromContext(ctx)
+	row["updated_by"] = actor.FromContext(ctx)
+
+	return r.dynamicStore.DynamicInsert(ctx, r.config.Table, row)
+}
+
+// Get returns the item with the given key
+func (r *Repository[T]) Get(ctx context.Context, key string) (T, error) {
+	var zero T
+
+	conditions := r.scope(ctx)
+	conditions["id"] = key
+
+	results, err := r.dynamicStore.DynamicSelect(ctx, r.config.Table, conditions)
+	if err != nil {
+		return zero, err
+	}
+	if len(results) == 0 {
+		return zero, r.config.NotFound
+	}
+
+	return r.config.FromRow(results[0])
+}
+
+// Update overwrites the mutable columns of an existing item
+func (r *Repository[T]) Update(ctx context.Context, item T) error {
+	key := r.config.Key(item)
+	if _, err := r.Get(ctx, key); err != nil {
+		return err
+	}
+
+	row, err := r.config.ToRow(item)
+	if err != nil {
+		return err
+	}
+	for _, column := range immutableColumns {
+		delete(row, column)
+	}
+	row["updated_at"] = time.Now()
+	row["updated_by"] = actor.FromContext(ctx)
+
+	return r.dynamicStore.DynamicUpdateScoped(ctx, r.config.Table, key, r.scope(ctx), row)
+}
+
+// Delete removes the item with the given key
+func (r *Repository[T]) Delete(ctx context.Context, key string) error {
+	// scope 밖의 항목은 삭제할 수 없음
+	if r.config.Scope != nil {
+		if _, err := r.Get(ctx, key); err != nil {
+			return err
+		}
+	}
+	return r.dynamicStore.DynamicDeleteScoped(ctx, r.config.Table, key, r.scope(ctx))
+}
+
+// List returns every item in the table
+func (r *Repository[T]) List(ctx context.Context) ([]T, error) {
+	results, err := r.dynamicStore.DynamicSelectOrdered(ctx, r.config.Table, r.scope(ctx), r.config.OrderBy)
+	if err != nil {
+		return nil, err
+	}
+	return r.fromRows(results)
+}
+
+// Count returns the number of items within the configured scope
+func (r *Repository[T]) Count(ctx context.Context) (int, error) {
+	return r.dynamicStore.CountActiveWhere(ctx, r.config.Table, r.scope(ctx))
+}
+
+// Query returns the items matching params within the configured scope
+func (r *Repository[T]) Query(ctx context.Context, params query.QueryParams) ([]T, error) {
+	params.Where = append(r.ScopeConditions(ctx), params.Where...)
+	results, err := r.dynamicStore.DynamicQuery(ctx, r.config.Table, params)
+	if err != nil {
+		return nil, err
+	}
+	return r.fromRows(results)
+}
+
+// ScopeConditions returns the configured scope as query conditions
+func (r *Repository[T]) ScopeConditions(ctx context.Context) []query.WhereCondition {
+	var conditions []query.WhereCondition
+	for column, value := range r.scope(ctx) {
+		conditions = append(conditions, query.WhereCondition{Column: column, Operator: "=", Value: value})
+	}
+	return conditions
+}
+
+func (r *Repository[T]) scope(ctx context.Context) map[string]interface{} {
+	conditions := make(map[string]interface{})
+	if r.config.Scope != nil {
+		for column, value := range r.config.Scope(ctx) {
+			conditions[column] = value
+		}
+	}
+	return conditions
+}
+
+func (r *Repository[T]) fromRows(rows []map[string]interface{}) ([]T, error) {
+	items := make([]T, 0, len(rows))
+	for _, row := range rows {
+		item, err := r.config.FromRow(row)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
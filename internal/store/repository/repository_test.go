@@ -0,0 +1,192 @@
+package repository
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/sukryu/pAuth/internal/store/dynamic"
+	"github.com/sukryu/pAuth/internal/store/dynamic/query"
+	"github.com/sukryu/pAuth/internal/store/manager"
+	"github.com/sukryu/pAuth/pkg/utils/actor"
+)
+
+// widget is a sample type used to exercise the generic repository
+type widget struct {
+	Name      string
+	Color     string
+	Size      int64
+	CreatedBy string
+	UpdatedBy string
+}
+
+var errWidgetNotFound = stderrors.New("widget not found")
+
+func setupTestRepository(t *testing.T) (*Repository[*widget], func()) {
+	manager, err := manager.NewSQLManager(manager.Config{
+		Type: "sqlite3",
+		DSN:  ":memory:",
+	})
+	if err != nil {
+		t.Fatalf("failed to create SQLManager: %v", err)
+	}
+
+	dbConn := manager.GetDB()
+	_, err = dbConn.Exec(`
+        CREATE TABLE IF NOT EXISTS entity_schemas (
+            id TEXT PRIMARY KEY,
+            name TEXT UNIQUE NOT NULL,
+            description TEXT,
+            fields TEXT NOT NULL,
+            indexes TEXT,
+            annotations TEXT,
+            created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+            updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+            deleted_at TIMESTAMP
+        );
+        CREATE TABLE IF NOT EXISTS widgets (
+            id TEXT PRIMARY KEY,
+            color TEXT NOT NULL,
+            size INTEGER,
+            created_by TEXT,
+            updated_by TEXT,
+            created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+            updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+            deleted_at TIMESTAMP
+        )
+    `)
+	if err != nil {
+		t.Fatalf("failed to create tables: %v", err)
+	}
+
+	dynStore, err := dynamic.NewDynamicStore(manager)
+	if err != nil {
+		t.Fatalf("failed to create dynamic store: %v", err)
+	}
+
+	repo := New(dynStore, Config[*widget]{
+		Table: "widgets",
+		Key:   func(w *widget) string { return w.Name },
+		ToRow: func(w *widget) (map[string]interface{}, error) {
+			return map[string]interface{}{
+				"color": w.Color,
+				"size":  w.Size,
+			}, nil
+		},
+		FromRow: func(row map[string]interface{}) (*widget, error) {
+			w := &widget{}
+			w.Name, _ = row["id"].(string)
+			w.Color, _ = row["color"].(string)
+			w.Size, _ = row["size"].(int64)
+			w.CreatedBy, _ = row["created_by"].(string)
+			w.UpdatedBy, _ = row["updated_by"].(string)
+			return w, nil
+		},
+		NotFound: errWidgetNotFound,
+	})
+
+	return repo, func() { dbConn.Close() }
+}
+
+func TestRepository_CRUD(t *testing.T) {
+	repo, cleanup := setupTestRepository(t)
+	defer cleanup()
+	ctx := actor.WithActor(context.Background(), "alice")
+
+	assert.NoError(t, repo.Create(ctx, &widget{Name: "w1", Color: "red", Size: 3}))
+	assert.NoError(t, repo.Create(ctx, &widget{Name: "w2", Color: "blue", Size: 1}))
+
+	got, err := repo.Get(ctx, "w1")
+	assert.NoError(t, err)
+	assert.Equal(t, &widget{Name: "w1", Color: "red", Size: 3, CreatedBy: "alice", UpdatedBy: "alice"}, got)
+
+	got.Color = "green"
+	assert.NoError(t, repo.Update(actor.WithActor(context.Background(), "bob"), got))
+
+	got, err = repo.Get(ctx, "w1")
+	assert.NoError(t, err)
+	assert.Equal(t, "green", got.Color)
+	assert.Equal(t, "alice", got.CreatedBy)
+	assert.Equal(t, "bob", got.UpdatedBy)
+
+	all, err := repo.List(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, all, 2)
+
+	ordered, err := repo.Query(ctx, query.QueryParams{
+		Where:   []query.WhereCondition{{Column: "deleted_at", Operator: "IS", Value: nil}},
+		OrderBy: []query.OrderByClause{{Column: "size"}},
+	})
+	assert.NoError(t, err)
+	if assert.Len(t, ordered, 2) {
+		assert.Equal(t, "w2", ordered[0].Name)
+	}
+
+	assert.NoError(t, repo.Delete(ctx, "w1"))
+	_, err = repo.Get(ctx, "w1")
+	assert.ErrorIs(t, err, errWidgetNotFound)
+}
+
+func TestRepository_UpdateMissing(t *testing.T) {
+	repo, cleanup := setupTestRepository(t)
+	defer cleanup()
+
+	err := repo.Update(context.Background(), &widget{Name: "missing", Color: "red"})
+	assert.ErrorIs(t, err, errWidgetNotFound)
+}
+
+func TestRepository_CreatedAtPreserved(t *testing.T) {
+	repo, cleanup := setupTestRepository(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	assert.NoError(t, repo.Create(ctx, &widget{Name: "w1", Color: "red"}))
+
+	// ToRow이 created_at을 반환하더라도 Update에서는 무시되어야 함
+	repo.config.ToRow = func(w *widget) (map[string]interface{}, error) {
+		return map[string]interface{}{"color": w.Color, "created_at": time.Unix(0, 0)}, nil
+	}
+	assert.NoError(t, repo.Update(ctx, &widget{Name: "w1", Color: "blue"}))
+
+	rows, err := repo.dynamicStore.DynamicSelect(ctx, "widgets", map[string]interface{}{"id": "w1"})
+	assert.NoError(t, err)
+	if assert.Len(t, rows, 1) {
+		createdAt, ok := rows[0]["created_at"].(time.Time)
+		assert.True(t, ok)
+		assert.True(t, createdAt.After(time.Unix(0, 0)))
+	}
+}
+
+func TestRepository_Scope(t *testing.T) {
+	repo, cleanup := setupTestRepository(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	assert.NoError(t, repo.Create(ctx, &widget{Name: "w1", Color: "red"}))
+	assert.NoError(t, repo.Create(ctx, &widget{Name: "w2", Color: "blue"}))
+
+	repo.config.Scope = func(ctx context.Context) map[string]interface{} {
+		return map[string]interface{}{"color": "red"}
+	}
+
+	_, err := repo.Get(ctx, "w2")
+	assert.ErrorIs(t, err, errWidgetNotFound)
+	assert.ErrorIs(t, repo.Delete(ctx, "w2"), errWidgetNotFound)
+
+	all, err := repo.List(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, all, 1)
+
+	count, err := repo.Count(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	queried, err := repo.Query(ctx, query.QueryParams{})
+	assert.NoError(t, err)
+	if assert.Len(t, queried, 1) {
+		assert.Equal(t, "w1", queried[0].Name)
+	}
+}
@@ -2,133 +2,199 @@ package role
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"time"
 
 	"github.com/sukryu/pAuth/internal/store/dynamic"
+	"github.com/sukryu/pAuth/internal/store/dynamic/query"
 	"github.com/sukryu/pAuth/internal/store/interfaces"
+	"github.com/sukryu/pAuth/internal/store/repository"
 	"github.com/sukryu/pAuth/pkg/apis/auth/v1alpha1"
 	"github.com/sukryu/pAuth/pkg/errors"
+	"github.com/sukryu/pAuth/pkg/utils/actor"
+	"github.com/sukryu/pAuth/pkg/utils/cursor"
+	"github.com/sukryu/pAuth/pkg/utils/namespace"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 type Config struct {
 	DatabaseType string
+
+	// NormalizedRules keeps role_rules (one row per verb/resource/apiGroup) in sync with
+	// roles.rules so FindByVerb/FindByResource/FindByAPIGroup use indexed exact matches
+	NormalizedRules bool
 }
 
+// rulesTable holds the normalized form of roles.rules
+const rulesTable = "role_rules"
+
 type Store struct {
 	dynamicStore *dynamic.DynamicStore
 	config       Config
 }
 
+var _ interfaces.RoleStore = (*Store)(nil)
+
+// NewStore creates a role store. NormalizedRules가 켜져 있으면 role_rules 행이 없는 기존 역할을 먼저 채움
 func NewStore(dynStore *dynamic.DynamicStore, cfg Config) (interfaces.RoleStore, error) {
-	return &Store{
+	s := &Store{
 		dynamicStore: dynStore,
 		config:       cfg,
-	}, nil
+	}
+	if cfg.NormalizedRules {
+		if _, err := s.backfillRules(context.Background()); err != nil {
+			return nil, fmt.Errorf("failed to backfill role rules: %w", err)
+		}
+	}
+	return s, nil
+}
+
+// HealthCheck checks the database holding the roles and role_rules tables
+func (s *Store) HealthCheck(ctx context.Context) error {
+	return s.dynamicStore.HealthCheck(ctx)
+}
+
+// InTransaction runs fn in a database transaction, rolling back when fn returns an error.
+// 같은 DynamicStore를 쓰는 다른 store도 fn의 ctx로 호출하면 같은 트랜잭션에 참여함
+func (s *Store) InTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return s.dynamicStore.InTransaction(ctx, fn)
+}
+
+// roles returns the generic repository backing the CRUD operations
+func (s *Store) roles() *repository.Repository[*v1alpha1.Role] {
+	return repository.New(s.dynamicStore, repository.Config[*v1alpha1.Role]{
+		Table:    "roles",
+		Key:      func(role *v1alpha1.Role) string { return role.Name },
+		ToRow:    roleToRow,
+		FromRow:  mapToRole,
+		NotFound: errors.ErrRoleNotFound,
+		Scope:    namespaceScope,
+	})
 }
 
 func (s *Store) Create(ctx context.Context, role *v1alpha1.Role) error {
-	if _, err := s.dynamicStore.GetTableSchema(ctx, "roles"); err != nil {
+	ns, err := namespace.Resolve(ctx, role.Namespace)
+	if err != nil {
 		return err
 	}
+	role.Namespace = ns
 
-	now := time.Now()
 	if role.CreationTimestamp.IsZero() {
-		role.CreationTimestamp = metav1.NewTime(now)
+		role.CreationTimestamp = metav1.NewTime(time.Now())
 	}
 
-	rulesJSON, err := json.Marshal(role.Rules)
-	if err != nil {
-		return fmt.Errorf("failed to marshal rules: %w", err)
-	}
+	// roles 행과 role_rules 행이 함께 커밋되도록 하나의 트랜잭션에서 기록
+	return s.InTransaction(ctx, func(ctx context.Context) error {
+		if err := s.roles().Create(ctx, role); err != nil {
+			return err
+		}
+		return s.syncRules(ctx, role.Name, role.Namespace, role.Rules)
+	})
+}
 
-	data := map[string]interface{}{
-		"id":          role.Name,
-		"name":        role.Name,
-		"description": role.Annotations["description"],
-		"rules":       string(rulesJSON),
-		"created_at":  role.CreationTimestamp.Time,
-		"updated_at":  now,
-	}
+func (s *Store) Get(ctx context.Context, name string) (*v1alpha1.Role, error) {
+	return s.roles().Get(ctx, name)
+}
 
-	if len(role.Annotations) > 0 {
-		annotationsJSON, err := json.Marshal(role.Annotations)
-		if err != nil {
-			return fmt.Errorf("failed to marshal annotations: %w", err)
+func (s *Store) Update(ctx context.Context, role *v1alpha1.Role) error {
+	return s.InTransaction(ctx, func(ctx context.Context) error {
+		if err := s.roles().Update(ctx, role); err != nil {
+			return err
 		}
-		data["annotations"] = string(annotationsJSON)
-	}
-
-	return s.dynamicStore.DynamicInsert(ctx, "roles", data)
+		return s.syncRules(ctx, role.Name, namespace.FromContext(ctx), role.Rules)
+	})
 }
 
-func (s *Store) Get(ctx context.Context, name string) (*v1alpha1.Role, error) {
-	results, err := s.dynamicStore.DynamicSelect(ctx, "roles", map[string]interface{}{
-		"name": name,
+func (s *Store) Delete(ctx context.Context, name string) error {
+	return s.InTransaction(ctx, func(ctx context.Context) error {
+		if err := s.roles().Delete(ctx, name); err != nil {
+			return err
+		}
+		return s.syncRules(ctx, name, namespace.FromContext(ctx), nil)
 	})
-	if err != nil {
-		return nil, err
-	}
-	if len(results) == 0 {
-		return nil, errors.ErrRoleNotFound
-	}
+}
 
-	return mapToRole(results[0])
+func (s *Store) List(ctx context.Context) ([]*v1alpha1.Role, error) {
+	return s.roles().List(ctx)
 }
 
-func (s *Store) Update(ctx context.Context, role *v1alpha1.Role) error {
-	_, err := s.Get(ctx, role.Name)
+// ListPaged returns a page of roles ordered by name together with the total number of roles
+func (s *Store) ListPaged(ctx context.Context, limit, offset int) ([]*v1alpha1.Role, int, error) {
+	total, err := s.roles().Count(ctx)
 	if err != nil {
-		return err
+		return nil, 0, err
 	}
 
-	rulesJSON, err := json.Marshal(role.Rules)
+	roles, err := s.roles().Query(ctx, query.QueryParams{
+		Where: []query.WhereCondition{
+			{Column: "deleted_at", Operator: "IS", Value: nil},
+		},
+		OrderBy: []query.OrderByClause{{Column: "name"}},
+		Limit:   limit,
+		Offset:  offset,
+	})
 	if err != nil {
-		return fmt.Errorf("failed to marshal rules: %w", err)
+		return nil, 0, err
 	}
 
-	data := map[string]interface{}{
-		"description": role.Annotations["description"],
-		"rules":       string(rulesJSON),
-		"updated_at":  time.Now(),
-	}
+	return roles, total, nil
+}
 
-	if len(role.Annotations) > 0 {
-		annotationsJSON, err := json.Marshal(role.Annotations)
+// ListWithCursor returns up to limit roles ordered by name, starting after the role encoded in next.
+// 페이지가 가득 찼을 때만 다음 cursor를 반환 (마지막 페이지는 빈 문자열)
+func (s *Store) ListWithCursor(ctx context.Context, limit int, next string) ([]*v1alpha1.Role, string, error) {
+	params := query.QueryParams{
+		Where: []query.WhereCondition{
+			{Column: "deleted_at", Operator: "IS", Value: nil},
+		},
+		OrderBy: []query.OrderByClause{{Column: "name"}},
+		Limit:   limit,
+	}
+	if next != "" {
+		after, err := cursor.Decode(next)
 		if err != nil {
-			return fmt.Errorf("failed to marshal annotations: %w", err)
+			return nil, "", err
 		}
-		data["annotations"] = string(annotationsJSON)
+		params.Where = append(params.Where, query.WhereCondition{Column: "name", Operator: ">", Value: after.ID})
 	}
 
-	return s.dynamicStore.DynamicUpdate(ctx, "roles", role.Name, data)
-}
+	roles, err := s.roles().Query(ctx, params)
+	if err != nil {
+		return nil, "", err
+	}
 
-func (s *Store) Delete(ctx context.Context, name string) error {
-	return s.dynamicStore.DynamicDelete(ctx, "roles", name)
+	if limit > 0 && len(roles) == limit {
+		return roles, cursor.Encode(cursor.Cursor{ID: roles[len(roles)-1].Name}), nil
+	}
+	return roles, "", nil
 }
 
-func (s *Store) List(ctx context.Context) ([]*v1alpha1.Role, error) {
-	results, err := s.dynamicStore.DynamicSelect(ctx, "roles", nil)
-	if err != nil {
-		return nil, err
+func (s *Store) FindByVerb(ctx context.Context, verb string) ([]*v1alpha1.Role, error) {
+	if s.config.NormalizedRules {
+		return s.findByRuleColumn(ctx, "verb", verb)
 	}
+	return s.filterRules(ctx, func(rule v1alpha1.PolicyRule) []string { return rule.Verbs }, verb)
+}
 
-	roles := make([]*v1alpha1.Role, 0, len(results))
-	for _, result := range results {
-		role, err := mapToRole(result)
-		if err != nil {
-			return nil, err
-		}
-		roles = append(roles, role)
+func (s *Store) FindByResource(ctx context.Context, resource string) ([]*v1alpha1.Role, error) {
+	if s.config.NormalizedRules {
+		return s.findByRuleColumn(ctx, "resource", resource)
 	}
+	return s.filterRules(ctx, func(rule v1alpha1.PolicyRule) []string { return rule.Resources }, resource)
+}
 
-	return roles, nil
+func (s *Store) FindByAPIGroup(ctx context.Context, apiGroup string) ([]*v1alpha1.Role, error) {
+	if s.config.NormalizedRules {
+		return s.findByRuleColumn(ctx, "api_group", apiGroup)
+	}
+	return s.filterRules(ctx, func(rule v1alpha1.PolicyRule) []string { return rule.APIGroups }, apiGroup)
 }
 
-func (s *Store) FindByVerb(ctx context.Context, verb string) ([]*v1alpha1.Role, error) {
+// filterRules scans every role and keeps those with a rule whose field(rule) contains value
+func (s *Store) filterRules(ctx context.Context, field func(v1alpha1.PolicyRule) []string, value string) ([]*v1alpha1.Role, error) {
 	roles, err := s.List(ctx)
 	if err != nil {
 		return nil, err
@@ -136,62 +202,183 @@ func (s *Store) FindByVerb(ctx context.Context, verb string) ([]*v1alpha1.Role,
 
 	var filtered []*v1alpha1.Role
 	for _, role := range roles {
-		for _, rule := range role.Rules {
-			for _, v := range rule.Verbs {
-				if v == verb {
-					filtered = append(filtered, role)
-					break
-				}
-			}
+		if roleHasRuleValue(role, field, value) {
+			filtered = append(filtered, role)
 		}
 	}
 
 	return filtered, nil
 }
 
-func (s *Store) FindByResource(ctx context.Context, resource string) ([]*v1alpha1.Role, error) {
-	roles, err := s.List(ctx)
+func roleHasRuleValue(role *v1alpha1.Role, field func(v1alpha1.PolicyRule) []string, value string) bool {
+	for _, rule := range role.Rules {
+		for _, v := range field(rule) {
+			if v == value {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// findByRuleColumn looks up role names in role_rules with an exact match on column
+func (s *Store) findByRuleColumn(ctx context.Context, column, value string) ([]*v1alpha1.Role, error) {
+	rows, err := s.dynamicStore.DynamicSelectOrdered(ctx, rulesTable, map[string]interface{}{
+		"namespace": namespace.FromContext(ctx),
+		column:      value,
+	}, []query.OrderByClause{{Column: "role_name"}})
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to query role rules: %w", err)
 	}
 
-	var filtered []*v1alpha1.Role
-	for _, role := range roles {
-		for _, rule := range role.Rules {
-			for _, r := range rule.Resources {
-				if r == resource {
-					filtered = append(filtered, role)
-					break
-				}
-			}
+	var roles []*v1alpha1.Role
+	seen := make(map[string]bool)
+	for _, row := range rows {
+		name, _ := row["role_name"].(string)
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		role, err := s.Get(ctx, name)
+		if err == errors.ErrRoleNotFound {
+			continue // 규칙 행만 남아 있는 경우
+		}
+		if err != nil {
+			return nil, err
 		}
+		roles = append(roles, role)
 	}
 
-	return filtered, nil
+	return roles, nil
 }
 
-func (s *Store) FindByAPIGroup(ctx context.Context, apiGroup string) ([]*v1alpha1.Role, error) {
-	roles, err := s.List(ctx)
+// syncRules replaces the role_rules rows of a role with the expansion of rules.
+// rules가 비어 있으면 기존 행만 삭제됨
+func (s *Store) syncRules(ctx context.Context, roleName, ns string, rules []v1alpha1.PolicyRule) error {
+	if !s.config.NormalizedRules {
+		return nil
+	}
+
+	existing, err := s.dynamicStore.DynamicSelect(ctx, rulesTable, map[string]interface{}{
+		"role_name": roleName,
+		"namespace": ns,
+	})
 	if err != nil {
-		return nil, err
+		return fmt.Errorf("failed to load role rules: %w", err)
+	}
+	for _, row := range existing {
+		id, _ := row["id"].(string)
+		if err := s.dynamicStore.DynamicDelete(ctx, rulesTable, id); err != nil {
+			return fmt.Errorf("failed to delete role rule: %w", err)
+		}
 	}
 
-	var filtered []*v1alpha1.Role
-	for _, role := range roles {
-		for _, rule := range role.Rules {
-			for _, g := range rule.APIGroups {
-				if g == apiGroup {
-					filtered = append(filtered, role)
-					break
+	seen := make(map[[3]string]bool)
+	for _, rule := range rules {
+		for _, verb := range rule.Verbs {
+			for _, resource := range rule.Resources {
+				for _, group := range rule.APIGroups {
+					key := [3]string{verb, resource, group}
+					if seen[key] {
+						continue
+					}
+					seen[key] = true
+
+					id, err := newRuleID()
+					if err != nil {
+						return err
+					}
+					if err := s.dynamicStore.DynamicInsert(ctx, rulesTable, map[string]interface{}{
+						"id":         id,
+						"role_name":  roleName,
+						"namespace":  ns,
+						"verb":       verb,
+						"resource":   resource,
+						"api_group":  group,
+						"created_at": time.Now(),
+						"updated_at": time.Now(),
+					}); err != nil {
+						return fmt.Errorf("failed to insert role rule: %w", err)
+					}
 				}
 			}
 		}
 	}
 
-	return filtered, nil
+	return nil
+}
+
+// backfillRules writes the role_rules rows of every live role that has rules but no rows,
+// such as roles created before NormalizedRules was enabled or before the two tables were written
+// in one transaction. 행이 있는 역할은 건드리지 않으므로 여러 번 실행해도 결과가 같음
+func (s *Store) backfillRules(ctx context.Context) (int, error) {
+	for _, table := range []string{"roles", rulesTable} {
+		exists, err := s.dynamicStore.TableExists(ctx, table)
+		if err != nil {
+			return 0, err
+		}
+		if !exists {
+			return 0, nil // 마이그레이션 전에는 채울 대상이 없음
+		}
+	}
+
+	var filled int
+	err := s.InTransaction(ctx, func(ctx context.Context) error {
+		ruleRows, err := s.dynamicStore.DynamicSelect(ctx, rulesTable, nil)
+		if err != nil {
+			return fmt.Errorf("failed to load role rules: %w", err)
+		}
+		synced := make(map[[2]string]bool, len(ruleRows))
+		for _, row := range ruleRows {
+			name, _ := row["role_name"].(string)
+			ns, _ := row["namespace"].(string)
+			synced[[2]string{ns, name}] = true
+		}
+
+		roleRows, err := s.dynamicStore.DynamicSelect(ctx, "roles", nil)
+		if err != nil {
+			return fmt.Errorf("failed to load roles: %w", err)
+		}
+		for _, row := range roleRows {
+			name, _ := row["name"].(string)
+			ns, _ := row["namespace"].(string)
+			rulesJSON, _ := row["rules"].(string)
+			if synced[[2]string{ns, name}] || rulesJSON == "" {
+				continue
+			}
+
+			var rules []v1alpha1.PolicyRule
+			if err := json.Unmarshal([]byte(rulesJSON), &rules); err != nil {
+				return fmt.Errorf("role %s/%s: failed to unmarshal rules: %w", ns, name, err)
+			}
+			if len(rules) == 0 {
+				continue
+			}
+			if err := s.syncRules(ctx, name, ns, rules); err != nil {
+				return fmt.Errorf("role %s/%s: %w", ns, name, err)
+			}
+			filled++
+		}
+		return nil
+	})
+	return filled, err
+}
+
+// newRuleID returns a random id. 소프트 삭제된 행과 충돌하지 않도록 매번 새로 생성
+func newRuleID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate role rule id: %w", err)
+	}
+	return hex.EncodeToString(b), nil
 }
 
 func (s *Store) UpdateRules(ctx context.Context, name string, rules []v1alpha1.PolicyRule) error {
+	if _, err := s.Get(ctx, name); err != nil {
+		return err
+	}
+
 	rulesJSON, err := json.Marshal(rules)
 	if err != nil {
 		return fmt.Errorf("failed to marshal rules: %w", err)
@@ -200,9 +387,15 @@ func (s *Store) UpdateRules(ctx context.Context, name string, rules []v1alpha1.P
 	data := map[string]interface{}{
 		"rules":      string(rulesJSON),
 		"updated_at": time.Now(),
+		"updated_by": actor.FromContext(ctx),
 	}
 
-	return s.dynamicStore.DynamicUpdate(ctx, "roles", name, data)
+	return s.InTransaction(ctx, func(ctx context.Context) error {
+		if err := s.dynamicStore.DynamicUpdateScoped(ctx, "roles", name, namespaceScope(ctx), data); err != nil {
+			return err
+		}
+		return s.syncRules(ctx, name, namespace.FromContext(ctx), rules)
+	})
 }
 
 func (s *Store) ListBySubject(ctx context.Context, subjectKind, subjectName string) ([]*v1alpha1.Role, error) {
@@ -211,6 +404,46 @@ func (s *Store) ListBySubject(ctx context.Context, subjectKind, subjectName stri
 	return nil, errors.ErrNotImplemented
 }
 
+// namespaceScope limits role lookups to the request namespace
+func namespaceScope(ctx context.Context) map[string]interface{} {
+	return map[string]interface{}{"namespace": namespace.FromContext(ctx)}
+}
+
+// roleToRow converts role into roles table columns
+func roleToRow(role *v1alpha1.Role) (map[string]interface{}, error) {
+	rulesJSON, err := json.Marshal(role.Rules)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal rules: %w", err)
+	}
+
+	row := map[string]interface{}{
+		"name":        role.Name,
+		"namespace":   role.Namespace,
+		"description": role.Annotations["description"],
+		"rules":       string(rulesJSON),
+		"inherits":    nil,
+		"created_at":  role.CreationTimestamp.Time,
+	}
+
+	if len(role.Inherits) > 0 {
+		inheritsJSON, err := json.Marshal(role.Inherits)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal inherits: %w", err)
+		}
+		row["inherits"] = string(inheritsJSON)
+	}
+
+	if len(role.Annotations) > 0 {
+		annotationsJSON, err := json.Marshal(role.Annotations)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal annotations: %w", err)
+		}
+		row["annotations"] = string(annotationsJSON)
+	}
+
+	return row, nil
+}
+
 func mapToRole(data map[string]interface{}) (*v1alpha1.Role, error) {
 	role := &v1alpha1.Role{
 		TypeMeta: metav1.TypeMeta{
@@ -223,6 +456,7 @@ func mapToRole(data map[string]interface{}) (*v1alpha1.Role, error) {
 			Annotations:       make(map[string]string),
 		},
 	}
+	role.Namespace, _ = data["namespace"].(string)
 
 	if description, ok := data["description"]; ok && description != nil {
 		role.Annotations["description"] = description.(string)
@@ -236,6 +470,14 @@ func mapToRole(data map[string]interface{}) (*v1alpha1.Role, error) {
 		role.Rules = rules
 	}
 
+	if inheritsJSON, ok := data["inherits"].(string); ok && inheritsJSON != "" {
+		var inherits []string
+		if err := json.Unmarshal([]byte(inheritsJSON), &inherits); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal inherits: %w", err)
+		}
+		role.Inherits = inherits
+	}
+
 	if annotations, ok := data["annotations"].(string); ok && annotations != "" {
 		var parsedAnnotations map[string]string
 		if err := json.Unmarshal([]byte(annotations), &parsedAnnotations); err != nil {
@@ -244,5 +486,13 @@ func mapToRole(data map[string]interface{}) (*v1alpha1.Role, error) {
 		role.Annotations = parsedAnnotations
 	}
 
+	// 생성자/수정자는 컬럼 값을 기준으로 annotation에 노출
+	if createdBy, ok := data["created_by"].(string); ok && createdBy != "" {
+		metav1.SetMetaDataAnnotation(&role.ObjectMeta, v1alpha1.AnnotationCreatedBy, createdBy)
+	}
+	if updatedBy, ok := data["updated_by"].(string); ok && updatedBy != "" {
+		metav1.SetMetaDataAnnotation(&role.ObjectMeta, v1alpha1.AnnotationUpdatedBy, updatedBy)
+	}
+
 	return role, nil
 }
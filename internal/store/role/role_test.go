@@ -3,13 +3,19 @@ package role
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"testing"
 
 	_ "github.com/mattn/go-sqlite3"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/sukryu/pAuth/internal/store/dynamic"
 	"github.com/sukryu/pAuth/internal/store/manager"
+	rolebinding "github.com/sukryu/pAuth/internal/store/role_binding"
 	"github.com/sukryu/pAuth/pkg/apis/auth/v1alpha1"
+	"github.com/sukryu/pAuth/pkg/errors"
+	"github.com/sukryu/pAuth/pkg/utils/actor"
+	"github.com/sukryu/pAuth/pkg/utils/namespace"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -49,8 +55,12 @@ func setupTestDB(t *testing.T) (*sql.DB, *dynamic.DynamicStore) {
        CREATE TABLE IF NOT EXISTS roles (
            id TEXT PRIMARY KEY,
            name TEXT UNIQUE NOT NULL,
+           namespace TEXT NOT NULL DEFAULT 'default',
            description TEXT,
            rules TEXT NOT NULL,
+           inherits TEXT,
+           created_by TEXT,
+           updated_by TEXT,
            annotations TEXT,
            created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
            updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
@@ -61,12 +71,27 @@ func setupTestDB(t *testing.T) (*sql.DB, *dynamic.DynamicStore) {
 		t.Fatalf("failed to create roles table: %v", err)
 	}
 
-	// DynamicStore 생성
-	store, err := dynamic.NewDynamicStore(manager)
+	// role_rules 테이블 생성 (NormalizedRules 사용 시)
+	_, err = dbConn.Exec(`
+       CREATE TABLE IF NOT EXISTS role_rules (
+           id TEXT PRIMARY KEY,
+           role_name TEXT NOT NULL,
+           namespace TEXT NOT NULL DEFAULT 'default',
+           verb TEXT NOT NULL,
+           resource TEXT NOT NULL,
+           api_group TEXT NOT NULL,
+           created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+           updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+           deleted_at TIMESTAMP
+       )
+   `)
 	if err != nil {
-		t.Fatalf("failed to create dynamic store: %v", err)
+		t.Fatalf("failed to create role_rules table: %v", err)
 	}
 
+	// DynamicStore 생성
+	store := dynamic.NewDynamicStoreFromDB(dbConn)
+
 	return dbConn, store
 }
 
@@ -153,6 +178,37 @@ func TestRoleStore_Get(t *testing.T) {
 		_, err := store.Get(ctx, "non-existent")
 		assert.Error(t, err)
 	})
+
+	t.Run("Get role with inherited roles", func(t *testing.T) {
+		role := createTestRole(t)
+		role.Name = "editor"
+		role.Inherits = []string{"test-role"}
+		assert.NoError(t, store.Create(ctx, role))
+
+		found, err := store.Get(ctx, "editor")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"test-role"}, found.Inherits)
+
+		found.Inherits = nil
+		assert.NoError(t, store.Update(ctx, found))
+		found, err = store.Get(ctx, "editor")
+		assert.NoError(t, err)
+		assert.Empty(t, found.Inherits)
+	})
+
+	t.Run("Returned role does not alias stored state", func(t *testing.T) {
+		found, err := store.Get(ctx, "test-role")
+		assert.NoError(t, err)
+		found.Rules[0].Verbs[0] = "delete"
+
+		roles, err := store.List(ctx)
+		assert.NoError(t, err)
+		roles[0].Rules = nil
+
+		again, err := store.Get(ctx, "test-role")
+		assert.NoError(t, err)
+		assert.Equal(t, createTestRole(t).Rules, again.Rules)
+	})
 }
 
 func TestRoleStore_Update(t *testing.T) {
@@ -283,3 +339,320 @@ func TestRoleStore_UpdateRules(t *testing.T) {
 		assert.Len(t, updated.Rules[0].Resources, 2)
 	})
 }
+
+func TestRoleStore_ListPaged(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	for _, name := range []string{"role-c", "role-a", "role-e", "role-b", "role-d"} {
+		role := createTestRole(t)
+		role.Name = name
+		assert.NoError(t, store.Create(ctx, role))
+	}
+
+	// 삭제된 역할은 결과와 전체 개수에서 제외
+	deleted := createTestRole(t)
+	deleted.Name = "role-deleted"
+	assert.NoError(t, store.Create(ctx, deleted))
+	assert.NoError(t, store.Delete(ctx, deleted.Name))
+
+	names := func(roles []*v1alpha1.Role) []string {
+		out := make([]string, len(roles))
+		for i, r := range roles {
+			out[i] = r.Name
+		}
+		return out
+	}
+
+	tests := []struct {
+		name   string
+		limit  int
+		offset int
+		want   []string
+	}{
+		{"first page", 2, 0, []string{"role-a", "role-b"}},
+		{"second page", 2, 2, []string{"role-c", "role-d"}},
+		{"last partial page", 2, 4, []string{"role-e"}},
+		{"past the end", 2, 10, []string{}},
+		{"no limit", 0, 0, []string{"role-a", "role-b", "role-c", "role-d", "role-e"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			roles, total, err := store.ListPaged(ctx, tt.limit, tt.offset)
+			assert.NoError(t, err)
+			assert.Equal(t, 5, total)
+			assert.Equal(t, tt.want, names(roles))
+		})
+	}
+}
+
+func TestRoleStore_ListWithCursor(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	for _, name := range []string{"role-c", "role-a", "role-e", "role-b", "role-d"} {
+		role := createTestRole(t)
+		role.Name = name
+		assert.NoError(t, store.Create(ctx, role))
+	}
+
+	var got []string
+	next := ""
+	for pages := 0; ; pages++ {
+		require.Less(t, pages, 5, "cursor did not terminate")
+		roles, continueToken, err := store.ListWithCursor(ctx, 2, next)
+		require.NoError(t, err)
+		for _, r := range roles {
+			got = append(got, r.Name)
+		}
+		if continueToken == "" {
+			break
+		}
+		next = continueToken
+
+		// 페이지 사이에 앞쪽 이름의 역할이 추가되어도 중복/누락이 없음
+		if pages == 0 {
+			role := createTestRole(t)
+			role.Name = "role-0"
+			require.NoError(t, store.Create(ctx, role))
+		}
+	}
+	assert.Equal(t, []string{"role-a", "role-b", "role-c", "role-d", "role-e"}, got)
+
+	_, _, err := store.ListWithCursor(ctx, 2, "not-a-cursor")
+	assert.Error(t, err)
+}
+
+func TestRoleStore_Attribution(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	role := createTestRole(t)
+	assert.NoError(t, store.Create(actor.WithActor(context.Background(), "alice"), role))
+
+	created, err := store.Get(context.Background(), role.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, "alice", created.Annotations[v1alpha1.AnnotationCreatedBy])
+	assert.Equal(t, "alice", created.Annotations[v1alpha1.AnnotationUpdatedBy])
+
+	created.Rules[0].Verbs = append(created.Rules[0].Verbs, "update")
+	assert.NoError(t, store.Update(actor.WithActor(context.Background(), "bob"), created))
+
+	updated, err := store.Get(context.Background(), role.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, "alice", updated.Annotations[v1alpha1.AnnotationCreatedBy])
+	assert.Equal(t, "bob", updated.Annotations[v1alpha1.AnnotationUpdatedBy])
+}
+
+func TestRoleStore_Namespace(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+	tenantA := namespace.WithNamespace(context.Background(), "tenant-a")
+	tenantB := namespace.WithNamespace(context.Background(), "tenant-b")
+
+	role := createTestRole(t)
+	assert.NoError(t, store.Create(tenantA, role))
+
+	got, err := store.Get(tenantA, role.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, "tenant-a", got.Namespace)
+
+	_, err = store.Get(tenantB, role.Name)
+	assert.Error(t, err)
+	assert.Error(t, store.UpdateRules(tenantB, role.Name, nil))
+	assert.Error(t, store.Delete(tenantB, role.Name))
+
+	roles, total, err := store.ListPaged(tenantB, 10, 0)
+	assert.NoError(t, err)
+	assert.Empty(t, roles)
+	assert.Equal(t, 0, total)
+
+	// Update는 namespace를 변경하지 않음
+	got.Namespace = ""
+	assert.NoError(t, store.Update(tenantA, got))
+	got, err = store.Get(tenantA, role.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, "tenant-a", got.Namespace)
+}
+
+func TestRoleStore_NormalizedRules(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+	store.config.NormalizedRules = true
+	ctx := context.Background()
+
+	getter := createTestRole(t)
+	getter.Name = "getter"
+	getter.Rules = []v1alpha1.PolicyRule{{Verbs: []string{"get", "get"}, Resources: []string{"users"}, APIGroups: []string{"auth.service"}}}
+	assert.NoError(t, store.Create(ctx, getter))
+
+	targeter := createTestRole(t)
+	targeter.Name = "targeter"
+	targeter.Rules = []v1alpha1.PolicyRule{
+		{Verbs: []string{"target"}, Resources: []string{"users-admin"}, APIGroups: []string{"auth.service.io"}},
+		{Verbs: []string{"target"}, Resources: []string{"roles"}, APIGroups: []string{""}},
+	}
+	assert.NoError(t, store.Create(ctx, targeter))
+
+	names := func(roles []*v1alpha1.Role, err error) []string {
+		assert.NoError(t, err)
+		result := []string{}
+		for _, r := range roles {
+			result = append(result, r.Name)
+		}
+		return result
+	}
+
+	t.Run("exact matches only", func(t *testing.T) {
+		assert.Equal(t, []string{"getter"}, names(store.FindByVerb(ctx, "get")))
+		assert.Equal(t, []string{"targeter"}, names(store.FindByVerb(ctx, "target")))
+		assert.Empty(t, names(store.FindByVerb(ctx, "ge")))
+		assert.Equal(t, []string{"getter"}, names(store.FindByResource(ctx, "users")))
+		assert.Equal(t, []string{"getter"}, names(store.FindByAPIGroup(ctx, "auth.service")))
+		assert.Equal(t, []string{"targeter"}, names(store.FindByAPIGroup(ctx, "")))
+	})
+
+	t.Run("rules follow updates and deletes", func(t *testing.T) {
+		assert.NoError(t, store.UpdateRules(ctx, "getter", []v1alpha1.PolicyRule{
+			{Verbs: []string{"list"}, Resources: []string{"users"}, APIGroups: []string{"auth.service"}},
+		}))
+		assert.Empty(t, names(store.FindByVerb(ctx, "get")))
+		assert.Equal(t, []string{"getter"}, names(store.FindByVerb(ctx, "list")))
+
+		targeter.Rules = []v1alpha1.PolicyRule{{Verbs: []string{"list"}, Resources: []string{"roles"}, APIGroups: []string{""}}}
+		assert.NoError(t, store.Update(ctx, targeter))
+		assert.Equal(t, []string{"getter", "targeter"}, names(store.FindByVerb(ctx, "list")))
+
+		assert.NoError(t, store.Delete(ctx, "getter"))
+		assert.Equal(t, []string{"targeter"}, names(store.FindByVerb(ctx, "list")))
+	})
+
+	t.Run("namespaced", func(t *testing.T) {
+		tenant := namespace.WithNamespace(ctx, "tenant-a")
+		assert.Empty(t, names(store.FindByVerb(tenant, "list")))
+	})
+}
+
+func TestRoleStore_NormalizedRulesAtomic(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+	store.config.NormalizedRules = true
+	ctx := context.Background()
+
+	// role_rules 기록이 실패하면 roles 행도 남지 않아야 함
+	assert.NoError(t, store.dynamicStore.DropDynamicTable(ctx, rulesTable))
+
+	assert.Error(t, store.Create(ctx, createTestRole(t)))
+	_, err := store.Get(ctx, "test-role")
+	assert.ErrorIs(t, err, errors.ErrRoleNotFound)
+}
+
+func TestRoleStore_BackfillRules(t *testing.T) {
+	dbConn, dynStore := setupTestDB(t)
+	defer dbConn.Close()
+	ctx := context.Background()
+
+	// NormalizedRules 없이 만든 역할은 role_rules 행이 없음
+	plain, err := NewStore(dynStore, Config{DatabaseType: "sqlite"})
+	assert.NoError(t, err)
+	assert.NoError(t, plain.Create(ctx, createTestRole(t)))
+	tenantRole := createTestRole(t)
+	tenantRole.Name = "tenant-role"
+	tenantRole.Namespace = "tenant-a"
+	assert.NoError(t, plain.Create(namespace.WithNamespace(ctx, "tenant-a"), tenantRole))
+
+	normalized, err := NewStore(dynStore, Config{DatabaseType: "sqlite", NormalizedRules: true})
+	assert.NoError(t, err)
+
+	roles, err := normalized.FindByVerb(ctx, "list")
+	assert.NoError(t, err)
+	if assert.Len(t, roles, 1) {
+		assert.Equal(t, "test-role", roles[0].Name)
+	}
+	roles, err = normalized.FindByVerb(namespace.WithNamespace(ctx, "tenant-a"), "get")
+	assert.NoError(t, err)
+	assert.Len(t, roles, 1)
+
+	// 이미 채워진 역할은 다시 기록되지 않음
+	filled, err := normalized.(*Store).backfillRules(ctx)
+	assert.NoError(t, err)
+	assert.Zero(t, filled)
+}
+
+func TestRoleStore_TransactionWithBinding(t *testing.T) {
+	dbConn, dynStore := setupTestDB(t)
+	defer dbConn.Close()
+	ctx := context.Background()
+
+	_, err := dbConn.Exec(`
+       CREATE TABLE role_bindings (
+           id TEXT PRIMARY KEY,
+           name TEXT UNIQUE NOT NULL,
+           namespace TEXT NOT NULL DEFAULT 'default',
+           role_ref TEXT NOT NULL,
+           role_refs TEXT,
+           subjects TEXT NOT NULL,
+           created_by TEXT,
+           updated_by TEXT,
+           annotations TEXT,
+           created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+           updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+           deleted_at TIMESTAMP
+       )
+   `)
+	assert.NoError(t, err)
+
+	roles, err := NewStore(dynStore, Config{DatabaseType: "sqlite"})
+	assert.NoError(t, err)
+	bindings, err := rolebinding.NewStore(dynStore, rolebinding.Config{DatabaseType: "sqlite"})
+	assert.NoError(t, err)
+
+	newBinding := func(name, roleName string) *v1alpha1.RoleBinding {
+		return &v1alpha1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			RoleRef:    v1alpha1.RoleRef{Kind: "Role", Name: roleName},
+			Subjects:   []v1alpha1.Subject{{Kind: "User", Name: "alice"}},
+		}
+	}
+
+	t.Run("role and binding are committed together", func(t *testing.T) {
+		role := createTestRole(t)
+		err := roles.InTransaction(ctx, func(ctx context.Context) error {
+			if err := roles.Create(ctx, role); err != nil {
+				return err
+			}
+			return bindings.Create(ctx, newBinding("test-binding", role.Name))
+		})
+		assert.NoError(t, err)
+
+		_, err = roles.Get(ctx, role.Name)
+		assert.NoError(t, err)
+		_, err = bindings.Get(ctx, "test-binding")
+		assert.NoError(t, err)
+	})
+
+	t.Run("error rolls back both", func(t *testing.T) {
+		role := createTestRole(t)
+		role.Name = "rolled-back"
+		failure := fmt.Errorf("binding rejected")
+
+		err := roles.InTransaction(ctx, func(ctx context.Context) error {
+			if err := roles.Create(ctx, role); err != nil {
+				return err
+			}
+			if err := bindings.Create(ctx, newBinding("rolled-back-binding", role.Name)); err != nil {
+				return err
+			}
+			return failure
+		})
+		assert.ErrorIs(t, err, failure)
+
+		_, err = roles.Get(ctx, "rolled-back")
+		assert.Error(t, err)
+		_, err = bindings.Get(ctx, "rolled-back-binding")
+		assert.Error(t, err)
+	})
+}
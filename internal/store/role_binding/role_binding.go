@@ -7,9 +7,13 @@ import (
 	"time"
 
 	"github.com/sukryu/pAuth/internal/store/dynamic"
+	"github.com/sukryu/pAuth/internal/store/dynamic/query"
 	"github.com/sukryu/pAuth/internal/store/interfaces"
 	"github.com/sukryu/pAuth/pkg/apis/auth/v1alpha1"
 	"github.com/sukryu/pAuth/pkg/errors"
+	"github.com/sukryu/pAuth/pkg/utils/actor"
+	"github.com/sukryu/pAuth/pkg/utils/cursor"
+	"github.com/sukryu/pAuth/pkg/utils/namespace"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -22,6 +26,8 @@ type Store struct {
 	config       Config
 }
 
+var _ interfaces.RoleBindingStore = (*Store)(nil)
+
 func NewStore(dynStore *dynamic.DynamicStore, cfg Config) (interfaces.RoleBindingStore, error) {
 	return &Store{
 		dynamicStore: dynStore,
@@ -29,11 +35,28 @@ func NewStore(dynStore *dynamic.DynamicStore, cfg Config) (interfaces.RoleBindin
 	}, nil
 }
 
+// HealthCheck checks the database holding the namespaced role_bindings table
+func (s *Store) HealthCheck(ctx context.Context) error {
+	return s.dynamicStore.HealthCheck(ctx)
+}
+
+// InTransaction runs fn in a database transaction, rolling back when fn returns an error.
+// 같은 DynamicStore를 쓰는 다른 store도 fn의 ctx로 호출하면 같은 트랜잭션에 참여함
+func (s *Store) InTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return s.dynamicStore.InTransaction(ctx, fn)
+}
+
 func (s *Store) Create(ctx context.Context, binding *v1alpha1.RoleBinding) error {
 	if _, err := s.dynamicStore.GetTableSchema(ctx, "role_bindings"); err != nil {
 		return err
 	}
 
+	ns, err := namespace.Resolve(ctx, binding.Namespace)
+	if err != nil {
+		return err
+	}
+	binding.Namespace = ns
+
 	now := time.Now()
 	if binding.CreationTimestamp.IsZero() {
 		binding.CreationTimestamp = metav1.NewTime(now)
@@ -47,10 +70,16 @@ func (s *Store) Create(ctx context.Context, binding *v1alpha1.RoleBinding) error
 	data := map[string]interface{}{
 		"id":         binding.Name,
 		"name":       binding.Name,
+		"namespace":  binding.Namespace,
 		"role_ref":   binding.RoleRef.Name,
 		"subjects":   string(subjectsJSON),
 		"created_at": binding.CreationTimestamp.Time,
 		"updated_at": now,
+		"created_by": actor.FromContext(ctx),
+		"updated_by": actor.FromContext(ctx),
+	}
+	if err := setRoleRefs(data, binding.RoleRefs); err != nil {
+		return err
 	}
 
 	if len(binding.Annotations) > 0 {
@@ -66,7 +95,8 @@ func (s *Store) Create(ctx context.Context, binding *v1alpha1.RoleBinding) error
 
 func (s *Store) Get(ctx context.Context, name string) (*v1alpha1.RoleBinding, error) {
 	results, err := s.dynamicStore.DynamicSelect(ctx, "role_bindings", map[string]interface{}{
-		"name": name,
+		"name":      name,
+		"namespace": namespace.FromContext(ctx),
 	})
 	if err != nil {
 		return nil, err
@@ -93,6 +123,10 @@ func (s *Store) Update(ctx context.Context, binding *v1alpha1.RoleBinding) error
 		"role_ref":   binding.RoleRef.Name,
 		"subjects":   string(subjectsJSON),
 		"updated_at": time.Now(),
+		"updated_by": actor.FromContext(ctx),
+	}
+	if err := setRoleRefs(data, binding.RoleRefs); err != nil {
+		return err
 	}
 
 	if len(binding.Annotations) > 0 {
@@ -103,15 +137,21 @@ func (s *Store) Update(ctx context.Context, binding *v1alpha1.RoleBinding) error
 		data["annotations"] = string(annotationsJSON)
 	}
 
-	return s.dynamicStore.DynamicUpdate(ctx, "role_bindings", binding.Name, data)
+	return s.dynamicStore.DynamicUpdateScoped(ctx, "role_bindings", binding.Name, namespaceScope(ctx), data)
 }
 
 func (s *Store) Delete(ctx context.Context, name string) error {
-	return s.dynamicStore.DynamicDelete(ctx, "role_bindings", name)
+	// 다른 namespace의 바인딩은 삭제할 수 없음
+	if _, err := s.Get(ctx, name); err != nil {
+		return err
+	}
+	return s.dynamicStore.DynamicDeleteScoped(ctx, "role_bindings", name, namespaceScope(ctx))
 }
 
 func (s *Store) List(ctx context.Context) ([]*v1alpha1.RoleBinding, error) {
-	results, err := s.dynamicStore.DynamicSelect(ctx, "role_bindings", nil)
+	results, err := s.dynamicStore.DynamicSelectOrdered(ctx, "role_bindings", map[string]interface{}{
+		"namespace": namespace.FromContext(ctx),
+	}, dynamic.DefaultListOrder)
 	if err != nil {
 		return nil, err
 	}
@@ -128,6 +168,79 @@ func (s *Store) List(ctx context.Context) ([]*v1alpha1.RoleBinding, error) {
 	return bindings, nil
 }
 
+// ListPaged returns a page of role bindings ordered by name together with the total number of bindings
+func (s *Store) ListPaged(ctx context.Context, limit, offset int) ([]*v1alpha1.RoleBinding, int, error) {
+	ns := namespace.FromContext(ctx)
+	total, err := s.dynamicStore.CountActiveWhere(ctx, "role_bindings", map[string]interface{}{"namespace": ns})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	params := query.QueryParams{
+		Where: []query.WhereCondition{
+			{Column: "deleted_at", Operator: "IS", Value: nil},
+			{Column: "namespace", Operator: "=", Value: ns},
+		},
+		OrderBy: []query.OrderByClause{{Column: "name"}},
+		Limit:   limit,
+		Offset:  offset,
+	}
+	results, err := s.dynamicStore.DynamicQuery(ctx, "role_bindings", params)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	bindings := make([]*v1alpha1.RoleBinding, 0, len(results))
+	for _, result := range results {
+		binding, err := mapToRoleBinding(result)
+		if err != nil {
+			return nil, 0, err
+		}
+		bindings = append(bindings, binding)
+	}
+
+	return bindings, total, nil
+}
+
+// ListWithCursor returns up to limit role bindings ordered by name, starting after the binding encoded in next.
+// 페이지가 가득 찼을 때만 다음 cursor를 반환 (마지막 페이지는 빈 문자열)
+func (s *Store) ListWithCursor(ctx context.Context, limit int, next string) ([]*v1alpha1.RoleBinding, string, error) {
+	params := query.QueryParams{
+		Where: []query.WhereCondition{
+			{Column: "deleted_at", Operator: "IS", Value: nil},
+			{Column: "namespace", Operator: "=", Value: namespace.FromContext(ctx)},
+		},
+		OrderBy: []query.OrderByClause{{Column: "name"}},
+		Limit:   limit,
+	}
+	if next != "" {
+		after, err := cursor.Decode(next)
+		if err != nil {
+			return nil, "", err
+		}
+		params.Where = append(params.Where, query.WhereCondition{Column: "name", Operator: ">", Value: after.ID})
+	}
+
+	results, err := s.dynamicStore.DynamicQuery(ctx, "role_bindings", params)
+	if err != nil {
+		return nil, "", err
+	}
+
+	bindings := make([]*v1alpha1.RoleBinding, 0, len(results))
+	for _, result := range results {
+		binding, err := mapToRoleBinding(result)
+		if err != nil {
+			return nil, "", err
+		}
+		bindings = append(bindings, binding)
+	}
+
+	if limit > 0 && len(bindings) == limit {
+		return bindings, cursor.Encode(cursor.Cursor{ID: bindings[len(bindings)-1].Name}), nil
+	}
+	return bindings, "", nil
+}
+
 func (s *Store) FindBySubject(ctx context.Context, subjectKind, subjectName string) ([]*v1alpha1.RoleBinding, error) {
 	bindings, err := s.List(ctx)
 	if err != nil {
@@ -147,26 +260,25 @@ func (s *Store) FindBySubject(ctx context.Context, subjectKind, subjectName stri
 	return filtered, nil
 }
 
+// FindByRole returns the bindings that reference roleName through RoleRef or RoleRefs
 func (s *Store) FindByRole(ctx context.Context, roleName string) ([]*v1alpha1.RoleBinding, error) {
-	results, err := s.dynamicStore.DynamicSelect(ctx, "role_bindings", map[string]interface{}{
-		"role_ref": roleName,
-	})
+	bindings, err := s.List(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	bindings := make([]*v1alpha1.RoleBinding, 0, len(results))
-	for _, result := range results {
-		binding, err := mapToRoleBinding(result)
-		if err != nil {
-			return nil, err
+	var filtered []*v1alpha1.RoleBinding
+	for _, binding := range bindings {
+		if binding.ReferencesRole(roleName) {
+			filtered = append(filtered, binding)
 		}
-		bindings = append(bindings, binding)
 	}
 
-	return bindings, nil
+	return filtered, nil
 }
 
+// AddSubject appends subject to the binding without checking RBAC.MaxSubjectsPerBinding.
+// API 요청은 제한을 적용하는 RBACController.AddRoleBindingSubject를 사용해야 함
 func (s *Store) AddSubject(ctx context.Context, name string, subject v1alpha1.Subject) error {
 	binding, err := s.Get(ctx, name)
 	if err != nil {
@@ -208,22 +320,6 @@ func (s *Store) RemoveSubject(ctx context.Context, name string, subject v1alpha1
 	return s.Update(ctx, binding)
 }
 
-// func (s *Store) ListByNamespace(ctx context.Context, namespace string) ([]*v1alpha1.RoleBinding, error) {
-// 	bindings, err := s.List(ctx)
-// 	if err != nil {
-// 		return nil, err
-// 	}
-
-// 	var filtered []*v1alpha1.RoleBinding
-// 	for _, binding := range bindings {
-// 		if binding.Namespace == namespace {
-// 			filtered = append(filtered, binding)
-// 		}
-// 	}
-
-// 	return filtered, nil
-// }
-
 func mapToRoleBinding(data map[string]interface{}) (*v1alpha1.RoleBinding, error) {
 	binding := &v1alpha1.RoleBinding{
 		TypeMeta: metav1.TypeMeta{
@@ -240,6 +336,7 @@ func mapToRoleBinding(data map[string]interface{}) (*v1alpha1.RoleBinding, error
 			Name: data["role_ref"].(string),
 		},
 	}
+	binding.Namespace, _ = data["namespace"].(string)
 
 	if subjectsJSON, ok := data["subjects"].(string); ok && subjectsJSON != "" {
 		var subjects []v1alpha1.Subject
@@ -249,6 +346,14 @@ func mapToRoleBinding(data map[string]interface{}) (*v1alpha1.RoleBinding, error
 		binding.Subjects = subjects
 	}
 
+	if roleRefsJSON, ok := data["role_refs"].(string); ok && roleRefsJSON != "" {
+		var roleRefs []v1alpha1.RoleRef
+		if err := json.Unmarshal([]byte(roleRefsJSON), &roleRefs); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal role refs: %w", err)
+		}
+		binding.RoleRefs = roleRefs
+	}
+
 	if annotations, ok := data["annotations"].(string); ok && annotations != "" {
 		var parsedAnnotations map[string]string
 		if err := json.Unmarshal([]byte(annotations), &parsedAnnotations); err != nil {
@@ -257,5 +362,32 @@ func mapToRoleBinding(data map[string]interface{}) (*v1alpha1.RoleBinding, error
 		binding.Annotations = parsedAnnotations
 	}
 
+	// 생성자/수정자는 컬럼 값을 기준으로 annotation에 노출
+	if createdBy, ok := data["created_by"].(string); ok && createdBy != "" {
+		metav1.SetMetaDataAnnotation(&binding.ObjectMeta, v1alpha1.AnnotationCreatedBy, createdBy)
+	}
+	if updatedBy, ok := data["updated_by"].(string); ok && updatedBy != "" {
+		metav1.SetMetaDataAnnotation(&binding.ObjectMeta, v1alpha1.AnnotationUpdatedBy, updatedBy)
+	}
+
 	return binding, nil
 }
+
+// setRoleRefs stores refs in the role_refs column; 비어 있으면 NULL로 저장하여 Update 시 이전 값을 지움
+func setRoleRefs(data map[string]interface{}, refs []v1alpha1.RoleRef) error {
+	if len(refs) == 0 {
+		data["role_refs"] = nil
+		return nil
+	}
+	roleRefsJSON, err := json.Marshal(refs)
+	if err != nil {
+		return fmt.Errorf("failed to marshal role refs: %w", err)
+	}
+	data["role_refs"] = string(roleRefsJSON)
+	return nil
+}
+
+// namespaceScope limits binding writes to the request namespace
+func namespaceScope(ctx context.Context) map[string]interface{} {
+	return map[string]interface{}{"namespace": namespace.FromContext(ctx)}
+}
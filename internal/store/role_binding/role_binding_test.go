@@ -10,6 +10,8 @@ import (
 	"github.com/sukryu/pAuth/internal/store/dynamic"
 	"github.com/sukryu/pAuth/internal/store/manager"
 	"github.com/sukryu/pAuth/pkg/apis/auth/v1alpha1"
+	"github.com/sukryu/pAuth/pkg/utils/actor"
+	"github.com/sukryu/pAuth/pkg/utils/namespace"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -49,8 +51,12 @@ func setupTestDB(t *testing.T) (*sql.DB, *dynamic.DynamicStore) {
        CREATE TABLE IF NOT EXISTS role_bindings (
            id TEXT PRIMARY KEY,
            name TEXT UNIQUE NOT NULL,
+           namespace TEXT NOT NULL DEFAULT 'default',
            role_ref TEXT NOT NULL,
+           role_refs TEXT,
            subjects TEXT NOT NULL,
+           created_by TEXT,
+           updated_by TEXT,
            annotations TEXT,
            created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
            updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
@@ -62,10 +68,7 @@ func setupTestDB(t *testing.T) (*sql.DB, *dynamic.DynamicStore) {
 	}
 
 	// DynamicStore 생성
-	store, err := dynamic.NewDynamicStore(manager)
-	if err != nil {
-		t.Fatalf("failed to create dynamic store: %v", err)
-	}
+	store := dynamic.NewDynamicStoreFromDB(dbConn)
 
 	return dbConn, store
 }
@@ -210,6 +213,29 @@ func TestRoleBindingStore_FindByRole(t *testing.T) {
 		assert.Len(t, bindings, 1)
 		assert.Equal(t, "binding1", bindings[0].Name)
 	})
+
+	t.Run("Find bindings by additional role ref", func(t *testing.T) {
+		binding3 := createTestRoleBinding(t)
+		binding3.Name = "binding3"
+		binding3.RoleRef.Name = "role2"
+		binding3.RoleRefs = []v1alpha1.RoleRef{{Kind: "Role", Name: "role2"}, {Kind: "Role", Name: "role1"}}
+		assert.NoError(t, store.Create(ctx, binding3))
+
+		saved, err := store.Get(ctx, "binding3")
+		assert.NoError(t, err)
+		assert.Equal(t, binding3.RoleRefs, saved.RoleRefs)
+
+		bindings, err := store.FindByRole(ctx, "role1")
+		assert.NoError(t, err)
+		assert.Len(t, bindings, 2)
+
+		// RoleRefs를 비우면 추가 참조가 제거됨
+		saved.RoleRefs = nil
+		assert.NoError(t, store.Update(ctx, saved))
+		bindings, err = store.FindByRole(ctx, "role1")
+		assert.NoError(t, err)
+		assert.Len(t, bindings, 1)
+	})
 }
 
 func TestRoleBindingStore_AddRemoveSubject(t *testing.T) {
@@ -255,27 +281,106 @@ func TestRoleBindingStore_AddRemoveSubject(t *testing.T) {
 	})
 }
 
-// func TestRoleBindingStore_ListByNamespace(t *testing.T) {
-// 	store, cleanup := setupTestStore(t)
-// 	defer cleanup()
-// 	ctx := context.Background()
-
-// 	binding1 := createTestRoleBinding(t)
-// 	binding1.Name = "binding1"
-// 	binding1.Namespace = "namespace1"
-// 	err := store.Create(ctx, binding1)
-// 	assert.NoError(t, err)
-
-// 	binding2 := createTestRoleBinding(t)
-// 	binding2.Name = "binding2"
-// 	binding2.Namespace = "namespace2"
-// 	err = store.Create(ctx, binding2)
-// 	assert.NoError(t, err)
-
-// 	t.Run("List bindings by namespace", func(t *testing.T) {
-// 		bindings, err := store.ListByNamespace(ctx, "namespace1")
-// 		assert.NoError(t, err)
-// 		assert.Len(t, bindings, 1)
-// 		assert.Equal(t, "binding1", bindings[0].Name)
-// 	})
-// }
+func TestRoleBindingStore_Attribution(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	binding := createTestRoleBinding(t)
+	assert.NoError(t, store.Create(actor.WithActor(context.Background(), "alice"), binding))
+
+	created, err := store.Get(context.Background(), binding.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, "alice", created.Annotations[v1alpha1.AnnotationCreatedBy])
+	assert.Equal(t, "alice", created.Annotations[v1alpha1.AnnotationUpdatedBy])
+
+	created.Subjects = append(created.Subjects, v1alpha1.Subject{Kind: "User", Name: "carol"})
+	assert.NoError(t, store.Update(actor.WithActor(context.Background(), "bob"), created))
+
+	updated, err := store.Get(context.Background(), binding.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, "alice", updated.Annotations[v1alpha1.AnnotationCreatedBy])
+	assert.Equal(t, "bob", updated.Annotations[v1alpha1.AnnotationUpdatedBy])
+}
+
+func TestRoleBindingStore_Namespace(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+	tenantA := namespace.WithNamespace(context.Background(), "tenant-a")
+	tenantB := namespace.WithNamespace(context.Background(), "tenant-b")
+
+	binding1 := createTestRoleBinding(t)
+	binding1.Name = "binding1"
+	assert.NoError(t, store.Create(tenantA, binding1))
+
+	binding2 := createTestRoleBinding(t)
+	binding2.Name = "binding2"
+	binding2.Namespace = "tenant-b"
+	assert.NoError(t, store.Create(tenantB, binding2))
+
+	t.Run("lookups are scoped to the request namespace", func(t *testing.T) {
+		bindings, err := store.List(tenantA)
+		assert.NoError(t, err)
+		if assert.Len(t, bindings, 1) {
+			assert.Equal(t, "binding1", bindings[0].Name)
+			assert.Equal(t, "tenant-a", bindings[0].Namespace)
+		}
+
+		_, err = store.Get(tenantA, "binding2")
+		assert.Error(t, err)
+
+		_, total, err := store.ListPaged(tenantB, 10, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, total)
+
+		paged, next, err := store.ListWithCursor(tenantA, 1, "")
+		assert.NoError(t, err)
+		if assert.Len(t, paged, 1) {
+			assert.Equal(t, "binding1", paged[0].Name)
+		}
+		paged, next, err = store.ListWithCursor(tenantA, 1, next)
+		assert.NoError(t, err)
+		assert.Empty(t, paged)
+		assert.Empty(t, next)
+
+		found, err := store.FindBySubject(tenantB, "User", "test-user")
+		assert.NoError(t, err)
+		assert.Len(t, found, 1)
+	})
+
+	t.Run("cannot write into another namespace", func(t *testing.T) {
+		other := createTestRoleBinding(t)
+		other.Name = "binding3"
+		other.Namespace = "tenant-b"
+		assert.Error(t, store.Create(tenantA, other))
+
+		assert.Error(t, store.Delete(tenantA, "binding2"))
+		_, err := store.Get(tenantB, "binding2")
+		assert.NoError(t, err)
+	})
+}
+
+func TestRoleBindingStore_ReadsDoNotAlias(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	binding := createTestRoleBinding(t)
+	assert.NoError(t, store.Create(ctx, binding))
+
+	found, err := store.Get(ctx, binding.Name)
+	assert.NoError(t, err)
+	found.Subjects[0].Name = "mallory"
+
+	bySubject, err := store.FindBySubject(ctx, "User", "test-user")
+	assert.NoError(t, err)
+	bySubject[0].RoleRef.Name = "admin"
+
+	byRole, err := store.FindByRole(ctx, "test-role")
+	assert.NoError(t, err)
+	byRole[0].Subjects = append(byRole[0].Subjects, v1alpha1.Subject{Kind: "User", Name: "mallory"})
+
+	saved, err := store.Get(ctx, binding.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, binding.Subjects, saved.Subjects)
+	assert.Equal(t, "test-role", saved.RoleRef.Name)
+}
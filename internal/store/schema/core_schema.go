@@ -15,42 +15,134 @@ var CoreSchemas = []EntitySchema{
 	{
 		Name:        "users",
 		Description: "User management table",
+		KeyColumns:  []string{"namespace"}, // 이름은 namespace 안에서만 유일
 		Fields: []FieldDef{
-			{Name: "username", Type: FieldTypeString, Required: true, Unique: true},
-			{Name: "email", Type: FieldTypeString, Required: true, Unique: true},
+			{Name: "username", Type: FieldTypeString, Required: true},
+			{Name: "namespace", Type: FieldTypeString, Required: true, DefaultValue: "'default'"}, // 테넌트 격리 단위
+			{Name: "email", Type: FieldTypeString, Required: true},
 			{Name: "password_hash", Type: FieldTypeString, Required: true},
+			{Name: "display_name", Type: FieldTypeString},
+			{Name: "profile", Type: FieldTypeJSON}, // 자유 형식 프로필 정보
+			{Name: "roles", Type: FieldTypeJSON},
 			{Name: "is_active", Type: FieldTypeBoolean, Required: true, DefaultValue: true},
 			{Name: "last_login", Type: FieldTypeTimestamp},
-			{Name: "annotations", Type: FieldTypeJSON}, // JSON으로 처리되는 사용자 정의 필드
+			{Name: "login_history", Type: FieldTypeJSON},    // 최근 로그인 기록 (최대 N개)
+			{Name: "password_history", Type: FieldTypeJSON}, // 이전 비밀번호의 bcrypt 해시 (최대 N개)
+			{Name: "password_changed_at", Type: FieldTypeTimestamp},
+			{Name: "pending_email", Type: FieldTypeString},
+			{Name: "email_change_token", Type: FieldTypeString}, // 검증 토큰의 SHA-256 해시
+			{Name: "email_change_expires", Type: FieldTypeTimestamp},
+			{Name: "activation_time", Type: FieldTypeTimestamp}, // 이 시각 이전에는 로그인 불가
+			{Name: "annotations", Type: FieldTypeJSON},          // JSON으로 처리되는 사용자 정의 필드
+			{Name: "created_by", Type: FieldTypeString},
+			{Name: "updated_by", Type: FieldTypeString},
 		},
 		Indexes: []IndexDef{
-			{Name: "idx_users_username", Columns: []string{"username"}, Unique: true},
-			{Name: "idx_users_email", Columns: []string{"email"}, Unique: true},
+			{Name: "idx_users_namespace_username", Columns: []string{"namespace", "username"}, Unique: true},
+			{Name: "idx_users_namespace_email", Columns: []string{"namespace", "email"}, Unique: true},
+			{Name: "idx_users_email_change_token", Columns: []string{"email_change_token"}},
+			{Name: "idx_users_display_name", Columns: []string{"display_name"}},
+			{Name: "idx_users_namespace", Columns: []string{"namespace"}},
+			{Name: "idx_users_namespace_created", Columns: []string{"namespace", "created_at", "id"}}, // List 정렬
 		},
 	},
 	{
 		Name:        "roles",
 		Description: "Role definition table",
+		KeyColumns:  []string{"namespace"},
 		Fields: []FieldDef{
-			{Name: "name", Type: FieldTypeString, Required: true, Unique: true},
+			{Name: "name", Type: FieldTypeString, Required: true},
+			{Name: "namespace", Type: FieldTypeString, Required: true, DefaultValue: "'default'"}, // 테넌트 격리 단위
 			{Name: "description", Type: FieldTypeString},
-			{Name: "rules", Type: FieldTypeJSON}, // PolicyRules를 JSON으로 저장
+			{Name: "rules", Type: FieldTypeJSON},    // PolicyRules를 JSON으로 저장
+			{Name: "inherits", Type: FieldTypeJSON}, // 상속하는 role 이름 목록
+			{Name: "annotations", Type: FieldTypeJSON},
+			{Name: "created_by", Type: FieldTypeString},
+			{Name: "updated_by", Type: FieldTypeString},
+		},
+		Indexes: []IndexDef{
+			{Name: "idx_roles_namespace_name", Columns: []string{"namespace", "name"}, Unique: true},
+			{Name: "idx_roles_namespace", Columns: []string{"namespace"}},
+			{Name: "idx_roles_namespace_created", Columns: []string{"namespace", "created_at", "id"}},
+		},
+	},
+	{
+		Name:        "role_rules",
+		Description: "Normalized role rules (one row per verb/resource/apiGroup)",
+		Fields: []FieldDef{
+			{Name: "role_name", Type: FieldTypeString, Required: true},
+			{Name: "namespace", Type: FieldTypeString, Required: true, DefaultValue: "'default'"},
+			{Name: "verb", Type: FieldTypeString, Required: true},
+			{Name: "resource", Type: FieldTypeString, Required: true},
+			{Name: "api_group", Type: FieldTypeString, Required: true},
 		},
 		Indexes: []IndexDef{
-			{Name: "idx_roles_name", Columns: []string{"name"}, Unique: true},
+			{Name: "idx_role_rules_role_name", Columns: []string{"role_name"}},
+			{Name: "idx_role_rules_verb", Columns: []string{"namespace", "verb"}},
+			{Name: "idx_role_rules_resource", Columns: []string{"namespace", "resource"}},
+			{Name: "idx_role_rules_api_group", Columns: []string{"namespace", "api_group"}},
 		},
 	},
 	{
 		Name:        "role_bindings",
 		Description: "Role assignment table",
+		KeyColumns:  []string{"namespace"},
 		Fields: []FieldDef{
-			{Name: "name", Type: FieldTypeString, Required: true, Unique: true},
+			{Name: "name", Type: FieldTypeString, Required: true},
+			{Name: "namespace", Type: FieldTypeString, Required: true, DefaultValue: "'default'"}, // 테넌트 격리 단위
 			{Name: "role_ref", Type: FieldTypeString, Required: true},
-			{Name: "subjects", Type: FieldTypeJSON}, // Subject 목록을 JSON으로 저장
+			{Name: "role_refs", Type: FieldTypeJSON}, // 추가로 바인딩된 RoleRef 목록
+			{Name: "subjects", Type: FieldTypeJSON},  // Subject 목록을 JSON으로 저장
+			{Name: "annotations", Type: FieldTypeJSON},
+			{Name: "created_by", Type: FieldTypeString},
+			{Name: "updated_by", Type: FieldTypeString},
 		},
 		Indexes: []IndexDef{
-			{Name: "idx_role_bindings_name", Columns: []string{"name"}, Unique: true},
+			{Name: "idx_role_bindings_namespace_name", Columns: []string{"namespace", "name"}, Unique: true},
 			{Name: "idx_role_bindings_role_ref", Columns: []string{"role_ref"}},
+			{Name: "idx_role_bindings_namespace", Columns: []string{"namespace"}},
+			{Name: "idx_role_bindings_namespace_created", Columns: []string{"namespace", "created_at", "id"}},
+		},
+	},
+	{
+		Name:        "cluster_roles",
+		Description: "Cluster-scoped role definition table",
+		Fields: []FieldDef{
+			{Name: "name", Type: FieldTypeString, Required: true, Unique: true},
+			{Name: "rules", Type: FieldTypeJSON},
+			{Name: "annotations", Type: FieldTypeJSON},
+			{Name: "created_by", Type: FieldTypeString},
+			{Name: "updated_by", Type: FieldTypeString},
+		},
+		Indexes: []IndexDef{
+			{Name: "idx_cluster_roles_name", Columns: []string{"name"}, Unique: true},
+		},
+	},
+	{
+		Name:        "cluster_role_bindings",
+		Description: "Cluster-scoped role assignment table",
+		Fields: []FieldDef{
+			{Name: "name", Type: FieldTypeString, Required: true, Unique: true},
+			{Name: "role_ref", Type: FieldTypeString, Required: true},
+			{Name: "subjects", Type: FieldTypeJSON},
+			{Name: "annotations", Type: FieldTypeJSON},
+			{Name: "created_by", Type: FieldTypeString},
+			{Name: "updated_by", Type: FieldTypeString},
+		},
+		Indexes: []IndexDef{
+			{Name: "idx_cluster_role_bindings_name", Columns: []string{"name"}, Unique: true},
+			{Name: "idx_cluster_role_bindings_role_ref", Columns: []string{"role_ref"}},
+		},
+	},
+	{
+		Name:        "service_accounts",
+		Description: "Service account table",
+		Fields: []FieldDef{
+			{Name: "name", Type: FieldTypeString, Required: true, Unique: true},
+			{Name: "annotations", Type: FieldTypeJSON},
+		},
+		Indexes: []IndexDef{
+			{Name: "idx_service_accounts_name", Columns: []string{"name"}, Unique: true},
 		},
 	},
 }
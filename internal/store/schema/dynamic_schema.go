@@ -1,8 +1,13 @@
 package schema
 
 import (
+	"encoding/json"
 	"fmt"
+	"math"
+	"strings"
 	"time"
+
+	"github.com/sukryu/pAuth/pkg/errors"
 )
 
 type EntitySchema struct {
@@ -11,6 +16,10 @@ type EntitySchema struct {
 	Description string     `json:"description"`
 	Fields      []FieldDef `json:"fields" gorm:"type:jsonb"`
 	Indexes     []IndexDef `json:"indexes" gorm:"type:jsonb"`
+
+	// KeyColumns are the fields that form the primary key together with id (e.g. namespace).
+	// 비어 있으면 id만으로 primary key
+	KeyColumns []string `json:"keyColumns,omitempty" gorm:"type:jsonb"`
 }
 
 type FieldType string
@@ -24,6 +33,26 @@ const (
 	FieldTypeJSON      FieldType = "JSON"
 )
 
+// AllowedFieldTypes is the authoritative list of column types accepted for dynamic tables
+var AllowedFieldTypes = []FieldType{
+	FieldTypeString,
+	FieldTypeNumber,
+	FieldTypeInteger,
+	FieldTypeBoolean,
+	FieldTypeTimestamp,
+	FieldTypeJSON,
+}
+
+// IsValid reports whether t is one of AllowedFieldTypes
+func (t FieldType) IsValid() bool {
+	for _, allowed := range AllowedFieldTypes {
+		if t == allowed {
+			return true
+		}
+	}
+	return false
+}
+
 type FieldDef struct {
 	Name          string    `json:"name"`
 	Type          FieldType `json:"type"`
@@ -40,11 +69,69 @@ type IndexDef struct {
 	Name    string   `json:"name"`
 	Columns []string `json:"fields"`
 	Unique  bool     `json:"unique"`
+
+	// Directions gives the sort order of each column in Columns. 비어 있거나 짧으면 나머지는 ASC
+	Directions []IndexDirection `json:"directions,omitempty"`
+
+	// Where makes the index partial; conditions are ANDed
+	Where []IndexCondition `json:"where,omitempty"`
+}
+
+// IndexDirection is the sort order of an indexed column
+type IndexDirection string
+
+const (
+	IndexAsc  IndexDirection = "ASC"
+	IndexDesc IndexDirection = "DESC"
+)
+
+// IndexCondition is one predicate of a partial index.
+// SQLite은 인덱스 WHERE 절에 바인딩 파라미터를 허용하지 않으므로 Value는 리터럴로 렌더링됨
+type IndexCondition struct {
+	Column   string      `json:"column"`
+	Operator string      `json:"operator"` // =, !=, <, <=, >, >=, IS, IS NOT
+	Value    interface{} `json:"value"`    // nil이면 NULL
+}
+
+// DependencyType은 schema_dependencies에 기록되는 스키마 간 관계 유형.
+type DependencyType string
+
+const (
+	DependencyOneToMany DependencyType = "ONE_TO_MANY"
+	DependencyManyToOne DependencyType = "MANY_TO_ONE"
+)
+
+// IsReferential reports whether the dependency implies a child row referencing a parent row.
+func (d DependencyType) IsReferential() bool {
+	return d == DependencyOneToMany || d == DependencyManyToOne
+}
+
+// ForeignKeyDef는 자식 테이블 컬럼이 부모 테이블 컬럼을 참조하는 제약 조건을 정의.
+type ForeignKeyDef struct {
+	Column    string `json:"column"`
+	RefTable  string `json:"refTable"`
+	RefColumn string `json:"refColumn"`
+	OnDelete  string `json:"onDelete,omitempty"` // CASCADE, SET NULL, RESTRICT 등
 }
 
 type TableOptions struct {
-	Fields  []FieldDef
-	Indexes []IndexDef
+	Fields      []FieldDef
+	Indexes     []IndexDef
+	ForeignKeys []ForeignKeyDef
+
+	// KeyColumns are created as PRIMARY KEY (KeyColumns..., id)
+	KeyColumns []string
+}
+
+// TableOptions returns the options that create the entity's table.
+// Required가 아닌 필드는 NULL을 허용하는 컬럼이 됨
+func (e EntitySchema) TableOptions() TableOptions {
+	fields := make([]FieldDef, len(e.Fields))
+	for i, field := range e.Fields {
+		field.Nullable = !field.Required
+		fields[i] = field
+	}
+	return TableOptions{Fields: fields, Indexes: e.Indexes, KeyColumns: e.KeyColumns}
 }
 
 func (f FieldDef) GenerateColumnDef() string {
@@ -58,6 +145,62 @@ func (f FieldDef) GenerateColumnDef() string {
 	return columnDef
 }
 
+func (fk ForeignKeyDef) GenerateConstraintDef() string {
+	refColumn := fk.RefColumn
+	if refColumn == "" {
+		refColumn = "id"
+	}
+	constraintDef := fmt.Sprintf("FOREIGN KEY (%s) REFERENCES %s(%s)", fk.Column, fk.RefTable, refColumn)
+	if fk.OnDelete != "" {
+		constraintDef += " ON DELETE " + fk.OnDelete
+	}
+	return constraintDef
+}
+
+// GenerateCreateIndexSQL renders the CREATE INDEX statement for tableName.
+// 식별자와 연산자 검증은 호출자의 책임
+func (idx IndexDef) GenerateCreateIndexSQL(tableName string) string {
+	columns := make([]string, len(idx.Columns))
+	for i, column := range idx.Columns {
+		columns[i] = column
+		if i < len(idx.Directions) && idx.Directions[i] == IndexDesc {
+			columns[i] += " DESC"
+		}
+	}
+
+	create := "CREATE INDEX"
+	if idx.Unique {
+		create = "CREATE UNIQUE INDEX"
+	}
+	stmt := fmt.Sprintf("%s IF NOT EXISTS %s ON %s (%s)", create, idx.Name, tableName, strings.Join(columns, ", "))
+
+	if len(idx.Where) > 0 {
+		conditions := make([]string, len(idx.Where))
+		for i, c := range idx.Where {
+			conditions[i] = fmt.Sprintf("%s %s %s", c.Column, c.Operator, sqlLiteral(c.Value))
+		}
+		stmt += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	return stmt
+}
+
+// sqlLiteral renders v as an SQL literal
+func sqlLiteral(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return "NULL"
+	case bool:
+		if val {
+			return "1"
+		}
+		return "0"
+	case string:
+		return "'" + strings.ReplaceAll(val, "'", "''") + "'"
+	default:
+		return fmt.Sprintf("%v", val)
+	}
+}
+
 func ValidateFieldType(value interface{}, fieldType FieldType) error {
 	switch fieldType {
 	case FieldTypeString:
@@ -96,3 +239,55 @@ func ValidateFieldType(value interface{}, fieldType FieldType) error {
 	}
 	return nil
 }
+
+// ValidateAgainstSchema checks data against the required and type constraints of s before insert.
+// 스키마에 없는 키는 컬럼 이름으로 그대로 SQL에 들어가므로 거부함 ("id" 제외).
+// unique 제약은 데이터베이스가 검사
+func ValidateAgainstSchema(data map[string]interface{}, s *EntitySchema) error {
+	verr := &errors.ValidationError{}
+
+	fields := make(map[string]FieldDef, len(s.Fields))
+	for _, field := range s.Fields {
+		fields[field.Name] = field
+		value, present := data[field.Name]
+		if !present || value == nil {
+			if field.Required && field.DefaultValue == nil && !field.AutoIncrement {
+				verr.Add(field.Name, "field is required")
+			}
+			continue
+		}
+		if err := validateSchemaValue(value, field.Type); err != nil {
+			verr.Add(field.Name, err.Error())
+		}
+	}
+
+	for name := range data {
+		if _, ok := fields[name]; !ok && name != "id" {
+			verr.Add(name, "unknown field")
+		}
+	}
+	if id, ok := data["id"]; ok {
+		if _, isString := id.(string); !isString {
+			verr.Add("id", "value must be of type string")
+		}
+	}
+
+	return verr.OrNil()
+}
+
+// validateSchemaValue extends ValidateFieldType for values decoded from JSON request bodies
+func validateSchemaValue(value interface{}, fieldType FieldType) error {
+	switch fieldType {
+	case FieldTypeInteger:
+		// JSON 숫자는 float64로 디코딩되므로 소수부가 없는지 확인
+		if f, ok := value.(float64); ok && f != math.Trunc(f) {
+			return fmt.Errorf("value must be of type integer")
+		}
+	case FieldTypeJSON:
+		if _, err := json.Marshal(value); err != nil {
+			return fmt.Errorf("value must be JSON serializable")
+		}
+		return nil
+	}
+	return ValidateFieldType(value, fieldType)
+}
@@ -0,0 +1,125 @@
+package serviceaccount
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/sukryu/pAuth/internal/store/dynamic"
+	"github.com/sukryu/pAuth/internal/store/interfaces"
+	"github.com/sukryu/pAuth/pkg/apis/auth/v1alpha1"
+	"github.com/sukryu/pAuth/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type Config struct {
+	DatabaseType string
+}
+
+type Store struct {
+	dynamicStore *dynamic.DynamicStore
+	config       Config
+}
+
+var _ interfaces.ServiceAccountStore = (*Store)(nil)
+
+func NewStore(dynStore *dynamic.DynamicStore, cfg Config) (interfaces.ServiceAccountStore, error) {
+	return &Store{
+		dynamicStore: dynStore,
+		config:       cfg,
+	}, nil
+}
+
+// HealthCheck checks the database holding the service_accounts table
+func (s *Store) HealthCheck(ctx context.Context) error {
+	return s.dynamicStore.HealthCheck(ctx)
+}
+
+func (s *Store) Create(ctx context.Context, sa *v1alpha1.ServiceAccount) error {
+	if _, err := s.dynamicStore.GetTableSchema(ctx, "service_accounts"); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if sa.CreationTimestamp.IsZero() {
+		sa.CreationTimestamp = metav1.NewTime(now)
+	}
+
+	data := map[string]interface{}{
+		"id":         sa.Name,
+		"name":       sa.Name,
+		"created_at": sa.CreationTimestamp.Time,
+		"updated_at": now,
+	}
+
+	if len(sa.Annotations) > 0 {
+		annotationsJSON, err := json.Marshal(sa.Annotations)
+		if err != nil {
+			return fmt.Errorf("failed to marshal annotations: %w", err)
+		}
+		data["annotations"] = string(annotationsJSON)
+	}
+
+	return s.dynamicStore.DynamicInsert(ctx, "service_accounts", data)
+}
+
+func (s *Store) Get(ctx context.Context, name string) (*v1alpha1.ServiceAccount, error) {
+	results, err := s.dynamicStore.DynamicSelect(ctx, "service_accounts", map[string]interface{}{
+		"name": name,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(results) == 0 {
+		return nil, errors.ErrNotFound
+	}
+
+	return mapToServiceAccount(results[0])
+}
+
+func (s *Store) Delete(ctx context.Context, name string) error {
+	return s.dynamicStore.DynamicDelete(ctx, "service_accounts", name)
+}
+
+func (s *Store) List(ctx context.Context) ([]*v1alpha1.ServiceAccount, error) {
+	results, err := s.dynamicStore.DynamicSelectOrdered(ctx, "service_accounts", nil, dynamic.DefaultListOrder)
+	if err != nil {
+		return nil, err
+	}
+
+	accounts := make([]*v1alpha1.ServiceAccount, 0, len(results))
+	for _, result := range results {
+		sa, err := mapToServiceAccount(result)
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, sa)
+	}
+
+	return accounts, nil
+}
+
+func mapToServiceAccount(data map[string]interface{}) (*v1alpha1.ServiceAccount, error) {
+	sa := &v1alpha1.ServiceAccount{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ServiceAccount",
+			APIVersion: "auth.service/v1alpha1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: make(map[string]string),
+		},
+	}
+	sa.Name, _ = data["name"].(string)
+	if createdAt, ok := data["created_at"].(time.Time); ok {
+		sa.CreationTimestamp = metav1.NewTime(createdAt)
+	}
+
+	if annotations, ok := data["annotations"].(string); ok && annotations != "" {
+		if err := json.Unmarshal([]byte(annotations), &sa.Annotations); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal annotations: %w", err)
+		}
+	}
+
+	return sa, nil
+}
@@ -0,0 +1,97 @@
+package serviceaccount
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/sukryu/pAuth/internal/store/dynamic"
+	"github.com/sukryu/pAuth/internal/store/manager"
+	"github.com/sukryu/pAuth/pkg/apis/auth/v1alpha1"
+	"github.com/sukryu/pAuth/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func setupTestDB(t *testing.T) (*sql.DB, *dynamic.DynamicStore) {
+	manager, err := manager.NewSQLManager(manager.Config{
+		Type: "sqlite3",
+		DSN:  ":memory:",
+	})
+	if err != nil {
+		t.Fatalf("failed to create SQLManager: %v", err)
+	}
+
+	dbConn := manager.GetDB()
+
+	// service_accounts 테이블 생성
+	_, err = dbConn.Exec(`
+       CREATE TABLE IF NOT EXISTS service_accounts (
+           id TEXT PRIMARY KEY,
+           name TEXT UNIQUE NOT NULL,
+           annotations TEXT,
+           created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+           updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+           deleted_at TIMESTAMP
+       )
+   `)
+	if err != nil {
+		t.Fatalf("failed to create service_accounts table: %v", err)
+	}
+
+	store, err := dynamic.NewDynamicStore(manager)
+	if err != nil {
+		t.Fatalf("failed to create dynamic store: %v", err)
+	}
+
+	return dbConn, store
+}
+
+func setupTestStore(t *testing.T) (*Store, func()) {
+	dbConn, dynStore := setupTestDB(t)
+	store := &Store{
+		dynamicStore: dynStore,
+		config:       Config{DatabaseType: "sqlite"},
+	}
+
+	return store, func() { dbConn.Close() }
+}
+
+func TestServiceAccountStore(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	sa := &v1alpha1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "ci-bot",
+			Annotations: map[string]string{"owner": "platform"},
+		},
+	}
+
+	t.Run("Create and get", func(t *testing.T) {
+		assert.NoError(t, store.Create(ctx, sa))
+
+		got, err := store.Get(ctx, "ci-bot")
+		assert.NoError(t, err)
+		assert.Equal(t, "ci-bot", got.Name)
+		assert.Equal(t, "ServiceAccount", got.Kind)
+		assert.Equal(t, "platform", got.Annotations["owner"])
+		assert.False(t, got.CreationTimestamp.IsZero())
+	})
+
+	t.Run("Duplicate create fails", func(t *testing.T) {
+		assert.Error(t, store.Create(ctx, &v1alpha1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "ci-bot"}}))
+	})
+
+	t.Run("List and delete", func(t *testing.T) {
+		accounts, err := store.List(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, accounts, 1)
+
+		assert.NoError(t, store.Delete(ctx, "ci-bot"))
+		_, err = store.Get(ctx, "ci-bot")
+		assert.Equal(t, errors.ErrNotFound, err)
+	})
+}
@@ -0,0 +1,129 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sukryu/pAuth/internal/store/dynamic"
+	"github.com/sukryu/pAuth/internal/store/schema"
+	"github.com/sukryu/pAuth/pkg/apis/auth/v1alpha1"
+)
+
+// coreColumns are the users table columns managed by the store; ExtraFields must not reuse them
+var coreColumns = map[string]bool{
+	"id": true, "namespace": true, "username": true, "email": true, "password_hash": true,
+	"display_name": true, "profile": true, "roles": true, "is_active": true,
+	"last_login": true, "login_history": true, "password_history": true, "password_changed_at": true, "pending_email": true,
+	"email_change_token": true, "email_change_expires": true, "activation_time": true, "annotations": true,
+	"created_by": true, "updated_by": true, "created_at": true, "updated_at": true, "deleted_at": true,
+}
+
+// validateExtraFields rejects extra fields that collide with core columns or each other.
+// 기존 행에는 값이 없으므로 NULL 허용 또는 기본값이 필요함
+func validateExtraFields(fields []schema.FieldDef) error {
+	seen := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		if !dynamic.IsValidIdentifier(field.Name) {
+			return fmt.Errorf("invalid extra field name: %q", field.Name)
+		}
+		if coreColumns[field.Name] {
+			return fmt.Errorf("extra field %q collides with a core users column", field.Name)
+		}
+		if seen[field.Name] {
+			return fmt.Errorf("duplicate extra field %q", field.Name)
+		}
+		seen[field.Name] = true
+
+		if !field.Nullable && field.DefaultValue == nil {
+			return fmt.Errorf("extra field %q must be nullable or have a default value", field.Name)
+		}
+	}
+	return nil
+}
+
+// ensureExtraColumns adds missing extra columns to an existing users table
+func (s *Store) ensureExtraColumns(ctx context.Context) error {
+	if len(s.config.ExtraFields) == 0 {
+		return nil
+	}
+
+	exists, err := s.dynamicStore.TableExists(ctx, "users")
+	if err != nil || !exists {
+		return err // 테이블은 스키마 생성 시 ExtraFields와 함께 만들어짐
+	}
+
+	columns, err := s.dynamicStore.GetTableSchema(ctx, "users")
+	if err != nil {
+		return err
+	}
+	existing := make(map[string]bool, len(columns))
+	for _, column := range columns {
+		existing[column] = true
+	}
+
+	for _, field := range s.config.ExtraFields {
+		if existing[field.Name] {
+			continue
+		}
+		if err := s.dynamicStore.AddColumn(ctx, "users", field.GenerateColumnDef()); err != nil {
+			return fmt.Errorf("failed to add extra field %q: %w", field.Name, err)
+		}
+	}
+	return nil
+}
+
+// splitProfile separates the extra field values stored in their own columns from the rest of profile
+func (s *Store) splitProfile(profile map[string]string) (map[string]string, map[string]interface{}) {
+	if len(s.config.ExtraFields) == 0 {
+		return profile, nil
+	}
+
+	rest := make(map[string]string, len(profile))
+	for k, v := range profile {
+		rest[k] = v
+	}
+	extras := make(map[string]interface{}, len(s.config.ExtraFields))
+	for _, field := range s.config.ExtraFields {
+		if v, ok := rest[field.Name]; ok {
+			extras[field.Name] = v
+			delete(rest, field.Name)
+		} else {
+			extras[field.Name] = nil // Update 시 값 제거도 반영
+		}
+	}
+	return rest, extras
+}
+
+// toUser converts a row into a user, exposing extra field columns through Spec.Profile
+func (s *Store) toUser(row map[string]interface{}) (*v1alpha1.User, error) {
+	user, err := mapToUser(row)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, field := range s.config.ExtraFields {
+		value, ok := row[field.Name]
+		if !ok || value == nil {
+			continue
+		}
+		if user.Spec.Profile == nil {
+			user.Spec.Profile = make(map[string]string)
+		}
+		user.Spec.Profile[field.Name] = formatExtraValue(value)
+	}
+	return user, nil
+}
+
+func formatExtraValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	case time.Time:
+		return v.Format(time.RFC3339)
+	default:
+		return fmt.Sprint(v)
+	}
+}
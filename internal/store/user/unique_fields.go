@@ -0,0 +1,51 @@
+package user
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sukryu/pAuth/internal/store/schema"
+	"github.com/sukryu/pAuth/pkg/errors"
+	"github.com/sukryu/pAuth/pkg/utils/namespace"
+)
+
+// validateUniqueFields accepts only core columns or configured extra fields.
+// 컬럼 이름이 그대로 조건절에 들어가므로 알려진 컬럼만 허용함
+func validateUniqueFields(fields []string, extras []schema.FieldDef) error {
+	known := make(map[string]bool, len(extras))
+	for _, field := range extras {
+		known[field.Name] = true
+	}
+
+	for _, field := range fields {
+		if field == "id" || (!coreColumns[field] && !known[field]) {
+			return fmt.Errorf("unknown unique field %q", field)
+		}
+	}
+	return nil
+}
+
+// checkUniqueFields rejects values of UniqueFields already used by another user.
+// username, email과 마찬가지로 namespace와 무관하게 검사하며 빈 값은 건너뜀
+func (s *Store) checkUniqueFields(ctx context.Context, name string, data map[string]interface{}) error {
+	for _, field := range s.config.UniqueFields {
+		value, ok := data[field]
+		if !ok || value == nil || value == "" {
+			continue
+		}
+
+		results, err := s.dynamicStore.DynamicSelect(ctx, "users", map[string]interface{}{
+			field:       value,
+			"namespace": namespace.FromContext(ctx),
+		})
+		if err != nil {
+			return err
+		}
+		for _, row := range results {
+			if row["id"] != name {
+				return errors.ErrUserExists.WithReason(fmt.Sprintf("%s is already in use", field))
+			}
+		}
+	}
+	return nil
+}
@@ -8,15 +8,27 @@ import (
 	"time"
 
 	"github.com/sukryu/pAuth/internal/store/dynamic"
+	"github.com/sukryu/pAuth/internal/store/dynamic/query"
 	"github.com/sukryu/pAuth/internal/store/interfaces"
 	"github.com/sukryu/pAuth/internal/store/schema"
 	"github.com/sukryu/pAuth/pkg/apis/auth/v1alpha1"
 	"github.com/sukryu/pAuth/pkg/errors"
+	"github.com/sukryu/pAuth/pkg/utils/actor"
+	"github.com/sukryu/pAuth/pkg/utils/cursor"
+	"github.com/sukryu/pAuth/pkg/utils/namespace"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 type Config struct {
 	DatabaseType string
+
+	// ExtraFields are deployment-specific users columns (e.g. employee_id).
+	// 값은 Spec.Profile의 같은 이름 키로 읽고 쓰며, 초기화 시 누락된 컬럼을 추가함
+	ExtraFields []schema.FieldDef
+
+	// UniqueFields are columns (core or ExtraFields) checked for duplicates on Create/Update.
+	// DB 제약 없이도 어떤 필드가 충돌했는지 ErrUserExists로 알려줌
+	UniqueFields []string
 }
 
 type Store struct {
@@ -24,11 +36,35 @@ type Store struct {
 	config       Config
 }
 
+var _ interfaces.UserStore = (*Store)(nil)
+
 func NewStore(dynStore *dynamic.DynamicStore, cfg Config) (interfaces.UserStore, error) {
-	return &Store{
+	if err := validateExtraFields(cfg.ExtraFields); err != nil {
+		return nil, err
+	}
+	if err := validateUniqueFields(cfg.UniqueFields, cfg.ExtraFields); err != nil {
+		return nil, err
+	}
+
+	s := &Store{
 		dynamicStore: dynStore,
 		config:       cfg,
-	}, nil
+	}
+	if err := s.ensureExtraColumns(context.Background()); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// HealthCheck checks the database holding the users table through the dynamic store
+func (s *Store) HealthCheck(ctx context.Context) error {
+	return s.dynamicStore.HealthCheck(ctx)
+}
+
+// InTransaction runs fn in a database transaction, rolling back when fn returns an error.
+// 같은 DynamicStore를 쓰는 다른 store도 fn의 ctx로 호출하면 같은 트랜잭션에 참여함
+func (s *Store) InTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return s.dynamicStore.InTransaction(ctx, fn)
 }
 
 func (s *Store) Create(ctx context.Context, user *v1alpha1.User) error {
@@ -37,6 +73,12 @@ func (s *Store) Create(ctx context.Context, user *v1alpha1.User) error {
 		return err
 	}
 
+	ns, err := namespace.Resolve(ctx, user.Namespace)
+	if err != nil {
+		return err
+	}
+	user.Namespace = ns
+
 	now := time.Now()
 	if user.CreationTimestamp.IsZero() {
 		user.CreationTimestamp = metav1.NewTime(now)
@@ -48,12 +90,16 @@ func (s *Store) Create(ctx context.Context, user *v1alpha1.User) error {
 	// 기본 필드 설정
 	coreFields := map[string]interface{}{
 		"id":            user.Name,
+		"namespace":     user.Namespace,
 		"username":      user.Spec.Username,
 		"email":         user.Spec.Email,
 		"password_hash": user.Spec.PasswordHash,
+		"display_name":  nullIfEmpty(user.Spec.DisplayName),
 		"is_active":     user.Status.Active,
 		"created_at":    user.CreationTimestamp.Time,
 		"updated_at":    now,
+		"created_by":    actor.FromContext(ctx),
+		"updated_by":    actor.FromContext(ctx),
 	}
 
 	// roles와 last_login 처리
@@ -65,10 +111,55 @@ func (s *Store) Create(ctx context.Context, user *v1alpha1.User) error {
 		coreFields["roles"] = string(rolesJSON)
 	}
 
+	profile, extras := s.splitProfile(user.Spec.Profile)
+	if len(profile) > 0 {
+		profileJSON, err := json.Marshal(profile)
+		if err != nil {
+			return fmt.Errorf("failed to marshal profile: %w", err)
+		}
+		coreFields["profile"] = string(profileJSON)
+	}
+	for column, value := range extras {
+		if value != nil {
+			coreFields[column] = value
+		}
+	}
+
+	if user.Spec.ActivationTime != nil {
+		coreFields["activation_time"] = user.Spec.ActivationTime.Time
+	}
+
 	if user.Status.LastLogin != nil {
 		coreFields["last_login"] = user.Status.LastLogin.Time
 	}
 
+	if len(user.Status.LoginHistory) > 0 {
+		historyJSON, err := json.Marshal(user.Status.LoginHistory)
+		if err != nil {
+			return fmt.Errorf("failed to marshal login history: %w", err)
+		}
+		coreFields["login_history"] = string(historyJSON)
+	}
+
+	if len(user.Status.PasswordHistory) > 0 {
+		historyJSON, err := json.Marshal(user.Status.PasswordHistory)
+		if err != nil {
+			return fmt.Errorf("failed to marshal password history: %w", err)
+		}
+		coreFields["password_history"] = string(historyJSON)
+	}
+	if user.Status.PasswordChangedAt != nil {
+		coreFields["password_changed_at"] = user.Status.PasswordChangedAt.Time
+	}
+
+	if user.Status.PendingEmail != "" {
+		coreFields["pending_email"] = user.Status.PendingEmail
+		coreFields["email_change_token"] = user.Status.EmailChangeTokenHash
+		if user.Status.EmailChangeExpiry != nil {
+			coreFields["email_change_expires"] = user.Status.EmailChangeExpiry.Time
+		}
+	}
+
 	// 기본 필드 복사
 	for k, v := range coreFields {
 		data[k] = v
@@ -83,13 +174,18 @@ func (s *Store) Create(ctx context.Context, user *v1alpha1.User) error {
 		data["annotations"] = string(annotationsJSON)
 	}
 
+	if err := s.checkUniqueFields(ctx, user.Name, data); err != nil {
+		return err
+	}
+
 	// 데이터 삽입
 	return s.dynamicStore.DynamicInsert(ctx, "users", data)
 }
 
 func (s *Store) Get(ctx context.Context, name string) (*v1alpha1.User, error) {
 	results, err := s.dynamicStore.DynamicSelect(ctx, "users", map[string]interface{}{
-		"id": name,
+		"id":        name,
+		"namespace": namespace.FromContext(ctx),
 	})
 	if err != nil {
 		return nil, err
@@ -99,7 +195,7 @@ func (s *Store) Get(ctx context.Context, name string) (*v1alpha1.User, error) {
 		return nil, errors.ErrUserNotFound
 	}
 
-	return mapToUser(results[0])
+	return s.toUser(results[0])
 }
 
 func (s *Store) Update(ctx context.Context, user *v1alpha1.User) error {
@@ -124,23 +220,82 @@ func (s *Store) Update(ctx context.Context, user *v1alpha1.User) error {
 	}
 
 	// 업데이트 데이터 생성
+	// is_active도 기록해야 가입 확인(ConfirmEmailChange)의 활성화가 반영됨
 	data := map[string]interface{}{
 		"username":   user.Spec.Username,
 		"email":      user.Spec.Email,
+		"is_active":  user.Status.Active,
 		"updated_at": time.Now(),
+		"updated_by": actor.FromContext(ctx),
 	}
 
-	// roles와 last_login 처리
+	// roles와 last_login 처리. roles는 비우는 경우도 반영되도록 항상 기록
 	if len(user.Spec.Roles) > 0 {
 		rolesJSON, err := json.Marshal(user.Spec.Roles)
 		if err != nil {
 			return err
 		}
 		data["roles"] = string(rolesJSON)
+	} else {
+		data["roles"] = nil
+	}
+
+	// 프로필 정보도 비우는 경우가 반영되도록 항상 기록
+	data["display_name"] = nullIfEmpty(user.Spec.DisplayName)
+	profile, extras := s.splitProfile(user.Spec.Profile)
+	if len(profile) > 0 {
+		profileJSON, err := json.Marshal(profile)
+		if err != nil {
+			return err
+		}
+		data["profile"] = string(profileJSON)
+	} else {
+		data["profile"] = nil
+	}
+	for column, value := range extras {
+		data[column] = value
+	}
+	if user.Spec.ActivationTime != nil {
+		data["activation_time"] = user.Spec.ActivationTime.Time
+	} else {
+		data["activation_time"] = nil
 	}
 	if user.Status.LastLogin != nil {
 		data["last_login"] = user.Status.LastLogin.Time
 	}
+	if len(user.Status.LoginHistory) > 0 {
+		historyJSON, err := json.Marshal(user.Status.LoginHistory)
+		if err != nil {
+			return err
+		}
+		data["login_history"] = string(historyJSON)
+	}
+
+	// 비밀번호 변경은 UpdateUser로 저장되므로 해시와 이력을 함께 기록 (빈 해시는 무시)
+	if user.Spec.PasswordHash != "" {
+		data["password_hash"] = user.Spec.PasswordHash
+	}
+	if len(user.Status.PasswordHistory) > 0 {
+		historyJSON, err := json.Marshal(user.Status.PasswordHistory)
+		if err != nil {
+			return err
+		}
+		data["password_history"] = string(historyJSON)
+	} else {
+		data["password_history"] = nil
+	}
+	if user.Status.PasswordChangedAt != nil {
+		data["password_changed_at"] = user.Status.PasswordChangedAt.Time
+	}
+
+	// 이메일 변경 대기 상태는 해제(빈 값)도 반영되어야 하므로 항상 기록
+	data["pending_email"] = nullIfEmpty(user.Status.PendingEmail)
+	data["email_change_token"] = nullIfEmpty(user.Status.EmailChangeTokenHash)
+	if user.Status.EmailChangeExpiry != nil {
+		data["email_change_expires"] = user.Status.EmailChangeExpiry.Time
+	} else {
+		data["email_change_expires"] = nil
+	}
 
 	// Annotations 처리
 	annotationsJSON, err := json.Marshal(user.Annotations)
@@ -149,15 +304,100 @@ func (s *Store) Update(ctx context.Context, user *v1alpha1.User) error {
 	}
 	data["annotations"] = string(annotationsJSON)
 
-	return s.dynamicStore.DynamicUpdate(ctx, "users", user.Name, data)
+	if err := s.checkUniqueFields(ctx, user.Name, data); err != nil {
+		return err
+	}
+
+	return s.dynamicStore.DynamicUpdateScoped(ctx, "users", user.Name, namespaceCondition(ctx), data)
 }
 
 func (s *Store) Delete(ctx context.Context, name string) error {
-	return s.dynamicStore.DynamicDelete(ctx, "users", name)
+	// 다른 namespace의 사용자는 삭제할 수 없음
+	if _, err := s.Get(ctx, name); err != nil {
+		return err
+	}
+	return s.dynamicStore.DynamicDeleteScoped(ctx, "users", name, namespaceCondition(ctx))
 }
 
 func (s *Store) List(ctx context.Context) (*v1alpha1.UserList, error) {
-	results, err := s.dynamicStore.DynamicSelect(ctx, "users", nil)
+	results, err := s.dynamicStore.DynamicSelectOrdered(ctx, "users", namespaceCondition(ctx), dynamic.DefaultListOrder)
+	if err != nil {
+		return nil, err
+	}
+
+	userList := &v1alpha1.UserList{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "UserList",
+			APIVersion: "auth.service/v1alpha1",
+		},
+	}
+
+	for _, result := range results {
+		user, err := s.toUser(result)
+		if err != nil {
+			return nil, err
+		}
+		userList.Items = append(userList.Items, user)
+	}
+
+	return userList, nil
+}
+
+// userFieldColumns maps API field names to users table columns.
+// password_hash는 의도적으로 제외 - 필드 선택으로는 절대 조회할 수 없음
+var userFieldColumns = map[string]string{
+	"name":              "id",
+	"creationTimestamp": "created_at",
+	"annotations":       "annotations",
+	"username":          "username",
+	"email":             "email",
+	"roles":             "roles",
+	"displayName":       "display_name",
+	"profile":           "profile",
+	"active":            "is_active",
+	"lastLogin":         "last_login",
+	"loginHistory":      "login_history",
+	"activationTime":    "activation_time",
+}
+
+// ListWithOptions lists users selecting only the columns backing opts.Fields.
+// opts.Limit/opts.Cursor가 지정되면 id 순으로 한 페이지만 반환하고, 다음 페이지가 있으면 Continue에 cursor를 설정
+func (s *Store) ListWithOptions(ctx context.Context, opts v1alpha1.ListOptions) (*v1alpha1.UserList, error) {
+	if len(opts.Fields) == 0 && !opts.Paged() {
+		return s.List(ctx)
+	}
+
+	params := query.QueryParams{
+		Where: []query.WhereCondition{
+			{Column: "deleted_at", Operator: "IS", Value: nil},
+			{Column: "namespace", Operator: "=", Value: namespace.FromContext(ctx)},
+		},
+	}
+	if len(opts.Fields) > 0 {
+		params.SelectColumns = []string{"id", "namespace"} // 식별을 위해 항상 포함
+		for _, field := range opts.Fields {
+			column, ok := userFieldColumns[field]
+			if !ok {
+				return nil, fmt.Errorf("unsupported field: %s", field)
+			}
+			if column != "id" {
+				params.SelectColumns = append(params.SelectColumns, column)
+			}
+		}
+	}
+	if opts.Paged() {
+		params.OrderBy = []query.OrderByClause{{Column: "id"}}
+		params.Limit = opts.Limit
+		if opts.Cursor != "" {
+			after, err := cursor.Decode(opts.Cursor)
+			if err != nil {
+				return nil, err
+			}
+			params.Where = append(params.Where, query.WhereCondition{Column: "id", Operator: ">", Value: after.ID})
+		}
+	}
+
+	results, err := s.dynamicStore.DynamicQuery(ctx, "users", params)
 	if err != nil {
 		return nil, err
 	}
@@ -170,36 +410,68 @@ func (s *Store) List(ctx context.Context) (*v1alpha1.UserList, error) {
 	}
 
 	for _, result := range results {
-		user, err := mapToUser(result)
+		user, err := s.toUser(result)
 		if err != nil {
 			return nil, err
 		}
 		userList.Items = append(userList.Items, user)
 	}
 
+	// 페이지가 가득 찼을 때만 다음 cursor를 반환 (마지막 페이지는 Continue가 비어 있음)
+	if opts.Limit > 0 && len(userList.Items) == opts.Limit {
+		userList.Continue = cursor.Encode(cursor.Cursor{ID: userList.Items[len(userList.Items)-1].Name})
+	}
+
 	return userList, nil
 }
 
+// streamBatchSize is the number of rows StreamUsers reads per query
+const streamBatchSize = 500
+
+// StreamUsers calls fn for every user of the request namespace in name order.
+// id 기준 keyset pagination으로 한 번에 streamBatchSize개 행만 메모리에 유지하며, fn이 에러를 반환하면 중단함
+func (s *Store) StreamUsers(ctx context.Context, fn func(*v1alpha1.User) error) error {
+	opts := v1alpha1.ListOptions{Limit: streamBatchSize}
+	for {
+		page, err := s.ListWithOptions(ctx, opts)
+		if err != nil {
+			return err
+		}
+
+		for _, user := range page.Items {
+			if err := fn(user); err != nil {
+				return err
+			}
+		}
+
+		if page.Continue == "" {
+			return nil
+		}
+		opts.Cursor = page.Continue
+	}
+}
+
 func mapToUser(data map[string]interface{}) (*v1alpha1.User, error) {
+	// 필드 선택 시 일부 컬럼만 존재할 수 있으므로 타입 단언은 모두 comma-ok로 처리
 	user := &v1alpha1.User{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       "User",
 			APIVersion: "auth.service/v1alpha1",
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			Name:              data["id"].(string),
-			CreationTimestamp: metav1.Time{Time: data["created_at"].(time.Time)},
-			Annotations:       make(map[string]string),
-		},
-		Spec: v1alpha1.UserSpec{
-			Username:     data["username"].(string),
-			Email:        data["email"].(string),
-			PasswordHash: data["password_hash"].(string),
-		},
-		Status: v1alpha1.UserStatus{
-			Active: data["is_active"].(bool),
+			Annotations: make(map[string]string),
 		},
 	}
+	user.Name, _ = data["id"].(string)
+	user.Namespace, _ = data["namespace"].(string)
+	if createdAt, ok := data["created_at"].(time.Time); ok {
+		user.CreationTimestamp = metav1.Time{Time: createdAt}
+	}
+	user.Spec.Username, _ = data["username"].(string)
+	user.Spec.Email, _ = data["email"].(string)
+	user.Spec.PasswordHash, _ = data["password_hash"].(string)
+	user.Spec.DisplayName, _ = data["display_name"].(string)
+	user.Status.Active, _ = data["is_active"].(bool)
 
 	// Roles 처리
 	if roles, ok := data["roles"]; ok && roles != nil {
@@ -210,6 +482,19 @@ func mapToUser(data map[string]interface{}) (*v1alpha1.User, error) {
 		user.Spec.Roles = rolesList
 	}
 
+	// Profile 처리
+	if profile, ok := data["profile"].(string); ok && profile != "" {
+		var parsedProfile map[string]string
+		if err := json.Unmarshal([]byte(profile), &parsedProfile); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal profile: %w", err)
+		}
+		user.Spec.Profile = parsedProfile
+	}
+
+	if activation, ok := data["activation_time"].(time.Time); ok {
+		user.Spec.ActivationTime = &metav1.Time{Time: activation}
+	}
+
 	// LastLogin 처리
 	if lastLogin, ok := data["last_login"]; ok && lastLogin != nil {
 		lastLoginTime, ok := lastLogin.(time.Time)
@@ -218,6 +503,31 @@ func mapToUser(data map[string]interface{}) (*v1alpha1.User, error) {
 		}
 	}
 
+	// LoginHistory 처리
+	if history, ok := data["login_history"].(string); ok && history != "" {
+		var records []v1alpha1.LoginRecord
+		if err := json.Unmarshal([]byte(history), &records); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal login history: %w", err)
+		}
+		user.Status.LoginHistory = records
+	}
+
+	if history, ok := data["password_history"].(string); ok && history != "" {
+		if err := json.Unmarshal([]byte(history), &user.Status.PasswordHistory); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal password history: %w", err)
+		}
+	}
+	if changed, ok := data["password_changed_at"].(time.Time); ok {
+		user.Status.PasswordChangedAt = &metav1.Time{Time: changed}
+	}
+
+	// 이메일 변경 대기 상태 처리
+	user.Status.PendingEmail, _ = data["pending_email"].(string)
+	user.Status.EmailChangeTokenHash, _ = data["email_change_token"].(string)
+	if expires, ok := data["email_change_expires"].(time.Time); ok {
+		user.Status.EmailChangeExpiry = &metav1.Time{Time: expires}
+	}
+
 	// 사용자 정의 필드 (Annotations) 처리
 	if annotations, ok := data["annotations"]; ok && annotations != nil {
 		var parsedAnnotations map[string]string
@@ -227,12 +537,21 @@ func mapToUser(data map[string]interface{}) (*v1alpha1.User, error) {
 		user.Annotations = parsedAnnotations
 	}
 
+	// 생성자/수정자는 컬럼 값을 기준으로 annotation에 노출
+	if createdBy, ok := data["created_by"].(string); ok && createdBy != "" {
+		metav1.SetMetaDataAnnotation(&user.ObjectMeta, v1alpha1.AnnotationCreatedBy, createdBy)
+	}
+	if updatedBy, ok := data["updated_by"].(string); ok && updatedBy != "" {
+		metav1.SetMetaDataAnnotation(&user.ObjectMeta, v1alpha1.AnnotationUpdatedBy, updatedBy)
+	}
+
 	return user, nil
 }
 
 func (s *Store) FindByEmail(ctx context.Context, email string) (*v1alpha1.User, error) {
 	results, err := s.dynamicStore.DynamicSelect(ctx, "users", map[string]interface{}{
-		"email": email,
+		"email":     email,
+		"namespace": namespace.FromContext(ctx),
 	})
 	if err != nil {
 		return nil, err
@@ -241,12 +560,33 @@ func (s *Store) FindByEmail(ctx context.Context, email string) (*v1alpha1.User,
 		return nil, errors.ErrUserNotFound
 	}
 
-	return mapToUser(results[0])
+	return s.toUser(results[0])
 }
 
 func (s *Store) FindByUsername(ctx context.Context, username string) (*v1alpha1.User, error) {
 	results, err := s.dynamicStore.DynamicSelect(ctx, "users", map[string]interface{}{
-		"username": username,
+		"username":  username,
+		"namespace": namespace.FromContext(ctx),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(results) == 0 {
+		return nil, errors.ErrUserNotFound
+	}
+
+	return s.toUser(results[0])
+}
+
+// FindByEmailChangeToken returns the user with a pending email change for the given token hash
+func (s *Store) FindByEmailChangeToken(ctx context.Context, tokenHash string) (*v1alpha1.User, error) {
+	if tokenHash == "" {
+		return nil, errors.ErrUserNotFound
+	}
+
+	results, err := s.dynamicStore.DynamicSelect(ctx, "users", map[string]interface{}{
+		"email_change_token": tokenHash,
+		"namespace":          namespace.FromContext(ctx),
 	})
 	if err != nil {
 		return nil, err
@@ -255,29 +595,100 @@ func (s *Store) FindByUsername(ctx context.Context, username string) (*v1alpha1.
 		return nil, errors.ErrUserNotFound
 	}
 
-	return mapToUser(results[0])
+	return s.toUser(results[0])
+}
+
+// FindByDisplayNamePrefix returns up to limit users whose display name starts with prefix
+// (대소문자 구분 없음), ordered by display name. 자동완성(type-ahead) 검색용.
+func (s *Store) FindByDisplayNamePrefix(ctx context.Context, prefix string, limit int) (*v1alpha1.UserList, error) {
+	if limit < 0 {
+		return nil, fmt.Errorf("limit must not be negative")
+	}
+
+	results, err := s.dynamicStore.DynamicQuery(ctx, "users", query.QueryParams{
+		Where: []query.WhereCondition{
+			{Column: "display_name", Operator: "LIKE", Value: prefix + "%"},
+			{Column: "deleted_at", Operator: "IS", Value: nil},
+			{Column: "namespace", Operator: "=", Value: namespace.FromContext(ctx)},
+		},
+		OrderBy: []query.OrderByClause{{Column: "display_name"}},
+		Limit:   limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	userList := &v1alpha1.UserList{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "UserList",
+			APIVersion: "auth.service/v1alpha1",
+		},
+	}
+
+	lowerPrefix := strings.ToLower(prefix)
+	for _, result := range results {
+		user, err := s.toUser(result)
+		if err != nil {
+			return nil, err
+		}
+		// prefix에 포함된 %, _ 는 LIKE 와일드카드로 해석되므로 실제 접두사인지 다시 확인
+		if !strings.HasPrefix(strings.ToLower(user.Spec.DisplayName), lowerPrefix) {
+			continue
+		}
+		userList.Items = append(userList.Items, user)
+	}
+
+	return userList, nil
+}
+
+// Rename changes the user's id from oldName to newName.
+// username이 이전 이름과 같으면 함께 변경하여 로그인 이름도 유지되도록 함
+func (s *Store) Rename(ctx context.Context, oldName, newName string) error {
+	existing, err := s.Get(ctx, oldName)
+	if err != nil {
+		return err
+	}
+	if _, err := s.Get(ctx, newName); err == nil {
+		return fmt.Errorf("user '%s' already exists", newName)
+	}
+
+	data := map[string]interface{}{
+		"id":         newName,
+		"updated_at": time.Now(),
+		"updated_by": actor.FromContext(ctx),
+	}
+	if existing.Spec.Username == oldName {
+		if conflict, err := s.FindByUsername(ctx, newName); err == nil && conflict.Name != oldName {
+			return fmt.Errorf("username '%s' already exists", newName)
+		}
+		data["username"] = newName
+	}
+
+	return s.dynamicStore.DynamicUpdateScoped(ctx, "users", oldName, namespaceCondition(ctx), data)
 }
 
 func (s *Store) UpdatePassword(ctx context.Context, name string, hashedPassword string) error {
 	data := map[string]interface{}{
 		"password_hash": hashedPassword,
 		"updated_at":    time.Now(),
+		"updated_by":    actor.FromContext(ctx),
 	}
 
-	return s.dynamicStore.DynamicUpdate(ctx, "users", name, data)
+	return s.dynamicStore.DynamicUpdateScoped(ctx, "users", name, namespaceCondition(ctx), data)
 }
 
 func (s *Store) UpdateStatus(ctx context.Context, name string, active bool) error {
 	data := map[string]interface{}{
 		"is_active":  active,
 		"updated_at": time.Now(),
+		"updated_by": actor.FromContext(ctx),
 	}
 
-	return s.dynamicStore.DynamicUpdate(ctx, "users", name, data)
+	return s.dynamicStore.DynamicUpdateScoped(ctx, "users", name, namespaceCondition(ctx), data)
 }
 
 func (s *Store) ListByRole(ctx context.Context, roleName string) (*v1alpha1.UserList, error) {
-	results, err := s.dynamicStore.DynamicSelect(ctx, "users", nil)
+	results, err := s.dynamicStore.DynamicSelectOrdered(ctx, "users", namespaceCondition(ctx), dynamic.DefaultListOrder)
 	if err != nil {
 		return nil, err
 	}
@@ -290,7 +701,7 @@ func (s *Store) ListByRole(ctx context.Context, roleName string) (*v1alpha1.User
 	}
 
 	for _, result := range results {
-		user, err := mapToUser(result)
+		user, err := s.toUser(result)
 		if err != nil {
 			return nil, err
 		}
@@ -315,6 +726,20 @@ func (s *Store) ListByRole(ctx context.Context, roleName string) (*v1alpha1.User
 	return userList, nil
 }
 
+// namespaceCondition limits user lookups and writes to the request namespace.
+// id, username, email은 namespace 안에서만 유일하므로 모든 조회와 수정에 적용해야 함
+func namespaceCondition(ctx context.Context) map[string]interface{} {
+	return map[string]interface{}{"namespace": namespace.FromContext(ctx)}
+}
+
+// nullIfEmpty stores empty strings as NULL
+func nullIfEmpty(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
 func parseSchemaFields(schemaFields []string) map[string]schema.FieldType {
 	parsedFields := make(map[string]schema.FieldType)
 
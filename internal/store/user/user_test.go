@@ -3,14 +3,21 @@ package user
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"testing"
 	"time"
 
 	_ "github.com/mattn/go-sqlite3"
 	"github.com/stretchr/testify/assert"
 	"github.com/sukryu/pAuth/internal/store/dynamic"
+	"github.com/sukryu/pAuth/internal/store/dynamic/query"
 	"github.com/sukryu/pAuth/internal/store/manager"
+	"github.com/sukryu/pAuth/internal/store/schema"
 	"github.com/sukryu/pAuth/pkg/apis/auth/v1alpha1"
+	"github.com/sukryu/pAuth/pkg/errors"
+	"github.com/sukryu/pAuth/pkg/utils/actor"
+	"github.com/sukryu/pAuth/pkg/utils/cursor"
+	"github.com/sukryu/pAuth/pkg/utils/namespace"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -49,12 +56,24 @@ func setupTestDB(t *testing.T) (*sql.DB, *dynamic.DynamicStore) {
 	_, err = dbConn.Exec(`
         CREATE TABLE IF NOT EXISTS users (
             id TEXT PRIMARY KEY,
+            namespace TEXT NOT NULL DEFAULT 'default',
             username TEXT UNIQUE NOT NULL,
             email TEXT UNIQUE NOT NULL,
             password_hash TEXT NOT NULL,
+            display_name TEXT,
+            profile TEXT,
             roles TEXT,
             is_active BOOLEAN DEFAULT true,
             last_login TIMESTAMP,
+            login_history TEXT,
+            password_history TEXT,
+            password_changed_at TIMESTAMP,
+            pending_email TEXT,
+            email_change_token TEXT,
+            email_change_expires TIMESTAMP,
+            activation_time TIMESTAMP,
+            created_by TEXT,
+            updated_by TEXT,
 			annotations TEXT,
             created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
             updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
@@ -66,10 +85,7 @@ func setupTestDB(t *testing.T) (*sql.DB, *dynamic.DynamicStore) {
 	}
 
 	// DynamicStore 생성
-	store, err := dynamic.NewDynamicStore(manager)
-	if err != nil {
-		t.Fatalf("failed to create dynamic store: %v", err)
-	}
+	store := dynamic.NewDynamicStoreFromDB(dbConn)
 
 	return dbConn, store
 }
@@ -187,6 +203,20 @@ func TestUserStore_Get(t *testing.T) {
 		_, err := store.Get(ctx, "non-existent")
 		assert.Error(t, err)
 	})
+
+	t.Run("Returned user does not alias stored state", func(t *testing.T) {
+		found, err := store.Get(ctx, "test-user")
+		assert.NoError(t, err)
+		found.Spec.Email = "mutated@example.com"
+
+		list, err := store.List(ctx)
+		assert.NoError(t, err)
+		list.Items[0].Spec.Email = "mutated@example.com"
+
+		again, err := store.Get(ctx, "test-user")
+		assert.NoError(t, err)
+		assert.Equal(t, "test@example.com", again.Spec.Email)
+	})
 }
 
 func TestUserStore_Update(t *testing.T) {
@@ -224,6 +254,28 @@ func TestUserStore_Update(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, "HR", updated.Annotations["department"])
 	})
+
+	t.Run("Update login history", func(t *testing.T) {
+		user := createTestUser(t)
+		user.Name = "history-user"
+		user.Spec.Username = "historyuser"
+		user.Spec.Email = "history@example.com"
+		err := store.Create(ctx, user)
+		assert.NoError(t, err)
+
+		user.Status.LoginHistory = []v1alpha1.LoginRecord{
+			{Timestamp: metav1.Now(), IP: "10.0.0.1", UserAgent: "test-agent"},
+		}
+		err = store.Update(ctx, user)
+		assert.NoError(t, err)
+
+		updated, err := store.Get(ctx, user.Name)
+		assert.NoError(t, err)
+		if assert.Len(t, updated.Status.LoginHistory, 1) {
+			assert.Equal(t, "10.0.0.1", updated.Status.LoginHistory[0].IP)
+			assert.Equal(t, "test-agent", updated.Status.LoginHistory[0].UserAgent)
+		}
+	})
 }
 
 func TestUserStore_FindByEmail(t *testing.T) {
@@ -343,3 +395,571 @@ func TestUserStore_ListByRole(t *testing.T) {
 		assert.Len(t, users.Items, 0)
 	})
 }
+
+func TestUserStore_ListWithOptions(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	user := createTestUser(t)
+	user.Spec.Roles = []string{"admin"}
+	assert.NoError(t, store.Create(ctx, user))
+
+	t.Run("Only requested fields are populated", func(t *testing.T) {
+		users, err := store.ListWithOptions(ctx, v1alpha1.ListOptions{Fields: []string{"username", "email"}})
+		assert.NoError(t, err)
+		if assert.Len(t, users.Items, 1) {
+			got := users.Items[0]
+			assert.Equal(t, user.Name, got.Name)
+			assert.Equal(t, user.Spec.Username, got.Spec.Username)
+			assert.Equal(t, user.Spec.Email, got.Spec.Email)
+			assert.Empty(t, got.Spec.PasswordHash)
+			assert.Nil(t, got.Spec.Roles)
+			assert.True(t, got.CreationTimestamp.IsZero())
+		}
+	})
+
+	t.Run("Password hash cannot be selected", func(t *testing.T) {
+		for _, field := range []string{"passwordHash", "password_hash"} {
+			_, err := store.ListWithOptions(ctx, v1alpha1.ListOptions{Fields: []string{field}})
+			assert.Error(t, err)
+		}
+	})
+
+	t.Run("No fields lists everything", func(t *testing.T) {
+		users, err := store.ListWithOptions(ctx, v1alpha1.ListOptions{})
+		assert.NoError(t, err)
+		if assert.Len(t, users.Items, 1) {
+			assert.Equal(t, []string{"admin"}, users.Items[0].Spec.Roles)
+		}
+	})
+}
+
+func TestUserStore_PendingEmailChange(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	user := createTestUser(t)
+	assert.NoError(t, store.Create(ctx, user))
+
+	expiry := metav1.NewTime(time.Now().Add(time.Hour).Truncate(time.Second))
+	user.Status.PendingEmail = "new@example.com"
+	user.Status.EmailChangeTokenHash = "token-hash"
+	user.Status.EmailChangeExpiry = &expiry
+	assert.NoError(t, store.Update(ctx, user))
+
+	found, err := store.FindByEmailChangeToken(ctx, "token-hash")
+	assert.NoError(t, err)
+	assert.Equal(t, user.Name, found.Name)
+	assert.Equal(t, "new@example.com", found.Status.PendingEmail)
+	if assert.NotNil(t, found.Status.EmailChangeExpiry) {
+		assert.True(t, expiry.Time.Equal(found.Status.EmailChangeExpiry.Time))
+	}
+
+	// 대기 상태 해제
+	found.Status.PendingEmail = ""
+	found.Status.EmailChangeTokenHash = ""
+	found.Status.EmailChangeExpiry = nil
+	assert.NoError(t, store.Update(ctx, found))
+
+	_, err = store.FindByEmailChangeToken(ctx, "token-hash")
+	assert.Equal(t, errors.ErrUserNotFound, err)
+
+	cleared, err := store.Get(ctx, user.Name)
+	assert.NoError(t, err)
+	assert.Empty(t, cleared.Status.PendingEmail)
+	assert.Nil(t, cleared.Status.EmailChangeExpiry)
+}
+
+func TestUserStore_UpdateClearsRoles(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	user := createTestUser(t)
+	user.Spec.Roles = []string{"admin"}
+	assert.NoError(t, store.Create(ctx, user))
+
+	user.Spec.Roles = nil
+	assert.NoError(t, store.Update(ctx, user))
+
+	updated, err := store.Get(ctx, user.Name)
+	assert.NoError(t, err)
+	assert.Empty(t, updated.Spec.Roles)
+}
+
+func TestUserStore_Attribution(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	t.Run("actor recorded on create and update", func(t *testing.T) {
+		user := createTestUser(t)
+		assert.NoError(t, store.Create(actor.WithActor(context.Background(), "alice"), user))
+
+		created, err := store.Get(context.Background(), user.Name)
+		assert.NoError(t, err)
+		assert.Equal(t, "alice", created.Annotations[v1alpha1.AnnotationCreatedBy])
+		assert.Equal(t, "alice", created.Annotations[v1alpha1.AnnotationUpdatedBy])
+
+		created.Spec.Email = "changed@example.com"
+		assert.NoError(t, store.Update(actor.WithActor(context.Background(), "bob"), created))
+
+		updated, err := store.Get(context.Background(), user.Name)
+		assert.NoError(t, err)
+		assert.Equal(t, "alice", updated.Annotations[v1alpha1.AnnotationCreatedBy])
+		assert.Equal(t, "bob", updated.Annotations[v1alpha1.AnnotationUpdatedBy])
+	})
+
+	t.Run("system when no actor", func(t *testing.T) {
+		user := createTestUser(t)
+		user.Name = "bootstrap-admin"
+		user.Spec.Username = "bootstrap-admin"
+		user.Spec.Email = "bootstrap@example.com"
+		assert.NoError(t, store.Create(context.Background(), user))
+
+		created, err := store.Get(context.Background(), user.Name)
+		assert.NoError(t, err)
+		assert.Equal(t, actor.System, created.Annotations[v1alpha1.AnnotationCreatedBy])
+	})
+}
+
+func TestUserStore_DisplayName(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	for i, displayName := range []string{"Alice Kim", "alex_park", "Bob Lee", "Alicia Keys"} {
+		user := createTestUser(t)
+		user.Name = fmt.Sprintf("user-%d", i)
+		user.Spec.Username = user.Name
+		user.Spec.Email = user.Name + "@example.com"
+		user.Spec.DisplayName = displayName
+		user.Spec.Profile = map[string]string{"locale": "ko-KR"}
+		assert.NoError(t, store.Create(ctx, user))
+	}
+
+	t.Run("persisted on create", func(t *testing.T) {
+		user, err := store.Get(ctx, "user-0")
+		assert.NoError(t, err)
+		assert.Equal(t, "Alice Kim", user.Spec.DisplayName)
+		assert.Equal(t, map[string]string{"locale": "ko-KR"}, user.Spec.Profile)
+	})
+
+	t.Run("search by prefix", func(t *testing.T) {
+		list, err := store.FindByDisplayNamePrefix(ctx, "ali", 0)
+		assert.NoError(t, err)
+		names := make([]string, 0, len(list.Items))
+		for _, u := range list.Items {
+			names = append(names, u.Spec.DisplayName)
+		}
+		assert.Equal(t, []string{"Alice Kim", "Alicia Keys"}, names)
+
+		list, err = store.FindByDisplayNamePrefix(ctx, "al", 1)
+		assert.NoError(t, err)
+		assert.Len(t, list.Items, 1)
+	})
+
+	t.Run("wildcards in prefix are literal", func(t *testing.T) {
+		list, err := store.FindByDisplayNamePrefix(ctx, "al_", 0)
+		assert.NoError(t, err)
+		assert.Empty(t, list.Items)
+
+		list, err = store.FindByDisplayNamePrefix(ctx, "alex_", 0)
+		assert.NoError(t, err)
+		assert.Len(t, list.Items, 1)
+	})
+
+	t.Run("cleared on update", func(t *testing.T) {
+		user, err := store.Get(ctx, "user-2")
+		assert.NoError(t, err)
+		user.Spec.DisplayName = ""
+		user.Spec.Profile = nil
+		assert.NoError(t, store.Update(ctx, user))
+
+		updated, err := store.Get(ctx, "user-2")
+		assert.NoError(t, err)
+		assert.Empty(t, updated.Spec.DisplayName)
+		assert.Nil(t, updated.Spec.Profile)
+	})
+}
+
+func TestUserStore_Namespace(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+	tenantA := namespace.WithNamespace(context.Background(), "tenant-a")
+	tenantB := namespace.WithNamespace(context.Background(), "tenant-b")
+
+	alice := createTestUser(t)
+	alice.Name = "alice"
+	alice.Spec.Username = "alice"
+	alice.Spec.Email = "alice@example.com"
+	assert.NoError(t, store.Create(tenantA, alice))
+
+	bob := createTestUser(t)
+	bob.Name = "bob"
+	bob.Spec.Username = "bob"
+	bob.Spec.Email = "bob@example.com"
+	assert.NoError(t, store.Create(tenantB, bob))
+
+	got, err := store.Get(tenantA, "alice")
+	assert.NoError(t, err)
+	assert.Equal(t, "tenant-a", got.Namespace)
+
+	// 다른 namespace의 사용자는 조회/삭제 불가
+	_, err = store.Get(tenantA, "bob")
+	assert.ErrorIs(t, err, errors.ErrUserNotFound)
+	assert.Error(t, store.Delete(tenantA, "bob"))
+
+	list, err := store.List(tenantB)
+	assert.NoError(t, err)
+	if assert.Len(t, list.Items, 1) {
+		assert.Equal(t, "bob", list.Items[0].Name)
+	}
+
+	byRole, err := store.ListByRole(tenantA, "role1")
+	assert.NoError(t, err)
+	assert.Len(t, byRole.Items, 1)
+
+	// namespace가 없는 요청은 기본 namespace를 사용
+	list, err = store.List(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, list.Items)
+}
+
+func TestUserStore_QueryLastLoginIsNull(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	active := createTestUser(t)
+	active.Name = "active"
+	active.Spec.Username = "active"
+	active.Spec.Email = "active@example.com"
+	assert.NoError(t, store.Create(ctx, active))
+
+	never := createTestUser(t)
+	never.Name = "never"
+	never.Spec.Username = "never"
+	never.Spec.Email = "never@example.com"
+	never.Status.LastLogin = nil
+	assert.NoError(t, store.Create(ctx, never))
+
+	usernames := func(op string) []string {
+		rows, err := store.dynamicStore.DynamicQuery(ctx, "users", query.QueryParams{
+			SelectColumns: []string{"username"},
+			Where: []query.WhereCondition{
+				{Column: "last_login", Operator: op},
+				{Column: "deleted_at", Operator: query.OpIsNull},
+			},
+			OrderBy: []query.OrderByClause{{Column: "username"}},
+		})
+		assert.NoError(t, err)
+		var names []string
+		for _, row := range rows {
+			names = append(names, row["username"].(string))
+		}
+		return names
+	}
+
+	assert.Equal(t, []string{"never"}, usernames(query.OpIsNull))
+	assert.Equal(t, []string{"active"}, usernames(query.OpIsNotNull))
+}
+
+func TestUserStore_ExtraFields(t *testing.T) {
+	dbConn, dynStore := setupTestDB(t)
+	defer dbConn.Close()
+	ctx := context.Background()
+
+	t.Run("collision with core field", func(t *testing.T) {
+		_, err := NewStore(dynStore, Config{ExtraFields: []schema.FieldDef{{Name: "email", Type: schema.FieldTypeString, Nullable: true}}})
+		assert.ErrorContains(t, err, "collides")
+
+		_, err = NewStore(dynStore, Config{ExtraFields: []schema.FieldDef{{Name: "badge", Type: schema.FieldTypeString}}})
+		assert.ErrorContains(t, err, "nullable")
+	})
+
+	store, err := NewStore(dynStore, Config{
+		DatabaseType: "sqlite",
+		ExtraFields:  []schema.FieldDef{{Name: "employee_id", Type: schema.FieldTypeString, Nullable: true}},
+	})
+	assert.NoError(t, err)
+
+	user := createTestUser(t)
+	user.Spec.Profile = map[string]string{"employee_id": "E-1001", "team": "platform"}
+	assert.NoError(t, store.Create(ctx, user))
+
+	// 추가 컬럼에 저장되고 profile JSON에는 중복 저장되지 않음
+	var employeeID, profile string
+	assert.NoError(t, dbConn.QueryRow("SELECT employee_id, profile FROM users WHERE id = ?", user.Name).Scan(&employeeID, &profile))
+	assert.Equal(t, "E-1001", employeeID)
+	assert.JSONEq(t, `{"team":"platform"}`, profile)
+
+	got, err := store.Get(ctx, user.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"employee_id": "E-1001", "team": "platform"}, got.Spec.Profile)
+
+	delete(got.Spec.Profile, "employee_id")
+	assert.NoError(t, store.Update(ctx, got))
+	got, err = store.Get(ctx, user.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"team": "platform"}, got.Spec.Profile)
+}
+
+func TestUserStore_UniqueFields(t *testing.T) {
+	dbConn, dynStore := setupTestDB(t)
+	defer dbConn.Close()
+	ctx := context.Background()
+	t.Cleanup(func() { errors.ErrUserExists.Reason = "" })
+
+	phone := []schema.FieldDef{{Name: "phone", Type: schema.FieldTypeString, Nullable: true}}
+
+	_, err := NewStore(dynStore, Config{ExtraFields: phone, UniqueFields: []string{"fax"}})
+	assert.ErrorContains(t, err, "unknown unique field")
+
+	store, err := NewStore(dynStore, Config{
+		DatabaseType: "sqlite",
+		ExtraFields:  phone,
+		UniqueFields: []string{"phone"},
+	})
+	assert.NoError(t, err)
+
+	first := createTestUser(t)
+	first.Spec.Profile = map[string]string{"phone": "+82-10-1234-5678"}
+	assert.NoError(t, store.Create(ctx, first))
+
+	second := createTestUser(t)
+	second.Name = "second-user"
+	second.Spec.Username = "second"
+	second.Spec.Email = "second@example.com"
+	second.Spec.Profile = map[string]string{"phone": "+82-10-1234-5678"}
+
+	t.Run("duplicate on create", func(t *testing.T) {
+		err := store.Create(ctx, second)
+		assert.Equal(t, errors.ErrUserExists, err)
+		assert.ErrorContains(t, err, "phone")
+	})
+
+	t.Run("duplicate on update", func(t *testing.T) {
+		second.Spec.Profile = map[string]string{"phone": "+82-10-9999-0000"}
+		assert.NoError(t, store.Create(ctx, second))
+
+		second.Spec.Profile["phone"] = "+82-10-1234-5678"
+		err := store.Update(ctx, second)
+		assert.Equal(t, errors.ErrUserExists, err)
+		assert.ErrorContains(t, err, "phone")
+	})
+
+	t.Run("own value is not a conflict", func(t *testing.T) {
+		got, err := store.Get(ctx, first.Name)
+		assert.NoError(t, err)
+		got.Spec.DisplayName = "First"
+		assert.NoError(t, store.Update(ctx, got))
+	})
+}
+
+func TestUserStore_StreamUsers(t *testing.T) {
+	dbConn, dynStore := setupTestDB(t)
+	defer dbConn.Close()
+	ctx := context.Background()
+
+	store, err := NewStore(dynStore, Config{DatabaseType: "sqlite"})
+	assert.NoError(t, err)
+
+	// 여러 배치에 걸치도록 streamBatchSize의 배수가 아닌 수를 사용
+	const total = 3*streamBatchSize + 17
+	for i := 0; i < total; i++ {
+		user := createTestUser(t)
+		user.Name = fmt.Sprintf("user-%05d", i)
+		user.Spec.Username = user.Name
+		user.Spec.Email = user.Name + "@example.com"
+		assert.NoError(t, store.Create(ctx, user))
+	}
+
+	seen := make(map[string]int, total)
+	err = store.StreamUsers(ctx, func(user *v1alpha1.User) error {
+		seen[user.Name]++
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Len(t, seen, total)
+	for name, count := range seen {
+		if count != 1 {
+			t.Errorf("user %s streamed %d times", name, count)
+		}
+	}
+
+	t.Run("callback error stops the stream", func(t *testing.T) {
+		calls := 0
+		err := store.StreamUsers(ctx, func(*v1alpha1.User) error {
+			calls++
+			return fmt.Errorf("stop")
+		})
+		assert.EqualError(t, err, "stop")
+		assert.Equal(t, 1, calls)
+	})
+}
+
+func TestUserStore_ListWithCursor(t *testing.T) {
+	dbConn, dynStore := setupTestDB(t)
+	defer dbConn.Close()
+	ctx := context.Background()
+
+	store, err := NewStore(dynStore, Config{DatabaseType: "sqlite"})
+	assert.NoError(t, err)
+
+	create := func(name string) {
+		user := createTestUser(t)
+		user.Name = name
+		user.Spec.Username = name
+		user.Spec.Email = name + "@example.com"
+		assert.NoError(t, store.Create(ctx, user))
+	}
+	for _, name := range []string{"user-b", "user-d", "user-f", "user-h"} {
+		create(name)
+	}
+
+	var names []string
+	opts := v1alpha1.ListOptions{Limit: 2}
+	for page := 0; ; page++ {
+		list, err := store.ListWithOptions(ctx, opts)
+		assert.NoError(t, err)
+		for _, user := range list.Items {
+			names = append(names, user.Name)
+		}
+
+		if page == 0 {
+			// 페이지 사이의 insert: 이미 지난 위치(user-a)는 건너뛰고 앞으로 올 위치(user-e)는 포함되어야 함
+			create("user-a")
+			create("user-e")
+		}
+		if list.Continue == "" {
+			break
+		}
+		opts.Cursor = list.Continue
+	}
+	assert.Equal(t, []string{"user-b", "user-d", "user-e", "user-f", "user-h"}, names)
+
+	_, err = store.ListWithOptions(ctx, v1alpha1.ListOptions{Limit: 2, Cursor: "garbage"})
+	assert.ErrorIs(t, err, cursor.ErrInvalidCursor)
+}
+
+func TestUserStore_ActivationTime(t *testing.T) {
+	dbConn, dynStore := setupTestDB(t)
+	defer dbConn.Close()
+	ctx := context.Background()
+
+	store, err := NewStore(dynStore, Config{DatabaseType: "sqlite"})
+	assert.NoError(t, err)
+
+	activation := time.Date(2030, 1, 1, 9, 0, 0, 0, time.UTC)
+	user := createTestUser(t)
+	user.Spec.ActivationTime = &metav1.Time{Time: activation}
+	assert.NoError(t, store.Create(ctx, user))
+
+	got, err := store.Get(ctx, user.Name)
+	assert.NoError(t, err)
+	if assert.NotNil(t, got.Spec.ActivationTime) {
+		assert.True(t, got.Spec.ActivationTime.Time.Equal(activation))
+	}
+
+	// nil로 업데이트하면 활성화 시각이 해제되어야 함
+	got.Spec.ActivationTime = nil
+	assert.NoError(t, store.Update(ctx, got))
+
+	got, err = store.Get(ctx, user.Name)
+	assert.NoError(t, err)
+	assert.Nil(t, got.Spec.ActivationTime)
+}
+
+func TestUserStore_Rename(t *testing.T) {
+	dbConn, dynStore := setupTestDB(t)
+	defer dbConn.Close()
+	ctx := context.Background()
+
+	store, err := NewStore(dynStore, Config{DatabaseType: "sqlite"})
+	assert.NoError(t, err)
+
+	user := createTestUser(t)
+	user.Name = "alice"
+	user.Spec.Username = "alice"
+	user.Spec.Email = "alice@example.com"
+	assert.NoError(t, store.Create(ctx, user))
+
+	other := createTestUser(t)
+	other.Name = "bob"
+	other.Spec.Username = "bob"
+	other.Spec.Email = "bob@example.com"
+	assert.NoError(t, store.Create(ctx, other))
+
+	assert.NoError(t, store.Rename(ctx, "alice", "alice2"))
+
+	_, err = store.Get(ctx, "alice")
+	assert.Error(t, err)
+	renamed, err := store.Get(ctx, "alice2")
+	assert.NoError(t, err)
+	assert.Equal(t, "alice2", renamed.Spec.Username)
+	assert.Equal(t, "alice@example.com", renamed.Spec.Email)
+
+	assert.Error(t, store.Rename(ctx, "alice2", "bob"))
+}
+
+func TestUserStore_PasswordHistory(t *testing.T) {
+	dbConn, dynStore := setupTestDB(t)
+	defer dbConn.Close()
+	ctx := context.Background()
+
+	store, err := NewStore(dynStore, Config{DatabaseType: "sqlite"})
+	assert.NoError(t, err)
+
+	user := createTestUser(t)
+	assert.NoError(t, store.Create(ctx, user))
+
+	previous := user.Spec.PasswordHash
+	user.Spec.PasswordHash = "new-hash"
+	user.Status.PasswordHistory = []string{previous}
+	changed := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	user.Status.PasswordChangedAt = &metav1.Time{Time: changed}
+	assert.NoError(t, store.Update(ctx, user))
+
+	saved, err := store.Get(ctx, user.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, "new-hash", saved.Spec.PasswordHash)
+	assert.Equal(t, []string{previous}, saved.Status.PasswordHistory)
+	if assert.NotNil(t, saved.Status.PasswordChangedAt) {
+		assert.True(t, changed.Equal(saved.Status.PasswordChangedAt.Time))
+	}
+}
+
+func TestUserStore_ListOrder(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, u := range []struct {
+		name    string
+		created time.Time
+	}{
+		{"carol", base},
+		{"alice", base.Add(time.Second)},
+		{"bob", base}, // created_at이 같으면 id 순
+	} {
+		user := createTestUser(t)
+		user.Name = u.name
+		user.Spec.Username = u.name
+		user.Spec.Email = u.name + "@example.com"
+		user.CreationTimestamp = metav1.NewTime(u.created)
+		assert.NoError(t, store.Create(ctx, user))
+	}
+
+	for i := 0; i < 3; i++ {
+		users, err := store.List(ctx)
+		assert.NoError(t, err)
+		names := make([]string, 0, len(users.Items))
+		for _, user := range users.Items {
+			names = append(names, user.Name)
+		}
+		assert.Equal(t, []string{"bob", "carol", "alice"}, names)
+	}
+}
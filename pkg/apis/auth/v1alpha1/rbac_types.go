@@ -10,6 +10,9 @@ type Role struct {
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
 	Rules []PolicyRule `json:"rules"`
+
+	// Inherits names roles in the same namespace whose rules this role also grants, transitively
+	Inherits []string `json:"inherits,omitempty"`
 }
 
 // PolicyRule 정의
@@ -26,18 +29,107 @@ type RoleBinding struct {
 
 	Subjects []Subject `json:"subjects"`
 	RoleRef  RoleRef   `json:"roleRef"`
+
+	// RoleRefs binds additional roles to the same subjects. RoleRef와 함께 지정하면 둘 다 적용됨
+	RoleRefs []RoleRef `json:"roleRefs,omitempty"`
+}
+
+// AllRoleRefs returns RoleRef followed by RoleRefs, skipping empty and duplicate names
+func (in *RoleBinding) AllRoleRefs() []RoleRef {
+	refs := make([]RoleRef, 0, len(in.RoleRefs)+1)
+	seen := make(map[string]bool, len(in.RoleRefs)+1)
+	for _, ref := range append([]RoleRef{in.RoleRef}, in.RoleRefs...) {
+		if ref.Name == "" || seen[ref.Name] {
+			continue
+		}
+		seen[ref.Name] = true
+		refs = append(refs, ref)
+	}
+	return refs
+}
+
+// ReferencesRole reports whether the binding binds the role name
+func (in *RoleBinding) ReferencesRole(name string) bool {
+	for _, ref := range in.AllRoleRefs() {
+		if ref.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ClusterRole 정의. namespace에 속하지 않으며 모든 namespace에서 적용됨
+type ClusterRole struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Rules []PolicyRule `json:"rules"`
+}
+
+// ClusterRoleBinding 정의. ClusterRole을 모든 namespace에 걸쳐 subject에 부여
+type ClusterRoleBinding struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Subjects []Subject `json:"subjects"`
+	RoleRef  RoleRef   `json:"roleRef"`
 }
 
 type Subject struct {
-	Kind string `json:"kind"` // User, Group
+	Kind string `json:"kind"` // User, Group, ServiceAccount
 	Name string `json:"name"`
 }
 
+// Supported Subject kinds
+const (
+	SubjectKindUser           = "User"
+	SubjectKindGroup          = "Group"
+	SubjectKindServiceAccount = "ServiceAccount"
+)
+
+// Supported RoleRef kinds
+const (
+	RoleRefKindRole        = "Role"
+	RoleRefKindClusterRole = "ClusterRole"
+)
+
 type RoleRef struct {
-	Kind string `json:"kind"` // Role
+	Kind string `json:"kind"` // Role, ClusterRole
 	Name string `json:"name"`
 }
 
+// AccessCheck describes a single permission to evaluate for the caller
+type AccessCheck struct {
+	Verb     string `json:"verb"`
+	Resource string `json:"resource"`
+	APIGroup string `json:"apiGroup"`
+}
+
+// AccessCheckResult is the outcome of an AccessCheck
+type AccessCheckResult struct {
+	AccessCheck `json:",inline"`
+	Allowed     bool `json:"allowed"`
+}
+
+// SubjectAccessCheck is an AccessCheck evaluated for an explicit subject instead of the caller
+type SubjectAccessCheck struct {
+	Subject     Subject `json:"subject"`
+	AccessCheck `json:",inline"`
+}
+
+// SubjectAccessCheckResult is the outcome of a SubjectAccessCheck
+type SubjectAccessCheckResult struct {
+	Subject           Subject `json:"subject"`
+	AccessCheckResult `json:",inline"`
+}
+
+// EffectivePermissions lists the roles bound to a subject and the rules they grant
+type EffectivePermissions struct {
+	Roles        []string     `json:"roles"`
+	ClusterRoles []string     `json:"clusterRoles,omitempty"`
+	Rules        []PolicyRule `json:"rules"`
+}
+
 // DeepCopyInto copies the receiver into out
 func (in *Role) DeepCopyInto(out *Role) {
 	*out = *in
@@ -50,6 +142,10 @@ func (in *Role) DeepCopyInto(out *Role) {
 			in.Rules[i].DeepCopyInto(&out.Rules[i])
 		}
 	}
+	if in.Inherits != nil {
+		out.Inherits = make([]string, len(in.Inherits))
+		copy(out.Inherits, in.Inherits)
+	}
 }
 
 // DeepCopy creates a deep copy of Role
@@ -92,6 +188,10 @@ func (in *RoleBinding) DeepCopyInto(out *RoleBinding) {
 			in.Subjects[i].DeepCopyInto(&out.Subjects[i])
 		}
 	}
+	if in.RoleRefs != nil {
+		out.RoleRefs = make([]RoleRef, len(in.RoleRefs))
+		copy(out.RoleRefs, in.RoleRefs)
+	}
 }
 
 // DeepCopy creates a deep copy of RoleBinding
@@ -104,6 +204,55 @@ func (in *RoleBinding) DeepCopy() *RoleBinding {
 	return out
 }
 
+// DeepCopyInto copies the receiver into out
+func (in *ClusterRole) DeepCopyInto(out *ClusterRole) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+
+	if in.Rules != nil {
+		out.Rules = make([]PolicyRule, len(in.Rules))
+		for i := range in.Rules {
+			in.Rules[i].DeepCopyInto(&out.Rules[i])
+		}
+	}
+}
+
+// DeepCopy creates a deep copy of ClusterRole
+func (in *ClusterRole) DeepCopy() *ClusterRole {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterRole)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies ClusterRoleBinding into out
+func (in *ClusterRoleBinding) DeepCopyInto(out *ClusterRoleBinding) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.RoleRef = in.RoleRef
+
+	if in.Subjects != nil {
+		out.Subjects = make([]Subject, len(in.Subjects))
+		for i := range in.Subjects {
+			in.Subjects[i].DeepCopyInto(&out.Subjects[i])
+		}
+	}
+}
+
+// DeepCopy creates a deep copy of ClusterRoleBinding
+func (in *ClusterRoleBinding) DeepCopy() *ClusterRoleBinding {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterRoleBinding)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto copies Subject into out
 func (in *Subject) DeepCopyInto(out *Subject) {
 	*out = *in
@@ -4,6 +4,12 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// Attribution annotations populated by the stores from the columns created_by / updated_by
+const (
+	AnnotationCreatedBy = "auth.service/created-by"
+	AnnotationUpdatedBy = "auth.service/updated-by"
+)
+
 // User defines the user resource
 type User struct {
 	metav1.TypeMeta   `json:",inline"`
@@ -18,11 +24,55 @@ type UserSpec struct {
 	Email        string   `json:"email"`
 	PasswordHash string   `json:"passwordHash,omitempty"`
 	Roles        []string `json:"roles,omitempty"`
+
+	// DisplayName is the human-friendly name shown by UIs
+	DisplayName string `json:"displayName,omitempty"`
+	// Profile holds free-form profile metadata (avatar URL, locale 등)
+	Profile map[string]string `json:"profile,omitempty"`
+
+	// ActivationTime is when the user may first log in; nil이면 즉시 로그인 가능
+	ActivationTime *metav1.Time `json:"activationTime,omitempty"`
 }
 
 type UserStatus struct {
-	Active    bool         `json:"active"`
-	LastLogin *metav1.Time `json:"lastLogin,omitempty"`
+	Active       bool          `json:"active"`
+	LastLogin    *metav1.Time  `json:"lastLogin,omitempty"`
+	LoginHistory []LoginRecord `json:"loginHistory,omitempty"`
+
+	// PendingEmail is the address awaiting verification via ConfirmEmailChange
+	PendingEmail string `json:"pendingEmail,omitempty"`
+	// 검증 토큰의 해시와 만료 시각. 내부 전용으로 API 응답에 포함되지 않음
+	EmailChangeTokenHash string       `json:"-"`
+	EmailChangeExpiry    *metav1.Time `json:"-"`
+
+	// PasswordHistory holds the bcrypt hashes of previous passwords, newest first. 내부 전용
+	PasswordHistory []string `json:"-"`
+	// PasswordChangedAt is when the password was last changed; 이보다 먼저 발급된 refresh token은 거부됨. 내부 전용
+	PasswordChangedAt *metav1.Time `json:"-"`
+}
+
+// LoginRecord describes a single successful sign-in
+type LoginRecord struct {
+	Timestamp metav1.Time `json:"timestamp"`
+	IP        string      `json:"ip,omitempty"`
+	UserAgent string      `json:"userAgent,omitempty"`
+}
+
+// ListOptions restricts what a list call returns
+type ListOptions struct {
+	// Fields limits the populated fields to the given JSON field names (e.g. "username", "email").
+	// 비어있으면 모든 필드를 반환
+	Fields []string `json:"fields,omitempty"`
+
+	// Limit caps the number of items returned. 0이면 제한 없음
+	Limit int `json:"limit,omitempty"`
+	// Cursor continues a previous list from the ListMeta.Continue value it returned
+	Cursor string `json:"cursor,omitempty"`
+}
+
+// Paged reports whether opts requests a single page instead of the full list
+func (opts ListOptions) Paged() bool {
+	return opts.Limit > 0 || opts.Cursor != ""
 }
 
 // UserList contains a list of User
@@ -31,3 +81,9 @@ type UserList struct {
 	metav1.ListMeta `json:"metadata,omitempty"`
 	Items           []*User `json:"items"`
 }
+
+// ServiceAccount is a non-interactive identity used for machine-to-machine access
+type ServiceAccount struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+}
@@ -1,19 +1,31 @@
 package handlers
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/sukryu/pAuth/pkg/apis/auth/v1alpha1"
 	"github.com/sukryu/pAuth/pkg/controllers"
 	"github.com/sukryu/pAuth/pkg/errors"
+	"github.com/sukryu/pAuth/pkg/events"
+	"github.com/sukryu/pAuth/pkg/middleware"
 	"github.com/sukryu/pAuth/pkg/utils/jwt"
+	"github.com/sukryu/pAuth/pkg/utils/mergepatch"
+	"github.com/sukryu/pAuth/pkg/utils/namespace"
 )
 
 type AuthHandler struct {
 	controller     controllers.AuthController
 	jwtManager     *jwt.JWTManager
 	rbacController controllers.RBACController
+	pagination     PaginationConfig
+	events         events.Bus
 }
 
 func NewAuthHandler(controller controllers.AuthController, jwtManager *jwt.JWTManager, rbacController controllers.RBACController) *AuthHandler {
@@ -24,70 +36,258 @@ func NewAuthHandler(controller controllers.AuthController, jwtManager *jwt.JWTMa
 	}
 }
 
+// SetPaginationConfig sets the default and maximum page size of list handlers
+func (h *AuthHandler) SetPaginationConfig(cfg PaginationConfig) {
+	h.pagination = cfg
+}
+
+// SetEventBus sets the bus streamed by the watch endpoints; nil이면 watch는 501
+func (h *AuthHandler) SetEventBus(bus events.Bus) {
+	h.events = bus
+}
+
 func (h *AuthHandler) Register(router *gin.Engine) {
 	auth := router.Group("/api/v1/auth")
 	{
 		auth.POST("/users", h.CreateUser)
 		auth.GET("/users/:name", h.GetUser)
 		auth.PUT("/users/:name", h.UpdateUser)
+		auth.PATCH("/users/:name", h.PatchUser)
 		auth.DELETE("/users/:name", h.DeleteUser)
+		auth.POST("/users:action", h.UserAction)
+		auth.GET("/users:action", h.UserGetAction)
 		auth.GET("/users", h.ListUsers)
 		auth.POST("/login", h.Login)
+		auth.POST("/register", h.RegisterUser)
+		auth.POST("/register/verify", h.VerifyRegistration)
+		auth.POST("/token/refresh", h.RefreshToken)
+		auth.POST("/token/introspect", h.IntrospectToken)
 		auth.PUT("/users/:name/password", h.ChangePassword)
-		auth.PUT("/users/:name/roles", h.AssignRoles)
+		auth.PUT("/users/:name/roles", h.SetRoles)
+		auth.POST("/users/:name/roles", h.AddRoles)
+		auth.DELETE("/users/:name/roles", h.RemoveRoles)
+		auth.GET("/users/:name/login-history", h.GetLoginHistory)
+		auth.GET("/users/:name/roles", h.GetUserRoles)
+		auth.POST("/users/:name/email/change", h.RequestEmailChange)
+		auth.POST("/users/:name/email/confirm", h.ConfirmEmailChange)
+	}
+}
+
+// RegisterRBAC mounts the role and role binding routes behind JWT authentication
+// and RBAC permission checks. createMiddleware runs only on the POST (create) routes.
+func (h *AuthHandler) RegisterRBAC(router *gin.Engine, createMiddleware ...gin.HandlerFunc) {
+	rbac := router.Group("/api/v1/auth")
+	rbac.Use(middleware.JWTAuth(h.jwtManager))
+	rbac.Use(middleware.RBACMiddleware(h.rbacController))
+	{
+		rbac.POST("/roles", withHandler(createMiddleware, h.CreateRole)...)
+		rbac.GET("/roles", h.ListRoles)
+		rbac.GET("/roles/:name", h.GetRole)
+		rbac.PUT("/roles/:name", h.UpdateRole)
+		// 강제 삭제는 참조하는 바인딩까지 지우므로 바인딩 삭제 권한도 필요
+		rbac.DELETE("/roles/:name", middleware.RequirePermissionForQuery(h.rbacController, "force", "delete", "rolebindings", "auth.service"), h.DeleteRole)
+
+		rbac.POST("/rolebindings", withHandler(createMiddleware, h.CreateRoleBinding)...)
+		rbac.GET("/rolebindings", h.ListRoleBindings)
+		rbac.GET("/rolebindings/:name", h.GetRoleBinding)
+		rbac.PUT("/rolebindings/:name", h.UpdateRoleBinding)
+		rbac.POST("/rolebindings/:name/subjects", h.AddRoleBindingSubject)
+		rbac.DELETE("/rolebindings/:name", h.DeleteRoleBinding)
+
+		rbac.POST("/clusterroles", withHandler(createMiddleware, h.CreateClusterRole)...)
+		rbac.GET("/clusterroles", h.ListClusterRoles)
+		rbac.GET("/clusterroles/:name", h.GetClusterRole)
+		rbac.DELETE("/clusterroles/:name", h.DeleteClusterRole)
+
+		rbac.POST("/clusterrolebindings", withHandler(createMiddleware, h.CreateClusterRoleBinding)...)
+		rbac.GET("/clusterrolebindings", h.ListClusterRoleBindings)
+		rbac.GET("/clusterrolebindings/:name", h.GetClusterRoleBinding)
+		rbac.DELETE("/clusterrolebindings/:name", h.DeleteClusterRoleBinding)
 	}
 }
 
+// withHandler appends handler to a copy of middleware
+func withHandler(middleware []gin.HandlerFunc, handler gin.HandlerFunc) []gin.HandlerFunc {
+	chain := make([]gin.HandlerFunc, 0, len(middleware)+1)
+	chain = append(chain, middleware...)
+	return append(chain, handler)
+}
+
 func (h *AuthHandler) CreateUser(c *gin.Context) {
-	var user v1alpha1.User
-	if err := c.ShouldBindJSON(&user); err != nil {
+	user, ok := bindJSON[v1alpha1.User](c)
+	if !ok {
+		return
+	}
+
+	ctx, status := dryRunContext(c)
+	// ?skipDefaultRoles=true: 설정된 기본 역할을 부여하지 않음
+	if c.Query("skipDefaultRoles") == "true" {
+		ctx = controllers.WithoutDefaultRoles(ctx)
+	}
+	result, err := h.controller.CreateUser(ctx, &user)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(status, redactUser(result))
+}
+
+// registerRequest intentionally has no roles or status; 그 외 필드는 바인딩 시 무시됨
+type registerRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Email       string `json:"email"`
+	Password    string `json:"password" binding:"required"`
+	DisplayName string `json:"displayName"`
+}
+
+// RegisterUser handles public self-registration (auth.allowSelfRegistration)
+func (h *AuthHandler) RegisterUser(c *gin.Context) {
+	var req registerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(errors.ErrInvalidInput.WithReason(err.Error()))
+		return
+	}
+
+	user, err := h.controller.RegisterUser(c.Request.Context(), controllers.Registration{
+		Name:        req.Name,
+		Email:       req.Email,
+		Password:    req.Password,
+		DisplayName: req.DisplayName,
+	})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	// 이메일 확인이 필요하면 아직 비활성 상태이므로 202
+	status := http.StatusCreated
+	if !user.Status.Active {
+		status = http.StatusAccepted
+	}
+	c.JSON(status, redactUser(user))
+}
+
+func (h *AuthHandler) VerifyRegistration(c *gin.Context) {
+	var req emailConfirmRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
 		c.Error(errors.ErrInvalidInput.WithReason(err.Error()))
 		return
 	}
 
-	result, err := h.controller.CreateUser(c.Request.Context(), &user)
+	user, err := h.controller.VerifyRegistration(c.Request.Context(), req.Token)
 	if err != nil {
 		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusCreated, result)
+	c.JSON(http.StatusOK, redactUser(user))
 }
 
 type loginRequest struct {
 	Username string `json:"username" binding:"required"`
 	Password string `json:"password" binding:"required"`
+
+	// RememberMe issues a refresh token with the longer remember-me lifetime
+	RememberMe bool `json:"rememberMe"`
 }
 
 type loginResponse struct {
 	Token string         `json:"token"`
 	User  *v1alpha1.User `json:"user"`
+
+	// RefreshToken is set when refresh tokens are enabled
+	RefreshToken string `json:"refreshToken,omitempty"`
 }
 
 func (h *AuthHandler) Login(c *gin.Context) {
-	var req loginRequest
+	req, ok := bindJSON[loginRequest](c)
+	if !ok {
+		return
+	}
+	// 로그인 기록이 남기 전에 거부
+	if req.RememberMe && !h.jwtManager.RefreshEnabled() {
+		c.Error(errors.ErrInvalidInput.WithReason("rememberMe requires refresh tokens to be enabled"))
+		return
+	}
+
+	ctx := controllers.WithClientInfo(c.Request.Context(), controllers.ClientInfo{
+		IP:        c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	})
+	// 인증 전이므로 요청 본문으로 namespace를 고를 수 없음. 사용자는 요청 ctx의 namespace(기본 namespace)에서 조회됨
+	user, err := h.controller.Login(ctx, req.Username, req.Password)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	// JWT 토큰 생성. 이후 요청은 사용자의 namespace로 한정됨
+	token, err := h.jwtManager.GenerateUserToken(user)
+	if err != nil {
+		c.Error(errors.ErrInternal.WithReason("failed to generate token"))
+		return
+	}
+
+	resp := loginResponse{
+		Token: token,
+		User:  redactUser(user),
+	}
+	if h.jwtManager.RefreshEnabled() {
+		resp.RefreshToken, err = h.jwtManager.GenerateRefreshToken(user.Name, user.Namespace, req.RememberMe)
+		if err != nil {
+			c.Error(errors.ErrInternal.WithReason("failed to generate refresh token"))
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+type refreshRequest struct {
+	RefreshToken string `json:"refreshToken" binding:"required"`
+}
+
+type refreshResponse struct {
+	Token string `json:"token"`
+}
+
+// RefreshToken issues a new access token for a valid refresh token.
+// 사용자를 다시 조회하므로 삭제된 사용자는 갱신할 수 없고 역할 변경이 반영됨
+func (h *AuthHandler) RefreshToken(c *gin.Context) {
+	var req refreshRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.Error(errors.ErrInvalidInput.WithReason(err.Error()))
 		return
 	}
 
-	user, err := h.controller.Login(c.Request.Context(), req.Username, req.Password)
+	claims, err := h.jwtManager.ValidateRefreshToken(req.RefreshToken)
+	if err != nil {
+		c.Error(errors.ErrInvalidToken.WithReason(err.Error()))
+		return
+	}
+
+	ctx := c.Request.Context()
+	if claims.Namespace != "" {
+		ctx = namespace.WithNamespace(ctx, claims.Namespace)
+	}
+	var issuedAt time.Time
+	if claims.IssuedAt != nil {
+		issuedAt = claims.IssuedAt.Time
+	}
+	user, err := h.controller.RefreshUser(ctx, claims.UserID, issuedAt)
 	if err != nil {
 		c.Error(err)
 		return
 	}
 
-	// JWT 토큰 생성
-	token, err := h.jwtManager.GenerateToken(user.Name, user.Spec.Roles)
+	token, err := h.jwtManager.GenerateUserToken(user)
 	if err != nil {
 		c.Error(errors.ErrInternal.WithReason("failed to generate token"))
 		return
 	}
 
-	c.JSON(http.StatusOK, loginResponse{
-		Token: token,
-		User:  user,
-	})
+	c.JSON(http.StatusOK, refreshResponse{Token: token})
 }
 
 type changePasswordRequest struct {
@@ -121,23 +321,120 @@ type assignRolesRequest struct {
 	Roles []string `json:"roles" binding:"required"`
 }
 
-func (h *AuthHandler) AssignRoles(c *gin.Context) {
-	name := c.Param("name")
+// SetRoles replaces the roles of a user
+func (h *AuthHandler) SetRoles(c *gin.Context) {
+	h.updateRoles(c, h.controller.SetRoles)
+}
+
+// AddRoles assigns additional roles to a user
+func (h *AuthHandler) AddRoles(c *gin.Context) {
+	h.updateRoles(c, h.controller.AddRoles)
+}
+
+// RemoveRoles unassigns the roles given as repeated ?role= query parameters
+func (h *AuthHandler) RemoveRoles(c *gin.Context) {
+	if err := h.controller.RemoveRoles(c.Request.Context(), c.Param("name"), c.QueryArray("role")); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *AuthHandler) updateRoles(c *gin.Context, update func(ctx context.Context, name string, roles []string) error) {
 	var req assignRolesRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(errors.ErrInvalidInput.WithReason(err.Error()))
 		return
 	}
 
-	err := h.controller.AssignRoles(c.Request.Context(), name, req.Roles)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := update(c.Request.Context(), c.Param("name"), req.Roles); err != nil {
+		c.Error(err)
 		return
 	}
 
 	c.Status(http.StatusOK)
 }
 
+func (h *AuthHandler) GetLoginHistory(c *gin.Context) {
+	name := c.Param("name")
+	if name == "" {
+		c.Error(errors.ErrInvalidInput.WithReason("name parameter is required"))
+		return
+	}
+
+	history, err := h.controller.GetLoginHistory(c.Request.Context(), name)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"items": history})
+}
+
+// GetUserRoles returns the roles bound to the user
+func (h *AuthHandler) GetUserRoles(c *gin.Context) {
+	name := c.Param("name")
+	if name == "" {
+		c.Error(errors.ErrInvalidInput.WithReason("name parameter is required"))
+		return
+	}
+
+	roles, err := h.rbacController.GetRolesForUser(c.Request.Context(), name)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"items": roles})
+}
+
+type emailChangeRequest struct {
+	Email string `json:"email" binding:"required"`
+}
+
+func (h *AuthHandler) RequestEmailChange(c *gin.Context) {
+	name := c.Param("name")
+	if name == "" {
+		c.Error(errors.ErrInvalidInput.WithReason("name parameter is required"))
+		return
+	}
+
+	var req emailChangeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(errors.ErrInvalidInput.WithReason(err.Error()))
+		return
+	}
+
+	// 토큰은 EmailChangeSender를 통해 새 주소로만 전달되며 응답에 포함하지 않음
+	if _, err := h.controller.RequestEmailChange(c.Request.Context(), name, req.Email); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"pendingEmail": req.Email})
+}
+
+type emailConfirmRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+func (h *AuthHandler) ConfirmEmailChange(c *gin.Context) {
+	var req emailConfirmRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(errors.ErrInvalidInput.WithReason(err.Error()))
+		return
+	}
+
+	user, err := h.controller.ConfirmEmailChange(c.Request.Context(), c.Param("name"), req.Token)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, redactUser(user))
+}
+
 func (h *AuthHandler) GetUser(c *gin.Context) {
 	name := c.Param("name")
 	if name == "" {
@@ -151,7 +448,7 @@ func (h *AuthHandler) GetUser(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, user)
+	respondWithETag(c, redactUser(user))
 }
 
 func (h *AuthHandler) UpdateUser(c *gin.Context) {
@@ -174,7 +471,65 @@ func (h *AuthHandler) UpdateUser(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, result)
+	c.JSON(http.StatusOK, redactUser(result))
+}
+
+// PatchUser applies an RFC 7386 JSON Merge Patch to the stored user.
+// 생략된 필드는 유지되고 null로 지정된 필드는 비워짐
+func (h *AuthHandler) PatchUser(c *gin.Context) {
+	name := c.Param("name")
+	if name == "" {
+		c.Error(errors.ErrInvalidInput.WithReason("name parameter is required"))
+		return
+	}
+
+	if contentType := c.ContentType(); contentType != mergepatch.ContentType && contentType != "application/json" {
+		c.Error(errors.NewStatusError(http.StatusUnsupportedMediaType, "unsupported media type").
+			WithReason("use " + mergepatch.ContentType))
+		return
+	}
+
+	patch, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		c.Error(errors.ErrInvalidInput.WithReason("failed to read request body"))
+		return
+	}
+
+	ctx := c.Request.Context()
+	existing, err := h.controller.GetUser(ctx, name)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	original, err := json.Marshal(existing)
+	if err != nil {
+		c.Error(errors.ErrInternal.WithReason("failed to encode user"))
+		return
+	}
+	patched, err := mergepatch.Apply(original, patch)
+	if err != nil {
+		c.Error(errors.ErrInvalidInput.WithReason(err.Error()))
+		return
+	}
+
+	var user v1alpha1.User
+	if err := json.Unmarshal(patched, &user); err != nil {
+		c.Error(errors.ErrInvalidInput.WithReason(err.Error()))
+		return
+	}
+	if user.Name != name {
+		c.Error(errors.ErrInvalidInput.WithReason("metadata.name cannot be changed"))
+		return
+	}
+
+	result, err := h.controller.UpdateUser(ctx, &user)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, redactUser(result))
 }
 
 func (h *AuthHandler) DeleteUser(c *gin.Context) {
@@ -194,119 +549,787 @@ func (h *AuthHandler) DeleteUser(c *gin.Context) {
 }
 
 func (h *AuthHandler) ListUsers(c *gin.Context) {
+	limit, next, paged, err := parseCursorPagination(c, h.pagination)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if fields := parseFields(c); len(fields) > 0 || paged {
+		opts := v1alpha1.ListOptions{Fields: fields, Limit: limit, Cursor: next}
+		users, err := h.controller.ListUsersWithOptions(c.Request.Context(), opts)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		if paged {
+			items := redactUserList(users).Items
+			if respondEnvelope(c, h.pagination, items, listMetadata{Limit: limit, NextCursor: users.Continue}) {
+				return
+			}
+			c.JSON(http.StatusOK, cursorPagedResponse{Items: items, Limit: limit, NextCursor: users.Continue})
+			return
+		}
+		if respondEnvelope(c, h.pagination, redactUserList(users).Items, listMetadata{Total: totalOf(len(users.Items))}) {
+			return
+		}
+		c.JSON(http.StatusOK, redactUserList(users))
+		return
+	}
+
 	users, err := h.controller.ListUsers(c.Request.Context())
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, users)
+	if respondEnvelope(c, h.pagination, redactUserList(users).Items, listMetadata{Total: totalOf(len(users.Items))}) {
+		return
+	}
+	c.JSON(http.StatusOK, redactUserList(users))
 }
 
 // RBAC 핸들러
 func (h *AuthHandler) CreateRole(c *gin.Context) {
-	var role v1alpha1.Role
-	if err := c.ShouldBindJSON(&role); err != nil {
-		c.Error(errors.ErrInvalidInput.WithReason(err.Error()))
+	role, ok := bindJSON[v1alpha1.Role](c)
+	if !ok {
 		return
 	}
 
-	err := h.rbacController.CreateRole(c.Request.Context(), &role)
+	ctx, status := dryRunContext(c)
+	err := h.rbacController.CreateRole(ctx, &role)
 	if err != nil {
 		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusCreated, role)
+	c.JSON(status, role)
 }
 
 func (h *AuthHandler) ListRoles(c *gin.Context) {
-	roles, err := h.rbacController.ListRoles(c.Request.Context())
+	if _, ok := c.GetQuery("cursor"); ok {
+		h.listRolesWithCursor(c)
+		return
+	}
+
+	limit, offset, paged, err := parsePagination(c, h.pagination)
 	if err != nil {
 		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, roles)
-}
+	if paged {
+		roles, total, err := h.rbacController.ListRolesPaged(c.Request.Context(), limit, offset)
+		if err != nil {
+			c.Error(err)
+			return
+		}
 
-func (h *AuthHandler) GetRole(c *gin.Context) {
-	name := c.Param("name")
-	if name == "" {
-		c.Error(errors.ErrInvalidInput.WithReason("name parameter is required"))
+		if respondEnvelope(c, h.pagination, roles, listMetadata{Total: totalOf(total), Limit: limit, Offset: offset}) {
+			return
+		}
+		c.JSON(http.StatusOK, pagedResponse{Items: roles, Total: total, Limit: limit, Offset: offset})
 		return
 	}
 
-	role, err := h.rbacController.GetRole(c.Request.Context(), name)
+	roles, err := h.rbacController.ListRoles(c.Request.Context())
 	if err != nil {
 		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, role)
-}
-
-func (h *AuthHandler) DeleteRole(c *gin.Context) {
-	name := c.Param("name")
-	err := h.rbacController.DeleteRole(c.Request.Context(), name)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if respondEnvelope(c, h.pagination, roles, listMetadata{Total: totalOf(len(roles))}) {
 		return
 	}
-
-	c.Status(http.StatusNoContent)
+	c.JSON(http.StatusOK, roles)
 }
 
-// RoleBinding 핸들러
-func (h *AuthHandler) CreateRoleBinding(c *gin.Context) {
-	var binding v1alpha1.RoleBinding
-	if err := c.ShouldBindJSON(&binding); err != nil {
-		c.Error(errors.ErrInvalidInput.WithReason(err.Error()))
+// listRolesWithCursor serves ListRoles requests with ?cursor=. 빈 cursor는 첫 페이지를 의미하며, offset과 함께 사용할 수 없음
+func (h *AuthHandler) listRolesWithCursor(c *gin.Context) {
+	if _, ok := c.GetQuery("offset"); ok {
+		c.Error(errors.ErrInvalidInput.WithReason("offset cannot be combined with cursor"))
 		return
 	}
-
-	err := h.rbacController.CreateRoleBinding(c.Request.Context(), &binding)
+	limit, next, _, err := parseCursorPagination(c, h.pagination)
 	if err != nil {
 		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusCreated, binding)
-}
-
-func (h *AuthHandler) ListRoleBindings(c *gin.Context) {
-	bindings, err := h.rbacController.ListRoleBindings(c.Request.Context())
+	roles, nextCursor, err := h.rbacController.ListRolesWithCursor(c.Request.Context(), limit, next)
 	if err != nil {
 		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, bindings)
+	if respondEnvelope(c, h.pagination, roles, listMetadata{Limit: limit, NextCursor: nextCursor}) {
+		return
+	}
+	c.JSON(http.StatusOK, cursorPagedResponse{Items: roles, Limit: limit, NextCursor: nextCursor})
 }
 
-func (h *AuthHandler) GetRoleBinding(c *gin.Context) {
+func (h *AuthHandler) GetRole(c *gin.Context) {
 	name := c.Param("name")
 	if name == "" {
 		c.Error(errors.ErrInvalidInput.WithReason("name parameter is required"))
 		return
 	}
 
-	binding, err := h.rbacController.GetRoleBinding(c.Request.Context(), name)
+	role, err := h.rbacController.GetRole(c.Request.Context(), name)
 	if err != nil {
 		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, binding)
+	respondWithETag(c, role)
 }
 
-func (h *AuthHandler) DeleteRoleBinding(c *gin.Context) {
+func (h *AuthHandler) UpdateRole(c *gin.Context) {
 	name := c.Param("name")
-	err := h.rbacController.DeleteRoleBinding(c.Request.Context(), name)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if name == "" {
+		c.Error(errors.ErrInvalidInput.WithReason("name parameter is required"))
 		return
 	}
 
-	c.Status(http.StatusNoContent)
+	var role v1alpha1.Role
+	if err := c.ShouldBindJSON(&role); err != nil {
+		c.Error(errors.ErrInvalidInput.WithReason(err.Error()))
+		return
+	}
+
+	role.Name = name
+	result, err := h.rbacController.UpdateRole(c.Request.Context(), &role)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// DeleteRole deletes a role. ?force=true이면 참조하는 RoleBinding도 함께 삭제
+func (h *AuthHandler) DeleteRole(c *gin.Context) {
+	name := c.Param("name")
+	ctx := c.Request.Context()
+	if c.Query("force") == "true" {
+		ctx = controllers.WithCascadeDelete(ctx)
+	}
+
+	err := h.rbacController.DeleteRole(ctx, name)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// RoleBinding 핸들러
+func (h *AuthHandler) CreateRoleBinding(c *gin.Context) {
+	var binding v1alpha1.RoleBinding
+	if err := c.ShouldBindJSON(&binding); err != nil {
+		c.Error(errors.ErrInvalidInput.WithReason(err.Error()))
+		return
+	}
+
+	err := h.rbacController.CreateRoleBinding(c.Request.Context(), &binding)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, binding)
+}
+
+func (h *AuthHandler) ListRoleBindings(c *gin.Context) {
+	if _, ok := c.GetQuery("cursor"); ok {
+		h.listRoleBindingsWithCursor(c)
+		return
+	}
+
+	limit, offset, paged, err := parsePagination(c, h.pagination)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if paged {
+		bindings, total, err := h.rbacController.ListRoleBindingsPaged(c.Request.Context(), limit, offset)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+
+		if respondEnvelope(c, h.pagination, bindings, listMetadata{Total: totalOf(total), Limit: limit, Offset: offset}) {
+			return
+		}
+		c.JSON(http.StatusOK, pagedResponse{Items: bindings, Total: total, Limit: limit, Offset: offset})
+		return
+	}
+
+	bindings, err := h.rbacController.ListRoleBindings(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if respondEnvelope(c, h.pagination, bindings, listMetadata{Total: totalOf(len(bindings))}) {
+		return
+	}
+	c.JSON(http.StatusOK, bindings)
+}
+
+// listRoleBindingsWithCursor serves ListRoleBindings requests with ?cursor=. 빈 cursor는 첫 페이지를 의미하며, offset과 함께 사용할 수 없음
+func (h *AuthHandler) listRoleBindingsWithCursor(c *gin.Context) {
+	if _, ok := c.GetQuery("offset"); ok {
+		c.Error(errors.ErrInvalidInput.WithReason("offset cannot be combined with cursor"))
+		return
+	}
+	limit, next, _, err := parseCursorPagination(c, h.pagination)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	bindings, nextCursor, err := h.rbacController.ListRoleBindingsWithCursor(c.Request.Context(), limit, next)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if respondEnvelope(c, h.pagination, bindings, listMetadata{Limit: limit, NextCursor: nextCursor}) {
+		return
+	}
+	c.JSON(http.StatusOK, cursorPagedResponse{Items: bindings, Limit: limit, NextCursor: nextCursor})
+}
+
+func (h *AuthHandler) GetRoleBinding(c *gin.Context) {
+	name := c.Param("name")
+	if name == "" {
+		c.Error(errors.ErrInvalidInput.WithReason("name parameter is required"))
+		return
+	}
+
+	binding, err := h.rbacController.GetRoleBinding(c.Request.Context(), name)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	respondWithETag(c, binding)
+}
+
+func (h *AuthHandler) UpdateRoleBinding(c *gin.Context) {
+	name := c.Param("name")
+	if name == "" {
+		c.Error(errors.ErrInvalidInput.WithReason("name parameter is required"))
+		return
+	}
+
+	var binding v1alpha1.RoleBinding
+	if err := c.ShouldBindJSON(&binding); err != nil {
+		c.Error(errors.ErrInvalidInput.WithReason(err.Error()))
+		return
+	}
+
+	binding.Name = name
+	if err := h.rbacController.UpdateRoleBinding(c.Request.Context(), &binding); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, binding)
+}
+
+// AddRoleBindingSubject appends the subject in the body to a role binding.
+// controller를 거치므로 MaxSubjectsPerBinding 제한이 적용됨
+func (h *AuthHandler) AddRoleBindingSubject(c *gin.Context) {
+	var subject v1alpha1.Subject
+	if err := c.ShouldBindJSON(&subject); err != nil {
+		c.Error(errors.ErrInvalidInput.WithReason(err.Error()))
+		return
+	}
+
+	ctx, _ := dryRunContext(c)
+	if err := h.rbacController.AddRoleBindingSubject(ctx, c.Param("name"), subject); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *AuthHandler) DeleteRoleBinding(c *gin.Context) {
+	name := c.Param("name")
+	err := h.rbacController.DeleteRoleBinding(c.Request.Context(), name)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ClusterRole 핸들러
+func (h *AuthHandler) CreateClusterRole(c *gin.Context) {
+	var role v1alpha1.ClusterRole
+	if err := c.ShouldBindJSON(&role); err != nil {
+		c.Error(errors.ErrInvalidInput.WithReason(err.Error()))
+		return
+	}
+
+	ctx, status := dryRunContext(c)
+	if err := h.rbacController.CreateClusterRole(ctx, &role); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(status, role)
+}
+
+func (h *AuthHandler) ListClusterRoles(c *gin.Context) {
+	roles, err := h.rbacController.ListClusterRoles(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, roles)
+}
+
+func (h *AuthHandler) GetClusterRole(c *gin.Context) {
+	role, err := h.rbacController.GetClusterRole(c.Request.Context(), c.Param("name"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	respondWithETag(c, role)
+}
+
+func (h *AuthHandler) DeleteClusterRole(c *gin.Context) {
+	if err := h.rbacController.DeleteClusterRole(c.Request.Context(), c.Param("name")); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ClusterRoleBinding 핸들러
+func (h *AuthHandler) CreateClusterRoleBinding(c *gin.Context) {
+	var binding v1alpha1.ClusterRoleBinding
+	if err := c.ShouldBindJSON(&binding); err != nil {
+		c.Error(errors.ErrInvalidInput.WithReason(err.Error()))
+		return
+	}
+
+	ctx, status := dryRunContext(c)
+	if err := h.rbacController.CreateClusterRoleBinding(ctx, &binding); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(status, binding)
+}
+
+func (h *AuthHandler) ListClusterRoleBindings(c *gin.Context) {
+	bindings, err := h.rbacController.ListClusterRoleBindings(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, bindings)
+}
+
+func (h *AuthHandler) GetClusterRoleBinding(c *gin.Context) {
+	binding, err := h.rbacController.GetClusterRoleBinding(c.Request.Context(), c.Param("name"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	respondWithETag(c, binding)
+}
+
+func (h *AuthHandler) DeleteClusterRoleBinding(c *gin.Context) {
+	if err := h.rbacController.DeleteClusterRoleBinding(c.Request.Context(), c.Param("name")); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// UserAction dispatches custom methods on the users collection (e.g. POST /users:batchDelete).
+// gin은 경로 중간의 ':'를 파라미터로 해석하므로 ":action" 값으로 분기
+func (h *AuthHandler) UserAction(c *gin.Context) {
+	switch c.Param("action") {
+	case ":batchDelete":
+		h.DeleteUsersBatch(c)
+	default:
+		c.Error(errors.NewStatusError(http.StatusNotFound, "unknown action"))
+	}
+}
+
+// UserGetAction dispatches GET /users:{action} (현재는 :watch만 지원)
+func (h *AuthHandler) UserGetAction(c *gin.Context) {
+	switch c.Param("action") {
+	case ":watch":
+		h.WatchUsers(c)
+	default:
+		c.Error(errors.NewStatusError(http.StatusNotFound, "unknown action"))
+	}
+}
+
+// WatchUsers streams user change events as server-sent events until the client disconnects
+func (h *AuthHandler) WatchUsers(c *gin.Context) {
+	h.watch(c, "User")
+}
+
+// watchHeartbeat is how often a comment line is sent so idle proxies keep the stream open
+const watchHeartbeat = 30 * time.Second
+
+// watch streams the events of kind in the request namespace from the event bus
+func (h *AuthHandler) watch(c *gin.Context, kind string) {
+	if h.events == nil {
+		c.Error(errors.ErrNotImplemented.WithReason("watch is not enabled"))
+		return
+	}
+
+	ctx := c.Request.Context()
+	ns := namespace.FromContext(ctx)
+	ch, err := h.events.Subscribe(ctx)
+	if err != nil {
+		c.Error(errors.ErrInternal.WithReason("failed to subscribe to events"))
+		return
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(watchHeartbeat)
+	defer heartbeat.Stop()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case event, ok := <-ch:
+			if !ok {
+				return false
+			}
+			// 다른 테넌트의 변경은 전달하지 않음
+			if event.Kind == kind && event.Namespace == ns {
+				c.SSEvent(string(event.Type), event)
+			}
+			return true
+		case <-heartbeat.C:
+			_, err := io.WriteString(w, ": ping\n\n")
+			return err == nil
+		case <-ctx.Done():
+			return false
+		}
+	})
+}
+
+type batchDeleteRequest struct {
+	Names []string `json:"names" binding:"required"`
+
+	// Cascade also removes the users from role bindings
+	Cascade bool `json:"cascade"`
+}
+
+type batchDeleteResult struct {
+	Name    string              `json:"name"`
+	Deleted bool                `json:"deleted"`
+	Error   *errors.StatusError `json:"error,omitempty"`
+}
+
+// DeleteUsersBatch deletes several users and reports the outcome per user
+func (h *AuthHandler) DeleteUsersBatch(c *gin.Context) {
+	var req batchDeleteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(errors.ErrInvalidInput.WithReason(err.Error()))
+		return
+	}
+
+	ctx := c.Request.Context()
+	if req.Cascade {
+		ctx = controllers.WithCascadeDelete(ctx)
+	}
+
+	failed, err := h.controller.DeleteUsersBatch(ctx, req.Names)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	results := make([]batchDeleteResult, 0, len(req.Names))
+	seen := make(map[string]bool, len(req.Names))
+	for _, name := range req.Names {
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		result := batchDeleteResult{Name: name, Deleted: true}
+		if err, ok := failed[name]; ok {
+			result.Deleted = false
+			if statusErr, ok := err.(*errors.StatusError); ok {
+				result.Error = statusErr
+			} else {
+				result.Error = errors.NewStatusError(http.StatusInternalServerError, "failed to delete user")
+			}
+		}
+		results = append(results, result)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"results": results})
+}
+
+type currentUserResponse struct {
+	User         *v1alpha1.User        `json:"user"`
+	Roles        []string              `json:"roles"`
+	ClusterRoles []string              `json:"clusterRoles,omitempty"`
+	Permissions  []v1alpha1.PolicyRule `json:"permissions"`
+}
+
+// GetCurrentUser returns the authenticated user together with its effective permissions
+func (h *AuthHandler) GetCurrentUser(c *gin.Context) {
+	subject, ok := middleware.SubjectFromContext(c)
+	if !ok {
+		c.Error(errors.ErrUnauthorized)
+		return
+	}
+	// ServiceAccount 토큰에는 사용자 정보가 없음
+	if subject.Kind != v1alpha1.SubjectKindUser {
+		c.Error(errors.ErrForbidden.WithReason("only user tokens have a current user"))
+		return
+	}
+
+	ctx := c.Request.Context()
+	user, err := h.controller.GetUser(ctx, subject.Name)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	perms, err := h.rbacController.ListEffectivePermissions(ctx, subject)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, currentUserResponse{
+		User:         redactUser(user),
+		Roles:        perms.Roles,
+		ClusterRoles: perms.ClusterRoles,
+		Permissions:  perms.Rules,
+	})
+}
+
+// ReviewAccess evaluates one check or a list of checks for the authenticated caller.
+// 임의 사용자에 대한 권한 조회를 막기 위해 항상 요청자의 신원만 사용.
+func (h *AuthHandler) ReviewAccess(c *gin.Context) {
+	subject, ok := middleware.SubjectFromContext(c)
+	if !ok {
+		c.Error(errors.ErrUnauthorized)
+		return
+	}
+
+	var raw json.RawMessage
+	if err := c.ShouldBindJSON(&raw); err != nil {
+		c.Error(errors.ErrInvalidInput.WithReason(err.Error()))
+		return
+	}
+
+	var checks []v1alpha1.AccessCheck
+	trimmed := bytes.TrimSpace(raw)
+	batch := len(trimmed) > 0 && trimmed[0] == '['
+	if batch {
+		if err := json.Unmarshal(raw, &checks); err != nil {
+			c.Error(errors.ErrInvalidInput.WithReason(err.Error()))
+			return
+		}
+	} else {
+		var check v1alpha1.AccessCheck
+		if err := json.Unmarshal(raw, &check); err != nil {
+			c.Error(errors.ErrInvalidInput.WithReason(err.Error()))
+			return
+		}
+		checks = []v1alpha1.AccessCheck{check}
+	}
+
+	verr := &errors.ValidationError{}
+	for i := range checks {
+		prefix := ""
+		if batch {
+			prefix = fmt.Sprintf("[%d].", i)
+		}
+		validateAccessCheck(verr, prefix, &checks[i])
+	}
+	if err := verr.OrNil(); err != nil {
+		c.Error(err)
+		return
+	}
+
+	results, err := h.rbacController.CheckAccessBatch(c.Request.Context(), subject, checks)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if !batch {
+		c.JSON(http.StatusOK, results[0])
+		return
+	}
+	c.JSON(http.StatusOK, results)
+}
+
+// validateAccessCheck requires verb and resource and defaults the API group
+func validateAccessCheck(verr *errors.ValidationError, prefix string, check *v1alpha1.AccessCheck) {
+	if check.Verb == "" {
+		verr.Add(prefix+"verb", "verb is required")
+	}
+	if check.Resource == "" {
+		verr.Add(prefix+"resource", "resource is required")
+	}
+	// RBACMiddleware와 동일한 기본 API 그룹
+	if check.APIGroup == "" {
+		check.APIGroup = "auth.service"
+	}
+}
+
+// MaxAuthorizeBatchSize bounds the number of checks in one AuthorizeBatch request
+const MaxAuthorizeBatchSize = 1000
+
+type authorizeBatchRequest struct {
+	Checks []v1alpha1.SubjectAccessCheck `json:"checks"`
+}
+
+// AuthorizeBatch evaluates access checks for explicit subjects, for trusted services authorizing on
+// behalf of their callers. 주체별로 한 번씩 CheckAccessBatch를 호출하고 결과는 요청 순서대로 반환
+func (h *AuthHandler) AuthorizeBatch(c *gin.Context) {
+	var req authorizeBatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(errors.ErrInvalidInput.WithReason(err.Error()))
+		return
+	}
+	if len(req.Checks) == 0 {
+		c.Error(errors.ErrInvalidInput.WithReason("checks is required"))
+		return
+	}
+	if len(req.Checks) > MaxAuthorizeBatchSize {
+		c.Error(errors.ErrInvalidInput.WithReason(fmt.Sprintf("at most %d checks are allowed", MaxAuthorizeBatchSize)))
+		return
+	}
+
+	verr := &errors.ValidationError{}
+	indexes := make(map[v1alpha1.Subject][]int)
+	var subjects []v1alpha1.Subject
+	for i := range req.Checks {
+		prefix := fmt.Sprintf("checks[%d].", i)
+		check := &req.Checks[i]
+		switch check.Subject.Kind {
+		case v1alpha1.SubjectKindUser, v1alpha1.SubjectKindServiceAccount:
+		default:
+			verr.Add(prefix+"subject.kind", "must be User or ServiceAccount")
+		}
+		if check.Subject.Name == "" {
+			verr.Add(prefix+"subject.name", "subject name is required")
+		}
+		validateAccessCheck(verr, prefix, &check.AccessCheck)
+
+		if _, ok := indexes[check.Subject]; !ok {
+			subjects = append(subjects, check.Subject)
+		}
+		indexes[check.Subject] = append(indexes[check.Subject], i)
+	}
+	if err := verr.OrNil(); err != nil {
+		c.Error(err)
+		return
+	}
+
+	results := make([]v1alpha1.SubjectAccessCheckResult, len(req.Checks))
+	for _, subject := range subjects {
+		checks := make([]v1alpha1.AccessCheck, len(indexes[subject]))
+		for j, i := range indexes[subject] {
+			checks[j] = req.Checks[i].AccessCheck
+		}
+
+		decisions, err := h.rbacController.CheckAccessBatch(c.Request.Context(), subject, checks)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		for j, i := range indexes[subject] {
+			results[i] = v1alpha1.SubjectAccessCheckResult{Subject: subject, AccessCheckResult: decisions[j]}
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"results": results})
+}
+
+// dryRunContext marks the request context for dry run when ?dryRun=true is given.
+// Dry run은 아무것도 생성하지 않으므로 201 대신 200을 반환.
+func dryRunContext(c *gin.Context) (context.Context, int) {
+	if c.Query("dryRun") == "true" {
+		return controllers.WithDryRun(c.Request.Context()), http.StatusOK
+	}
+	return c.Request.Context(), http.StatusCreated
+}
+
+type introspectRequest struct {
+	Token string `json:"token" form:"token" binding:"required"`
+}
+
+// introspectResponse follows RFC 7662. 비활성 토큰은 active 외의 필드를 포함하지 않음
+type introspectResponse struct {
+	Active    bool     `json:"active"`
+	User      string   `json:"user,omitempty"`
+	Roles     []string `json:"roles,omitempty"`
+	Type      string   `json:"typ,omitempty"`
+	Namespace string   `json:"ns,omitempty"`
+	Exp       int64    `json:"exp,omitempty"`
+	Iat       int64    `json:"iat,omitempty"`
+	Jti       string   `json:"jti,omitempty"`
+}
+
+// IntrospectToken reports whether a token is active and, if so, its claims.
+// RFC 7662와 같이 만료/위조/폐기된 토큰은 에러 대신 active:false로 응답
+func (h *AuthHandler) IntrospectToken(c *gin.Context) {
+	var req introspectRequest
+	if err := c.ShouldBind(&req); err != nil {
+		c.Error(errors.ErrInvalidInput.WithReason(err.Error()))
+		return
+	}
+
+	claims, err := h.jwtManager.ValidateToken(req.Token)
+	if err != nil {
+		c.JSON(http.StatusOK, introspectResponse{Active: false})
+		return
+	}
+
+	resp := introspectResponse{
+		Active:    true,
+		User:      claims.UserID,
+		Roles:     claims.Roles,
+		Type:      claims.Type,
+		Namespace: claims.Namespace,
+		Jti:       claims.ID,
+	}
+	if claims.Type == jwt.TokenTypeServiceAccount {
+		resp.User = claims.Subject
+	}
+	if claims.ExpiresAt != nil {
+		resp.Exp = claims.ExpiresAt.Unix()
+	}
+	if claims.IssuedAt != nil {
+		resp.Iat = claims.IssuedAt.Unix()
+	}
+
+	c.JSON(http.StatusOK, resp)
 }
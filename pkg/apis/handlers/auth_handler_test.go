@@ -0,0 +1,1532 @@
+package handlers
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/sukryu/pAuth/internal/store/cache"
+	"github.com/sukryu/pAuth/pkg/apis/auth/v1alpha1"
+	"github.com/sukryu/pAuth/pkg/controllers"
+	"github.com/sukryu/pAuth/pkg/errors"
+	"github.com/sukryu/pAuth/pkg/events"
+	"github.com/sukryu/pAuth/pkg/middleware"
+	"github.com/sukryu/pAuth/pkg/mocks"
+	"github.com/sukryu/pAuth/pkg/utils/cursor"
+	"github.com/sukryu/pAuth/pkg/utils/jwt"
+	"github.com/sukryu/pAuth/pkg/utils/namespace"
+	"golang.org/x/crypto/bcrypt"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func setupTestRouter(ms *mocks.MockStore) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	handler := NewAuthHandler(controllers.NewAuthController(ms), nil, controllers.NewRBACController(ms))
+	router := gin.New()
+	router.Use(middleware.ErrorMiddleware())
+	router.GET("/users", handler.ListUsers)
+	router.GET("/users/:name", handler.GetUser)
+	router.GET("/users/:name/roles", handler.GetUserRoles)
+	router.GET("/roles/:name", handler.GetRole)
+	router.GET("/rolebindings/:name", handler.GetRoleBinding)
+	return router
+}
+
+func performRequest(router *gin.Engine, method, path string, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestAuthHandler_ETag(t *testing.T) {
+	ms := mocks.NewMockStore()
+	ms.On("GetUser", mock.Anything, "testuser").Return(&v1alpha1.User{
+		ObjectMeta: metav1.ObjectMeta{Name: "testuser"},
+		Spec:       v1alpha1.UserSpec{Username: "testuser", Email: "test@example.com"},
+	}, nil)
+	ms.On("GetRole", mock.Anything, "reader").Return(&v1alpha1.Role{
+		ObjectMeta: metav1.ObjectMeta{Name: "reader", ResourceVersion: "42"},
+		Rules: []v1alpha1.PolicyRule{{
+			Verbs:     []string{"get"},
+			Resources: []string{"users"},
+			APIGroups: []string{"auth.service"},
+		}},
+	}, nil)
+	ms.On("GetRoleBinding", mock.Anything, "reader-binding").Return(&v1alpha1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "reader-binding"},
+		RoleRef:    v1alpha1.RoleRef{Kind: "Role", Name: "reader"},
+	}, nil)
+
+	router := setupTestRouter(ms)
+
+	for _, path := range []string{"/users/testuser", "/roles/reader", "/rolebindings/reader-binding"} {
+		t.Run(path, func(t *testing.T) {
+			first := performRequest(router, http.MethodGet, path, nil)
+			assert.Equal(t, http.StatusOK, first.Code)
+			etag := first.Header().Get("ETag")
+			assert.NotEmpty(t, etag)
+			assert.NotEmpty(t, first.Body.String())
+
+			second := performRequest(router, http.MethodGet, path, map[string]string{"If-None-Match": etag})
+			assert.Equal(t, http.StatusNotModified, second.Code)
+			assert.Empty(t, second.Body.String())
+			assert.Equal(t, etag, second.Header().Get("ETag"))
+
+			stale := performRequest(router, http.MethodGet, path, map[string]string{"If-None-Match": `W/"stale"`})
+			assert.Equal(t, http.StatusOK, stale.Code)
+		})
+	}
+
+	t.Run("resource version is used when present", func(t *testing.T) {
+		w := performRequest(router, http.MethodGet, "/roles/reader", nil)
+		assert.Equal(t, `W/"42"`, w.Header().Get("ETag"))
+	})
+}
+
+func TestEtagMatches(t *testing.T) {
+	tests := []struct {
+		name        string
+		ifNoneMatch string
+		etag        string
+		want        bool
+	}{
+		{"empty header", "", `W/"abc"`, false},
+		{"exact match", `W/"abc"`, `W/"abc"`, true},
+		{"strong vs weak", `"abc"`, `W/"abc"`, true},
+		{"list match", `W/"x", W/"abc"`, `W/"abc"`, true},
+		{"wildcard", "*", `W/"abc"`, true},
+		{"no match", `W/"x"`, `W/"abc"`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, etagMatches(tt.ifNoneMatch, tt.etag))
+		})
+	}
+}
+
+func TestAuthHandler_ListRolesPaged(t *testing.T) {
+	ms := mocks.NewMockStore()
+	ms.On("ListRolesPaged", mock.Anything, 1, 1).Return([]*v1alpha1.Role{
+		{ObjectMeta: metav1.ObjectMeta{Name: "role-b"}},
+	}, 3, nil)
+	ms.On("ListRolesPaged", mock.Anything, 0, 0).Return([]*v1alpha1.Role{}, 0, nil)
+
+	gin.SetMode(gin.TestMode)
+	handler := NewAuthHandler(controllers.NewAuthController(ms), nil, controllers.NewRBACController(ms))
+	router := gin.New()
+	router.Use(middleware.ErrorMiddleware())
+	router.GET("/roles", handler.ListRoles)
+
+	t.Run("paged", func(t *testing.T) {
+		w := performRequest(router, http.MethodGet, "/roles?limit=1&offset=1", nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"items":[{"metadata":{"name":"role-b","creationTimestamp":null},"rules":null}],"total":3,"limit":1,"offset":1}`, w.Body.String())
+	})
+
+	t.Run("unpaged keeps array response", func(t *testing.T) {
+		w := performRequest(router, http.MethodGet, "/roles", nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `[]`, w.Body.String())
+	})
+
+	t.Run("invalid limit", func(t *testing.T) {
+		w := performRequest(router, http.MethodGet, "/roles?limit=abc", nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("cursor", func(t *testing.T) {
+		next := cursor.Encode(cursor.Cursor{ID: "role-a"})
+		ms.On("ListRolesWithCursor", mock.Anything, 1, "").Return([]*v1alpha1.Role{
+			{ObjectMeta: metav1.ObjectMeta{Name: "role-a"}},
+		}, next, nil).Once()
+		ms.On("ListRolesWithCursor", mock.Anything, 1, next).Return([]*v1alpha1.Role{}, "", nil).Once()
+
+		w := performRequest(router, http.MethodGet, "/roles?limit=1&cursor=", nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"items":[{"metadata":{"name":"role-a","creationTimestamp":null},"rules":null}],"limit":1,"nextCursor":"`+next+`"}`, w.Body.String())
+
+		w = performRequest(router, http.MethodGet, "/roles?limit=1&cursor="+next, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"items":[],"limit":1}`, w.Body.String())
+
+		w = performRequest(router, http.MethodGet, "/roles?cursor=bogus", nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		w = performRequest(router, http.MethodGet, "/roles?cursor=&offset=2", nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestAuthHandler_ListUsersFields(t *testing.T) {
+	ms := mocks.NewMockStore()
+	opts := v1alpha1.ListOptions{Fields: []string{"username", "email"}}
+	ms.ExpectListUsersWithOptions(opts, &v1alpha1.UserList{Items: []*v1alpha1.User{{
+		ObjectMeta: metav1.ObjectMeta{Name: "testuser"},
+		Spec: v1alpha1.UserSpec{
+			Username:     "testuser",
+			Email:        "test@example.com",
+			PasswordHash: "$2a$10$hash",
+			Roles:        []string{"admin"},
+		},
+	}}}, nil)
+
+	router := setupTestRouter(ms)
+
+	t.Run("subset of fields", func(t *testing.T) {
+		w := performRequest(router, http.MethodGet, "/users?fields=username,%20email", nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"email":"test@example.com"`)
+		assert.NotContains(t, w.Body.String(), "passwordHash")
+		assert.NotContains(t, w.Body.String(), "roles")
+	})
+
+	t.Run("password hash rejected", func(t *testing.T) {
+		w := performRequest(router, http.MethodGet, "/users?fields=passwordHash", nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestAuthHandler_PasswordHashRedacted(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.DefaultCost)
+	assert.NoError(t, err)
+
+	newUser := func() *v1alpha1.User {
+		return &v1alpha1.User{
+			ObjectMeta: metav1.ObjectMeta{Name: "testuser"},
+			Spec: v1alpha1.UserSpec{
+				Username:     "testuser",
+				Email:        "test@example.com",
+				PasswordHash: string(hash),
+			},
+			Status: v1alpha1.UserStatus{Active: true},
+		}
+	}
+
+	t.Run("GetUser", func(t *testing.T) {
+		ms := mocks.NewMockStore()
+		ms.ExpectGetUser("testuser", newUser(), nil)
+		router := setupTestRouter(ms)
+
+		w := performRequest(router, http.MethodGet, "/users/testuser", nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NotContains(t, w.Body.String(), "passwordHash")
+		assert.NotContains(t, w.Body.String(), string(hash))
+	})
+
+	t.Run("ListUsers", func(t *testing.T) {
+		ms := mocks.NewMockStore()
+		ms.ExpectListUsers(&v1alpha1.UserList{Items: []*v1alpha1.User{newUser()}}, nil)
+		router := setupTestRouter(ms)
+
+		w := performRequest(router, http.MethodGet, "/users", nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "testuser")
+		assert.NotContains(t, w.Body.String(), "passwordHash")
+	})
+
+	t.Run("Login", func(t *testing.T) {
+		ms := mocks.NewMockStore()
+		user := newUser()
+		ms.ExpectGetUser("testuser", user, nil)
+		ms.On("UpdateUser", mock.Anything, mock.Anything).Return(nil)
+
+		gin.SetMode(gin.TestMode)
+		handler := NewAuthHandler(controllers.NewAuthController(ms), jwt.NewJWTManager("test-secret", time.Hour), controllers.NewRBACController(ms))
+		router := gin.New()
+		router.Use(middleware.ErrorMiddleware())
+		router.POST("/login", handler.Login)
+
+		req := httptest.NewRequest(http.MethodPost, "/login", strings.NewReader(`{"username":"testuser","password":"password123"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "token")
+		assert.NotContains(t, w.Body.String(), "passwordHash")
+		// 내부 객체의 해시는 유지되어야 함
+		assert.Equal(t, string(hash), user.Spec.PasswordHash)
+	})
+}
+
+func TestAuthHandler_ValidationErrors(t *testing.T) {
+	ms := mocks.NewMockStore()
+	gin.SetMode(gin.TestMode)
+	handler := NewAuthHandler(controllers.NewAuthController(ms), nil, controllers.NewRBACController(ms))
+	router := gin.New()
+	router.Use(middleware.ErrorMiddleware())
+	router.POST("/roles", handler.CreateRole)
+
+	body := `{"metadata":{"name":""},"rules":[{"apiGroups":["*"]}]}`
+	req := httptest.NewRequest(http.MethodPost, "/roles", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var resp struct {
+		Errors []struct {
+			Field   string `json:"field"`
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	if assert.Len(t, resp.Errors, 3) {
+		assert.Equal(t, "metadata.name", resp.Errors[0].Field)
+		assert.Equal(t, "rules[0].verbs", resp.Errors[1].Field)
+		assert.Equal(t, "rules[0].resources", resp.Errors[2].Field)
+		assert.Equal(t, "resources are required in rule 0", resp.Errors[2].Message)
+	}
+	ms.AssertNotCalled(t, "CreateRole", mock.Anything, mock.Anything)
+}
+
+func TestAuthHandler_BindJSON(t *testing.T) {
+	ms := mocks.NewMockStore()
+	gin.SetMode(gin.TestMode)
+	handler := NewAuthHandler(controllers.NewAuthController(ms), nil, controllers.NewRBACController(ms))
+	router := gin.New()
+	router.Use(middleware.ErrorMiddleware())
+	router.POST("/users", handler.CreateUser)
+	router.POST("/login", handler.Login)
+	router.POST("/roles", handler.CreateRole)
+
+	type fieldError struct {
+		Field   string `json:"field"`
+		Message string `json:"message"`
+	}
+	post := func(path, body string) (int, []fieldError) {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var resp struct {
+			Error  *errors.StatusError `json:"error"`
+			Errors []fieldError        `json:"errors"`
+		}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		if assert.NotNil(t, resp.Error) {
+			assert.Equal(t, http.StatusBadRequest, resp.Error.Code)
+		}
+		return w.Code, resp.Errors
+	}
+
+	// 모든 핸들러가 잘못된 본문에 대해 같은 형태의 400을 반환해야 함
+	for _, path := range []string{"/users", "/login", "/roles"} {
+		t.Run("malformed "+path, func(t *testing.T) {
+			code, errs := post(path, `{"metadata":`)
+			assert.Equal(t, http.StatusBadRequest, code)
+			assert.Equal(t, []fieldError{{Field: "body", Message: "malformed JSON body"}}, errs)
+		})
+
+		t.Run("empty "+path, func(t *testing.T) {
+			code, errs := post(path, ``)
+			assert.Equal(t, http.StatusBadRequest, code)
+			assert.Equal(t, []fieldError{{Field: "body", Message: "request body is required"}}, errs)
+		})
+	}
+
+	t.Run("wrong type", func(t *testing.T) {
+		code, errs := post("/roles", `{"metadata":{"name":"viewer"},"rules":"all"}`)
+		assert.Equal(t, http.StatusBadRequest, code)
+		if assert.Len(t, errs, 1) {
+			assert.Equal(t, "rules", errs[0].Field)
+		}
+	})
+
+	t.Run("missing required fields", func(t *testing.T) {
+		code, errs := post("/login", `{}`)
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.Equal(t, []fieldError{
+			{Field: "username", Message: "username is required"},
+			{Field: "password", Message: "password is required"},
+		}, errs)
+	})
+
+	ms.AssertNotCalled(t, "CreateUser", mock.Anything, mock.Anything)
+	ms.AssertNotCalled(t, "CreateRole", mock.Anything, mock.Anything)
+}
+
+func TestAuthHandler_UpdateRole(t *testing.T) {
+	ms := mocks.NewMockStore()
+	gin.SetMode(gin.TestMode)
+	handler := NewAuthHandler(controllers.NewAuthController(ms), nil, controllers.NewRBACController(ms))
+	router := gin.New()
+	router.Use(middleware.ErrorMiddleware())
+	router.PUT("/roles/:name", handler.UpdateRole)
+
+	stored := &v1alpha1.Role{
+		ObjectMeta: metav1.ObjectMeta{Name: "reader"},
+		Rules:      []v1alpha1.PolicyRule{{Verbs: []string{"get"}, Resources: []string{"users"}, APIGroups: []string{"auth.service"}}},
+	}
+	ms.ExpectGetRole("reader", stored, nil)
+	ms.ExpectListRoleBindings(nil, nil)
+	ms.ExpectListClusterRoleBindings(nil, nil)
+	ms.ExpectUpdateRole(&v1alpha1.Role{
+		ObjectMeta: metav1.ObjectMeta{Name: "reader"},
+		Rules:      []v1alpha1.PolicyRule{{Verbs: []string{"get", "list"}, Resources: []string{"users"}, APIGroups: []string{"auth.service"}}},
+	}, nil).Once()
+
+	put := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPut, "/roles/reader", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// 이름은 URL 경로에서 가져옴
+	w := put(`{"rules":[{"verbs":["get","list"],"resources":["users"],"apiGroups":["auth.service"]}]}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var got v1alpha1.Role
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+	assert.Equal(t, "reader", got.Name)
+	assert.Equal(t, []string{"get", "list"}, got.Rules[0].Verbs)
+
+	// 변경 사항이 없으면 저장하지 않고 기존 Role을 반환
+	w = put(`{"rules":[{"verbs":["get"],"resources":["users"],"apiGroups":["auth.service"]}]}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	ms.AssertNumberOfCalls(t, "UpdateRole", 1)
+}
+
+func TestAuthHandler_UpdateRoleBinding(t *testing.T) {
+	ms := mocks.NewMockStore()
+	gin.SetMode(gin.TestMode)
+	handler := NewAuthHandler(controllers.NewAuthController(ms), nil, controllers.NewRBACController(ms))
+	router := gin.New()
+	router.Use(middleware.ErrorMiddleware())
+	router.PUT("/rolebindings/:name", handler.UpdateRoleBinding)
+
+	updated := &v1alpha1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "reader-binding"},
+		RoleRef:    v1alpha1.RoleRef{Kind: "Role", Name: "reader"},
+		Subjects:   []v1alpha1.Subject{{Kind: "Group", Name: "auditors"}},
+	}
+	ms.ExpectGetRoleBinding("reader-binding", &v1alpha1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "reader-binding"},
+		RoleRef:    v1alpha1.RoleRef{Kind: "Role", Name: "reader"},
+	}, nil)
+	ms.ExpectGetRole("reader", &v1alpha1.Role{ObjectMeta: metav1.ObjectMeta{Name: "reader"}}, nil)
+	ms.ExpectListRoleBindings(nil, nil)
+	ms.ExpectListClusterRoleBindings(nil, nil)
+	ms.ExpectUpdateRoleBinding(updated, nil)
+
+	// 이름은 URL 경로에서 가져옴
+	body := `{"roleRef":{"kind":"Role","name":"reader"},"subjects":[{"kind":"Group","name":"auditors"}]}`
+	req := httptest.NewRequest(http.MethodPut, "/rolebindings/reader-binding", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var got v1alpha1.RoleBinding
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+	assert.Equal(t, "reader-binding", got.Name)
+	assert.Equal(t, updated.Subjects, got.Subjects)
+	ms.AssertExpectations(t)
+}
+
+func TestAuthHandler_AddRoleBindingSubject(t *testing.T) {
+	ms := mocks.NewMockStore()
+	gin.SetMode(gin.TestMode)
+	handler := NewAuthHandler(controllers.NewAuthController(ms), nil,
+		controllers.NewRBACControllerWithConfig(ms, controllers.RBACControllerConfig{MaxSubjectsPerBinding: 1}))
+	router := gin.New()
+	router.Use(middleware.ErrorMiddleware())
+	router.POST("/rolebindings/:name/subjects", handler.AddRoleBindingSubject)
+
+	post := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/rolebindings/readers/subjects", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	binding := &v1alpha1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "readers"},
+		RoleRef:    v1alpha1.RoleRef{Kind: v1alpha1.RoleRefKindRole, Name: "reader"},
+	}
+	ms.ExpectGetRoleBinding("readers", binding, nil)
+	ms.ExpectUpdateRoleBinding(binding, nil)
+
+	w := post(`{"kind":"User","name":"alice"}`)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Len(t, binding.Subjects, 1)
+
+	// 제한을 넘는 subject는 저장하지 않고 거부
+	w = post(`{"kind":"User","name":"bob"}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Len(t, binding.Subjects, 1)
+	ms.AssertNumberOfCalls(t, "UpdateRoleBinding", 1)
+}
+
+func TestAuthHandler_ReviewAccess(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ms := mocks.NewMockStore()
+	ms.ExpectListRoleBindings([]*v1alpha1.RoleBinding{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "alice-reader"},
+			RoleRef:    v1alpha1.RoleRef{Kind: "Role", Name: "reader"},
+			Subjects:   []v1alpha1.Subject{{Kind: v1alpha1.SubjectKindUser, Name: "alice"}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "bob-admin"},
+			RoleRef:    v1alpha1.RoleRef{Kind: "Role", Name: "admin"},
+			Subjects:   []v1alpha1.Subject{{Kind: v1alpha1.SubjectKindUser, Name: "bob"}},
+		},
+	}, nil)
+	ms.ExpectGetRole("reader", &v1alpha1.Role{
+		ObjectMeta: metav1.ObjectMeta{Name: "reader"},
+		Rules: []v1alpha1.PolicyRule{{
+			Verbs:     []string{"get"},
+			Resources: []string{"users"},
+			APIGroups: []string{"auth.service"},
+		}},
+	}, nil)
+	ms.ExpectListClusterRoleBindings(nil, nil)
+
+	handler := NewAuthHandler(controllers.NewAuthController(ms), nil, controllers.NewRBACController(ms))
+	router := gin.New()
+	router.Use(middleware.ErrorMiddleware())
+	router.POST("/access/review", func(c *gin.Context) {
+		// JWTAuth가 설정하는 사용자 정보를 흉내냄
+		if user := c.GetHeader("X-Test-User"); user != "" {
+			c.Set("userID", user)
+		}
+		c.Next()
+	}, handler.ReviewAccess)
+
+	review := func(body, user string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/access/review", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if user != "" {
+			req.Header.Set("X-Test-User", user)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("allowed", func(t *testing.T) {
+		w := review(`{"verb":"get","resource":"users","apiGroup":"auth.service"}`, "alice")
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var result v1alpha1.AccessCheckResult
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+		assert.True(t, result.Allowed)
+		assert.Equal(t, "get", result.Verb)
+	})
+
+	t.Run("denied", func(t *testing.T) {
+		w := review(`{"verb":"delete","resource":"users","apiGroup":"auth.service"}`, "alice")
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var result v1alpha1.AccessCheckResult
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+		assert.False(t, result.Allowed)
+	})
+
+	t.Run("batch", func(t *testing.T) {
+		w := review(`[{"verb":"get","resource":"users"},{"verb":"create","resource":"roles"}]`, "alice")
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var results []v1alpha1.AccessCheckResult
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &results))
+		if assert.Len(t, results, 2) {
+			assert.True(t, results[0].Allowed)
+			assert.False(t, results[1].Allowed)
+		}
+	})
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		w := review(`{"verb":"get","resource":"users"}`, "")
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	// 다른 사용자의 바인딩은 평가에 사용되지 않음
+	ms.AssertNotCalled(t, "GetRole", mock.Anything, "admin")
+}
+
+func TestAuthHandler_ClusterRoles(t *testing.T) {
+	ms := mocks.NewMockStore()
+	gin.SetMode(gin.TestMode)
+	handler := NewAuthHandler(controllers.NewAuthController(ms), nil, controllers.NewRBACController(ms))
+	router := gin.New()
+	router.Use(middleware.ErrorMiddleware())
+	router.POST("/clusterroles", handler.CreateClusterRole)
+	router.POST("/clusterrolebindings", handler.CreateClusterRoleBinding)
+	router.DELETE("/clusterroles/:name", handler.DeleteClusterRole)
+
+	ms.On("CreateClusterRole", mock.Anything, mock.Anything).Return(nil)
+	ms.ExpectGetClusterRole("cluster-reader", &v1alpha1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "cluster-reader"}}, nil)
+	ms.On("CreateClusterRoleBinding", mock.Anything, mock.Anything).Return(nil)
+	ms.ExpectListClusterRoleBindings([]*v1alpha1.ClusterRoleBinding{{
+		ObjectMeta: metav1.ObjectMeta{Name: "alice-cluster-reader"},
+		RoleRef:    v1alpha1.RoleRef{Kind: v1alpha1.RoleRefKindClusterRole, Name: "cluster-reader"},
+	}}, nil)
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := do(http.MethodPost, "/clusterroles", `{"metadata":{"name":"cluster-reader"},"rules":[{"verbs":["get"],"resources":["users"],"apiGroups":["auth.service"]}]}`)
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	w = do(http.MethodPost, "/clusterrolebindings", `{"metadata":{"name":"alice-cluster-reader"},"roleRef":{"name":"cluster-reader"},"subjects":[{"kind":"User","name":"alice"}]}`)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var binding v1alpha1.ClusterRoleBinding
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &binding))
+	assert.Equal(t, v1alpha1.RoleRefKindClusterRole, binding.RoleRef.Kind)
+
+	// 참조 중인 ClusterRole은 삭제할 수 없음
+	w = do(http.MethodDelete, "/clusterroles/cluster-reader", "")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	ms.AssertNotCalled(t, "DeleteClusterRole", mock.Anything, mock.Anything)
+}
+
+func TestAuthHandler_DeleteUsersBatch(t *testing.T) {
+	ms := mocks.NewMockStore()
+	gin.SetMode(gin.TestMode)
+	handler := NewAuthHandler(controllers.NewAuthController(ms), nil, controllers.NewRBACController(ms))
+	router := gin.New()
+	router.Use(middleware.ErrorMiddleware())
+	router.POST("/users:action", handler.UserAction)
+
+	ms.ExpectListRoleBindings([]*v1alpha1.RoleBinding{}, nil)
+	ms.ExpectListClusterRoleBindings([]*v1alpha1.ClusterRoleBinding{}, nil)
+	ms.ExpectGetUser("alice", &v1alpha1.User{ObjectMeta: metav1.ObjectMeta{Name: "alice"}}, nil)
+	ms.ExpectGetUser("ghost", nil, errors.ErrUserNotFound)
+	ms.ExpectDeleteUser("alice", nil)
+
+	do := func(path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := do("/users:batchDelete", `{"names":["alice","ghost"]}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp struct {
+		Results []struct {
+			Name    string `json:"name"`
+			Deleted bool   `json:"deleted"`
+			Error   *struct {
+				Code int `json:"code"`
+			} `json:"error"`
+		} `json:"results"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	if assert.Len(t, resp.Results, 2) {
+		assert.Equal(t, "alice", resp.Results[0].Name)
+		assert.True(t, resp.Results[0].Deleted)
+		assert.Nil(t, resp.Results[0].Error)
+
+		assert.Equal(t, "ghost", resp.Results[1].Name)
+		assert.False(t, resp.Results[1].Deleted)
+		if assert.NotNil(t, resp.Results[1].Error) {
+			assert.Equal(t, http.StatusNotFound, resp.Results[1].Error.Code)
+		}
+	}
+
+	assert.Equal(t, http.StatusBadRequest, do("/users:batchDelete", `{}`).Code)
+	assert.Equal(t, http.StatusNotFound, do("/users:purge", `{"names":["alice"]}`).Code)
+}
+
+func TestAuthHandler_GetCurrentUser(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ms := mocks.NewMockStore()
+	ms.ExpectGetUser("alice", &v1alpha1.User{
+		ObjectMeta: metav1.ObjectMeta{Name: "alice"},
+		Spec: v1alpha1.UserSpec{
+			Username:     "alice",
+			Email:        "alice@example.com",
+			PasswordHash: "$2a$10$secret",
+		},
+	}, nil)
+	ms.ExpectListRoleBindings([]*v1alpha1.RoleBinding{{
+		ObjectMeta: metav1.ObjectMeta{Name: "alice-reader"},
+		RoleRef:    v1alpha1.RoleRef{Kind: "Role", Name: "reader"},
+		Subjects:   []v1alpha1.Subject{{Kind: v1alpha1.SubjectKindUser, Name: "alice"}},
+	}}, nil)
+	ms.ExpectGetRole("reader", &v1alpha1.Role{
+		ObjectMeta: metav1.ObjectMeta{Name: "reader"},
+		Rules: []v1alpha1.PolicyRule{{
+			Verbs:     []string{"get"},
+			Resources: []string{"users"},
+			APIGroups: []string{"auth.service"},
+		}},
+	}, nil)
+	ms.ExpectListClusterRoleBindings([]*v1alpha1.ClusterRoleBinding{{
+		ObjectMeta: metav1.ObjectMeta{Name: "alice-auditor"},
+		RoleRef:    v1alpha1.RoleRef{Kind: v1alpha1.RoleRefKindClusterRole, Name: "auditor"},
+		Subjects:   []v1alpha1.Subject{{Kind: v1alpha1.SubjectKindUser, Name: "alice"}},
+	}}, nil)
+	ms.ExpectGetClusterRole("auditor", &v1alpha1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{Name: "auditor"},
+		Rules: []v1alpha1.PolicyRule{{
+			Verbs:     []string{"get"},
+			Resources: []string{"rolebindings"},
+			APIGroups: []string{"auth.service"},
+		}},
+	}, nil)
+
+	jwtManager := jwt.NewJWTManager("test-secret", time.Hour)
+	handler := NewAuthHandler(controllers.NewAuthController(ms), jwtManager, controllers.NewRBACController(ms))
+	router := gin.New()
+	router.Use(middleware.ErrorMiddleware())
+	router.GET("/me", middleware.JWTAuth(jwtManager), handler.GetCurrentUser)
+
+	token, err := jwtManager.GenerateToken("alice", nil)
+	assert.NoError(t, err)
+
+	w := performRequest(router, http.MethodGet, "/me", map[string]string{"Authorization": "Bearer " + token})
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), "$2a$10$secret")
+
+	var resp struct {
+		User         v1alpha1.User         `json:"user"`
+		Roles        []string              `json:"roles"`
+		ClusterRoles []string              `json:"clusterRoles"`
+		Permissions  []v1alpha1.PolicyRule `json:"permissions"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "alice", resp.User.Name)
+	assert.Equal(t, []string{"reader"}, resp.Roles)
+	assert.Equal(t, []string{"auditor"}, resp.ClusterRoles)
+	if assert.Len(t, resp.Permissions, 2) {
+		assert.Equal(t, []string{"users"}, resp.Permissions[0].Resources)
+		assert.Equal(t, []string{"rolebindings"}, resp.Permissions[1].Resources)
+	}
+
+	w = performRequest(router, http.MethodGet, "/me", nil)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestAuthHandler_PatchUser(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	existing := func() *v1alpha1.User {
+		return &v1alpha1.User{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "alice",
+				Annotations: map[string]string{"team": "platform"},
+			},
+			Spec: v1alpha1.UserSpec{
+				Username:     "alice",
+				Email:        "alice@example.com",
+				PasswordHash: "$2a$10$secret",
+				Roles:        []string{"admin", "viewer"},
+				DisplayName:  "Alice",
+			},
+			Status: v1alpha1.UserStatus{Active: true},
+		}
+	}
+
+	patch := func(ms *mocks.MockStore, body, contentType string) *httptest.ResponseRecorder {
+		handler := NewAuthHandler(controllers.NewAuthController(ms), nil, controllers.NewRBACController(ms))
+		router := gin.New()
+		router.Use(middleware.ErrorMiddleware())
+		router.PATCH("/users/:name", handler.PatchUser)
+
+		req := httptest.NewRequest(http.MethodPatch, "/users/alice", strings.NewReader(body))
+		req.Header.Set("Content-Type", contentType)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("omitted fields are preserved", func(t *testing.T) {
+		ms := mocks.NewMockStore()
+		ms.ExpectGetUser("alice", existing(), nil)
+		var saved *v1alpha1.User
+		ms.On("UpdateUser", mock.Anything, mock.MatchedBy(func(u *v1alpha1.User) bool {
+			saved = u
+			return true
+		})).Return(nil)
+
+		w := patch(ms, `{"spec":{"displayName":"Alice Kim"}}`, "application/merge-patch+json")
+		assert.Equal(t, http.StatusOK, w.Code)
+		if assert.NotNil(t, saved) {
+			assert.Equal(t, "Alice Kim", saved.Spec.DisplayName)
+			assert.Equal(t, []string{"admin", "viewer"}, saved.Spec.Roles)
+			assert.Equal(t, map[string]string{"team": "platform"}, saved.Annotations)
+			assert.Equal(t, "alice@example.com", saved.Spec.Email)
+			assert.Equal(t, "$2a$10$secret", saved.Spec.PasswordHash)
+		}
+		assert.NotContains(t, w.Body.String(), "$2a$10$secret")
+	})
+
+	t.Run("email is not changed without verification", func(t *testing.T) {
+		ms := mocks.NewMockStore()
+		ms.ExpectGetUser("alice", existing(), nil)
+
+		w := patch(ms, `{"spec":{"email":"mallory@example.com"}}`, "application/merge-patch+json")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "alice@example.com")
+		ms.AssertNotCalled(t, "UpdateUser", mock.Anything, mock.Anything)
+	})
+
+	t.Run("null clears a field", func(t *testing.T) {
+		ms := mocks.NewMockStore()
+		ms.ExpectGetUser("alice", existing(), nil)
+		var saved *v1alpha1.User
+		ms.On("UpdateUser", mock.Anything, mock.MatchedBy(func(u *v1alpha1.User) bool {
+			saved = u
+			return true
+		})).Return(nil)
+
+		w := patch(ms, `{"metadata":{"annotations":null},"spec":{"displayName":null}}`, "application/merge-patch+json")
+		assert.Equal(t, http.StatusOK, w.Code)
+		if assert.NotNil(t, saved) {
+			assert.Empty(t, saved.Annotations)
+			assert.Empty(t, saved.Spec.DisplayName)
+			assert.Equal(t, []string{"admin", "viewer"}, saved.Spec.Roles)
+		}
+	})
+
+	t.Run("rename and bad media type are rejected", func(t *testing.T) {
+		ms := mocks.NewMockStore()
+		ms.ExpectGetUser("alice", existing(), nil)
+
+		w := patch(ms, `{"metadata":{"name":"mallory"}}`, "application/merge-patch+json")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = patch(ms, `{"spec":{"email":"x@example.com"}}`, "text/plain")
+		assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+
+		ms.AssertNotCalled(t, "UpdateUser", mock.Anything, mock.Anything)
+	})
+}
+
+func TestAuthHandler_PageSizeLimits(t *testing.T) {
+	ms := mocks.NewMockStore()
+	ms.On("ListRoleBindingsPaged", mock.Anything, 100, 0).Return([]*v1alpha1.RoleBinding{}, 0, nil)
+	ms.On("ListRoleBindingsPaged", mock.Anything, 20, 40).Return([]*v1alpha1.RoleBinding{}, 0, nil)
+	ms.On("ListRoleBindingsPaged", mock.Anything, 5, 0).Return([]*v1alpha1.RoleBinding{}, 0, nil)
+
+	gin.SetMode(gin.TestMode)
+	handler := NewAuthHandler(controllers.NewAuthController(ms), nil, controllers.NewRBACController(ms))
+	handler.SetPaginationConfig(PaginationConfig{DefaultPageSize: 20, MaxPageSize: 100})
+	router := gin.New()
+	router.Use(middleware.ErrorMiddleware())
+	router.GET("/rolebindings", handler.ListRoleBindings)
+
+	tests := []struct {
+		name string
+		path string
+		want string
+	}{
+		{"over-limit is clamped", "/rolebindings?limit=1000000", `{"items":[],"total":0,"limit":100,"offset":0}`},
+		{"absent limit gets default", "/rolebindings?offset=40", `{"items":[],"total":0,"limit":20,"offset":40}`},
+		{"in-range limit is kept", "/rolebindings?limit=5", `{"items":[],"total":0,"limit":5,"offset":0}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := performRequest(router, http.MethodGet, tt.path, nil)
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.JSONEq(t, tt.want, w.Body.String())
+		})
+	}
+	ms.AssertExpectations(t)
+}
+
+func TestAuthHandler_IntrospectToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ms := mocks.NewMockStore()
+	jwtManager := jwt.NewJWTManagerWithConfig(jwt.Config{
+		SecretKey:   "test-secret",
+		Expiry:      time.Hour,
+		Revocations: cache.NewMemoryCache(),
+	})
+	handler := NewAuthHandler(controllers.NewAuthController(ms), jwtManager, controllers.NewRBACController(ms))
+	router := gin.New()
+	router.Use(middleware.ErrorMiddleware())
+	router.POST("/token/introspect", handler.IntrospectToken)
+
+	introspect := func(token string) map[string]interface{} {
+		body, _ := json.Marshal(map[string]string{"token": token})
+		req := httptest.NewRequest(http.MethodPost, "/token/introspect", strings.NewReader(string(body)))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var resp map[string]interface{}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		return resp
+	}
+
+	t.Run("active token", func(t *testing.T) {
+		token, err := jwtManager.GenerateNamespacedToken("alice", "tenant-a", []string{"admin"})
+		assert.NoError(t, err)
+		claims, err := jwtManager.ValidateToken(token)
+		assert.NoError(t, err)
+
+		resp := introspect(token)
+		assert.Equal(t, true, resp["active"])
+		assert.Equal(t, "alice", resp["user"])
+		assert.Equal(t, []interface{}{"admin"}, resp["roles"])
+		assert.Equal(t, "tenant-a", resp["ns"])
+		assert.Equal(t, claims.ID, resp["jti"])
+		assert.Equal(t, float64(claims.ExpiresAt.Unix()), resp["exp"])
+		assert.Equal(t, float64(claims.IssuedAt.Unix()), resp["iat"])
+	})
+
+	t.Run("expired token", func(t *testing.T) {
+		expired := jwt.NewJWTManager("test-secret", -time.Minute)
+		token, err := expired.GenerateToken("alice", nil)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"active": false}, introspect(token))
+	})
+
+	t.Run("revoked token", func(t *testing.T) {
+		token, err := jwtManager.GenerateToken("alice", nil)
+		assert.NoError(t, err)
+		claims, err := jwtManager.ValidateToken(token)
+		assert.NoError(t, err)
+
+		assert.NoError(t, jwtManager.Revoke(context.Background(), claims))
+		assert.Equal(t, map[string]interface{}{"active": false}, introspect(token))
+	})
+
+	t.Run("missing token", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/token/introspect", strings.NewReader(`{}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestAuthHandler_UserRoles(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	user := func() *v1alpha1.User {
+		return &v1alpha1.User{
+			ObjectMeta: metav1.ObjectMeta{Name: "alice"},
+			Spec:       v1alpha1.UserSpec{Username: "alice", Roles: []string{"user"}},
+		}
+	}
+	setup := func(ms *mocks.MockStore) *gin.Engine {
+		handler := NewAuthHandler(controllers.NewAuthController(ms), nil, controllers.NewRBACController(ms))
+		router := gin.New()
+		router.Use(middleware.ErrorMiddleware())
+		router.PUT("/users/:name/roles", handler.SetRoles)
+		router.POST("/users/:name/roles", handler.AddRoles)
+		router.DELETE("/users/:name/roles", handler.RemoveRoles)
+		return router
+	}
+	send := func(router *gin.Engine, method, path, body string) int {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+	rolesAre := func(want ...string) interface{} {
+		return mock.MatchedBy(func(u *v1alpha1.User) bool {
+			return len(want) == len(u.Spec.Roles) && (len(want) == 0 || assert.ObjectsAreEqual(want, u.Spec.Roles))
+		})
+	}
+
+	t.Run("replace", func(t *testing.T) {
+		ms := mocks.NewMockStore()
+		ms.ExpectGetRole("admin", &v1alpha1.Role{}, nil)
+		ms.ExpectGetUser("alice", user(), nil)
+		ms.On("UpdateUser", mock.Anything, rolesAre("admin")).Return(nil)
+		assert.Equal(t, http.StatusOK, send(setup(ms), http.MethodPut, "/users/alice/roles", `{"roles":["admin"]}`))
+		ms.AssertExpectations(t)
+	})
+
+	t.Run("add", func(t *testing.T) {
+		ms := mocks.NewMockStore()
+		ms.ExpectGetRole("admin", &v1alpha1.Role{}, nil)
+		ms.ExpectGetUser("alice", user(), nil)
+		ms.On("UpdateUser", mock.Anything, rolesAre("user", "admin")).Return(nil)
+		assert.Equal(t, http.StatusOK, send(setup(ms), http.MethodPost, "/users/alice/roles", `{"roles":["admin"]}`))
+		ms.AssertExpectations(t)
+	})
+
+	t.Run("add unknown role", func(t *testing.T) {
+		ms := mocks.NewMockStore()
+		ms.ExpectGetRole("ghost", nil, errors.ErrRoleNotFound)
+		assert.Equal(t, http.StatusNotFound, send(setup(ms), http.MethodPost, "/users/alice/roles", `{"roles":["ghost"]}`))
+		errors.ErrRoleNotFound.Reason = ""
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		ms := mocks.NewMockStore()
+		ms.ExpectGetUser("alice", user(), nil)
+		ms.On("UpdateUser", mock.Anything, rolesAre()).Return(nil)
+		assert.Equal(t, http.StatusOK, send(setup(ms), http.MethodDelete, "/users/alice/roles?role=user", ""))
+		ms.AssertExpectations(t)
+	})
+
+	t.Run("remove without roles", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, send(setup(mocks.NewMockStore()), http.MethodDelete, "/users/alice/roles", ""))
+	})
+}
+
+func TestAuthHandler_ListUsersGzip(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	users := &v1alpha1.UserList{}
+	for i := 0; i < 50; i++ {
+		name := fmt.Sprintf("user-%02d", i)
+		users.Items = append(users.Items, &v1alpha1.User{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       v1alpha1.UserSpec{Username: name, Email: name + "@example.com"},
+		})
+	}
+	ms := mocks.NewMockStore()
+	ms.ExpectListUsers(users, nil)
+
+	newRouter := func(minSize int) *gin.Engine {
+		handler := NewAuthHandler(controllers.NewAuthController(ms), nil, controllers.NewRBACController(ms))
+		router := gin.New()
+		router.Use(middleware.Gzip(middleware.GzipConfig{Enabled: true, MinSize: minSize}), middleware.ErrorMiddleware())
+		router.GET("/users", handler.ListUsers)
+		return router
+	}
+
+	plain := performRequest(newRouter(0), http.MethodGet, "/users", nil)
+	assert.Equal(t, http.StatusOK, plain.Code)
+	assert.Greater(t, plain.Body.Len(), middleware.DefaultGzipMinSize)
+
+	t.Run("compressed when requested", func(t *testing.T) {
+		w := performRequest(newRouter(0), http.MethodGet, "/users", map[string]string{"Accept-Encoding": "gzip"})
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+		assert.Equal(t, "Accept-Encoding", w.Header().Get("Vary"))
+		assert.Less(t, w.Body.Len(), plain.Body.Len())
+
+		reader, err := gzip.NewReader(w.Body)
+		if !assert.NoError(t, err) {
+			return
+		}
+		body, err := io.ReadAll(reader)
+		assert.NoError(t, err)
+		assert.Equal(t, plain.Body.String(), string(body))
+	})
+
+	t.Run("plain without Accept-Encoding", func(t *testing.T) {
+		assert.Empty(t, plain.Header().Get("Content-Encoding"))
+		assert.Contains(t, plain.Body.String(), "user-00@example.com")
+	})
+
+	t.Run("plain below threshold", func(t *testing.T) {
+		w := performRequest(newRouter(1<<20), http.MethodGet, "/users", map[string]string{"Accept-Encoding": "gzip"})
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, w.Header().Get("Content-Encoding"))
+		assert.Equal(t, plain.Body.String(), w.Body.String())
+	})
+}
+
+func TestAuthHandler_LoginIgnoresBodyNamespace(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	assert.NoError(t, err)
+	ms := mocks.NewMockStore()
+	// 본문의 namespace와 무관하게 기본 namespace에서 조회되어야 함
+	defaultNamespace := mock.MatchedBy(func(ctx context.Context) bool {
+		return namespace.FromContext(ctx) == namespace.Default
+	})
+	ms.On("GetUser", defaultNamespace, "testuser").Return(&v1alpha1.User{
+		ObjectMeta: metav1.ObjectMeta{Name: "testuser", Namespace: namespace.Default},
+		Spec:       v1alpha1.UserSpec{Username: "testuser", PasswordHash: string(hash)},
+		Status:     v1alpha1.UserStatus{Active: true},
+	}, nil)
+	ms.On("UpdateUser", mock.Anything, mock.Anything).Return(nil)
+
+	jwtManager := jwt.NewJWTManager("test-secret", 15*time.Minute)
+	handler := NewAuthHandler(controllers.NewAuthController(ms), jwtManager, controllers.NewRBACController(ms))
+	router := gin.New()
+	router.Use(middleware.ErrorMiddleware())
+	router.POST("/login", handler.Login)
+
+	req := httptest.NewRequest(http.MethodPost, "/login",
+		strings.NewReader(`{"username":"testuser","password":"password123","namespace":"team-a"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp loginResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	claims, err := jwtManager.ValidateToken(resp.Token)
+	assert.NoError(t, err)
+	assert.Equal(t, namespace.Default, claims.Namespace)
+	ms.AssertExpectations(t)
+}
+
+func TestAuthHandler_LoginRememberMe(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Cleanup(func() {
+		errors.ErrInvalidInput.Reason = ""
+		errors.ErrInvalidToken.Reason = ""
+	})
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	assert.NoError(t, err)
+	ms := mocks.NewMockStore()
+	user := &v1alpha1.User{
+		ObjectMeta: metav1.ObjectMeta{Name: "testuser"},
+		Spec:       v1alpha1.UserSpec{Username: "testuser", PasswordHash: string(hash), Roles: []string{"viewer"}},
+		Status:     v1alpha1.UserStatus{Active: true},
+	}
+	ms.On("GetUser", mock.Anything, "testuser").Return(user, nil)
+	ms.On("UpdateUser", mock.Anything, mock.Anything).Return(nil)
+
+	newRouter := func(jwtManager *jwt.JWTManager) *gin.Engine {
+		handler := NewAuthHandler(controllers.NewAuthController(ms), jwtManager, controllers.NewRBACController(ms))
+		router := gin.New()
+		router.Use(middleware.ErrorMiddleware())
+		router.POST("/login", handler.Login)
+		router.POST("/token/refresh", handler.RefreshToken)
+		return router
+	}
+	post := func(router *gin.Engine, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	jwtManager := jwt.NewJWTManagerWithConfig(jwt.Config{
+		SecretKey:        "test-secret",
+		Expiry:           15 * time.Minute,
+		RefreshExpiry:    24 * time.Hour,
+		RememberMeExpiry: 30 * 24 * time.Hour,
+	})
+	router := newRouter(jwtManager)
+
+	login := func(t *testing.T, body string) (access, refresh *jwt.Claims, refreshToken string) {
+		w := post(router, "/login", body)
+		if !assert.Equal(t, http.StatusOK, w.Code) {
+			return nil, nil, ""
+		}
+		var resp loginResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+
+		access, err := jwtManager.ValidateToken(resp.Token)
+		assert.NoError(t, err)
+		refresh, err = jwtManager.ValidateRefreshToken(resp.RefreshToken)
+		assert.NoError(t, err)
+		return access, refresh, resp.RefreshToken
+	}
+	lifetime := func(claims *jwt.Claims) time.Duration {
+		return claims.ExpiresAt.Sub(claims.IssuedAt.Time)
+	}
+
+	t.Run("rememberMe extends refresh token only", func(t *testing.T) {
+		access, refresh, _ := login(t, `{"username":"testuser","password":"password123","rememberMe":true}`)
+		if assert.NotNil(t, refresh) {
+			assert.Equal(t, 15*time.Minute, lifetime(access))
+			assert.Equal(t, 30*24*time.Hour, lifetime(refresh))
+		}
+	})
+
+	t.Run("standard login uses refresh TTL", func(t *testing.T) {
+		access, refresh, _ := login(t, `{"username":"testuser","password":"password123"}`)
+		if assert.NotNil(t, refresh) {
+			assert.Equal(t, 15*time.Minute, lifetime(access))
+			assert.Equal(t, 24*time.Hour, lifetime(refresh))
+		}
+	})
+
+	t.Run("refresh issues a new access token", func(t *testing.T) {
+		_, _, refreshToken := login(t, `{"username":"testuser","password":"password123"}`)
+
+		w := post(router, "/token/refresh", `{"refreshToken":"`+refreshToken+`"}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var resp refreshResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		claims, err := jwtManager.ValidateToken(resp.Token)
+		if assert.NoError(t, err) {
+			assert.Empty(t, claims.Type)
+			assert.Equal(t, []string{"viewer"}, claims.Roles)
+		}
+
+		// access token으로는 갱신할 수 없음
+		w = post(router, "/token/refresh", `{"refreshToken":"`+resp.Token+`"}`)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("refresh rejected for an inactive user", func(t *testing.T) {
+		_, _, refreshToken := login(t, `{"username":"testuser","password":"password123"}`)
+		user.Status.Active = false
+		defer func() { user.Status.Active = true }()
+
+		w := post(router, "/token/refresh", `{"refreshToken":"`+refreshToken+`"}`)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("refresh rejected after a password change", func(t *testing.T) {
+		_, _, refreshToken := login(t, `{"username":"testuser","password":"password123"}`)
+		user.Status.PasswordChangedAt = &metav1.Time{Time: time.Now().Add(time.Minute)}
+		defer func() { user.Status.PasswordChangedAt = nil }()
+
+		w := post(router, "/token/refresh", `{"refreshToken":"`+refreshToken+`"}`)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("rememberMe rejected when refresh tokens are disabled", func(t *testing.T) {
+		w := post(newRouter(jwt.NewJWTManager("test-secret", time.Hour)), "/login",
+			`{"username":"testuser","password":"password123","rememberMe":true}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "refresh tokens")
+	})
+}
+
+func TestAuthHandler_ListUsersCursor(t *testing.T) {
+	ms := mocks.NewMockStore()
+	next := cursor.Encode(cursor.Cursor{ID: "bob"})
+	page := func(names ...string) *v1alpha1.UserList {
+		list := &v1alpha1.UserList{}
+		for _, name := range names {
+			list.Items = append(list.Items, &v1alpha1.User{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				Spec:       v1alpha1.UserSpec{Username: name, PasswordHash: "$2a$10$hash"},
+			})
+		}
+		return list
+	}
+	first := page("alice", "bob")
+	first.Continue = next
+	ms.ExpectListUsersWithOptions(v1alpha1.ListOptions{Limit: 2}, first, nil)
+	ms.ExpectListUsersWithOptions(v1alpha1.ListOptions{Limit: 2, Cursor: next}, page("carol"), nil)
+
+	router := setupTestRouter(ms)
+
+	var resp struct {
+		Items      []*v1alpha1.User `json:"items"`
+		NextCursor string           `json:"nextCursor"`
+	}
+	w := performRequest(router, http.MethodGet, "/users?limit=2", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), "$2a$10$hash")
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Len(t, resp.Items, 2)
+	assert.Equal(t, next, resp.NextCursor)
+
+	w = performRequest(router, http.MethodGet, "/users?limit=2&cursor="+resp.NextCursor, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), "nextCursor")
+
+	w = performRequest(router, http.MethodGet, "/users?cursor=garbage", nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestAuthHandler_GetUserRoles(t *testing.T) {
+	ms := mocks.NewMockStore()
+	alice := v1alpha1.Subject{Kind: v1alpha1.SubjectKindUser, Name: "alice"}
+	ms.ExpectListRoleBindings([]*v1alpha1.RoleBinding{
+		{ObjectMeta: metav1.ObjectMeta{Name: "a"}, RoleRef: v1alpha1.RoleRef{Kind: "Role", Name: "reader"}, Subjects: []v1alpha1.Subject{alice}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "b"}, RoleRef: v1alpha1.RoleRef{Kind: "Role", Name: "writer"}, Subjects: []v1alpha1.Subject{alice}},
+	}, nil)
+	ms.ExpectGetRole("reader", &v1alpha1.Role{ObjectMeta: metav1.ObjectMeta{Name: "reader"}}, nil)
+	ms.ExpectGetRole("writer", &v1alpha1.Role{ObjectMeta: metav1.ObjectMeta{Name: "writer"}}, nil)
+	router := setupTestRouter(ms)
+
+	w := performRequest(router, http.MethodGet, "/users/alice/roles", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp struct {
+		Items []v1alpha1.Role `json:"items"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	if assert.Len(t, resp.Items, 2) {
+		assert.Equal(t, "reader", resp.Items[0].Name)
+		assert.Equal(t, "writer", resp.Items[1].Name)
+	}
+}
+
+func TestAuthHandler_CreateRoleBindingRoleRefKind(t *testing.T) {
+	ms := mocks.NewMockStore()
+	gin.SetMode(gin.TestMode)
+	handler := NewAuthHandler(controllers.NewAuthController(ms), nil, controllers.NewRBACController(ms))
+	router := gin.New()
+	router.Use(middleware.ErrorMiddleware())
+	router.POST("/rolebindings", handler.CreateRoleBinding)
+
+	ms.ExpectGetRole("reader", &v1alpha1.Role{ObjectMeta: metav1.ObjectMeta{Name: "reader"}}, nil)
+	ms.On("CreateRoleBinding", mock.Anything, mock.Anything).Return(nil)
+
+	do := func(kind string) *httptest.ResponseRecorder {
+		body := `{"metadata":{"name":"alice-reader"},"roleRef":{"kind":"` + kind + `","name":"reader"},"subjects":[{"kind":"User","name":"alice"}]}`
+		req := httptest.NewRequest(http.MethodPost, "/rolebindings", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	for _, kind := range []string{"ClusterRole", "Rol"} {
+		w := do(kind)
+		assert.Equal(t, http.StatusBadRequest, w.Code, kind)
+
+		var resp struct {
+			Errors []struct {
+				Field string `json:"field"`
+			} `json:"errors"`
+		}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		if assert.Len(t, resp.Errors, 1) {
+			assert.Equal(t, "roleRef.kind", resp.Errors[0].Field)
+		}
+	}
+	ms.AssertNotCalled(t, "CreateRoleBinding", mock.Anything, mock.Anything)
+
+	w := do("Role")
+	assert.Equal(t, http.StatusCreated, w.Code)
+	ms.AssertCalled(t, "CreateRoleBinding", mock.Anything, mock.Anything)
+}
+
+func TestAuthHandler_RegisterUser(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	newRouter := func(ms *mocks.MockStore, allow bool) *gin.Engine {
+		controller := controllers.NewAuthControllerWithConfig(ms, controllers.AuthControllerConfig{
+			AllowSelfRegistration: allow,
+			DefaultRoles:          []string{"viewer"},
+		})
+		handler := NewAuthHandler(controller, nil, controllers.NewRBACController(ms))
+		router := gin.New()
+		router.Use(middleware.ErrorMiddleware())
+		router.POST("/register", handler.RegisterUser)
+		return router
+	}
+	register := func(router *gin.Engine, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/register", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		ms := mocks.NewMockStore()
+		w := register(newRouter(ms, false), `{"name":"mallory","password":"correct-horse"}`)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		ms.AssertNotCalled(t, "CreateUser", mock.Anything, mock.Anything)
+	})
+
+	t.Run("enabled assigns only default roles", func(t *testing.T) {
+		ms := mocks.NewMockStore()
+		ms.ExpectGetRole("viewer", &v1alpha1.Role{ObjectMeta: metav1.ObjectMeta{Name: "viewer"}}, nil)
+		ms.On("FindUserByEmail", mock.Anything, "mallory@example.com").Return(nil, errors.ErrUserNotFound)
+		ms.On("CreateUser", mock.Anything, mock.Anything).Return(nil)
+
+		// 본문에 roles/status를 넣어도 무시됨
+		w := register(newRouter(ms, true), `{"name":"mallory","email":"mallory@example.com","password":"correct-horse",
+			"roles":["admin"],"spec":{"roles":["admin"]},"status":{"active":false}}`)
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		var created v1alpha1.User
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+		assert.Equal(t, []string{"viewer"}, created.Spec.Roles)
+		assert.True(t, created.Status.Active)
+		assert.Empty(t, created.Spec.PasswordHash)
+		ms.AssertCalled(t, "CreateUser", mock.Anything, mock.MatchedBy(func(u *v1alpha1.User) bool {
+			return u.Name == "mallory" && len(u.Spec.Roles) == 1 && u.Spec.Roles[0] == "viewer"
+		}))
+	})
+
+	t.Run("password policy", func(t *testing.T) {
+		ms := mocks.NewMockStore()
+		w := register(newRouter(ms, true), `{"name":"mallory","password":"short"}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), `"password"`)
+		ms.AssertNotCalled(t, "CreateUser", mock.Anything, mock.Anything)
+	})
+}
+
+func TestAuthHandler_WatchUsers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	bus := events.NewMemoryBus(0)
+	ms := mocks.NewMockStore()
+	ms.On("CreateUser", mock.Anything, mock.Anything).Return(nil)
+	ms.On("DeleteUser", mock.Anything, "alice").Return(nil)
+
+	controller := controllers.NewAuthControllerWithConfig(ms, controllers.AuthControllerConfig{Events: bus, AllowRemovingLastAdmin: true})
+	handler := NewAuthHandler(controller, nil, controllers.NewRBACController(ms))
+	handler.SetEventBus(bus)
+	router := gin.New()
+	router.Use(middleware.ErrorMiddleware())
+	// SSE 응답은 압축 미들웨어를 거쳐도 바로 전달되어야 함
+	router.Use(middleware.Gzip(middleware.GzipConfig{Enabled: true, MinSize: 1}))
+	router.GET("/users:action", handler.UserGetAction)
+
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/users:watch", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	resp, err := http.DefaultTransport.RoundTrip(req)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	assert.Empty(t, resp.Header.Get("Content-Encoding"))
+
+	type watchEvent struct {
+		Type      string        `json:"type"`
+		Kind      string        `json:"kind"`
+		Name      string        `json:"name"`
+		Namespace string        `json:"namespace"`
+		Object    v1alpha1.User `json:"object"`
+	}
+	reader := bufio.NewReader(resp.Body)
+	next := func() (string, watchEvent) {
+		var eventName, data string
+		var event watchEvent
+		for data == "" {
+			line, err := reader.ReadString('\n')
+			if !assert.NoError(t, err) {
+				return "", event
+			}
+			switch {
+			case strings.HasPrefix(line, "event:"):
+				eventName = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+			case strings.HasPrefix(line, "data:"):
+				data = strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+			}
+		}
+		assert.NoError(t, json.Unmarshal([]byte(data), &event))
+		return eventName, event
+	}
+
+	// 응답 헤더를 받은 시점에는 구독이 완료되어 있음. 다른 namespace의 변경은 전달되지 않음
+	_, err = controller.CreateUser(namespace.WithNamespace(context.Background(), "team-a"), &v1alpha1.User{
+		ObjectMeta: metav1.ObjectMeta{Name: "bob", Namespace: "team-a"},
+		Spec:       v1alpha1.UserSpec{PasswordHash: "password123"},
+	})
+	assert.NoError(t, err)
+	_, err = controller.CreateUser(context.Background(), &v1alpha1.User{
+		ObjectMeta: metav1.ObjectMeta{Name: "alice"},
+		Spec:       v1alpha1.UserSpec{PasswordHash: "password123"},
+	})
+	assert.NoError(t, err)
+
+	eventName, event := next()
+	assert.Equal(t, "ADDED", eventName)
+	assert.Equal(t, "User", event.Kind)
+	assert.Equal(t, "alice", event.Name)
+	assert.Equal(t, namespace.Default, event.Namespace)
+	assert.Empty(t, event.Object.Spec.PasswordHash)
+
+	// 삭제 이벤트에도 namespace가 기록되어 같은 watch로 전달됨
+	assert.NoError(t, controller.DeleteUser(context.Background(), "alice"))
+	eventName, event = next()
+	assert.Equal(t, "DELETED", eventName)
+	assert.Equal(t, "alice", event.Name)
+	assert.Equal(t, namespace.Default, event.Namespace)
+
+	t.Run("without bus", func(t *testing.T) {
+		plain := NewAuthHandler(controllers.NewAuthController(ms), nil, controllers.NewRBACController(ms))
+		r := gin.New()
+		r.Use(middleware.ErrorMiddleware())
+		r.GET("/users:action", plain.UserGetAction)
+		w := performRequest(r, http.MethodGet, "/users:watch", nil)
+		assert.Equal(t, http.StatusNotImplemented, w.Code)
+	})
+}
+
+func TestAuthHandler_ListEnvelope(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ms := mocks.NewMockStore()
+	ms.On("ListRolesPaged", mock.Anything, 1, 1).Return([]*v1alpha1.Role{
+		{ObjectMeta: metav1.ObjectMeta{Name: "role-b"}},
+	}, 3, nil)
+	ms.On("ListRoleBindingsPaged", mock.Anything, 2, 0).Return([]*v1alpha1.RoleBinding{
+		{ObjectMeta: metav1.ObjectMeta{Name: "binding-a"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "binding-b"}},
+	}, 5, nil)
+	ms.ExpectListUsers(&v1alpha1.UserList{Items: []*v1alpha1.User{
+		{ObjectMeta: metav1.ObjectMeta{Name: "alice"}, Spec: v1alpha1.UserSpec{PasswordHash: "secret"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "bob"}},
+	}}, nil)
+
+	newRouter := func(cfg PaginationConfig) *gin.Engine {
+		handler := NewAuthHandler(controllers.NewAuthController(ms), nil, controllers.NewRBACController(ms))
+		handler.SetPaginationConfig(cfg)
+		router := gin.New()
+		router.Use(middleware.ErrorMiddleware())
+		router.GET("/users", handler.ListUsers)
+		router.GET("/roles", handler.ListRoles)
+		router.GET("/rolebindings", handler.ListRoleBindings)
+		return router
+	}
+	type envelope struct {
+		Items    []map[string]interface{} `json:"items"`
+		Metadata map[string]interface{}   `json:"metadata"`
+	}
+	get := func(router *gin.Engine, path string, headers map[string]string) envelope {
+		w := performRequest(router, http.MethodGet, path, headers)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var body envelope
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body), w.Body.String())
+		return body
+	}
+
+	t.Run("configured", func(t *testing.T) {
+		router := newRouter(PaginationConfig{Envelope: true})
+
+		roles := get(router, "/roles?limit=1&offset=1", nil)
+		assert.Len(t, roles.Items, 1)
+		assert.Equal(t, map[string]interface{}{"total": float64(3), "limit": float64(1), "offset": float64(1)}, roles.Metadata)
+
+		bindings := get(router, "/rolebindings?limit=2", nil)
+		assert.Len(t, bindings.Items, 2)
+		assert.Equal(t, float64(5), bindings.Metadata["total"])
+
+		users := get(router, "/users", nil)
+		assert.Len(t, users.Items, 2)
+		assert.Equal(t, map[string]interface{}{"total": float64(2)}, users.Metadata)
+		assert.NotContains(t, users.Items[0]["spec"], "passwordHash")
+	})
+
+	t.Run("accept header", func(t *testing.T) {
+		router := newRouter(PaginationConfig{})
+
+		roles := get(router, "/roles?limit=1&offset=1", map[string]string{"Accept": ListEnvelopeMediaType})
+		assert.Equal(t, float64(3), roles.Metadata["total"])
+
+		// 헤더가 없으면 기존 응답 형식 유지
+		w := performRequest(router, http.MethodGet, "/users", nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NotContains(t, w.Body.String(), `"metadata":{"total"`)
+	})
+}
@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/sukryu/pAuth/pkg/errors"
+)
+
+// bindJSON decodes the request body into a T and runs its binding validation.
+// 실패 시 ValidationError를 c.Error로 기록하고 false를 반환하므로 핸들러는 바로 return하면 됨
+func bindJSON[T any](c *gin.Context) (T, bool) {
+	var v T
+	if err := c.ShouldBindJSON(&v); err != nil {
+		c.Error(bindError(reflect.TypeOf(v), err))
+		return v, false
+	}
+	return v, true
+}
+
+// bindError converts a ShouldBindJSON failure into field-level validation errors
+func bindError(t reflect.Type, err error) *errors.ValidationError {
+	verr := errors.NewValidationError()
+
+	var (
+		fieldErrs validator.ValidationErrors
+		typeErr   *json.UnmarshalTypeError
+		syntaxErr *json.SyntaxError
+	)
+	switch {
+	case stderrors.As(err, &fieldErrs):
+		for _, fe := range fieldErrs {
+			name := jsonFieldName(t, fe.StructField())
+			verr.Add(name, validationMessage(name, fe))
+		}
+	case stderrors.As(err, &typeErr):
+		field := typeErr.Field
+		if field == "" {
+			field = "body"
+		}
+		verr.Add(field, fmt.Sprintf("%s must be of type %s", field, typeErr.Type))
+	case stderrors.Is(err, io.EOF):
+		verr.Add("body", "request body is required")
+	case stderrors.As(err, &syntaxErr), stderrors.Is(err, io.ErrUnexpectedEOF):
+		verr.Add("body", "malformed JSON body")
+	default:
+		verr.Add("body", err.Error())
+	}
+	return verr
+}
+
+// jsonFieldName returns the json name of the top-level struct field, falling back to the Go name
+func jsonFieldName(t reflect.Type, field string) string {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return field
+	}
+	sf, ok := t.FieldByName(field)
+	if !ok {
+		return field
+	}
+	name, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+	if name == "" || name == "-" {
+		return field
+	}
+	return name
+}
+
+func validationMessage(name string, fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return name + " is required"
+	case "email":
+		return "invalid email address"
+	default:
+		return fmt.Sprintf("%s failed %s validation", name, fe.Tag())
+	}
+}
@@ -0,0 +1,324 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sukryu/pAuth/internal/store/dynamic"
+	"github.com/sukryu/pAuth/internal/store/dynamic/query"
+	"github.com/sukryu/pAuth/internal/store/interfaces"
+	"github.com/sukryu/pAuth/internal/store/schema"
+	"github.com/sukryu/pAuth/pkg/errors"
+)
+
+// DynamicHandler exposes CRUD on dynamic entity tables over HTTP.
+// 등록된 스키마의 테이블만 노출되며 (users 등 코어 테이블 보호) 요청은 스키마 제약으로 검증됨
+type DynamicHandler struct {
+	store      interfaces.DynamicStore
+	schemas    map[string]*schema.EntitySchema
+	pagination PaginationConfig
+}
+
+func NewDynamicHandler(store interfaces.DynamicStore, schemas ...schema.EntitySchema) *DynamicHandler {
+	h := &DynamicHandler{
+		store:   store,
+		schemas: make(map[string]*schema.EntitySchema, len(schemas)),
+	}
+	for i := range schemas {
+		h.schemas[schemas[i].Name] = &schemas[i]
+	}
+	return h
+}
+
+// SetPaginationConfig sets the default and maximum page size of List
+func (h *DynamicHandler) SetPaginationConfig(cfg PaginationConfig) {
+	h.pagination = cfg
+}
+
+// Register mounts the CRUD routes under /api/v1/dynamic/:table. middleware runs before every route
+// (인증과 middleware.RequirePermissionForParam 등), createMiddleware only on POST (Idempotency-Key 처리 등)
+func (h *DynamicHandler) Register(router gin.IRouter, middleware []gin.HandlerFunc, createMiddleware ...gin.HandlerFunc) {
+	group := router.Group("/api/v1/dynamic")
+	group.Use(middleware...)
+	{
+		group.GET("/:table", h.List)
+		group.POST("/:table", withHandler(createMiddleware, h.Create)...)
+		group.GET("/:table/:id", h.Get)
+		group.PUT("/:table/:id", h.Update)
+		group.DELETE("/:table/:id", h.Delete)
+	}
+}
+
+// entity returns the registered schema of the :table parameter
+func (h *DynamicHandler) entity(c *gin.Context) (*schema.EntitySchema, bool) {
+	entity, ok := h.schemas[c.Param("table")]
+	if !ok {
+		c.Error(errors.ErrNotFound.WithReason(fmt.Sprintf("unknown table %q", c.Param("table"))))
+	}
+	return entity, ok
+}
+
+// List returns one page of live rows ordered by creation time.
+// 동적 테이블은 크기 제한이 없으므로 limit/offset이 없어도 기본 페이지 크기를 적용함
+func (h *DynamicHandler) List(c *gin.Context) {
+	entity, ok := h.entity(c)
+	if !ok {
+		return
+	}
+
+	limit, offset, paged, err := parsePagination(c, h.pagination)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if !paged {
+		limit = h.pagination.withDefaults().DefaultPageSize
+	}
+
+	ctx := c.Request.Context()
+	total, err := h.store.CountActive(ctx, entity.Name)
+	if err != nil {
+		c.Error(errors.ErrStorageOperation.WithReason(err.Error()))
+		return
+	}
+	rows, err := h.store.DynamicQuery(ctx, entity.Name, query.QueryParams{
+		Where:   []query.WhereCondition{{Column: "deleted_at", Operator: query.OpIsNull}},
+		OrderBy: []query.OrderByClause{{Column: "created_at"}, {Column: "id"}},
+		Limit:   limit,
+		Offset:  offset,
+	})
+	if err != nil {
+		c.Error(errors.ErrStorageOperation.WithReason(err.Error()))
+		return
+	}
+	for _, row := range rows {
+		decodeJSONFields(row, entity)
+	}
+
+	if respondEnvelope(c, h.pagination, rows, listMetadata{Total: totalOf(total), Limit: limit, Offset: offset}) {
+		return
+	}
+	c.JSON(http.StatusOK, pagedResponse{Items: rows, Total: total, Limit: limit, Offset: offset})
+}
+
+// Create inserts the JSON body after ValidateAgainstSchema; id가 없으면 생성
+func (h *DynamicHandler) Create(c *gin.Context) {
+	entity, ok := h.entity(c)
+	if !ok {
+		return
+	}
+
+	data, ok := bindEntity(c, entity)
+	if !ok {
+		return
+	}
+
+	row, err := dynamicRow(data, entity)
+	if err != nil {
+		c.Error(errors.ErrInternal.WithReason(err.Error()))
+		return
+	}
+	if id, _ := row["id"].(string); id == "" {
+		if row["id"], err = generateDynamicID(); err != nil {
+			c.Error(errors.ErrInternal.WithReason(err.Error()))
+			return
+		}
+	}
+	if err := h.store.DynamicInsert(c.Request.Context(), entity.Name, row); err != nil {
+		storeError(c, err)
+		return
+	}
+
+	h.respondRow(c, http.StatusCreated, entity, row["id"].(string))
+}
+
+func (h *DynamicHandler) Get(c *gin.Context) {
+	entity, ok := h.entity(c)
+	if !ok {
+		return
+	}
+	h.respondRow(c, http.StatusOK, entity, c.Param("id"))
+}
+
+// Update replaces the row's fields with the JSON body (PUT: required 필드 모두 필요).
+// 생략된 필드는 기본값(없으면 NULL)으로 되돌림. key column과 auto increment 필드는 유지
+func (h *DynamicHandler) Update(c *gin.Context) {
+	entity, ok := h.entity(c)
+	if !ok {
+		return
+	}
+	id := c.Param("id")
+
+	data, ok := bindEntity(c, entity)
+	if !ok {
+		return
+	}
+	if bodyID, present := data["id"]; present {
+		if bodyID != id {
+			c.Error(errors.ErrInvalidInput.WithReason("id in body does not match the path"))
+			return
+		}
+		delete(data, "id")
+	}
+
+	if _, ok := h.findRow(c, entity, id); !ok {
+		return
+	}
+	resetOmittedFields(data, entity)
+
+	row, err := dynamicRow(data, entity)
+	if err != nil {
+		c.Error(errors.ErrInternal.WithReason(err.Error()))
+		return
+	}
+	if len(row) > 0 {
+		if err := h.store.DynamicUpdate(c.Request.Context(), entity.Name, id, row); err != nil {
+			storeError(c, err)
+			return
+		}
+	}
+
+	h.respondRow(c, http.StatusOK, entity, id)
+}
+
+// Delete soft-deletes the row
+func (h *DynamicHandler) Delete(c *gin.Context) {
+	entity, ok := h.entity(c)
+	if !ok {
+		return
+	}
+	id := c.Param("id")
+
+	if _, ok := h.findRow(c, entity, id); !ok {
+		return
+	}
+	if err := h.store.DynamicDelete(c.Request.Context(), entity.Name, id); err != nil {
+		c.Error(errors.ErrStorageOperation.WithReason(err.Error()))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// storeError reports a write failure. 제약 위반은 SQL 메시지를 노출하지 않고 필드 단위 400으로 보고하며,
+// store가 이미 변환한 *errors.ValidationError는 그대로 전달됨
+func storeError(c *gin.Context, err error) {
+	var verr *errors.ValidationError
+	if stderrors.As(err, &verr) {
+		c.Error(verr)
+		return
+	}
+	if verr, ok := dynamic.ConstraintViolation(err); ok {
+		if verr != nil {
+			c.Error(verr)
+		} else {
+			c.Error(errors.ErrInvalidInput.WithReason("request violates a table constraint"))
+		}
+		return
+	}
+	c.Error(errors.ErrStorageOperation.WithReason(err.Error()))
+}
+
+// findRow returns the live row with id, reporting 404 when it does not exist or was deleted
+func (h *DynamicHandler) findRow(c *gin.Context, entity *schema.EntitySchema, id string) (map[string]interface{}, bool) {
+	rows, err := h.store.DynamicSelect(c.Request.Context(), entity.Name, map[string]interface{}{"id": id})
+	if err != nil {
+		c.Error(errors.ErrStorageOperation.WithReason(err.Error()))
+		return nil, false
+	}
+	if len(rows) == 0 {
+		c.Error(errors.ErrNotFound.WithReason(fmt.Sprintf("%s %q not found", entity.Name, id)))
+		return nil, false
+	}
+	return rows[0], true
+}
+
+func (h *DynamicHandler) respondRow(c *gin.Context, status int, entity *schema.EntitySchema, id string) {
+	row, ok := h.findRow(c, entity, id)
+	if !ok {
+		return
+	}
+	decodeJSONFields(row, entity)
+	c.JSON(status, row)
+}
+
+// bindEntity decodes the JSON body and validates it against entity
+func bindEntity(c *gin.Context, entity *schema.EntitySchema) (map[string]interface{}, bool) {
+	var data map[string]interface{}
+	if err := c.ShouldBindJSON(&data); err != nil {
+		c.Error(errors.ErrInvalidInput.WithReason(err.Error()))
+		return nil, false
+	}
+	if err := schema.ValidateAgainstSchema(data, entity); err != nil {
+		c.Error(err)
+		return nil, false
+	}
+	return data, true
+}
+
+// dynamicRow converts validated request data into column values (JSON 필드는 문자열로 직렬화)
+func dynamicRow(data map[string]interface{}, entity *schema.EntitySchema) (map[string]interface{}, error) {
+	row := make(map[string]interface{}, len(data)+1)
+	for k, v := range data {
+		row[k] = v
+	}
+	for _, field := range entity.Fields {
+		value, ok := row[field.Name]
+		if !ok || value == nil || field.Type != schema.FieldTypeJSON {
+			continue
+		}
+		if _, isString := value.(string); isString {
+			continue
+		}
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		row[field.Name] = string(encoded)
+	}
+	return row, nil
+}
+
+// resetOmittedFields fills fields missing from a PUT body with their default so the body replaces the row
+func resetOmittedFields(data map[string]interface{}, entity *schema.EntitySchema) {
+	keys := make(map[string]bool, len(entity.KeyColumns))
+	for _, key := range entity.KeyColumns {
+		keys[key] = true
+	}
+	for _, field := range entity.Fields {
+		if _, present := data[field.Name]; present || keys[field.Name] || field.AutoIncrement {
+			continue
+		}
+		data[field.Name] = field.DefaultValue
+	}
+}
+
+// decodeJSONFields turns stored JSON strings back into values so responses mirror requests
+func decodeJSONFields(row map[string]interface{}, entity *schema.EntitySchema) {
+	for _, field := range entity.Fields {
+		if field.Type != schema.FieldTypeJSON {
+			continue
+		}
+		raw, ok := row[field.Name].(string)
+		if !ok {
+			continue
+		}
+		var decoded interface{}
+		if err := json.Unmarshal([]byte(raw), &decoded); err == nil {
+			row[field.Name] = decoded
+		}
+	}
+}
+
+func generateDynamicID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate id: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
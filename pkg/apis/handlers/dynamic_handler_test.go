@@ -0,0 +1,317 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/sukryu/pAuth/internal/store/cache"
+	"github.com/sukryu/pAuth/internal/store/dynamic"
+	"github.com/sukryu/pAuth/internal/store/schema"
+	"github.com/sukryu/pAuth/pkg/apis/auth/v1alpha1"
+	"github.com/sukryu/pAuth/pkg/controllers"
+	"github.com/sukryu/pAuth/pkg/middleware"
+	"github.com/sukryu/pAuth/pkg/mocks"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDynamicHandler_Create(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	dbConn, err := sql.Open("sqlite3", ":memory:")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer dbConn.Close()
+	dbConn.SetMaxOpenConns(1)
+
+	products := schema.EntitySchema{
+		Name: "products",
+		Fields: []schema.FieldDef{
+			{Name: "title", Type: schema.FieldTypeString, Required: true},
+			{Name: "price", Type: schema.FieldTypeNumber, Required: true},
+			{Name: "quantity", Type: schema.FieldTypeInteger, Nullable: true},
+			{Name: "tags", Type: schema.FieldTypeJSON, Nullable: true},
+			{Name: "sku", Type: schema.FieldTypeString, Nullable: true},
+		},
+	}
+	store := dynamic.NewDynamicStoreFromDB(dbConn)
+	store.SetConstraintErrors(true)
+	assert.NoError(t, store.CreateDynamicTable(context.Background(), products.Name, schema.TableOptions{
+		Fields:  products.Fields,
+		Indexes: []schema.IndexDef{{Name: "idx_products_sku", Columns: []string{"sku"}, Unique: true}},
+	}))
+
+	router := gin.New()
+	router.Use(middleware.ErrorMiddleware())
+	NewDynamicHandler(store, products).Register(router, nil)
+
+	post := func(table, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/dynamic/"+table, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	fieldErrors := func(w *httptest.ResponseRecorder) []string {
+		var resp struct {
+			Errors []struct {
+				Field string `json:"field"`
+			} `json:"errors"`
+		}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		fields := make([]string, 0, len(resp.Errors))
+		for _, e := range resp.Errors {
+			fields = append(fields, e.Field)
+		}
+		return fields
+	}
+
+	t.Run("missing required field", func(t *testing.T) {
+		w := post("products", `{"price": 9.5}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, []string{"title"}, fieldErrors(w))
+	})
+
+	t.Run("wrong type", func(t *testing.T) {
+		w := post("products", `{"title": "pen", "price": "cheap", "quantity": 1.5}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.ElementsMatch(t, []string{"price", "quantity"}, fieldErrors(w))
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		w := post("products", `{"title": "pen", "price": 1, "price; DROP TABLE products": 1}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, []string{"price; DROP TABLE products"}, fieldErrors(w))
+	})
+
+	t.Run("unknown resource", func(t *testing.T) {
+		w := post("users", `{"username": "mallory"}`)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("valid insert", func(t *testing.T) {
+		w := post("products", `{"title": "pen", "price": 1.25, "quantity": 3, "tags": ["office"]}`)
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		rows, err := store.DynamicSelect(context.Background(), "products", map[string]interface{}{"title": "pen"})
+		assert.NoError(t, err)
+		if assert.Len(t, rows, 1) {
+			assert.NotEmpty(t, rows[0]["id"])
+			assert.Equal(t, `["office"]`, rows[0]["tags"])
+		}
+	})
+
+	t.Run("constraint violations are field errors", func(t *testing.T) {
+		w := post("products", `{"title": "ink", "price": 2, "sku": "INK-1"}`)
+		assert.Equal(t, http.StatusCreated, w.Code)
+		var created map[string]interface{}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+
+		w = post("products", `{"title": "ink", "price": 2, "sku": "INK-1"}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, []string{"sku"}, fieldErrors(w))
+
+		w = post("products", `{"title": "paper", "price": 3, "sku": "PAPER-1"}`)
+		assert.Equal(t, http.StatusCreated, w.Code)
+		var other map[string]interface{}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &other))
+
+		req := httptest.NewRequest(http.MethodPut, "/api/v1/dynamic/products/"+other["id"].(string),
+			strings.NewReader(`{"title": "paper", "price": 3, "sku": "INK-1"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, []string{"sku"}, fieldErrors(w))
+	})
+
+	t.Run("constraint violations do not leak SQL without store translation", func(t *testing.T) {
+		raw := gin.New()
+		raw.Use(middleware.ErrorMiddleware())
+		NewDynamicHandler(dynamic.NewDynamicStoreFromDB(dbConn), products).Register(raw, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/dynamic/products", strings.NewReader(`{"title": "ink", "price": 2, "sku": "INK-1"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		raw.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, []string{"sku"}, fieldErrors(w))
+		assert.NotContains(t, w.Body.String(), "UNIQUE")
+	})
+}
+
+func TestDynamicHandler_CRUD(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	dbConn, err := sql.Open("sqlite3", ":memory:")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer dbConn.Close()
+	dbConn.SetMaxOpenConns(1)
+
+	notes := schema.EntitySchema{
+		Name: "notes",
+		Fields: []schema.FieldDef{
+			{Name: "body", Type: schema.FieldTypeString, Required: true},
+			{Name: "pinned", Type: schema.FieldTypeBoolean, Nullable: true},
+			{Name: "meta", Type: schema.FieldTypeJSON, Nullable: true},
+		},
+	}
+	store := dynamic.NewDynamicStoreFromDB(dbConn)
+	assert.NoError(t, store.CreateDynamicTable(context.Background(), notes.Name, schema.TableOptions{Fields: notes.Fields}))
+
+	// alice만 notes 테이블에 대한 권한을 가짐
+	ms := mocks.NewMockStore()
+	ms.ExpectListRoleBindings([]*v1alpha1.RoleBinding{{
+		ObjectMeta: metav1.ObjectMeta{Name: "notes-editor-binding"},
+		RoleRef:    v1alpha1.RoleRef{Kind: "Role", Name: "notes-editor"},
+		Subjects:   []v1alpha1.Subject{{Kind: v1alpha1.SubjectKindUser, Name: "alice"}},
+	}}, nil)
+	ms.ExpectGetRole("notes-editor", &v1alpha1.Role{
+		ObjectMeta: metav1.ObjectMeta{Name: "notes-editor"},
+		Rules: []v1alpha1.PolicyRule{{
+			Verbs:     []string{"get", "list", "create", "update", "delete"},
+			Resources: []string{"notes"},
+			APIGroups: []string{"auth.service"},
+		}},
+	}, nil)
+	ms.ExpectListClusterRoleBindings(nil, nil)
+
+	router := gin.New()
+	router.Use(middleware.ErrorMiddleware())
+	handler := NewDynamicHandler(store, notes)
+	handler.SetPaginationConfig(PaginationConfig{DefaultPageSize: 2})
+	handler.Register(router, []gin.HandlerFunc{
+		func(c *gin.Context) { c.Set("userID", c.GetHeader("X-Test-User")) },
+		middleware.RequirePermissionForParam(controllers.NewRBACController(ms), "table", "auth.service"),
+	}, middleware.Idempotency(middleware.IdempotencyConfig{Cache: cache.NewMemoryCache()}))
+
+	do := func(user, method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Test-User", user)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	decode := func(w *httptest.ResponseRecorder) map[string]interface{} {
+		var body map[string]interface{}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		return body
+	}
+
+	// create
+	w := do("alice", http.MethodPost, "/api/v1/dynamic/notes", `{"body": "hello", "meta": {"color": "red"}}`)
+	if !assert.Equal(t, http.StatusCreated, w.Code, w.Body.String()) {
+		return
+	}
+	created := decode(w)
+	id, _ := created["id"].(string)
+	assert.NotEmpty(t, id)
+	assert.Equal(t, map[string]interface{}{"color": "red"}, created["meta"])
+
+	// get
+	w = do("alice", http.MethodGet, "/api/v1/dynamic/notes/"+id, "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "hello", decode(w)["body"])
+
+	// list
+	w = do("alice", http.MethodGet, "/api/v1/dynamic/notes", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	var list struct {
+		Items []map[string]interface{} `json:"items"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	assert.Len(t, list.Items, 1)
+
+	// update
+	w = do("alice", http.MethodPut, "/api/v1/dynamic/notes/"+id, `{"body": "updated", "pinned": true}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "updated", decode(w)["body"])
+
+	// PUT은 전체 교체: 생략된 optional 필드(meta, pinned)는 NULL로 되돌아감
+	w = do("alice", http.MethodPut, "/api/v1/dynamic/notes/"+id, `{"body": "replaced"}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	replaced := decode(w)
+	assert.Equal(t, "replaced", replaced["body"])
+	assert.Nil(t, replaced["meta"])
+	assert.Nil(t, replaced["pinned"])
+
+	w = do("alice", http.MethodPut, "/api/v1/dynamic/notes/"+id, `{"pinned": false}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w = do("alice", http.MethodPut, "/api/v1/dynamic/notes/missing", `{"body": "x"}`)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// delete
+	w = do("alice", http.MethodDelete, "/api/v1/dynamic/notes/"+id, "")
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	w = do("alice", http.MethodGet, "/api/v1/dynamic/notes/"+id, "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	w = do("alice", http.MethodDelete, "/api/v1/dynamic/notes/"+id, "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	t.Run("rbac on table name", func(t *testing.T) {
+		w := do("bob", http.MethodGet, "/api/v1/dynamic/notes", "")
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		w = do("bob", http.MethodPost, "/api/v1/dynamic/notes", `{"body": "sneaky"}`)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("other tables need their own permission", func(t *testing.T) {
+		w := do("alice", http.MethodGet, "/api/v1/dynamic/entity_schemas", "")
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("list is paged", func(t *testing.T) {
+		for _, body := range []string{"one", "two", "three"} {
+			w := do("alice", http.MethodPost, "/api/v1/dynamic/notes", `{"body": "`+body+`"}`)
+			assert.Equal(t, http.StatusCreated, w.Code)
+		}
+
+		var page pagedResponse
+		var items []map[string]interface{}
+		page.Items = &items
+		w := do("alice", http.MethodGet, "/api/v1/dynamic/notes", "")
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &page))
+		assert.Equal(t, 3, page.Total)
+		assert.Equal(t, 2, page.Limit)
+		assert.Len(t, items, 2)
+		bodies := []interface{}{items[0]["body"], items[1]["body"]}
+
+		w = do("alice", http.MethodGet, "/api/v1/dynamic/notes?offset=2", "")
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &page))
+		if assert.Len(t, items, 1) {
+			bodies = append(bodies, items[0]["body"])
+		}
+		assert.ElementsMatch(t, []interface{}{"one", "two", "three"}, bodies)
+	})
+
+	t.Run("create honours Idempotency-Key", func(t *testing.T) {
+		create := func() *httptest.ResponseRecorder {
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/dynamic/notes", strings.NewReader(`{"body": "once"}`))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Test-User", "alice")
+			req.Header.Set(middleware.IdempotencyKeyHeader, "note-once")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			return w
+		}
+		first, second := create(), create()
+		assert.Equal(t, http.StatusCreated, first.Code)
+		assert.Equal(t, first.Body.String(), second.Body.String())
+		assert.Equal(t, "true", second.Header().Get(middleware.IdempotencyReplayedHeader))
+
+		rows, err := store.DynamicSelect(context.Background(), "notes", map[string]interface{}{"body": "once"})
+		assert.NoError(t, err)
+		assert.Len(t, rows, 1)
+	})
+}
@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// computeETag returns a weak ETag for obj.
+// ResourceVersion이 있으면 그대로 사용하고, 없으면 직렬화된 내용의 해시를 사용.
+func computeETag(obj interface{}) (string, error) {
+	if accessor, ok := obj.(metav1.Object); ok && accessor.GetResourceVersion() != "" {
+		return fmt.Sprintf(`W/"%s"`, accessor.GetResourceVersion()), nil
+	}
+
+	body, err := json.Marshal(obj)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(body)
+	return fmt.Sprintf(`W/"%s"`, hex.EncodeToString(sum[:16])), nil
+}
+
+// etagMatches reports whether the If-None-Match header matches etag using weak comparison
+func etagMatches(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+	target := strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == target {
+			return true
+		}
+	}
+	return false
+}
+
+// respondWithETag writes obj as JSON with an ETag header, or 304 when the client copy is current
+func respondWithETag(c *gin.Context, obj interface{}) {
+	etag, err := computeETag(obj)
+	if err != nil {
+		// ETag 계산 실패는 응답 자체를 막지 않음
+		c.JSON(http.StatusOK, obj)
+		return
+	}
+
+	c.Header("ETag", etag)
+	if etagMatches(c.GetHeader("If-None-Match"), etag) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	c.JSON(http.StatusOK, obj)
+}
@@ -0,0 +1,178 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sukryu/pAuth/pkg/errors"
+	"github.com/sukryu/pAuth/pkg/utils/cursor"
+)
+
+type pagedResponse struct {
+	Items  interface{} `json:"items"`
+	Total  int         `json:"total"`
+	Limit  int         `json:"limit"`
+	Offset int         `json:"offset"`
+}
+
+// cursorPagedResponse is returned by lists paged with ?cursor=. 마지막 페이지에서는 nextCursor가 생략됨
+type cursorPagedResponse struct {
+	Items      interface{} `json:"items"`
+	Limit      int         `json:"limit"`
+	NextCursor string      `json:"nextCursor,omitempty"`
+}
+
+const (
+	// DefaultPageSize is used when a paged request omits limit
+	DefaultPageSize = 50
+	// DefaultMaxPageSize caps limit unless PaginationConfig.MaxPageSize overrides it
+	DefaultMaxPageSize = 500
+)
+
+// PaginationConfig bounds the page size of list handlers
+type PaginationConfig struct {
+	DefaultPageSize int // 0이면 DefaultPageSize
+	MaxPageSize     int // 0이면 DefaultMaxPageSize
+
+	// Envelope wraps every list response in {items, metadata}.
+	// false이면 Accept: ListEnvelopeMediaType 요청에만 적용 (기존 클라이언트 호환)
+	Envelope bool
+}
+
+// ListEnvelopeMediaType requests the {items, metadata} list envelope via the Accept header
+const ListEnvelopeMediaType = "application/vnd.pauth.list+json"
+
+// listEnvelope is the list response shape when the envelope is enabled
+type listEnvelope struct {
+	Items    interface{}  `json:"items"`
+	Metadata listMetadata `json:"metadata"`
+}
+
+// listMetadata describes the page of a listEnvelope. total은 알 수 없으면 (cursor 페이지) 생략
+type listMetadata struct {
+	Total      *int   `json:"total,omitempty"`
+	Limit      int    `json:"limit,omitempty"`
+	Offset     int    `json:"offset,omitempty"`
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// wantsEnvelope reports whether the list response of c should use listEnvelope
+func wantsEnvelope(c *gin.Context, cfg PaginationConfig) bool {
+	return cfg.Envelope || strings.Contains(c.GetHeader("Accept"), ListEnvelopeMediaType)
+}
+
+// respondEnvelope writes items in a listEnvelope when requested and reports whether it did
+func respondEnvelope(c *gin.Context, cfg PaginationConfig, items interface{}, meta listMetadata) bool {
+	if !wantsEnvelope(c, cfg) {
+		return false
+	}
+	c.JSON(http.StatusOK, listEnvelope{Items: items, Metadata: meta})
+	return true
+}
+
+// totalOf returns a pointer for listMetadata.Total
+func totalOf(n int) *int {
+	return &n
+}
+
+func (cfg PaginationConfig) withDefaults() PaginationConfig {
+	if cfg.MaxPageSize <= 0 {
+		cfg.MaxPageSize = DefaultMaxPageSize
+	}
+	if cfg.DefaultPageSize <= 0 {
+		cfg.DefaultPageSize = DefaultPageSize
+	}
+	if cfg.DefaultPageSize > cfg.MaxPageSize {
+		cfg.DefaultPageSize = cfg.MaxPageSize
+	}
+	return cfg
+}
+
+// parsePagination reads the limit/offset query parameters.
+// limit이 없거나 0이면 기본 페이지 크기를, MaxPageSize보다 크면 MaxPageSize를 적용함.
+//
+// 두 파라미터가 모두 없으면 paged=false를 반환하며, 역할/바인딩 목록은 페이지 없이 전체 배열을 반환함.
+// 이 예외는 페이지 도입 전의 응답 형식(배열)을 쓰는 클라이언트 호환을 위한 것으로, 이 경우 크기 제한이
+// 적용되지 않음. 새 목록 엔드포인트는 DynamicHandler.List처럼 paged=false여도 기본 페이지 크기를 적용해야 함
+func parsePagination(c *gin.Context, cfg PaginationConfig) (limit, offset int, paged bool, err error) {
+	limitStr, hasLimit := c.GetQuery("limit")
+	offsetStr, hasOffset := c.GetQuery("offset")
+	if !hasLimit && !hasOffset {
+		return 0, 0, false, nil
+	}
+
+	if hasLimit {
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit < 0 {
+			return 0, 0, false, errors.ErrInvalidInput.WithReason("limit must be a non-negative integer")
+		}
+	}
+	if hasOffset {
+		offset, err = strconv.Atoi(offsetStr)
+		if err != nil || offset < 0 {
+			return 0, 0, false, errors.ErrInvalidInput.WithReason("offset must be a non-negative integer")
+		}
+	}
+
+	cfg = cfg.withDefaults()
+	if limit == 0 {
+		limit = cfg.DefaultPageSize
+	}
+	if limit > cfg.MaxPageSize {
+		limit = cfg.MaxPageSize
+	}
+
+	return limit, offset, true, nil
+}
+
+// parseCursorPagination reads the limit/cursor query parameters.
+// limit 기본값/상한은 parsePagination과 동일. 두 파라미터가 모두 없으면 paged=false이며,
+// 사용자 목록은 parsePagination과 같은 이유로 (기존 클라이언트 호환) 제한 없이 전체를 반환함
+// 빈 cursor(?cursor=)는 첫 페이지를 의미함
+func parseCursorPagination(c *gin.Context, cfg PaginationConfig) (limit int, next string, paged bool, err error) {
+	limitStr, hasLimit := c.GetQuery("limit")
+	next, hasCursor := c.GetQuery("cursor")
+	if !hasLimit && !hasCursor {
+		return 0, "", false, nil
+	}
+
+	if hasLimit {
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit < 0 {
+			return 0, "", false, errors.ErrInvalidInput.WithReason("limit must be a non-negative integer")
+		}
+	}
+	if next != "" {
+		if _, err := cursor.Decode(next); err != nil {
+			return 0, "", false, errors.ErrInvalidInput.WithReason("invalid cursor")
+		}
+	}
+
+	cfg = cfg.withDefaults()
+	if limit == 0 {
+		limit = cfg.DefaultPageSize
+	}
+	if limit > cfg.MaxPageSize {
+		limit = cfg.MaxPageSize
+	}
+
+	return limit, next, true, nil
+}
+
+// parseFields reads the comma separated fields query parameter
+func parseFields(c *gin.Context) []string {
+	raw := c.Query("fields")
+	if raw == "" {
+		return nil
+	}
+
+	var fields []string
+	for _, f := range strings.Split(raw, ",") {
+		if f = strings.TrimSpace(f); f != "" {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
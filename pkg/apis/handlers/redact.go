@@ -0,0 +1,29 @@
+package handlers
+
+import (
+	"github.com/sukryu/pAuth/pkg/apis/auth/v1alpha1"
+)
+
+// redactUser returns a copy of user safe to write to API clients.
+// PasswordHash는 내부에서만 사용하고 응답에는 절대 포함하지 않음
+func redactUser(user *v1alpha1.User) *v1alpha1.User {
+	if user == nil {
+		return nil
+	}
+	redacted := *user
+	redacted.Spec.PasswordHash = ""
+	return &redacted
+}
+
+// redactUserList applies redactUser to every item of list
+func redactUserList(list *v1alpha1.UserList) *v1alpha1.UserList {
+	if list == nil {
+		return nil
+	}
+	redacted := *list
+	redacted.Items = make([]*v1alpha1.User, len(list.Items))
+	for i, user := range list.Items {
+		redacted.Items[i] = redactUser(user)
+	}
+	return &redacted
+}
@@ -1,6 +1,8 @@
 package router
 
 import (
+	"log"
+
 	"github.com/gin-gonic/gin"
 	"github.com/sukryu/pAuth/pkg/apis/handlers"
 	"github.com/sukryu/pAuth/pkg/controllers"
@@ -12,31 +14,120 @@ type Router struct {
 	authHandler    *handlers.AuthHandler
 	jwtManager     *jwt.JWTManager
 	rbacController controllers.RBACController
+	config         Config
+}
+
+// Config holds optional router settings
+type Config struct {
+	// Idempotency enables Idempotency-Key handling on create endpoints when Cache is set
+	Idempotency middleware.IdempotencyConfig
+
+	// CSRF protects cookie-authenticated requests when CSRF.Enabled is set
+	CSRF middleware.CSRFConfig
+
+	// CORS is the cross-origin policy for every route unless a CORSRoutes entry covers the path.
+	// 예: 공개 인증 경로는 넓게 허용하고 /api/v1/auth/roles 등 관리자 경로는 내부 origin으로 제한
+	CORS       middleware.CORSConfig
+	CORSRoutes []middleware.CORSRoute
+
+	// Gzip compresses large responses for clients accepting gzip when Gzip.Enabled is set
+	Gzip middleware.GzipConfig
+
+	// RateLimit limits login, token refresh and registration requests per client when RateLimit.Cache is set
+	RateLimit middleware.RateLimitConfig
+
+	// RequestLogger receives one line per request tagged with its request ID; nil이면 log.Default()
+	RequestLogger *log.Logger
+
+	// Dynamic mounts CRUD routes under /api/v1/dynamic/:table for the registered entity schemas when set
+	Dynamic *handlers.DynamicHandler
 }
 
 func NewRouter(
 	authHandler *handlers.AuthHandler,
 	jwtManager *jwt.JWTManager,
 	rbacController controllers.RBACController,
+) *Router {
+	return NewRouterWithConfig(authHandler, jwtManager, rbacController, Config{})
+}
+
+func NewRouterWithConfig(
+	authHandler *handlers.AuthHandler,
+	jwtManager *jwt.JWTManager,
+	rbacController controllers.RBACController,
+	config Config,
 ) *Router {
 	return &Router{
 		authHandler:    authHandler,
 		jwtManager:     jwtManager,
 		rbacController: rbacController,
+		config:         config,
 	}
 }
 
 func (r *Router) Setup() *gin.Engine {
-	router := gin.Default()
+	router := gin.New()
+	router.Use(gin.Recovery())
+
+	// 요청 ID 부여 및 요청 로그 (gin 기본 Logger 대체)
+	router.Use(middleware.RequestID(r.config.RequestLogger))
+
+	// CORS (preflight는 라우트 매칭 전에 응답해야 하므로 전역으로 등록하고 경로별 정책을 선택)
+	router.Use(middleware.CORS(r.config.CORS, r.config.CORSRoutes...))
+
+	// 응답 압축 (에러 응답도 압축되도록 ErrorMiddleware보다 먼저 등록)
+	router.Use(middleware.Gzip(r.config.Gzip))
 
 	// 에러 핸들링 미들웨어
 	router.Use(middleware.ErrorMiddleware())
 
-	// Public routes
+	// 쿠키 기반 세션의 CSRF 방어 (비활성화 시 통과)
+	router.Use(middleware.CSRF(r.config.CSRF))
+
+	// 생성 요청의 중복 처리를 막는 Idempotency-Key 미들웨어
+	idempotency := middleware.Idempotency(r.config.Idempotency)
+
+	// Public routes (비밀번호 대입을 막기 위해 클라이언트별 요청 수 제한)
 	public := router.Group("/api/v1/auth")
+	public.Use(middleware.RateLimit(r.config.RateLimit))
 	{
 		public.POST("/login", r.authHandler.Login)
-		public.POST("/users", r.authHandler.CreateUser)
+		public.POST("/token/refresh", r.authHandler.RefreshToken)
+		// 자가 가입은 auth.allowSelfRegistration이 꺼져 있으면 403
+		public.POST("/register", idempotency, r.authHandler.RegisterUser)
+		public.POST("/register/verify", r.authHandler.VerifyRegistration)
+	}
+
+	// Self-service routes: 본인이거나 권한이 있는 경우 허용
+	self := router.Group("/api/v1/auth")
+	self.Use(middleware.JWTAuth(r.jwtManager))
+	{
+		self.PUT("/users/:name", middleware.RequireSelfOrPermission(r.rbacController, "update", "users", "auth.service"), r.authHandler.UpdateUser)
+		self.PATCH("/users/:name", middleware.RequireSelfOrPermission(r.rbacController, "update", "users", "auth.service"), r.authHandler.PatchUser)
+		self.PUT("/users/:name/password", middleware.RequireSelfOrPermission(r.rbacController, "update", "users", "auth.service"), r.authHandler.ChangePassword)
+		self.POST("/users/:name/email/change", middleware.RequireSelfOrPermission(r.rbacController, "update", "users", "auth.service"), r.authHandler.RequestEmailChange)
+		self.POST("/users/:name/email/confirm", middleware.RequireSelfOrPermission(r.rbacController, "update", "users", "auth.service"), r.authHandler.ConfirmEmailChange)
+		// 바인딩된 역할 조회 (role chip 표시용)
+		self.GET("/users/:name/roles", middleware.RequireSelfOrPermission(r.rbacController, "get", "users", "auth.service"), r.authHandler.GetUserRoles)
+
+		// 관리자의 사용자 생성. 익명 가입은 /register (auth.allowSelfRegistration)로만 가능
+		self.POST("/users", middleware.RequirePermission(r.rbacController, "create", "users", "auth.service"), idempotency, r.authHandler.CreateUser)
+
+		// 일괄 삭제는 경로로 리소스를 추론할 수 없으므로 권한을 직접 지정
+		self.POST("/users:action", middleware.RequirePermission(r.rbacController, "delete", "users", "auth.service"), r.authHandler.UserAction)
+		// 변경 이벤트 스트림 (SSE)
+		self.GET("/users:action", middleware.RequirePermission(r.rbacController, "watch", "users", "auth.service"), r.authHandler.UserGetAction)
+
+		// 역할 추가/해제는 메서드와 무관하게 사용자 수정 권한으로 판단 (본인 허용 안 함)
+		self.POST("/users/:name/roles", middleware.RequirePermission(r.rbacController, "update", "users", "auth.service"), r.authHandler.AddRoles)
+		self.DELETE("/users/:name/roles", middleware.RequirePermission(r.rbacController, "update", "users", "auth.service"), r.authHandler.RemoveRoles)
+
+		// 권한 미리보기와 본인 정보 조회는 인증만 필요 (요청자 본인의 권한만 평가)
+		self.POST("/access/review", r.authHandler.ReviewAccess)
+		self.GET("/me", r.authHandler.GetCurrentUser)
+
+		// 토큰 introspection은 관리자 전용 (RFC 7662)
+		self.POST("/token/introspect", middleware.RequirePermission(r.rbacController, "create", "tokenreviews", "auth.service"), r.authHandler.IntrospectToken)
 	}
 
 	// Protected routes
@@ -45,22 +136,28 @@ func (r *Router) Setup() *gin.Engine {
 	protected.Use(middleware.RBACMiddleware(r.rbacController))
 	{
 		protected.GET("/users/:name", r.authHandler.GetUser)
-		protected.PUT("/users/:name", r.authHandler.UpdateUser)
 		protected.DELETE("/users/:name", r.authHandler.DeleteUser)
 		protected.GET("/users", r.authHandler.ListUsers)
-		protected.PUT("/users/:name/password", r.authHandler.ChangePassword)
-		protected.PUT("/users/:name/roles", r.authHandler.AssignRoles)
-
-		// RBAC 관련 라우트
-		protected.POST("/roles", r.authHandler.CreateRole)
-		protected.GET("/roles", r.authHandler.ListRoles)
-		protected.GET("/roles/:name", r.authHandler.GetRole)
-		protected.DELETE("/roles/:name", r.authHandler.DeleteRole)
-
-		protected.POST("/rolebindings", r.authHandler.CreateRoleBinding)
-		protected.GET("/rolebindings", r.authHandler.ListRoleBindings)
-		protected.GET("/rolebindings/:name", r.authHandler.GetRoleBinding)
-		protected.DELETE("/rolebindings/:name", r.authHandler.DeleteRoleBinding)
+		protected.PUT("/users/:name/roles", r.authHandler.SetRoles)
+		protected.GET("/users/:name/login-history", r.authHandler.GetLoginHistory)
+	}
+
+	// 서비스 간 인가: 사이드카 등 신뢰된 서비스가 다른 주체의 권한을 일괄 확인
+	authz := router.Group("/api/v1/authz")
+	authz.Use(middleware.JWTAuth(r.jwtManager))
+	{
+		authz.POST("/batch", middleware.RequirePermission(r.rbacController, "create", "subjectaccessreviews", "auth.service"), r.authHandler.AuthorizeBatch)
+	}
+
+	// RBAC 관련 라우트
+	r.authHandler.RegisterRBAC(router, idempotency)
+
+	// 동적 엔티티 CRUD: 테이블 이름을 리소스로 RBAC 검사
+	if r.config.Dynamic != nil {
+		r.config.Dynamic.Register(router, []gin.HandlerFunc{
+			middleware.JWTAuth(r.jwtManager),
+			middleware.RequirePermissionForParam(r.rbacController, "table", "auth.service"),
+		}, idempotency)
 	}
 
 	return router
@@ -0,0 +1,291 @@
+package router
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/sukryu/pAuth/internal/store/cache"
+	"github.com/sukryu/pAuth/pkg/apis/auth/v1alpha1"
+	"github.com/sukryu/pAuth/pkg/apis/handlers"
+	"github.com/sukryu/pAuth/pkg/controllers"
+	"github.com/sukryu/pAuth/pkg/middleware"
+	"github.com/sukryu/pAuth/pkg/mocks"
+	"github.com/sukryu/pAuth/pkg/utils/jwt"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRouter_RBACRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ms := mocks.NewMockStore()
+	ms.ExpectListRoleBindings([]*v1alpha1.RoleBinding{{
+		ObjectMeta: metav1.ObjectMeta{Name: "role-admin-binding"},
+		RoleRef:    v1alpha1.RoleRef{Kind: "Role", Name: "role-admin"},
+		Subjects:   []v1alpha1.Subject{{Kind: v1alpha1.SubjectKindUser, Name: "admin"}},
+	}}, nil)
+	ms.ExpectGetRole("role-admin", &v1alpha1.Role{
+		ObjectMeta: metav1.ObjectMeta{Name: "role-admin"},
+		Rules: []v1alpha1.PolicyRule{{
+			Verbs:     []string{"get", "create"},
+			Resources: []string{"roles"},
+			APIGroups: []string{"auth.service"},
+		}},
+	}, nil)
+	ms.ExpectListRoles([]*v1alpha1.Role{}, nil)
+	ms.On("ListRolesPaged", mock.Anything, 0, 0).Return([]*v1alpha1.Role{}, 0, nil)
+	ms.ExpectListClusterRoleBindings(nil, nil)
+	ms.On("CreateRole", mock.Anything, mock.Anything).Return(nil)
+
+	jwtManager := jwt.NewJWTManager("test-secret", time.Hour)
+	rbacController := controllers.NewRBACController(ms)
+	authHandler := handlers.NewAuthHandler(controllers.NewAuthController(ms), jwtManager, rbacController)
+	router := NewRouter(authHandler, jwtManager, rbacController).Setup()
+
+	do := func(method, path, body, userID string) int {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if userID != "" {
+			token, err := jwtManager.GenerateToken(userID, nil)
+			assert.NoError(t, err)
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	role := `{"metadata":{"name":"reader"},"rules":[{"verbs":["get"],"resources":["users"],"apiGroups":["auth.service"]}]}`
+
+	// 권한이 있는 사용자는 접근 가능
+	assert.Equal(t, http.StatusCreated, do(http.MethodPost, "/api/v1/auth/roles", role, "admin"))
+	assert.Equal(t, http.StatusOK, do(http.MethodGet, "/api/v1/auth/roles", "", "admin"))
+
+	// 토큰 없이 또는 바인딩 없는 사용자는 거부
+	assert.Equal(t, http.StatusUnauthorized, do(http.MethodPost, "/api/v1/auth/roles", role, ""))
+	assert.Equal(t, http.StatusForbidden, do(http.MethodGet, "/api/v1/auth/roles", "", "mallory"))
+	assert.Equal(t, http.StatusForbidden, do(http.MethodPost, "/api/v1/auth/roles", role, "mallory"))
+
+	// 사용자 생성은 익명으로 호출할 수 없고 create users 권한이 필요
+	user := `{"metadata":{"name":"eve"},"spec":{"username":"eve","email":"eve@example.com","passwordHash":"secret123"}}`
+	assert.Equal(t, http.StatusUnauthorized, do(http.MethodPost, "/api/v1/auth/users", user, ""))
+	assert.Equal(t, http.StatusForbidden, do(http.MethodPost, "/api/v1/auth/users", user, "mallory"))
+
+	ms.AssertNumberOfCalls(t, "CreateRole", 1)
+	ms.AssertNotCalled(t, "CreateUser", mock.Anything, mock.Anything)
+}
+
+func TestRouter_IdempotentCreate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ms := mocks.NewMockStore()
+	ms.ExpectListRoleBindings([]*v1alpha1.RoleBinding{{
+		ObjectMeta: metav1.ObjectMeta{Name: "rbac-admin-binding"},
+		RoleRef:    v1alpha1.RoleRef{Kind: "Role", Name: "rbac-admin"},
+		Subjects:   []v1alpha1.Subject{{Kind: v1alpha1.SubjectKindUser, Name: "admin"}},
+	}}, nil)
+	ms.ExpectGetRole("rbac-admin", &v1alpha1.Role{
+		ObjectMeta: metav1.ObjectMeta{Name: "rbac-admin"},
+		Rules: []v1alpha1.PolicyRule{{
+			Verbs:     []string{"create"},
+			Resources: []string{"users", "roles", "rolebindings"},
+			APIGroups: []string{"auth.service"},
+		}},
+	}, nil)
+	ms.ExpectGetRole("reader", &v1alpha1.Role{ObjectMeta: metav1.ObjectMeta{Name: "reader"}}, nil)
+	ms.On("CreateUser", mock.Anything, mock.Anything).Return(nil)
+	ms.On("CreateRole", mock.Anything, mock.Anything).Return(nil)
+	ms.On("CreateRoleBinding", mock.Anything, mock.Anything).Return(nil)
+
+	jwtManager := jwt.NewJWTManager("test-secret", time.Hour)
+	rbacController := controllers.NewRBACController(ms)
+	authHandler := handlers.NewAuthHandler(controllers.NewAuthController(ms), jwtManager, rbacController)
+	router := NewRouterWithConfig(authHandler, jwtManager, rbacController, Config{
+		Idempotency: middleware.IdempotencyConfig{Cache: cache.NewMemoryCache(), TTL: time.Minute},
+	}).Setup()
+
+	token, err := jwtManager.GenerateToken("admin", nil)
+	assert.NoError(t, err)
+
+	do := func(path, body, key string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set(middleware.IdempotencyKeyHeader, key)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	tests := []struct {
+		path   string
+		body   string
+		method string
+	}{
+		{"/api/v1/auth/users", `{"metadata":{"name":"alice"},"spec":{"username":"alice","email":"alice@example.com","passwordHash":"secret123"}}`, "CreateUser"},
+		{"/api/v1/auth/roles", `{"metadata":{"name":"reader"},"rules":[{"verbs":["get"],"resources":["users"],"apiGroups":["auth.service"]}]}`, "CreateRole"},
+		{"/api/v1/auth/rolebindings", `{"metadata":{"name":"reader-binding"},"roleRef":{"kind":"Role","name":"reader"},"subjects":[{"kind":"User","name":"alice"}]}`, "CreateRoleBinding"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			first := do(tt.path, tt.body, "key-"+tt.method)
+			second := do(tt.path, tt.body, "key-"+tt.method)
+
+			assert.Equal(t, http.StatusCreated, first.Code)
+			assert.Equal(t, first.Code, second.Code)
+			assert.Equal(t, first.Body.String(), second.Body.String())
+			assert.Equal(t, "true", second.Header().Get(middleware.IdempotencyReplayedHeader))
+			ms.AssertNumberOfCalls(t, tt.method, 1)
+		})
+	}
+
+	t.Run("same key with a different body is rejected", func(t *testing.T) {
+		w := do(tests[1].path, `{"metadata":{"name":"writer"},"rules":[{"verbs":["update"],"resources":["users"],"apiGroups":["auth.service"]}]}`, "key-CreateRole")
+
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		ms.AssertNumberOfCalls(t, "CreateRole", 1)
+	})
+}
+
+func TestRouter_CORSPerRouteGroup(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ms := mocks.NewMockStore()
+	jwtManager := jwt.NewJWTManager("test-secret", time.Hour)
+	rbacController := controllers.NewRBACController(ms)
+	authHandler := handlers.NewAuthHandler(controllers.NewAuthController(ms), jwtManager, rbacController)
+	const internal = "https://admin.internal"
+	router := NewRouterWithConfig(authHandler, jwtManager, rbacController, Config{
+		CORS: middleware.CORSConfig{Enabled: true, AllowedOrigins: []string{"*"}},
+		CORSRoutes: []middleware.CORSRoute{
+			{PathPrefix: "/api/v1/auth/roles", Config: middleware.CORSConfig{Enabled: true, AllowedOrigins: []string{internal}, AllowCredentials: true}},
+			{PathPrefix: "/api/v1/auth/rolebindings", Config: middleware.CORSConfig{Enabled: true, AllowedOrigins: []string{internal}, AllowCredentials: true}},
+		},
+	}).Setup()
+
+	do := func(method, path, origin string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader("{}"))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Origin", origin)
+		if method == http.MethodOptions {
+			req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// 공개 경로는 모든 origin 허용
+	w := do(http.MethodOptions, "/api/v1/auth/login", "https://app.example.com")
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, "*", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Contains(t, w.Header().Get("Access-Control-Allow-Headers"), "Authorization")
+
+	w = do(http.MethodPost, "/api/v1/auth/login", "https://app.example.com")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, "*", w.Header().Get("Access-Control-Allow-Origin"))
+
+	// 관리자 경로는 내부 origin만 허용
+	w = do(http.MethodOptions, "/api/v1/auth/roles", "https://app.example.com")
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+
+	w = do(http.MethodGet, "/api/v1/auth/rolebindings/viewers", "https://app.example.com")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+
+	w = do(http.MethodOptions, "/api/v1/auth/roles/reader", internal)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, internal, w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "true", w.Header().Get("Access-Control-Allow-Credentials"))
+
+	// 같은 origin 요청(Origin 헤더 없음)은 정책과 무관
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/auth/roles", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestRouter_AuthorizeBatch(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	binding := func(user, role string) *v1alpha1.RoleBinding {
+		return &v1alpha1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: user + "-" + role},
+			RoleRef:    v1alpha1.RoleRef{Kind: "Role", Name: role},
+			Subjects:   []v1alpha1.Subject{{Kind: v1alpha1.SubjectKindUser, Name: user}},
+		}
+	}
+	role := func(name, verb, resource string) *v1alpha1.Role {
+		return &v1alpha1.Role{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Rules:      []v1alpha1.PolicyRule{{Verbs: []string{verb}, Resources: []string{resource}, APIGroups: []string{"auth.service"}}},
+		}
+	}
+
+	ms := mocks.NewMockStore()
+	ms.ExpectListRoleBindings([]*v1alpha1.RoleBinding{
+		binding("sidecar", "authorizer"),
+		binding("alice", "reader"),
+		binding("bob", "writer"),
+	}, nil)
+	ms.ExpectListClusterRoleBindings([]*v1alpha1.ClusterRoleBinding{}, nil)
+	ms.ExpectGetRole("authorizer", role("authorizer", "create", "subjectaccessreviews"), nil)
+	ms.ExpectGetRole("reader", role("reader", "get", "users"), nil)
+	ms.ExpectGetRole("writer", role("writer", "update", "users"), nil)
+
+	jwtManager := jwt.NewJWTManager("test-secret", time.Hour)
+	rbacController := controllers.NewRBACController(ms)
+	authHandler := handlers.NewAuthHandler(controllers.NewAuthController(ms), jwtManager, rbacController)
+	router := NewRouter(authHandler, jwtManager, rbacController).Setup()
+
+	do := func(userID, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/authz/batch", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		token, err := jwtManager.GenerateToken(userID, nil)
+		assert.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	body := `{"checks":[
+		{"subject":{"kind":"User","name":"alice"},"verb":"get","resource":"users"},
+		{"subject":{"kind":"User","name":"bob"},"verb":"get","resource":"users"},
+		{"subject":{"kind":"User","name":"alice"},"verb":"update","resource":"users"},
+		{"subject":{"kind":"User","name":"bob"},"verb":"update","resource":"users","apiGroup":"auth.service"}
+	]}`
+
+	w := do("sidecar", body)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp struct {
+		Results []v1alpha1.SubjectAccessCheckResult `json:"results"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	if assert.Len(t, resp.Results, 4) {
+		expected := []struct {
+			user    string
+			verb    string
+			allowed bool
+		}{{"alice", "get", true}, {"bob", "get", false}, {"alice", "update", false}, {"bob", "update", true}}
+		for i, want := range expected {
+			assert.Equal(t, want.user, resp.Results[i].Subject.Name)
+			assert.Equal(t, want.verb, resp.Results[i].Verb)
+			assert.Equal(t, "auth.service", resp.Results[i].APIGroup)
+			assert.Equal(t, want.allowed, resp.Results[i].Allowed, "check %d", i)
+		}
+	}
+
+	// 권한 없는 호출자와 잘못된 요청은 거부
+	assert.Equal(t, http.StatusForbidden, do("alice", body).Code)
+	assert.Equal(t, http.StatusBadRequest, do("sidecar", `{"checks":[]}`).Code)
+	assert.Equal(t, http.StatusBadRequest, do("sidecar", `{"checks":[{"subject":{"kind":"Robot","name":"x"},"verb":"get","resource":"users"}]}`).Code)
+}
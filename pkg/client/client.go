@@ -0,0 +1,207 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sukryu/pAuth/pkg/apis/auth/v1alpha1"
+	"github.com/sukryu/pAuth/pkg/errors"
+)
+
+// DefaultTimeout bounds each request when Config.HTTPClient is nil
+const DefaultTimeout = 30 * time.Second
+
+// Config holds client settings
+type Config struct {
+	// BaseURL is the pAuth server address, e.g. "https://auth.example.com"
+	BaseURL string
+
+	// HTTPClient sends the requests; nil이면 DefaultTimeout이 적용된 http.Client
+	HTTPClient *http.Client
+}
+
+// Client is a typed client for the pAuth auth API.
+// 발급받은 토큰을 보관해 요청마다 Bearer로 첨부하고, 401 응답은 refresh token으로 한 번 갱신 후 재시도함.
+// 실패한 응답은 *errors.StatusError 또는 *errors.ValidationError로 반환됨
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+
+	mu           sync.Mutex
+	token        string
+	refreshToken string
+}
+
+// New returns a client for the server at baseURL
+func New(baseURL string) *Client {
+	return NewWithConfig(Config{BaseURL: baseURL})
+}
+
+// NewWithConfig returns a client built from cfg
+func NewWithConfig(cfg Config) *Client {
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = &http.Client{Timeout: DefaultTimeout}
+	}
+
+	return &Client{
+		baseURL:    strings.TrimRight(cfg.BaseURL, "/"),
+		httpClient: cfg.HTTPClient,
+	}
+}
+
+// SetTokens sets the access and refresh tokens, e.g. restored from a previous session
+func (c *Client) SetTokens(token, refreshToken string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.token, c.refreshToken = token, refreshToken
+}
+
+// Tokens returns the current access and refresh tokens
+func (c *Client) Tokens() (token, refreshToken string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.token, c.refreshToken
+}
+
+type loginRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+type loginResponse struct {
+	Token        string         `json:"token"`
+	User         *v1alpha1.User `json:"user"`
+	RefreshToken string         `json:"refreshToken,omitempty"`
+}
+
+// Login authenticates with a username (or email) and password and keeps the issued tokens
+func (c *Client) Login(ctx context.Context, username, password string) (*v1alpha1.User, error) {
+	var resp loginResponse
+	req := loginRequest{Username: username, Password: password}
+	if err := c.send(ctx, http.MethodPost, "/api/v1/auth/login", req, &resp, false); err != nil {
+		return nil, err
+	}
+
+	c.SetTokens(resp.Token, resp.RefreshToken)
+	return resp.User, nil
+}
+
+// Refresh exchanges the stored refresh token for a new access token
+func (c *Client) Refresh(ctx context.Context) error {
+	_, refreshToken := c.Tokens()
+	if refreshToken == "" {
+		return errors.ErrUnauthorized.WithReason("no refresh token")
+	}
+
+	var resp struct {
+		Token string `json:"token"`
+	}
+	req := map[string]string{"refreshToken": refreshToken}
+	if err := c.send(ctx, http.MethodPost, "/api/v1/auth/token/refresh", req, &resp, false); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	c.token = resp.Token
+	c.mu.Unlock()
+	return nil
+}
+
+// GetUser returns the named user
+func (c *Client) GetUser(ctx context.Context, name string) (*v1alpha1.User, error) {
+	var user v1alpha1.User
+	if err := c.do(ctx, http.MethodGet, "/api/v1/auth/users/"+url.PathEscape(name), nil, &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// CreateUser creates a user; Spec.PasswordHash carries the plain-text password
+func (c *Client) CreateUser(ctx context.Context, user *v1alpha1.User) (*v1alpha1.User, error) {
+	var created v1alpha1.User
+	if err := c.do(ctx, http.MethodPost, "/api/v1/auth/users", user, &created); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// CheckAccess reports whether the authenticated caller may perform verb on resource.
+// apiGroup이 비어 있으면 서버 기본값(auth.service)
+func (c *Client) CheckAccess(ctx context.Context, verb, resource, apiGroup string) (bool, error) {
+	var result v1alpha1.AccessCheckResult
+	check := v1alpha1.AccessCheck{Verb: verb, Resource: resource, APIGroup: apiGroup}
+	if err := c.do(ctx, http.MethodPost, "/api/v1/auth/access/review", check, &result); err != nil {
+		return false, err
+	}
+	return result.Allowed, nil
+}
+
+// do sends an authenticated request, refreshing the access token once on 401
+func (c *Client) do(ctx context.Context, method, path string, body, out interface{}) error {
+	err := c.send(ctx, method, path, body, out, true)
+	if !isUnauthorized(err) {
+		return err
+	}
+	if _, refreshToken := c.Tokens(); refreshToken == "" {
+		return err
+	}
+	if refreshErr := c.Refresh(ctx); refreshErr != nil {
+		return refreshErr
+	}
+	return c.send(ctx, method, path, body, out, true)
+}
+
+func (c *Client) send(ctx context.Context, method, path string, body, out interface{}, authenticated bool) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("encode request: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if authenticated {
+		if token, _ := c.Tokens(); token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := errors.FromResponse(resp); err != nil {
+		return err
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+	return nil
+}
+
+func isUnauthorized(err error) bool {
+	statusErr, ok := err.(*errors.StatusError)
+	return ok && statusErr.Code == http.StatusUnauthorized
+}
@@ -0,0 +1,151 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/sukryu/pAuth/pkg/apis/auth/v1alpha1"
+	"github.com/sukryu/pAuth/pkg/apis/handlers"
+	"github.com/sukryu/pAuth/pkg/apis/router"
+	"github.com/sukryu/pAuth/pkg/controllers"
+	"github.com/sukryu/pAuth/pkg/errors"
+	"github.com/sukryu/pAuth/pkg/mocks"
+	"github.com/sukryu/pAuth/pkg/utils/clock"
+	"github.com/sukryu/pAuth/pkg/utils/jwt"
+	"golang.org/x/crypto/bcrypt"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newTestServer(t *testing.T) (*httptest.Server, *mocks.MockStore, *clock.Fake) {
+	gin.SetMode(gin.TestMode)
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	ms := mocks.NewMockStore()
+	ms.On("GetUser", mock.Anything, "alice").Return(&v1alpha1.User{
+		ObjectMeta: metav1.ObjectMeta{Name: "alice"},
+		Spec:       v1alpha1.UserSpec{Username: "alice", Email: "alice@example.com", PasswordHash: string(hash)},
+		Status:     v1alpha1.UserStatus{Active: true},
+	}, nil)
+	ms.On("UpdateUser", mock.Anything, mock.Anything).Return(nil)
+	ms.ExpectListRoleBindings([]*v1alpha1.RoleBinding{{
+		ObjectMeta: metav1.ObjectMeta{Name: "alice-reader"},
+		RoleRef:    v1alpha1.RoleRef{Kind: v1alpha1.RoleRefKindRole, Name: "reader"},
+		Subjects:   []v1alpha1.Subject{{Kind: v1alpha1.SubjectKindUser, Name: "alice"}},
+	}}, nil)
+	ms.ExpectGetRole("reader", &v1alpha1.Role{
+		ObjectMeta: metav1.ObjectMeta{Name: "reader"},
+		Rules: []v1alpha1.PolicyRule{{
+			Verbs:     []string{"get", "create"},
+			Resources: []string{"users"},
+			APIGroups: []string{"auth.service"},
+		}},
+	}, nil)
+	ms.ExpectListClusterRoleBindings(nil, nil)
+
+	fake := clock.NewFake(time.Now())
+	jwtManager := jwt.NewJWTManagerWithConfig(jwt.Config{
+		SecretKey:     "test-secret",
+		Expiry:        15 * time.Minute,
+		RefreshExpiry: 24 * time.Hour,
+		Clock:         fake,
+	})
+	rbacController := controllers.NewRBACController(ms)
+	authHandler := handlers.NewAuthHandler(controllers.NewAuthController(ms), jwtManager, rbacController)
+
+	server := httptest.NewServer(router.NewRouter(authHandler, jwtManager, rbacController).Setup())
+	t.Cleanup(server.Close)
+	return server, ms, fake
+}
+
+func TestClient_LoginAndRefresh(t *testing.T) {
+	server, _, fake := newTestServer(t)
+	ctx := context.Background()
+	c := New(server.URL)
+
+	user, err := c.Login(ctx, "alice", "password123")
+	require.NoError(t, err)
+	assert.Equal(t, "alice", user.Name)
+	assert.Empty(t, user.Spec.PasswordHash)
+
+	token, refreshToken := c.Tokens()
+	assert.NotEmpty(t, token)
+	assert.NotEmpty(t, refreshToken)
+
+	got, err := c.GetUser(ctx, "alice")
+	require.NoError(t, err)
+	assert.Equal(t, "alice@example.com", got.Spec.Email)
+
+	allowed, err := c.CheckAccess(ctx, "get", "users", "")
+	require.NoError(t, err)
+	assert.True(t, allowed)
+	allowed, err = c.CheckAccess(ctx, "delete", "users", "")
+	require.NoError(t, err)
+	assert.False(t, allowed)
+
+	t.Run("expired access token is refreshed", func(t *testing.T) {
+		fake.Advance(time.Hour)
+
+		got, err := c.GetUser(ctx, "alice")
+		require.NoError(t, err)
+		assert.Equal(t, "alice", got.Name)
+
+		refreshed, _ := c.Tokens()
+		assert.NotEqual(t, token, refreshed)
+	})
+
+	t.Run("without refresh token the 401 is returned", func(t *testing.T) {
+		fake.Advance(time.Hour)
+		current, _ := c.Tokens()
+		c.SetTokens(current, "")
+
+		_, err := c.GetUser(ctx, "alice")
+		var statusErr *errors.StatusError
+		require.ErrorAs(t, err, &statusErr)
+		assert.Equal(t, http.StatusUnauthorized, statusErr.Code)
+	})
+}
+
+func TestClient_Errors(t *testing.T) {
+	server, ms, _ := newTestServer(t)
+	hash, err := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	require.NoError(t, err)
+	ms.On("GetUser", mock.Anything, "mallory").Return(&v1alpha1.User{
+		ObjectMeta: metav1.ObjectMeta{Name: "mallory"},
+		Spec:       v1alpha1.UserSpec{Username: "mallory", PasswordHash: string(hash)},
+		Status:     v1alpha1.UserStatus{Active: true},
+	}, nil)
+	ctx := context.Background()
+	c := New(server.URL)
+
+	_, err = c.Login(ctx, "mallory", "wrong-password")
+	assert.ErrorIs(t, err, errors.ErrInvalidCredentials)
+
+	_, err = c.Login(ctx, "mallory", "password123")
+	require.NoError(t, err)
+
+	// 바인딩이 없는 사용자
+	_, err = c.GetUser(ctx, "alice")
+	var statusErr *errors.StatusError
+	require.ErrorAs(t, err, &statusErr)
+	assert.Equal(t, http.StatusForbidden, statusErr.Code)
+	assert.ErrorIs(t, err, errors.ErrForbidden)
+
+	_, err = c.CreateUser(ctx, &v1alpha1.User{ObjectMeta: metav1.ObjectMeta{Name: "carol"}})
+	assert.ErrorIs(t, err, errors.ErrForbidden)
+
+	_, err = c.Login(ctx, "alice", "password123")
+	require.NoError(t, err)
+
+	_, err = c.CreateUser(ctx, &v1alpha1.User{ObjectMeta: metav1.ObjectMeta{Name: "carol"}})
+	var verr *errors.ValidationError
+	assert.ErrorAs(t, err, &verr)
+}
@@ -0,0 +1,76 @@
+package controllers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/sukryu/pAuth/internal/store/cache"
+	"github.com/sukryu/pAuth/pkg/apis/auth/v1alpha1"
+	"github.com/sukryu/pAuth/pkg/utils/namespace"
+)
+
+// DefaultAccessCacheTTL bounds how long a cached access decision is reused when no TTL is configured.
+// controller를 거치지 않은 변경 (authctl import, DB 직접 수정)은 최대 이 시간 동안 반영되지 않을 수 있음
+const DefaultAccessCacheTTL = 30 * time.Second
+
+// accessGenerationKey holds a counter that is part of every cached decision key.
+// RBAC 객체를 변경하면 값을 올려 모든 인스턴스의 이전 판정을 한 번에 무효화함
+const accessGenerationKey = "rbac:access:generation"
+
+// accessCache caches CheckSubjectAccess decisions. nil이면 캐시를 사용하지 않으며,
+// cache 오류는 무시하고 저장소에서 직접 평가함
+type accessCache struct {
+	cache cache.Cache
+	ttl   time.Duration
+}
+
+func newAccessCache(c cache.Cache, ttl time.Duration) *accessCache {
+	if c == nil {
+		return nil
+	}
+	if ttl <= 0 {
+		ttl = DefaultAccessCacheTTL
+	}
+	return &accessCache{cache: c, ttl: ttl}
+}
+
+// key returns the cache key of a decision in the request namespace at the current generation
+func (a *accessCache) key(ctx context.Context, subject v1alpha1.Subject, verb, resource, apiGroup string) (string, bool) {
+	if a == nil {
+		return "", false
+	}
+	generation, _, err := a.cache.Get(ctx, accessGenerationKey)
+	if err != nil {
+		return "", false
+	}
+	fields := []string{namespace.FromContext(ctx), subject.Kind, subject.Name, verb, resource, apiGroup}
+	sum := sha256.Sum256([]byte(strings.Join(fields, "\x00")))
+	return "rbac:access:" + generation + ":" + hex.EncodeToString(sum[:]), true
+}
+
+func (a *accessCache) get(ctx context.Context, key string) (allowed, found bool) {
+	value, found, err := a.cache.Get(ctx, key)
+	if err != nil || !found {
+		return false, false
+	}
+	return value == "1", true
+}
+
+func (a *accessCache) set(ctx context.Context, key string, allowed bool) {
+	value := "0"
+	if allowed {
+		value = "1"
+	}
+	_ = a.cache.Set(ctx, key, value, a.ttl)
+}
+
+// invalidate drops every cached decision after a role, binding or cluster role change
+func (a *accessCache) invalidate(ctx context.Context) {
+	if a == nil {
+		return
+	}
+	_, _ = a.cache.Incr(ctx, accessGenerationKey, 0)
+}
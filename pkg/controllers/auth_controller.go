@@ -2,10 +2,23 @@ package controllers
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"net/mail"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/sukryu/pAuth/internal/store/cache"
 	"github.com/sukryu/pAuth/pkg/apis/auth/v1alpha1"
 	"github.com/sukryu/pAuth/pkg/errors"
+	"github.com/sukryu/pAuth/pkg/events"
+	"github.com/sukryu/pAuth/pkg/utils/clock"
+	"github.com/sukryu/pAuth/pkg/utils/cursor"
+	"github.com/sukryu/pAuth/pkg/utils/namespace"
 	"golang.org/x/crypto/bcrypt"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -16,35 +29,302 @@ type AuthController interface {
 	GetUser(ctx context.Context, name string) (*v1alpha1.User, error)
 	UpdateUser(ctx context.Context, user *v1alpha1.User) (*v1alpha1.User, error)
 	DeleteUser(ctx context.Context, name string) error
+	DeleteUsersBatch(ctx context.Context, names []string) (map[string]error, error)
 	ListUsers(ctx context.Context) (*v1alpha1.UserList, error)
+	ListUsersWithOptions(ctx context.Context, opts v1alpha1.ListOptions) (*v1alpha1.UserList, error)
 	Login(ctx context.Context, username, password string) (*v1alpha1.User, error)
+	// RefreshUser returns the user a refresh token issued at issuedAt may renew an access token for
+	RefreshUser(ctx context.Context, name string, issuedAt time.Time) (*v1alpha1.User, error)
 	ChangePassword(ctx context.Context, name, oldPassword, newPassword string) error
-	AssignRoles(ctx context.Context, name string, roles []string) error
+	// SetRoles replaces the user's roles; AddRoles/RemoveRoles merge into or subtract from them
+	SetRoles(ctx context.Context, name string, roles []string) error
+	AddRoles(ctx context.Context, name string, roles []string) error
+	RemoveRoles(ctx context.Context, name string, roles []string) error
+	GetLoginHistory(ctx context.Context, name string) ([]v1alpha1.LoginRecord, error)
+	RequestEmailChange(ctx context.Context, name, newEmail string) (string, error)
+	ConfirmEmailChange(ctx context.Context, name, token string) (*v1alpha1.User, error)
+	// RenameUser changes the user's name and rewrites the binding subjects referring to it
+	RenameUser(ctx context.Context, oldName, newName string) (*v1alpha1.User, error)
+	// RegisterUser creates a user through public self-registration; VerifyRegistration activates it
+	// when email verification is required
+	RegisterUser(ctx context.Context, reg Registration) (*v1alpha1.User, error)
+	VerifyRegistration(ctx context.Context, token string) (*v1alpha1.User, error)
+	// CreateUserWithBindings creates user and a RoleBinding to each role atomically
+	CreateUserWithBindings(ctx context.Context, user *v1alpha1.User, roleNames []string) (*v1alpha1.User, error)
+}
+
+// DefaultLoginHistoryLimit is the number of login records kept per user when not configured
+const DefaultLoginHistoryLimit = 10
+
+// DefaultPasswordHistorySize is the number of previous passwords that cannot be reused when not configured
+const DefaultPasswordHistorySize = 5
+
+// DefaultMinPasswordLength is the minimum password length for self-registration when not configured
+const DefaultMinPasswordLength = 8
+
+// LoginIdentifier is a kind of identifier Login accepts
+type LoginIdentifier string
+
+const (
+	// LoginByName matches the identifier against the user name
+	LoginByName LoginIdentifier = "name"
+	// LoginByEmail matches the identifier against the user email (식별자에 @가 있을 때만 조회)
+	LoginByEmail LoginIdentifier = "email"
+)
+
+// DefaultLoginIdentifiers are tried in order when AuthControllerConfig.LoginIdentifiers is empty
+var DefaultLoginIdentifiers = []LoginIdentifier{LoginByName, LoginByEmail}
+
+// DefaultEmailChangeTTL is how long an email change verification token stays valid when not configured
+const DefaultEmailChangeTTL = 24 * time.Hour
+
+// EmailChangeSender delivers the email change verification token to the new address
+type EmailChangeSender interface {
+	SendEmailChangeVerification(ctx context.Context, user *v1alpha1.User, newEmail, token string) error
+}
+
+// AuthControllerConfig holds tunable behaviour of the auth controller
+type AuthControllerConfig struct {
+	// LoginHistoryLimit caps the number of login records stored on a user
+	LoginHistoryLimit int
+
+	// LoginIdentifiers are the identifier kinds Login tries, in order; 비어 있으면 DefaultLoginIdentifiers
+	LoginIdentifiers []LoginIdentifier
+
+	// PasswordHistorySize is how many previous passwords ChangePassword refuses to reuse.
+	// 0이면 DefaultPasswordHistorySize, 음수이면 현재 비밀번호만 거부
+	PasswordHistorySize int
+
+	// EmailChangeTTL is the validity period of email change verification tokens
+	EmailChangeTTL time.Duration
+	// EmailChangeSender delivers verification tokens. nil이면 RequestEmailChange와
+	// RequireEmailVerification 가입은 토큰을 전달할 수 없으므로 ErrInvalidConfig로 거부됨
+	EmailChangeSender EmailChangeSender
+
+	// DefaultRoles are assigned to new users that specify no roles.
+	// WithoutDefaultRoles로 요청 단위 생략 가능
+	DefaultRoles []string
+
+	// AllowSelfRegistration enables RegisterUser. false이면 ErrForbidden
+	AllowSelfRegistration bool
+	// RequireEmailVerification keeps self-registered users inactive until VerifyRegistration.
+	// 토큰은 EmailChangeSender로 전달되며 유효 기간은 EmailChangeTTL
+	RequireEmailVerification bool
+	// MinPasswordLength is the password policy of self-registration; 0이면 DefaultMinPasswordLength
+	MinPasswordLength int
+
+	// AllowRemovingLastAdmin disables the guard that rejects deleting the last user with */*/* access.
+	// 장애 복구 시에만 사용
+	AllowRemovingLastAdmin bool
+
+	// Names restricts the length and characters of user names and of the bindings CreateUserWithBindings creates
+	Names NamePolicy
+
+	// MaxSubjectsPerBinding is the RBACControllerConfig.MaxSubjectsPerBinding applied to the bindings
+	// CreateUserWithBindings creates; 0이면 제한 없음
+	MaxSubjectsPerBinding int
+
+	// Clock is used for activation checks, login timestamps and email change expiry; nil이면 clock.Real
+	Clock clock.Clock
+
+	// Events receives user change notifications; nil이면 발행하지 않음
+	Events events.Bus
+
+	// AccessCache is the RBACControllerConfig.AccessCache whose decisions are invalidated when
+	// creating, renaming or cascade-deleting a user changes role bindings; nil이면 무효화하지 않음
+	AccessCache cache.Cache
 }
 
 type authController struct {
-	store Store
+	store  Store
+	config AuthControllerConfig
+	access *accessCache
 }
 
 func NewAuthController(store Store) AuthController {
+	return NewAuthControllerWithConfig(store, AuthControllerConfig{})
+}
+
+func NewAuthControllerWithConfig(store Store, cfg AuthControllerConfig) AuthController {
+	if cfg.LoginHistoryLimit <= 0 {
+		cfg.LoginHistoryLimit = DefaultLoginHistoryLimit
+	}
+	if cfg.EmailChangeTTL <= 0 {
+		cfg.EmailChangeTTL = DefaultEmailChangeTTL
+	}
+	if len(cfg.LoginIdentifiers) == 0 {
+		cfg.LoginIdentifiers = DefaultLoginIdentifiers
+	}
+	if cfg.MinPasswordLength <= 0 {
+		cfg.MinPasswordLength = DefaultMinPasswordLength
+	}
+	if cfg.PasswordHistorySize == 0 {
+		cfg.PasswordHistorySize = DefaultPasswordHistorySize
+	}
+	if cfg.Clock == nil {
+		cfg.Clock = clock.Real{}
+	}
+
 	return &authController{
-		store: store,
+		store:  store,
+		config: cfg,
+		access: newAccessCache(cfg.AccessCache, 0),
 	}
 }
 
+// now returns the current time of the configured clock
+func (c *authController) now() metav1.Time {
+	return metav1.NewTime(c.config.Clock.Now())
+}
+
 func (c *authController) CreateUser(ctx context.Context, user *v1alpha1.User) (*v1alpha1.User, error) {
-	if user.ObjectMeta.Name == "" {
-		return nil, errors.ErrInvalidInput.WithReason("user name cannot be empty")
+	return c.createUser(ctx, user, v1alpha1.UserStatus{Active: true})
+}
+
+// createUser validates, hashes and stores user with the given initial status
+func (c *authController) createUser(ctx context.Context, user *v1alpha1.User, status v1alpha1.UserStatus) (*v1alpha1.User, error) {
+	if err := c.prepareUser(ctx, user, status); err != nil {
+		return nil, err
 	}
 
+	// Dry run: 검증과 해싱까지만 수행하고 해시는 폐기
+	if IsDryRun(ctx) {
+		user.Spec.PasswordHash = ""
+		return user, nil
+	}
+
+	err := c.store.CreateUser(ctx, user)
+	if err != nil {
+		return nil, err // Store already returns appropriate error
+	}
+
+	c.publishUser(ctx, events.Added, user)
+	return user, nil
+}
+
+// CreateUserWithBindings creates user and binds it to each of roleNames with a RoleBinding named
+// "<user>-<role>". 하나라도 실패하면 user와 binding 모두 롤백되며, 트랜잭션을 지원하지 않는 store에서는
+// 각 연산이 개별적으로 적용됨
+func (c *authController) CreateUserWithBindings(ctx context.Context, user *v1alpha1.User, roleNames []string) (*v1alpha1.User, error) {
+	if user == nil {
+		return nil, errors.ErrInvalidInput.WithReason("user cannot be nil")
+	}
+	verr := &errors.ValidationError{}
+	for i, role := range roleNames {
+		if role == "" {
+			verr.Add(fmt.Sprintf("roleNames[%d]", i), "role name cannot be empty")
+		}
+	}
+	if err := verr.OrNil(); err != nil {
+		return nil, err
+	}
+	if err := c.prepareUser(ctx, user, v1alpha1.UserStatus{Active: true}); err != nil {
+		return nil, err
+	}
+
+	var bindings []*v1alpha1.RoleBinding
+	seen := make(map[string]bool, len(roleNames))
+	for i, role := range roleNames {
+		if seen[role] {
+			continue
+		}
+		seen[role] = true
+		binding := &v1alpha1.RoleBinding{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "auth.service/v1alpha1",
+				Kind:       "RoleBinding",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              user.Name + "-" + role,
+				Namespace:         user.Namespace,
+				CreationTimestamp: user.CreationTimestamp,
+			},
+			Subjects: []v1alpha1.Subject{{Kind: v1alpha1.SubjectKindUser, Name: user.Name}},
+			RoleRef:  v1alpha1.RoleRef{Kind: v1alpha1.RoleRefKindRole, Name: role},
+		}
+		// 생성되는 "<user>-<role>" 바인딩도 CreateRoleBinding과 같은 정책을 통과해야 함
+		if err := validateNewRoleBinding(binding, c.config.Names, c.config.MaxSubjectsPerBinding); err != nil {
+			if bindingErr, ok := err.(*errors.ValidationError); ok {
+				for _, fe := range bindingErr.Errors {
+					verr.Add(fmt.Sprintf("roleNames[%d]", i), fmt.Sprintf("role binding %q: %s", binding.Name, fe.Message))
+				}
+				continue
+			}
+			return nil, err
+		}
+		bindings = append(bindings, binding)
+	}
+	if err := verr.OrNil(); err != nil {
+		return nil, err
+	}
+
+	err := inTransaction(ctx, c.store, func(ctx context.Context) error {
+		for _, binding := range bindings {
+			if _, err := c.store.GetRole(ctx, binding.RoleRef.Name); err != nil {
+				return err
+			}
+		}
+		if IsDryRun(ctx) {
+			return nil
+		}
+
+		if err := c.store.CreateUser(ctx, user); err != nil {
+			return err
+		}
+		for _, binding := range bindings {
+			if err := c.store.CreateRoleBinding(ctx, binding); err != nil {
+				return fmt.Errorf("failed to create role binding %q: %w", binding.Name, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if IsDryRun(ctx) {
+		user.Spec.PasswordHash = ""
+		return user, nil
+	}
+
+	// 커밋된 뒤에만 이벤트 발행
+	if len(bindings) > 0 {
+		c.access.invalidate(ctx)
+	}
+	c.publishUser(ctx, events.Added, user)
+	return user, nil
+}
+
+// prepareUser validates user and fills in the hashed password, type, status and creation time
+func (c *authController) prepareUser(ctx context.Context, user *v1alpha1.User, status v1alpha1.UserStatus) error {
+	verr := &errors.ValidationError{}
+	if user.ObjectMeta.Name == "" {
+		verr.Add("metadata.name", "user name cannot be empty")
+	} else if err := validateResourceName(user.Name, c.config.Names); err != nil {
+		verr.Add("metadata.name", err.Error())
+	}
 	if user.Spec.PasswordHash == "" {
-		return nil, errors.ErrInvalidInput.WithReason("password cannot be empty")
+		verr.Add("spec.passwordHash", "password cannot be empty")
+	}
+	if err := verr.OrNil(); err != nil {
+		return err
+	}
+
+	// 요청 namespace 밖에는 생성할 수 없음
+	if _, err := namespace.Resolve(ctx, user.Namespace); err != nil {
+		return err
+	}
+
+	if len(user.Spec.Roles) == 0 && len(c.config.DefaultRoles) > 0 && !IsWithoutDefaultRoles(ctx) {
+		if err := c.ensureDefaultRolesExist(ctx); err != nil {
+			return err
+		}
+		user.Spec.Roles = append([]string(nil), c.config.DefaultRoles...)
 	}
 
 	// Hash password
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(user.Spec.PasswordHash), bcrypt.DefaultCost)
 	if err != nil {
-		return nil, errors.ErrInternal.WithReason("failed to hash password")
+		return errors.ErrInternal.WithReason("failed to hash password")
 	}
 	user.Spec.PasswordHash = string(hashedPassword)
 
@@ -55,20 +335,24 @@ func (c *authController) CreateUser(ctx context.Context, user *v1alpha1.User) (*
 	}
 
 	// Set status
-	user.Status = v1alpha1.UserStatus{
-		Active: true,
-	}
+	user.Status = status
 
 	// Set metadata
-	now := metav1.Now()
-	user.ObjectMeta.CreationTimestamp = now
+	user.ObjectMeta.CreationTimestamp = c.now()
+	return nil
+}
 
-	err = c.store.CreateUser(ctx, user)
-	if err != nil {
-		return nil, err // Store already returns appropriate error
+// ensureDefaultRolesExist reports a misconfigured default role instead of silently granting nothing
+func (c *authController) ensureDefaultRolesExist(ctx context.Context) error {
+	for _, name := range c.config.DefaultRoles {
+		if _, err := c.store.GetRole(ctx, name); err != nil {
+			if err == errors.ErrRoleNotFound {
+				return errors.ErrInvalidConfig.WithReason(fmt.Sprintf("default role %q does not exist", name))
+			}
+			return err
+		}
 	}
-
-	return user, nil
+	return nil
 }
 
 func (c *authController) GetUser(ctx context.Context, name string) (*v1alpha1.User, error) {
@@ -89,20 +373,40 @@ func (c *authController) UpdateUser(ctx context.Context, user *v1alpha1.User) (*
 		return nil, fmt.Errorf("user name cannot be empty")
 	}
 
+	// 이름 형식은 생성/이름 변경 시에만 검증. 정책 도입 전의 기존 이름도 계속 수정할 수 있어야 함
 	existing, err := c.store.GetUser(ctx, user.Name)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get existing user: %v", err)
 	}
+	if IsSelfService(ctx) {
+		user = selfServiceUpdate(existing, user)
+	}
 
-	// Preserve password hash and creation timestamp
+	// Preserve password hash and history, email, active flag, creation timestamp and pending email change.
+	// PasswordHistory는 API에 노출되지 않으므로 요청 본문에는 항상 비어 있음.
+	// 이메일은 검증을 거치는 RequestEmailChange/ConfirmEmailChange로만 변경 가능하며,
+	// 활성 상태는 본문에서 생략되면 false가 되므로 기존 값을 유지
 	user.Spec.PasswordHash = existing.Spec.PasswordHash
+	user.Status.Active = existing.Status.Active
+	user.Spec.Email = existing.Spec.Email
+	user.Status.PasswordHistory = existing.Status.PasswordHistory
+	user.Status.PasswordChangedAt = existing.Status.PasswordChangedAt
 	user.ObjectMeta.CreationTimestamp = existing.ObjectMeta.CreationTimestamp
+	user.Status.PendingEmail = existing.Status.PendingEmail
+	user.Status.EmailChangeTokenHash = existing.Status.EmailChangeTokenHash
+	user.Status.EmailChangeExpiry = existing.Status.EmailChangeExpiry
+
+	// 변경 사항이 없으면 쓰기를 생략 (updated_at도 갱신하지 않음)
+	if userMutableFieldsEqual(existing, user) {
+		return existing, nil
+	}
 
 	err = c.store.UpdateUser(ctx, user)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update user: %v", err)
 	}
 
+	c.publishUser(ctx, events.Modified, user)
 	return user, nil
 }
 
@@ -110,15 +414,216 @@ func (c *authController) DeleteUser(ctx context.Context, name string) error {
 	if name == "" {
 		return fmt.Errorf("user name cannot be empty")
 	}
+	if err := c.ensureAdminRemains(ctx, name); err != nil {
+		return err
+	}
 
 	err := c.store.DeleteUser(ctx, name)
 	if err != nil {
 		return fmt.Errorf("failed to delete user: %v", err)
 	}
 
+	publish(ctx, c.config.Events, events.Deleted, "User", name, "", nil)
+	return nil
+}
+
+// DeleteUsersBatch deletes each named user and returns the failures keyed by user name.
+// 한 사용자의 실패가 나머지 삭제를 막지 않으며, WithCascadeDelete가 설정된 경우
+// 해당 사용자를 참조하는 RoleBinding도 함께 정리됨
+func (c *authController) DeleteUsersBatch(ctx context.Context, names []string) (map[string]error, error) {
+	if len(names) == 0 {
+		return nil, errors.ErrInvalidInput.WithReason("at least one user name is required")
+	}
+	for _, name := range names {
+		if name == "" {
+			return nil, errors.ErrInvalidInput.WithReason("user name cannot be empty")
+		}
+	}
+
+	failed := make(map[string]error)
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		err := inTransaction(ctx, c.store, func(ctx context.Context) error {
+			if _, err := c.store.GetUser(ctx, name); err != nil {
+				return err
+			}
+			if err := c.ensureAdminRemains(ctx, name); err != nil {
+				return err
+			}
+			// 바인딩 정리를 사용자 삭제보다 먼저 수행하여, 트랜잭션이 없는 store에서 정리가 실패해도
+			// 사용자는 남아 있게 함 (결과의 deleted=false가 실제 상태와 일치)
+			if IsCascadeDelete(ctx) {
+				if err := c.removeUserFromBindings(ctx, name); err != nil {
+					return err
+				}
+			}
+			return c.store.DeleteUser(ctx, name)
+		})
+		if err != nil {
+			failed[name] = err
+			continue
+		}
+		if IsCascadeDelete(ctx) {
+			c.access.invalidate(ctx)
+		}
+		publish(ctx, c.config.Events, events.Deleted, "User", name, "", nil)
+	}
+
+	return failed, nil
+}
+
+// ensureAdminRemains rejects deleting the user name when it is the last admin
+func (c *authController) ensureAdminRemains(ctx context.Context, name string) error {
+	if c.config.AllowRemovingLastAdmin {
+		return nil
+	}
+	return ensureAdminRemains(ctx, c.store, pendingRemoval{user: name})
+}
+
+// removeUserFromBindings drops the user from every RoleBinding subject list,
+// deleting bindings that would be left without subjects
+func (c *authController) removeUserFromBindings(ctx context.Context, name string) error {
+	bindings, err := c.store.ListRoleBindings(ctx)
+	if err != nil {
+		return err
+	}
+
+	user := v1alpha1.Subject{Kind: v1alpha1.SubjectKindUser, Name: name}
+	for _, binding := range bindings {
+		if !hasSubject(binding.Subjects, user) {
+			continue
+		}
+
+		subjects := make([]v1alpha1.Subject, 0, len(binding.Subjects)-1)
+		for _, s := range binding.Subjects {
+			if !(s.Kind == user.Kind && s.Name == user.Name) {
+				subjects = append(subjects, s)
+			}
+		}
+
+		if len(subjects) == 0 {
+			err = c.store.DeleteRoleBinding(ctx, binding.Name)
+		} else {
+			binding.Subjects = subjects
+			err = c.store.UpdateRoleBinding(ctx, binding)
+		}
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// RenameUser renames the user oldName to newName. 이름이 곧 주체 식별자이므로
+// RoleBinding/ClusterRoleBinding의 subject도 같은 트랜잭션에서 새 이름으로 변경하여 권한을 유지함
+func (c *authController) RenameUser(ctx context.Context, oldName, newName string) (*v1alpha1.User, error) {
+	verr := &errors.ValidationError{}
+	if oldName == "" {
+		verr.Add("oldName", "user name cannot be empty")
+	}
+	if newName == "" {
+		verr.Add("newName", "new user name cannot be empty")
+	} else if err := validateResourceName(newName, c.config.Names); err != nil {
+		verr.Add("newName", err.Error())
+	}
+	if err := verr.OrNil(); err != nil {
+		return nil, err
+	}
+	if oldName == newName {
+		return nil, errors.ErrInvalidInput.WithReason("new name must differ from the current name")
+	}
+
+	user, err := c.store.GetUser(ctx, oldName)
+	if err != nil {
+		return nil, err
+	}
+	// 바인딩 갱신을 사용자의 namespace로 한정
+	if user.Namespace != "" {
+		ctx = namespace.WithNamespace(ctx, user.Namespace)
+	}
+	if _, err := c.store.GetUser(ctx, newName); err == nil {
+		return nil, errors.ErrUserExists.WithReason(fmt.Sprintf("user %s already exists", newName))
+	}
+	if err := c.checkNoClusterBindings(ctx, oldName); err != nil {
+		return nil, err
+	}
+
+	err = inTransaction(ctx, c.store, func(ctx context.Context) error {
+		if err := c.store.RenameUser(ctx, oldName, newName); err != nil {
+			return err
+		}
+		return c.renameBindingSubjects(ctx, oldName, newName)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to rename user: %w", err)
+	}
+	c.access.invalidate(ctx)
+
+	renamed, err := c.store.GetUser(ctx, newName)
+	if err != nil {
+		return nil, err
+	}
+	publish(ctx, c.config.Events, events.Deleted, "User", oldName, "", nil)
+	c.publishUser(ctx, events.Added, renamed)
+	return renamed, nil
+}
+
+// checkNoClusterBindings rejects renaming a user named by a ClusterRoleBinding.
+// ClusterRoleBinding의 subject는 namespace 구분 없이 모든 테넌트의 같은 이름 사용자에게 적용되므로
+// 한 테넌트의 이름 변경으로 수정할 수 없음
+func (c *authController) checkNoClusterBindings(ctx context.Context, name string) error {
+	subject := v1alpha1.Subject{Kind: v1alpha1.SubjectKindUser, Name: name}
+
+	bindings, err := c.store.ListClusterRoleBindings(ctx)
+	if err != nil {
+		return err
+	}
+	for _, binding := range bindings {
+		if hasSubject(binding.Subjects, subject) {
+			return errors.ErrInvalidInput.WithReason(fmt.Sprintf("user %s is a subject of cluster role binding %s; update the cluster role binding before renaming", name, binding.Name))
+		}
+	}
+	return nil
+}
+
+// renameBindingSubjects replaces the user subject oldName with newName in the role bindings of the request namespace.
+// 바인딩을 제자리에서 수정하므로 메타데이터(생성 시각, 레이블 등)가 유지됨
+func (c *authController) renameBindingSubjects(ctx context.Context, oldName, newName string) error {
+	old := v1alpha1.Subject{Kind: v1alpha1.SubjectKindUser, Name: oldName}
+
+	bindings, err := c.store.ListRoleBindings(ctx)
+	if err != nil {
+		return err
+	}
+	for _, binding := range bindings {
+		if !hasSubject(binding.Subjects, old) {
+			continue
+		}
+		binding.Subjects = renameSubject(binding.Subjects, old, newName)
+		if err := c.store.UpdateRoleBinding(ctx, binding); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renameSubject returns a copy of subjects with old renamed to newName
+func renameSubject(subjects []v1alpha1.Subject, old v1alpha1.Subject, newName string) []v1alpha1.Subject {
+	renamed := make([]v1alpha1.Subject, len(subjects))
+	for i, s := range subjects {
+		if s.Kind == old.Kind && s.Name == old.Name {
+			s.Name = newName
+		}
+		renamed[i] = s
+	}
+	return renamed
+}
+
 func (c *authController) ListUsers(ctx context.Context) (*v1alpha1.UserList, error) {
 	users, err := c.store.ListUsers(ctx)
 	if err != nil {
@@ -128,24 +633,68 @@ func (c *authController) ListUsers(ctx context.Context) (*v1alpha1.UserList, err
 	return users, nil
 }
 
+// ListUsersWithOptions lists users populating only the fields requested in opts.
+// passwordHash는 선택할 수 없으며 결과에서도 항상 제거됨
+func (c *authController) ListUsersWithOptions(ctx context.Context, opts v1alpha1.ListOptions) (*v1alpha1.UserList, error) {
+	if len(opts.Fields) == 0 && !opts.Paged() {
+		return c.ListUsers(ctx)
+	}
+
+	for _, field := range opts.Fields {
+		if !selectableUserFields[field] {
+			return nil, errors.ErrInvalidInput.WithReason(fmt.Sprintf("unsupported field: %s", field))
+		}
+	}
+	if opts.Limit < 0 {
+		return nil, errors.ErrInvalidInput.WithReason("limit must not be negative")
+	}
+	if opts.Cursor != "" {
+		if _, err := cursor.Decode(opts.Cursor); err != nil {
+			return nil, errors.ErrInvalidInput.WithReason("invalid cursor")
+		}
+	}
+
+	users, err := c.store.ListUsersWithOptions(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %v", err)
+	}
+
+	// store가 필드 선택을 지원하지 않더라도 요청되지 않은 필드는 비워서 반환
+	if len(opts.Fields) > 0 {
+		for _, user := range users.Items {
+			selectUserFields(user, opts.Fields)
+		}
+	}
+
+	return users, nil
+}
+
 func (c *authController) Login(ctx context.Context, username, password string) (*v1alpha1.User, error) {
 	if username == "" || password == "" {
 		return nil, errors.ErrInvalidInput.WithReason("username and password are required")
 	}
 
-	user, err := c.store.GetUser(ctx, username)
-	if err != nil {
+	user := c.findLoginUser(ctx, username)
+	if user == nil {
+		// 존재하지 않는 계정도 해시 비교 시간만큼 소요되도록 하여 응답 시간으로 계정 존재 여부를 알 수 없게 함
+		_ = bcrypt.CompareHashAndPassword(dummyPasswordHash(), []byte(password))
 		return nil, errors.ErrInvalidCredentials.WithReason("invalid username or password")
 	}
 
-	err = bcrypt.CompareHashAndPassword([]byte(user.Spec.PasswordHash), []byte(password))
+	err := bcrypt.CompareHashAndPassword([]byte(user.Spec.PasswordHash), []byte(password))
 	if err != nil {
 		return nil, errors.ErrInvalidCredentials.WithReason("invalid username or password")
 	}
 
-	// Update last login time
-	now := metav1.Now()
+	// 비밀번호 확인 후에만 알려 계정 존재 여부가 노출되지 않도록 함
+	now := c.now()
+	if err := checkCanAuthenticate(user, now); err != nil {
+		return nil, err
+	}
+
+	// Update last login time and history
 	user.Status.LastLogin = &now
+	c.recordLogin(ctx, user, now)
 
 	err = c.store.UpdateUser(ctx, user)
 	if err != nil {
@@ -155,6 +704,74 @@ func (c *authController) Login(ctx context.Context, username, password string) (
 	return user, nil
 }
 
+// RefreshUser re-checks the account before a refresh token is exchanged, so a token issued
+// before the account was deactivated or its password changed can no longer be renewed
+func (c *authController) RefreshUser(ctx context.Context, name string, issuedAt time.Time) (*v1alpha1.User, error) {
+	user, err := c.store.GetUser(ctx, name)
+	if err != nil || user == nil {
+		return nil, errors.ErrInvalidToken.WithReason("user no longer exists")
+	}
+
+	if err := checkCanAuthenticate(user, c.now()); err != nil {
+		return nil, err
+	}
+	// JWT iat는 초 단위이므로 변경 시각도 초 단위로 잘라 비교 (변경 직후 발급된 토큰은 허용)
+	if changed := user.Status.PasswordChangedAt; changed != nil && issuedAt.Before(changed.Truncate(time.Second)) {
+		return nil, errors.ErrInvalidToken.WithReason("token was issued before the password was changed")
+	}
+
+	return user, nil
+}
+
+// checkCanAuthenticate rejects users that may not sign in: before ActivationTime, while a
+// self-registration awaits email verification, or once deactivated. Login과 RefreshUser가 함께 사용
+func checkCanAuthenticate(user *v1alpha1.User, now metav1.Time) error {
+	if activation := user.Spec.ActivationTime; activation != nil && now.Before(activation) {
+		return errors.ErrAccountNotYetActive.WithReason(fmt.Sprintf("account activates at %s", activation.UTC().Format(time.RFC3339)))
+	}
+	if awaitingRegistrationVerification(user) {
+		return errors.ErrEmailNotVerified.WithReason("verify your email address before logging in")
+	}
+	if !user.Status.Active {
+		return errors.ErrAccountInactive.WithReason("account is inactive")
+	}
+	return nil
+}
+
+// findLoginUser resolves identifier with the configured LoginIdentifiers in order.
+// 조회 실패 원인은 구분하지 않고 nil을 반환 (호출자는 항상 같은 오류를 반환)
+func (c *authController) findLoginUser(ctx context.Context, identifier string) *v1alpha1.User {
+	for _, kind := range c.config.LoginIdentifiers {
+		switch kind {
+		case LoginByName:
+			if user, err := c.store.GetUser(ctx, identifier); err == nil && user != nil {
+				return user
+			}
+		case LoginByEmail:
+			if !strings.Contains(identifier, "@") {
+				continue
+			}
+			if user, err := c.store.FindUserByEmail(ctx, identifier); err == nil && user != nil {
+				return user
+			}
+		}
+	}
+	return nil
+}
+
+var (
+	dummyHashOnce sync.Once
+	dummyHash     []byte
+)
+
+// dummyPasswordHash returns a bcrypt hash compared against when no user matches
+func dummyPasswordHash() []byte {
+	dummyHashOnce.Do(func() {
+		dummyHash, _ = bcrypt.GenerateFromPassword([]byte("pauth-dummy-password"), bcrypt.DefaultCost)
+	})
+	return dummyHash
+}
+
 func (c *authController) ChangePassword(ctx context.Context, name, oldPassword, newPassword string) error {
 	if name == "" || oldPassword == "" || newPassword == "" {
 		return errors.ErrInvalidInput.WithReason("all fields are required")
@@ -171,26 +788,497 @@ func (c *authController) ChangePassword(ctx context.Context, name, oldPassword,
 		return errors.ErrInvalidCredentials.WithReason("invalid old password")
 	}
 
+	if passwordReused(newPassword, user.Spec.PasswordHash, user.Status.PasswordHistory) {
+		return errors.ErrPasswordReused.WithReason("new password must differ from recently used passwords")
+	}
+
 	// Hash new password
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
 	if err != nil {
 		return errors.ErrInternal.WithReason("failed to hash new password")
 	}
 
+	// 변경 시각 이전에 발급된 refresh token은 RefreshUser에서 거부됨
+	changed := c.now()
+	user.Status.PasswordHistory = c.pushPasswordHistory(user.Spec.PasswordHash, user.Status.PasswordHistory)
+	user.Status.PasswordChangedAt = &changed
 	user.Spec.PasswordHash = string(hashedPassword)
 	return c.store.UpdateUser(ctx, user)
 }
 
-func (c *authController) AssignRoles(ctx context.Context, name string, roles []string) error {
+// passwordReused reports whether password matches the current hash or any hash in history.
+// bcrypt 비교는 해시마다 상수 시간으로 수행되며, 일치 여부와 무관하게 모든 해시를 비교함
+func passwordReused(password, current string, history []string) bool {
+	reused := false
+	for _, hash := range append([]string{current}, history...) {
+		if bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil {
+			reused = true
+		}
+	}
+	return reused
+}
+
+// pushPasswordHistory prepends the replaced hash and trims history to PasswordHistorySize
+func (c *authController) pushPasswordHistory(replaced string, history []string) []string {
+	size := c.config.PasswordHistorySize
+	if size < 0 {
+		return nil
+	}
+	history = append([]string{replaced}, history...)
+	if len(history) > size {
+		history = history[:size]
+	}
+	return history
+}
+
+// SetRoles replaces the roles of a user with roles
+func (c *authController) SetRoles(ctx context.Context, name string, roles []string) error {
+	return c.updateRoles(ctx, name, roles, true, func(_ []string) []string {
+		return dedupeStrings(roles)
+	})
+}
+
+// AddRoles assigns roles in addition to the roles the user already has
+func (c *authController) AddRoles(ctx context.Context, name string, roles []string) error {
+	return c.updateRoles(ctx, name, roles, true, func(current []string) []string {
+		return dedupeStrings(append(append([]string{}, current...), roles...))
+	})
+}
+
+// RemoveRoles unassigns roles from a user. 이미 삭제된 역할도 해제할 수 있도록 존재 여부는 확인하지 않음
+func (c *authController) RemoveRoles(ctx context.Context, name string, roles []string) error {
+	return c.updateRoles(ctx, name, roles, false, func(current []string) []string {
+		remove := make(map[string]bool, len(roles))
+		for _, r := range roles {
+			remove[r] = true
+		}
+		kept := []string{}
+		for _, r := range current {
+			if !remove[r] {
+				kept = append(kept, r)
+			}
+		}
+		return kept
+	})
+}
+
+// updateRoles validates the request, then stores the roles computed by apply from the user's current roles
+func (c *authController) updateRoles(ctx context.Context, name string, roles []string, mustExist bool, apply func(current []string) []string) error {
 	if name == "" || len(roles) == 0 {
 		return errors.ErrInvalidInput.WithReason("name and roles are required")
 	}
 
+	if mustExist {
+		if err := c.ensureRolesExist(ctx, roles); err != nil {
+			return err
+		}
+	}
+
 	user, err := c.store.GetUser(ctx, name)
 	if err != nil {
 		return err
 	}
 
-	user.Spec.Roles = roles
-	return c.store.UpdateUser(ctx, user)
+	user.Spec.Roles = apply(user.Spec.Roles)
+	if err := c.store.UpdateUser(ctx, user); err != nil {
+		return err
+	}
+	c.publishUser(ctx, events.Modified, user)
+	return nil
+}
+
+// ensureRolesExist returns ErrRoleNotFound naming every role that does not exist,
+// so a typo'd role is reported instead of silently granting nothing
+func (c *authController) ensureRolesExist(ctx context.Context, roles []string) error {
+	var missing []string
+	for _, role := range roles {
+		if role == "" {
+			return errors.ErrInvalidInput.WithReason("role name cannot be empty")
+		}
+		if _, err := c.store.GetRole(ctx, role); err != nil {
+			if err != errors.ErrRoleNotFound {
+				return err
+			}
+			missing = append(missing, role)
+		}
+	}
+
+	if len(missing) > 0 {
+		return errors.ErrRoleNotFound.WithReason("unknown roles: " + strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// dedupeStrings returns values without duplicates, keeping first occurrences in order
+func dedupeStrings(values []string) []string {
+	result := make([]string, 0, len(values))
+	seen := make(map[string]bool, len(values))
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+func (c *authController) GetLoginHistory(ctx context.Context, name string) ([]v1alpha1.LoginRecord, error) {
+	if name == "" {
+		return nil, errors.ErrInvalidInput.WithReason("user name cannot be empty")
+	}
+
+	user, err := c.store.GetUser(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	history := user.Status.LoginHistory
+	if history == nil {
+		history = []v1alpha1.LoginRecord{}
+	}
+	return history, nil
+}
+
+// recordLogin prepends a login record and trims the history to the configured limit
+func (c *authController) recordLogin(ctx context.Context, user *v1alpha1.User, at metav1.Time) {
+	info := ClientInfoFromContext(ctx)
+	record := v1alpha1.LoginRecord{
+		Timestamp: at,
+		IP:        info.IP,
+		UserAgent: info.UserAgent,
+	}
+
+	// 최신 기록이 앞에 오도록 유지
+	history := append([]v1alpha1.LoginRecord{record}, user.Status.LoginHistory...)
+	if len(history) > c.config.LoginHistoryLimit {
+		history = history[:c.config.LoginHistoryLimit]
+	}
+	user.Status.LoginHistory = history
+}
+
+// RequestEmailChange stores newEmail as pending together with a verification token and returns the token.
+// 새 이메일은 ConfirmEmailChange로 토큰이 확인된 후에만 활성화됨
+func (c *authController) RequestEmailChange(ctx context.Context, name, newEmail string) (string, error) {
+	if name == "" || newEmail == "" {
+		return "", errors.ErrInvalidInput.WithReason("name and email are required")
+	}
+	if c.config.EmailChangeSender == nil {
+		return "", errors.ErrInvalidConfig.WithReason("email change requires an email sender")
+	}
+	if _, err := mail.ParseAddress(newEmail); err != nil {
+		return "", errors.ErrInvalidInput.WithReason("invalid email address")
+	}
+
+	user, err := c.store.GetUser(ctx, name)
+	if err != nil {
+		return "", errors.ErrUserNotFound
+	}
+	if user.Spec.Email == newEmail {
+		return "", errors.ErrInvalidInput.WithReason("new email is the same as the current email")
+	}
+	if err := c.ensureEmailAvailable(ctx, name, newEmail); err != nil {
+		return "", err
+	}
+
+	token, err := generateEmailChangeToken()
+	if err != nil {
+		return "", errors.ErrInternal.WithReason("failed to generate verification token")
+	}
+
+	expiry := metav1.NewTime(c.config.Clock.Now().Add(c.config.EmailChangeTTL))
+	user.Status.PendingEmail = newEmail
+	user.Status.EmailChangeTokenHash = hashEmailChangeToken(token)
+	user.Status.EmailChangeExpiry = &expiry
+
+	if err := c.store.UpdateUser(ctx, user); err != nil {
+		return "", errors.ErrInternal.WithReason("failed to store pending email change")
+	}
+
+	if err := c.config.EmailChangeSender.SendEmailChangeVerification(ctx, user, newEmail, token); err != nil {
+		return "", errors.ErrInternal.WithReason("failed to send verification email")
+	}
+
+	return token, nil
+}
+
+// ConfirmEmailChange promotes the pending email matching token to the active email of user name.
+// 다른 사용자의 토큰으로는 확인할 수 없도록 토큰의 사용자와 name이 일치해야 함
+func (c *authController) ConfirmEmailChange(ctx context.Context, name, token string) (*v1alpha1.User, error) {
+	if name == "" || token == "" {
+		return nil, errors.ErrInvalidInput.WithReason("name and token are required")
+	}
+
+	user, err := c.store.FindUserByEmailChangeToken(ctx, hashEmailChangeToken(token))
+	if err != nil || user.Status.PendingEmail == "" || user.Name != name {
+		return nil, errors.ErrInvalidToken.WithReason("invalid email change token")
+	}
+
+	pendingEmail := user.Status.PendingEmail
+	activate := awaitingRegistrationVerification(user)
+	expired := user.Status.EmailChangeExpiry == nil || c.config.Clock.Now().After(user.Status.EmailChangeExpiry.Time)
+	clearPendingEmail(user)
+
+	if expired {
+		// 만료된 요청은 정리 후 거부
+		if err := c.store.UpdateUser(ctx, user); err != nil {
+			return nil, errors.ErrInternal.WithReason("failed to clear expired email change")
+		}
+		return nil, errors.ErrTokenExpired.WithReason("email change token expired")
+	}
+
+	// 요청 이후 다른 사용자가 같은 이메일을 사용했을 수 있으므로 재확인
+	if err := c.ensureEmailAvailable(ctx, user.Name, pendingEmail); err != nil {
+		return nil, err
+	}
+
+	user.Spec.Email = pendingEmail
+	if activate {
+		user.Status.Active = true
+	}
+	if err := c.store.UpdateUser(ctx, user); err != nil {
+		return nil, errors.ErrInternal.WithReason("failed to update email")
+	}
+
+	return user, nil
+}
+
+// Registration is the input of RegisterUser.
+// 역할과 활성 상태는 의도적으로 포함하지 않음 (기본 역할만 부여)
+type Registration struct {
+	Name        string
+	Email       string
+	Password    string
+	DisplayName string
+}
+
+// RegisterUser creates a self-registered user with only the configured default roles.
+// RequireEmailVerification이면 이메일은 확인 전까지 pending으로 남고 로그인할 수 없음
+func (c *authController) RegisterUser(ctx context.Context, reg Registration) (*v1alpha1.User, error) {
+	if !c.config.AllowSelfRegistration {
+		return nil, errors.ErrForbidden.WithReason("self-registration is disabled")
+	}
+
+	verr := &errors.ValidationError{}
+	if reg.Name == "" {
+		verr.Add("name", "name cannot be empty")
+	} else if err := validateResourceName(reg.Name, c.config.Names); err != nil {
+		verr.Add("name", err.Error())
+	}
+	if len(reg.Password) < c.config.MinPasswordLength {
+		verr.Add("password", fmt.Sprintf("password must be at least %d characters", c.config.MinPasswordLength))
+	}
+	if reg.Email == "" {
+		if c.config.RequireEmailVerification {
+			verr.Add("email", "email is required")
+		}
+	} else if _, err := mail.ParseAddress(reg.Email); err != nil {
+		verr.Add("email", "invalid email address")
+	}
+	if err := verr.OrNil(); err != nil {
+		return nil, err
+	}
+
+	if reg.Email != "" {
+		if err := c.ensureEmailAvailable(ctx, reg.Name, reg.Email); err != nil {
+			return nil, err
+		}
+	}
+
+	user := &v1alpha1.User{
+		ObjectMeta: metav1.ObjectMeta{Name: reg.Name},
+		Spec: v1alpha1.UserSpec{
+			Username:     reg.Name,
+			Email:        reg.Email,
+			DisplayName:  reg.DisplayName,
+			PasswordHash: reg.Password,
+		},
+	}
+	// 호출자의 컨텍스트와 무관하게 항상 기본 역할을 부여
+	ctx = context.WithValue(ctx, withoutDefaultRolesKey{}, false)
+
+	if !c.config.RequireEmailVerification {
+		return c.createUser(ctx, user, v1alpha1.UserStatus{Active: true})
+	}
+	if c.config.EmailChangeSender == nil {
+		return nil, errors.ErrInvalidConfig.WithReason("email verification requires an email sender")
+	}
+
+	token, err := generateEmailChangeToken()
+	if err != nil {
+		return nil, errors.ErrInternal.WithReason("failed to generate verification token")
+	}
+	expiry := metav1.NewTime(c.config.Clock.Now().Add(c.config.EmailChangeTTL))
+	user.Spec.Email = ""
+	created, err := c.createUser(ctx, user, v1alpha1.UserStatus{
+		PendingEmail:         reg.Email,
+		EmailChangeTokenHash: hashEmailChangeToken(token),
+		EmailChangeExpiry:    &expiry,
+	})
+	if err != nil || IsDryRun(ctx) {
+		return created, err
+	}
+
+	if err := c.config.EmailChangeSender.SendEmailChangeVerification(ctx, created, reg.Email, token); err != nil {
+		return nil, errors.ErrInternal.WithReason("failed to send verification email")
+	}
+	return created, nil
+}
+
+// VerifyRegistration confirms the email of a self-registered user and activates the account
+func (c *authController) VerifyRegistration(ctx context.Context, token string) (*v1alpha1.User, error) {
+	if token == "" {
+		return nil, errors.ErrInvalidInput.WithReason("token is required")
+	}
+
+	// 이메일 변경 토큰으로는 가입 확인을 할 수 없도록 대상 사용자를 먼저 확인
+	user, err := c.store.FindUserByEmailChangeToken(ctx, hashEmailChangeToken(token))
+	if err != nil || !awaitingRegistrationVerification(user) {
+		return nil, errors.ErrInvalidToken.WithReason("invalid registration token")
+	}
+	return c.ConfirmEmailChange(ctx, user.Name, token)
+}
+
+// awaitingRegistrationVerification reports whether user self-registered and has not yet confirmed the email.
+// 확인 전 사용자는 비활성 상태이며 이메일이 pending으로만 존재함
+func awaitingRegistrationVerification(user *v1alpha1.User) bool {
+	return !user.Status.Active && user.Spec.Email == "" && user.Status.PendingEmail != ""
+}
+
+// ensureEmailAvailable fails with ErrAlreadyExists when another user already uses email
+func (c *authController) ensureEmailAvailable(ctx context.Context, name, email string) error {
+	other, err := c.store.FindUserByEmail(ctx, email)
+	if err != nil {
+		if err == errors.ErrUserNotFound {
+			return nil
+		}
+		return errors.ErrInternal.WithReason("failed to check email availability")
+	}
+	if other != nil && other.Name != name {
+		return errors.ErrAlreadyExists.WithReason("email already in use")
+	}
+	return nil
+}
+
+func clearPendingEmail(user *v1alpha1.User) {
+	user.Status.PendingEmail = ""
+	user.Status.EmailChangeTokenHash = ""
+	user.Status.EmailChangeExpiry = nil
+}
+
+func generateEmailChangeToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// hashEmailChangeToken returns the form of the token persisted in the store
+func hashEmailChangeToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// userMutableFieldsEqual reports whether UpdateUser would persist nothing new.
+// nil과 빈 값은 동일하게 취급하되, 값이 있던 필드를 비우는 것은 변경으로 간주
+// selfServiceUpdate returns a copy of existing with only the fields a user may change on their own account:
+// displayName and profile. 상태, 역할, 어노테이션, 활성화 시각 등은 update 권한이 있어야 변경 가능
+func selfServiceUpdate(existing, requested *v1alpha1.User) *v1alpha1.User {
+	updated := *existing
+	updated.Spec.DisplayName = requested.Spec.DisplayName
+	updated.Spec.Profile = requested.Spec.Profile
+	return &updated
+}
+
+// userMutableFieldsEqual reports whether an update would leave every field UpdateUser writes unchanged.
+// 새로 수정 가능한 필드를 추가하면 여기에도 추가해야 변경이 누락되지 않음
+func userMutableFieldsEqual(a, b *v1alpha1.User) bool {
+	return a.Spec.Username == b.Spec.Username &&
+		a.Spec.Email == b.Spec.Email &&
+		a.Spec.DisplayName == b.Spec.DisplayName &&
+		reflect.DeepEqual(nonNilMap(a.Spec.Profile), nonNilMap(b.Spec.Profile)) &&
+		reflect.DeepEqual(nonNilStrings(a.Spec.Roles), nonNilStrings(b.Spec.Roles)) &&
+		timesEqual(a.Spec.ActivationTime, b.Spec.ActivationTime) &&
+		reflect.DeepEqual(nonNilMap(a.Annotations), nonNilMap(b.Annotations))
+}
+
+// timesEqual compares optional timestamps; nil은 nil과만 같음
+func timesEqual(a, b *metav1.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(b)
+}
+
+func nonNilStrings(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
+
+func nonNilMap(m map[string]string) map[string]string {
+	if m == nil {
+		return map[string]string{}
+	}
+	return m
+}
+
+// selectableUserFields lists the User fields that can be requested via ListOptions.Fields
+var selectableUserFields = map[string]bool{
+	"name":              true,
+	"creationTimestamp": true,
+	"annotations":       true,
+	"username":          true,
+	"email":             true,
+	"roles":             true,
+	"displayName":       true,
+	"profile":           true,
+	"active":            true,
+	"lastLogin":         true,
+	"loginHistory":      true,
+	"activationTime":    true,
+}
+
+// selectUserFields zeroes every field of user not listed in fields. Name is always kept.
+func selectUserFields(user *v1alpha1.User, fields []string) {
+	keep := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		keep[f] = true
+	}
+
+	user.Spec.PasswordHash = ""
+	if !keep["creationTimestamp"] {
+		user.CreationTimestamp = metav1.Time{}
+	}
+	if !keep["annotations"] {
+		user.Annotations = nil
+	}
+	if !keep["username"] {
+		user.Spec.Username = ""
+	}
+	if !keep["email"] {
+		user.Spec.Email = ""
+	}
+	if !keep["roles"] {
+		user.Spec.Roles = nil
+	}
+	if !keep["displayName"] {
+		user.Spec.DisplayName = ""
+	}
+	if !keep["profile"] {
+		user.Spec.Profile = nil
+	}
+	if !keep["active"] {
+		user.Status.Active = false
+	}
+	if !keep["lastLogin"] {
+		user.Status.LastLogin = nil
+	}
+	if !keep["loginHistory"] {
+		user.Status.LoginHistory = nil
+	}
+	if !keep["activationTime"] {
+		user.Spec.ActivationTime = nil
+	}
 }
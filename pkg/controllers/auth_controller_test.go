@@ -2,13 +2,17 @@ package controllers
 
 import (
 	"context"
+	"net/http"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/sukryu/pAuth/pkg/apis/auth/v1alpha1"
 	"github.com/sukryu/pAuth/pkg/errors"
 	"github.com/sukryu/pAuth/pkg/mocks"
+	"github.com/sukryu/pAuth/pkg/utils/clock"
 	"golang.org/x/crypto/bcrypt"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -72,7 +76,10 @@ func TestAuthController_CreateUser(t *testing.T) {
 				},
 			},
 			setupMock: func(ms *mocks.MockStore) {},
-			wantErr:   errors.ErrInvalidInput,
+			wantErr: errors.NewValidationError(
+				errors.FieldError{Field: "metadata.name", Message: "user name cannot be empty"},
+				errors.FieldError{Field: "spec.passwordHash", Message: "password cannot be empty"},
+			),
 		},
 	}
 
@@ -118,6 +125,7 @@ func TestAuthController_Login(t *testing.T) {
 						Email:        "test@example.com",
 						PasswordHash: string(hashedPassword),
 					},
+					Status: v1alpha1.UserStatus{Active: true},
 				}
 				ms.On("GetUser", mock.Anything, "testuser").Return(user, nil)
 				ms.On("UpdateUser", mock.Anything, mock.MatchedBy(func(u *v1alpha1.User) bool {
@@ -235,8 +243,9 @@ func TestAuthController_UpdateUser(t *testing.T) {
 					Name: "testuser",
 				},
 				Spec: v1alpha1.UserSpec{
-					Username: "testuser",
-					Email:    "updated@example.com",
+					Username:    "testuser",
+					Email:       "updated@example.com",
+					DisplayName: "Test User",
 				},
 			},
 			setupMock: func(ms *mocks.MockStore) {
@@ -250,8 +259,61 @@ func TestAuthController_UpdateUser(t *testing.T) {
 					},
 				}
 				ms.On("GetUser", mock.Anything, "testuser").Return(existingUser, nil)
+				// 이메일은 검증 절차 없이 변경되지 않음
+				ms.On("UpdateUser", mock.Anything, mock.MatchedBy(func(u *v1alpha1.User) bool {
+					return u.Name == "testuser" && u.Spec.DisplayName == "Test User" && u.Spec.Email == "old@example.com"
+				})).Return(nil)
+			},
+			wantErr: "",
+		},
+		{
+			name: "password history is preserved",
+			user: &v1alpha1.User{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "testuser",
+				},
+				Spec: v1alpha1.UserSpec{
+					Username: "renamed",
+				},
+			},
+			setupMock: func(ms *mocks.MockStore) {
+				existingUser := &v1alpha1.User{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "testuser",
+					},
+					Spec: v1alpha1.UserSpec{
+						Username: "testuser",
+					},
+					Status: v1alpha1.UserStatus{
+						PasswordHistory: []string{"$2a$10$old"},
+					},
+				}
+				ms.On("GetUser", mock.Anything, "testuser").Return(existingUser, nil)
 				ms.On("UpdateUser", mock.Anything, mock.MatchedBy(func(u *v1alpha1.User) bool {
-					return u.Name == "testuser" && u.Spec.Email == "updated@example.com"
+					return len(u.Status.PasswordHistory) == 1 && u.Status.PasswordHistory[0] == "$2a$10$old"
+				})).Return(nil)
+			},
+			wantErr: "",
+		},
+		{
+			name: "legacy name that predates the name policy",
+			user: &v1alpha1.User{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "Legacy_User",
+				},
+				Spec: v1alpha1.UserSpec{
+					DisplayName: "Legacy",
+				},
+			},
+			setupMock: func(ms *mocks.MockStore) {
+				existingUser := &v1alpha1.User{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "Legacy_User",
+					},
+				}
+				ms.On("GetUser", mock.Anything, "Legacy_User").Return(existingUser, nil)
+				ms.On("UpdateUser", mock.Anything, mock.MatchedBy(func(u *v1alpha1.User) bool {
+					return u.Name == "Legacy_User" && u.Spec.DisplayName == "Legacy"
 				})).Return(nil)
 			},
 			wantErr: "",
@@ -275,8 +337,8 @@ func TestAuthController_UpdateUser(t *testing.T) {
 					Name: "testuser",
 				},
 				Spec: v1alpha1.UserSpec{
-					Username: "testuser",
-					Email:    "updated@example.com",
+					Username:    "testuser",
+					DisplayName: "Test User",
 				},
 			},
 			setupMock: func(ms *mocks.MockStore) {
@@ -335,6 +397,8 @@ func TestAuthController_DeleteUser(t *testing.T) {
 			name:     "successful deletion",
 			username: "testuser",
 			setupMock: func(ms *mocks.MockStore) {
+				ms.ExpectListRoleBindings([]*v1alpha1.RoleBinding{}, nil)
+				ms.ExpectListClusterRoleBindings([]*v1alpha1.ClusterRoleBinding{}, nil)
 				ms.On("DeleteUser", mock.Anything, "testuser").Return(nil)
 			},
 			wantErr: "",
@@ -343,6 +407,8 @@ func TestAuthController_DeleteUser(t *testing.T) {
 			name:     "user not found",
 			username: "nonexistent",
 			setupMock: func(ms *mocks.MockStore) {
+				ms.ExpectListRoleBindings([]*v1alpha1.RoleBinding{}, nil)
+				ms.ExpectListClusterRoleBindings([]*v1alpha1.ClusterRoleBinding{}, nil)
 				ms.On("DeleteUser", mock.Anything, "nonexistent").Return(errors.ErrUserNotFound)
 			},
 			wantErr: "failed to delete user: status 404: user not found",
@@ -535,7 +601,10 @@ func TestAuthController_ChangePassword(t *testing.T) {
 	}
 }
 
-func TestAuthController_AssignRoles(t *testing.T) {
+func TestAuthController_SetRoles(t *testing.T) {
+	// WithReason는 전역 에러를 변경하므로 이후 테스트를 위해 복원
+	t.Cleanup(func() { errors.ErrRoleNotFound.Reason = "" })
+
 	tests := []struct {
 		name      string
 		username  string
@@ -544,9 +613,9 @@ func TestAuthController_AssignRoles(t *testing.T) {
 		wantErr   string
 	}{
 		{
-			name:     "successful role assignment",
+			name:     "roles are replaced",
 			username: "testuser",
-			roles:    []string{"admin", "user"},
+			roles:    []string{"admin", "viewer"},
 			setupMock: func(ms *mocks.MockStore) {
 				existingUser := &v1alpha1.User{
 					ObjectMeta: metav1.ObjectMeta{
@@ -557,12 +626,11 @@ func TestAuthController_AssignRoles(t *testing.T) {
 						Roles:    []string{"user"},
 					},
 				}
+				ms.ExpectGetRole("admin", &v1alpha1.Role{ObjectMeta: metav1.ObjectMeta{Name: "admin"}}, nil)
+				ms.ExpectGetRole("viewer", &v1alpha1.Role{ObjectMeta: metav1.ObjectMeta{Name: "viewer"}}, nil)
 				ms.On("GetUser", mock.Anything, "testuser").Return(existingUser, nil)
 				ms.On("UpdateUser", mock.Anything, mock.MatchedBy(func(u *v1alpha1.User) bool {
-					return u.Name == "testuser" &&
-						len(u.Spec.Roles) == 2 &&
-						containsString(u.Spec.Roles, "admin") &&
-						containsString(u.Spec.Roles, "user")
+					return u.Name == "testuser" && assert.ObjectsAreEqual([]string{"admin", "viewer"}, u.Spec.Roles)
 				})).Return(nil)
 			},
 			wantErr: "",
@@ -572,10 +640,20 @@ func TestAuthController_AssignRoles(t *testing.T) {
 			username: "nonexistent",
 			roles:    []string{"admin"},
 			setupMock: func(ms *mocks.MockStore) {
+				ms.ExpectGetRole("admin", &v1alpha1.Role{ObjectMeta: metav1.ObjectMeta{Name: "admin"}}, nil)
 				ms.On("GetUser", mock.Anything, "nonexistent").Return(nil, errors.ErrUserNotFound)
 			},
 			wantErr: "status 404: user not found",
 		},
+		{
+			name:     "unknown role",
+			username: "testuser",
+			roles:    []string{"ghost"},
+			setupMock: func(ms *mocks.MockStore) {
+				ms.ExpectGetRole("ghost", nil, errors.ErrRoleNotFound)
+			},
+			wantErr: "status 404: role not found: unknown roles: ghost",
+		},
 		{
 			name:      "empty username",
 			username:  "",
@@ -598,7 +676,7 @@ func TestAuthController_AssignRoles(t *testing.T) {
 			tt.setupMock(mockStore)
 
 			controller := NewAuthController(mockStore)
-			err := controller.AssignRoles(context.Background(), tt.username, tt.roles)
+			err := controller.SetRoles(context.Background(), tt.username, tt.roles)
 
 			if tt.wantErr != "" {
 				assert.Error(t, err)
@@ -620,3 +698,1160 @@ func containsString(slice []string, str string) bool {
 	}
 	return false
 }
+
+func TestAuthController_LoginHistory(t *testing.T) {
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	newUser := func(history []v1alpha1.LoginRecord) *v1alpha1.User {
+		return &v1alpha1.User{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "testuser",
+			},
+			Spec: v1alpha1.UserSpec{
+				Username:     "testuser",
+				PasswordHash: string(hashedPassword),
+			},
+			Status: v1alpha1.UserStatus{
+				Active:       true,
+				LoginHistory: history,
+			},
+		}
+	}
+
+	t.Run("login appends record with client info", func(t *testing.T) {
+		mockStore := mocks.NewMockStore()
+		mockStore.On("GetUser", mock.Anything, "testuser").Return(newUser(nil), nil)
+		mockStore.On("UpdateUser", mock.Anything, mock.MatchedBy(func(u *v1alpha1.User) bool {
+			return len(u.Status.LoginHistory) == 1 &&
+				u.Status.LoginHistory[0].IP == "10.0.0.1" &&
+				u.Status.LoginHistory[0].UserAgent == "test-agent"
+		})).Return(nil)
+
+		controller := NewAuthController(mockStore)
+		ctx := WithClientInfo(context.Background(), ClientInfo{IP: "10.0.0.1", UserAgent: "test-agent"})
+		_, err := controller.Login(ctx, "testuser", "password123")
+		assert.NoError(t, err)
+		mockStore.AssertExpectations(t)
+	})
+
+	t.Run("history is capped at configured limit", func(t *testing.T) {
+		existing := make([]v1alpha1.LoginRecord, 3)
+		for i := range existing {
+			existing[i] = v1alpha1.LoginRecord{IP: "old"}
+		}
+
+		mockStore := mocks.NewMockStore()
+		mockStore.On("GetUser", mock.Anything, "testuser").Return(newUser(existing), nil)
+		mockStore.On("UpdateUser", mock.Anything, mock.MatchedBy(func(u *v1alpha1.User) bool {
+			return len(u.Status.LoginHistory) == 3 && u.Status.LoginHistory[0].IP == "10.0.0.2"
+		})).Return(nil)
+
+		controller := NewAuthControllerWithConfig(mockStore, AuthControllerConfig{LoginHistoryLimit: 3})
+		ctx := WithClientInfo(context.Background(), ClientInfo{IP: "10.0.0.2"})
+		_, err := controller.Login(ctx, "testuser", "password123")
+		assert.NoError(t, err)
+		mockStore.AssertExpectations(t)
+	})
+
+	t.Run("get login history", func(t *testing.T) {
+		mockStore := mocks.NewMockStore()
+		mockStore.On("GetUser", mock.Anything, "testuser").Return(newUser([]v1alpha1.LoginRecord{{IP: "10.0.0.1"}}), nil)
+
+		controller := NewAuthController(mockStore)
+		history, err := controller.GetLoginHistory(context.Background(), "testuser")
+		assert.NoError(t, err)
+		assert.Len(t, history, 1)
+		mockStore.AssertExpectations(t)
+	})
+
+	t.Run("get login history for missing user", func(t *testing.T) {
+		mockStore := mocks.NewMockStore()
+		mockStore.On("GetUser", mock.Anything, "nonexistent").Return(nil, errors.ErrUserNotFound)
+
+		controller := NewAuthController(mockStore)
+		_, err := controller.GetLoginHistory(context.Background(), "nonexistent")
+		assert.Equal(t, errors.ErrUserNotFound, err)
+	})
+}
+
+func TestAuthController_CreateUserInvalidName(t *testing.T) {
+	newUser := func(name string) *v1alpha1.User {
+		return &v1alpha1.User{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       v1alpha1.UserSpec{PasswordHash: "password123"},
+		}
+	}
+
+	t.Run("rejected before store write", func(t *testing.T) {
+		mockStore := mocks.NewMockStore()
+		controller := NewAuthController(mockStore)
+
+		for _, name := range []string{strings.Repeat("a", 10*1024), "alice\nadmin", "Alice"} {
+			_, err := controller.CreateUser(context.Background(), newUser(name))
+			var verr *errors.ValidationError
+			if assert.ErrorAs(t, err, &verr) && assert.Len(t, verr.Errors, 1) {
+				assert.Equal(t, "metadata.name", verr.Errors[0].Field)
+			}
+		}
+		mockStore.AssertNotCalled(t, "CreateUser", mock.Anything, mock.Anything)
+	})
+
+	t.Run("configured policy", func(t *testing.T) {
+		mockStore := mocks.NewMockStore()
+		mockStore.On("CreateUser", mock.Anything, mock.Anything).Return(nil)
+		controller := NewAuthControllerWithConfig(mockStore, AuthControllerConfig{
+			Names: NamePolicy{MaxLength: 8, AllowUppercase: true},
+		})
+
+		_, err := controller.CreateUser(context.Background(), newUser("Alice"))
+		assert.NoError(t, err)
+		_, err = controller.CreateUser(context.Background(), newUser("alice-admin"))
+		assert.Error(t, err)
+		mockStore.AssertNumberOfCalls(t, "CreateUser", 1)
+	})
+
+	t.Run("rename", func(t *testing.T) {
+		mockStore := mocks.NewMockStore()
+		controller := NewAuthController(mockStore)
+
+		_, err := controller.RenameUser(context.Background(), "alice", "alice admin")
+		var verr *errors.ValidationError
+		if assert.ErrorAs(t, err, &verr) && assert.Len(t, verr.Errors, 1) {
+			assert.Equal(t, "newName", verr.Errors[0].Field)
+		}
+	})
+}
+
+// rollbackStore records whether InTransaction would commit or roll back
+type rollbackStore struct {
+	*mocks.MockStore
+	committed, rolledBack int
+}
+
+func (s *rollbackStore) InTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if err := fn(ctx); err != nil {
+		s.rolledBack++
+		return err
+	}
+	s.committed++
+	return nil
+}
+
+func TestAuthController_CreateUserWithBindings(t *testing.T) {
+	reader := &v1alpha1.Role{ObjectMeta: metav1.ObjectMeta{Name: "reader"}}
+	writer := &v1alpha1.Role{ObjectMeta: metav1.ObjectMeta{Name: "writer"}}
+	newUser := func() *v1alpha1.User {
+		return &v1alpha1.User{
+			ObjectMeta: metav1.ObjectMeta{Name: "alice"},
+			Spec:       v1alpha1.UserSpec{Username: "alice", PasswordHash: "password123"},
+		}
+	}
+	boundTo := func(role string) interface{} {
+		return mock.MatchedBy(func(b *v1alpha1.RoleBinding) bool {
+			return b.Name == "alice-"+role && b.RoleRef.Name == role &&
+				len(b.Subjects) == 1 && b.Subjects[0] == v1alpha1.Subject{Kind: v1alpha1.SubjectKindUser, Name: "alice"}
+		})
+	}
+
+	t.Run("creates user and bindings in one transaction", func(t *testing.T) {
+		store := &rollbackStore{MockStore: mocks.NewMockStore()}
+		store.ExpectGetRole("reader", reader, nil)
+		store.ExpectGetRole("writer", writer, nil)
+		store.On("CreateUser", mock.Anything, mock.Anything).Return(nil)
+		store.On("CreateRoleBinding", mock.Anything, boundTo("reader")).Return(nil).Once()
+		store.On("CreateRoleBinding", mock.Anything, boundTo("writer")).Return(nil).Once()
+
+		user, err := NewAuthController(store).CreateUserWithBindings(context.Background(), newUser(), []string{"reader", "writer", "reader"})
+		assert.NoError(t, err)
+		assert.Equal(t, "alice", user.Name)
+		assert.True(t, user.Status.Active)
+		assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(user.Spec.PasswordHash), []byte("password123")))
+		assert.Equal(t, 1, store.committed)
+		store.AssertExpectations(t)
+	})
+
+	t.Run("binding failure rolls back the user", func(t *testing.T) {
+		store := &rollbackStore{MockStore: mocks.NewMockStore()}
+		store.ExpectGetRole("reader", reader, nil)
+		store.ExpectGetRole("writer", writer, nil)
+		store.On("CreateUser", mock.Anything, mock.Anything).Return(nil)
+		store.On("CreateRoleBinding", mock.Anything, boundTo("reader")).Return(nil)
+		store.On("CreateRoleBinding", mock.Anything, boundTo("writer")).Return(errors.ErrAlreadyExists)
+
+		_, err := NewAuthController(store).CreateUserWithBindings(context.Background(), newUser(), []string{"reader", "writer"})
+		assert.ErrorIs(t, err, errors.ErrAlreadyExists)
+		assert.Equal(t, 1, store.rolledBack)
+		assert.Zero(t, store.committed)
+	})
+
+	t.Run("missing role creates nothing", func(t *testing.T) {
+		store := &rollbackStore{MockStore: mocks.NewMockStore()}
+		store.ExpectGetRole("ghost", nil, errors.ErrRoleNotFound)
+
+		_, err := NewAuthController(store).CreateUserWithBindings(context.Background(), newUser(), []string{"ghost"})
+		assert.ErrorIs(t, err, errors.ErrRoleNotFound)
+		store.AssertNotCalled(t, "CreateUser", mock.Anything, mock.Anything)
+		store.AssertNotCalled(t, "CreateRoleBinding", mock.Anything, mock.Anything)
+	})
+
+	t.Run("empty role name", func(t *testing.T) {
+		store := &rollbackStore{MockStore: mocks.NewMockStore()}
+
+		_, err := NewAuthController(store).CreateUserWithBindings(context.Background(), newUser(), []string{"reader", ""})
+		var verr *errors.ValidationError
+		if assert.ErrorAs(t, err, &verr) && assert.Len(t, verr.Errors, 1) {
+			assert.Equal(t, "roleNames[1]", verr.Errors[0].Field)
+		}
+		assert.Zero(t, store.committed+store.rolledBack)
+	})
+
+	t.Run("generated binding name violates name policy", func(t *testing.T) {
+		store := &rollbackStore{MockStore: mocks.NewMockStore()}
+		controller := NewAuthControllerWithConfig(store, AuthControllerConfig{Names: NamePolicy{MaxLength: 12}})
+
+		// "alice-reader"는 12자로 허용되지만 "alice-longrole"은 초과
+		_, err := controller.CreateUserWithBindings(context.Background(), newUser(), []string{"reader", "longrole"})
+		var verr *errors.ValidationError
+		if assert.ErrorAs(t, err, &verr) && assert.Len(t, verr.Errors, 1) {
+			assert.Equal(t, "roleNames[1]", verr.Errors[0].Field)
+			assert.Contains(t, verr.Errors[0].Message, `"alice-longrole"`)
+		}
+		assert.Zero(t, store.committed+store.rolledBack)
+		store.AssertNotCalled(t, "CreateUser", mock.Anything, mock.Anything)
+		store.AssertNotCalled(t, "CreateRoleBinding", mock.Anything, mock.Anything)
+	})
+}
+
+func TestAuthController_CreateUserDryRun(t *testing.T) {
+	t.Run("dry run skips store write", func(t *testing.T) {
+		mockStore := mocks.NewMockStore()
+
+		controller := NewAuthController(mockStore)
+		user := &v1alpha1.User{
+			ObjectMeta: metav1.ObjectMeta{Name: "testuser"},
+			Spec: v1alpha1.UserSpec{
+				Username:     "testuser",
+				Email:        "test@example.com",
+				PasswordHash: "password123",
+			},
+		}
+		result, err := controller.CreateUser(WithDryRun(context.Background()), user)
+		assert.NoError(t, err)
+		assert.Equal(t, "testuser", result.Name)
+		assert.True(t, result.Status.Active)
+		assert.Empty(t, result.Spec.PasswordHash)
+		mockStore.AssertNotCalled(t, "CreateUser", mock.Anything, mock.Anything)
+	})
+
+	t.Run("dry run still validates", func(t *testing.T) {
+		mockStore := mocks.NewMockStore()
+
+		controller := NewAuthController(mockStore)
+		user := &v1alpha1.User{
+			ObjectMeta: metav1.ObjectMeta{Name: "testuser"},
+		}
+		_, err := controller.CreateUser(WithDryRun(context.Background()), user)
+		assert.Error(t, err)
+		mockStore.AssertNotCalled(t, "CreateUser", mock.Anything, mock.Anything)
+	})
+}
+
+func TestAuthController_ListUsersWithOptions(t *testing.T) {
+	fullUser := func() *v1alpha1.User {
+		return &v1alpha1.User{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "testuser",
+				Annotations: map[string]string{"team": "dev"},
+			},
+			Spec: v1alpha1.UserSpec{
+				Username:     "testuser",
+				Email:        "test@example.com",
+				PasswordHash: "$2a$10$hash",
+				Roles:        []string{"admin"},
+			},
+			Status: v1alpha1.UserStatus{Active: true},
+		}
+	}
+
+	t.Run("unrequested fields are zeroed", func(t *testing.T) {
+		mockStore := mocks.NewMockStore()
+		opts := v1alpha1.ListOptions{Fields: []string{"username", "email"}}
+		mockStore.ExpectListUsersWithOptions(opts, &v1alpha1.UserList{Items: []*v1alpha1.User{fullUser()}}, nil)
+
+		controller := NewAuthController(mockStore)
+		users, err := controller.ListUsersWithOptions(context.Background(), opts)
+		assert.NoError(t, err)
+		if assert.Len(t, users.Items, 1) {
+			got := users.Items[0]
+			assert.Equal(t, "testuser", got.Name)
+			assert.Equal(t, "testuser", got.Spec.Username)
+			assert.Equal(t, "test@example.com", got.Spec.Email)
+			assert.Empty(t, got.Spec.PasswordHash)
+			assert.Nil(t, got.Spec.Roles)
+			assert.Nil(t, got.Annotations)
+			assert.False(t, got.Status.Active)
+		}
+	})
+
+	t.Run("password hash cannot be requested", func(t *testing.T) {
+		mockStore := mocks.NewMockStore()
+
+		controller := NewAuthController(mockStore)
+		_, err := controller.ListUsersWithOptions(context.Background(), v1alpha1.ListOptions{Fields: []string{"passwordHash"}})
+		assert.Error(t, err)
+		mockStore.AssertNotCalled(t, "ListUsersWithOptions", mock.Anything, mock.Anything)
+	})
+
+	t.Run("no fields falls back to full list", func(t *testing.T) {
+		mockStore := mocks.NewMockStore()
+		mockStore.ExpectListUsers(&v1alpha1.UserList{Items: []*v1alpha1.User{fullUser()}}, nil)
+
+		controller := NewAuthController(mockStore)
+		users, err := controller.ListUsersWithOptions(context.Background(), v1alpha1.ListOptions{})
+		assert.NoError(t, err)
+		assert.Len(t, users.Items, 1)
+		assert.Equal(t, []string{"admin"}, users.Items[0].Spec.Roles)
+	})
+}
+
+type recordingEmailSender struct {
+	newEmail string
+	token    string
+}
+
+func (s *recordingEmailSender) SendEmailChangeVerification(ctx context.Context, user *v1alpha1.User, newEmail, token string) error {
+	s.newEmail = newEmail
+	s.token = token
+	return nil
+}
+
+func TestAuthController_EmailChange(t *testing.T) {
+	newUser := func() *v1alpha1.User {
+		return &v1alpha1.User{
+			ObjectMeta: metav1.ObjectMeta{Name: "testuser"},
+			Spec: v1alpha1.UserSpec{
+				Username: "testuser",
+				Email:    "old@example.com",
+			},
+		}
+	}
+
+	t.Run("pending then confirm", func(t *testing.T) {
+		mockStore := mocks.NewMockStore()
+		user := newUser()
+		mockStore.ExpectGetUser("testuser", user, nil)
+		mockStore.On("FindUserByEmail", mock.Anything, "new@example.com").Return(nil, errors.ErrUserNotFound)
+		mockStore.On("UpdateUser", mock.Anything, mock.Anything).Return(nil)
+
+		sender := &recordingEmailSender{}
+		controller := NewAuthControllerWithConfig(mockStore, AuthControllerConfig{EmailChangeSender: sender})
+
+		token, err := controller.RequestEmailChange(context.Background(), "testuser", "new@example.com")
+		assert.NoError(t, err)
+		assert.NotEmpty(t, token)
+		assert.Equal(t, token, sender.token)
+		assert.Equal(t, "new@example.com", sender.newEmail)
+
+		// 확인 전에는 기존 이메일 유지
+		assert.Equal(t, "old@example.com", user.Spec.Email)
+		assert.Equal(t, "new@example.com", user.Status.PendingEmail)
+		assert.NotEqual(t, token, user.Status.EmailChangeTokenHash)
+
+		mockStore.On("FindUserByEmailChangeToken", mock.Anything, user.Status.EmailChangeTokenHash).Return(user, nil)
+
+		confirmed, err := controller.ConfirmEmailChange(context.Background(), "testuser", token)
+		assert.NoError(t, err)
+		assert.Equal(t, "new@example.com", confirmed.Spec.Email)
+		assert.Empty(t, confirmed.Status.PendingEmail)
+		assert.Empty(t, confirmed.Status.EmailChangeTokenHash)
+		assert.Nil(t, confirmed.Status.EmailChangeExpiry)
+	})
+
+	t.Run("token of another user is rejected", func(t *testing.T) {
+		mockStore := mocks.NewMockStore()
+		user := newUser()
+		expiry := metav1.NewTime(time.Now().Add(time.Hour))
+		user.Status.PendingEmail = "new@example.com"
+		user.Status.EmailChangeTokenHash = hashEmailChangeToken("alice-token")
+		user.Status.EmailChangeExpiry = &expiry
+		mockStore.On("FindUserByEmailChangeToken", mock.Anything, hashEmailChangeToken("alice-token")).Return(user, nil)
+
+		_, err := NewAuthController(mockStore).ConfirmEmailChange(context.Background(), "mallory", "alice-token")
+		assert.ErrorIs(t, err, errors.ErrInvalidToken)
+		assert.Equal(t, "old@example.com", user.Spec.Email)
+		mockStore.AssertNotCalled(t, "UpdateUser", mock.Anything, mock.Anything)
+	})
+
+	t.Run("without a sender the request is rejected", func(t *testing.T) {
+		mockStore := mocks.NewMockStore()
+
+		// 토큰을 전달할 방법이 없으면 pending 상태를 만들지 않음
+		_, err := NewAuthController(mockStore).RequestEmailChange(context.Background(), "testuser", "new@example.com")
+		assert.ErrorIs(t, err, errors.ErrInvalidConfig)
+		mockStore.AssertNotCalled(t, "UpdateUser", mock.Anything, mock.Anything)
+	})
+
+	t.Run("duplicate new email is rejected", func(t *testing.T) {
+		mockStore := mocks.NewMockStore()
+		mockStore.ExpectGetUser("testuser", newUser(), nil)
+		mockStore.On("FindUserByEmail", mock.Anything, "taken@example.com").Return(&v1alpha1.User{
+			ObjectMeta: metav1.ObjectMeta{Name: "otheruser"},
+		}, nil)
+
+		controller := NewAuthControllerWithConfig(mockStore, AuthControllerConfig{EmailChangeSender: &recordingEmailSender{}})
+		_, err := controller.RequestEmailChange(context.Background(), "testuser", "taken@example.com")
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusConflict, err.(*errors.StatusError).Code)
+		mockStore.AssertNotCalled(t, "UpdateUser", mock.Anything, mock.Anything)
+	})
+
+	t.Run("expired token", func(t *testing.T) {
+		mockStore := mocks.NewMockStore()
+		user := newUser()
+		expired := metav1.NewTime(time.Now().Add(-time.Minute))
+		user.Status.PendingEmail = "new@example.com"
+		user.Status.EmailChangeTokenHash = hashEmailChangeToken("expired-token")
+		user.Status.EmailChangeExpiry = &expired
+		mockStore.On("FindUserByEmailChangeToken", mock.Anything, hashEmailChangeToken("expired-token")).Return(user, nil)
+		mockStore.On("UpdateUser", mock.Anything, mock.Anything).Return(nil)
+
+		controller := NewAuthController(mockStore)
+		_, err := controller.ConfirmEmailChange(context.Background(), "testuser", "expired-token")
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusUnauthorized, err.(*errors.StatusError).Code)
+		assert.Equal(t, "old@example.com", user.Spec.Email)
+		assert.Empty(t, user.Status.PendingEmail)
+	})
+
+	t.Run("unknown token", func(t *testing.T) {
+		mockStore := mocks.NewMockStore()
+		mockStore.On("FindUserByEmailChangeToken", mock.Anything, mock.Anything).Return(nil, errors.ErrUserNotFound)
+
+		controller := NewAuthController(mockStore)
+		_, err := controller.ConfirmEmailChange(context.Background(), "testuser", "bogus")
+		assert.Error(t, err)
+	})
+}
+
+func TestAuthController_UpdateUserSelfService(t *testing.T) {
+	mockStore := mocks.NewMockStore()
+	mockStore.ExpectGetUser("alice", &v1alpha1.User{
+		ObjectMeta: metav1.ObjectMeta{Name: "alice"},
+		Spec: v1alpha1.UserSpec{
+			Username:    "alice",
+			Email:       "alice@example.com",
+			Roles:       []string{"viewer"},
+			DisplayName: "Alice",
+		},
+		Status: v1alpha1.UserStatus{Active: false},
+	}, nil)
+	var saved *v1alpha1.User
+	mockStore.On("UpdateUser", mock.Anything, mock.MatchedBy(func(u *v1alpha1.User) bool {
+		saved = u
+		return true
+	})).Return(nil)
+
+	activation := metav1.Now()
+	_, err := NewAuthController(mockStore).UpdateUser(WithSelfService(context.Background()), &v1alpha1.User{
+		ObjectMeta: metav1.ObjectMeta{Name: "alice", Annotations: map[string]string{"team": "admins"}},
+		Spec: v1alpha1.UserSpec{
+			Username:       "root",
+			Roles:          []string{"admin"},
+			DisplayName:    "Alice Kim",
+			Profile:        map[string]string{"locale": "ko"},
+			ActivationTime: &activation,
+		},
+		Status: v1alpha1.UserStatus{Active: true},
+	})
+	assert.NoError(t, err)
+
+	// 본인은 displayName과 profile만 변경할 수 있음
+	if assert.NotNil(t, saved) {
+		assert.Equal(t, "Alice Kim", saved.Spec.DisplayName)
+		assert.Equal(t, map[string]string{"locale": "ko"}, saved.Spec.Profile)
+		assert.Equal(t, "alice", saved.Spec.Username)
+		assert.Equal(t, []string{"viewer"}, saved.Spec.Roles)
+		assert.Nil(t, saved.Spec.ActivationTime)
+		assert.Empty(t, saved.Annotations)
+		assert.False(t, saved.Status.Active)
+	}
+}
+
+func TestAuthController_UpdateUserNoOp(t *testing.T) {
+	existing := func() *v1alpha1.User {
+		return &v1alpha1.User{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "testuser",
+				Annotations: map[string]string{},
+			},
+			Spec: v1alpha1.UserSpec{
+				Username:     "testuser",
+				Email:        "test@example.com",
+				PasswordHash: "hash",
+				Roles:        []string{"admin"},
+			},
+		}
+	}
+
+	t.Run("identical spec performs no store write", func(t *testing.T) {
+		mockStore := mocks.NewMockStore()
+		mockStore.ExpectGetUser("testuser", existing(), nil)
+
+		controller := NewAuthController(mockStore)
+		result, err := controller.UpdateUser(context.Background(), &v1alpha1.User{
+			ObjectMeta: metav1.ObjectMeta{Name: "testuser"},
+			Spec: v1alpha1.UserSpec{
+				Username: "testuser",
+				Email:    "test@example.com",
+				Roles:    []string{"admin"},
+			},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "test@example.com", result.Spec.Email)
+		mockStore.AssertNotCalled(t, "UpdateUser", mock.Anything, mock.Anything)
+	})
+
+	t.Run("real change is written", func(t *testing.T) {
+		mockStore := mocks.NewMockStore()
+		mockStore.ExpectGetUser("testuser", existing(), nil)
+		mockStore.On("UpdateUser", mock.Anything, mock.Anything).Return(nil)
+
+		controller := NewAuthController(mockStore)
+		_, err := controller.UpdateUser(context.Background(), &v1alpha1.User{
+			ObjectMeta: metav1.ObjectMeta{Name: "testuser"},
+			Spec: v1alpha1.UserSpec{
+				Username:    "testuser",
+				Email:       "test@example.com",
+				Roles:       []string{"admin"},
+				DisplayName: "Test User",
+			},
+		})
+		assert.NoError(t, err)
+		mockStore.AssertNumberOfCalls(t, "UpdateUser", 1)
+	})
+
+	t.Run("clearing roles is written", func(t *testing.T) {
+		mockStore := mocks.NewMockStore()
+		mockStore.ExpectGetUser("testuser", existing(), nil)
+		mockStore.On("UpdateUser", mock.Anything, mock.MatchedBy(func(u *v1alpha1.User) bool {
+			return len(u.Spec.Roles) == 0
+		})).Return(nil)
+
+		controller := NewAuthController(mockStore)
+		_, err := controller.UpdateUser(context.Background(), &v1alpha1.User{
+			ObjectMeta: metav1.ObjectMeta{Name: "testuser"},
+			Spec: v1alpha1.UserSpec{
+				Username: "testuser",
+				Email:    "test@example.com",
+				Roles:    []string{},
+			},
+		})
+		assert.NoError(t, err)
+		mockStore.AssertNumberOfCalls(t, "UpdateUser", 1)
+	})
+
+	t.Run("each mutable field is detected", func(t *testing.T) {
+		activation := metav1.NewTime(time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC))
+		changes := map[string]func(u *v1alpha1.User){
+			"username":       func(u *v1alpha1.User) { u.Spec.Username = "renamed" },
+			"email":          func(u *v1alpha1.User) { u.Spec.Email = "other@example.com" },
+			"displayName":    func(u *v1alpha1.User) { u.Spec.DisplayName = "Test User" },
+			"profile":        func(u *v1alpha1.User) { u.Spec.Profile = map[string]string{"team": "infra"} },
+			"roles":          func(u *v1alpha1.User) { u.Spec.Roles = []string{"viewer"} },
+			"activationTime": func(u *v1alpha1.User) { u.Spec.ActivationTime = &activation },
+			"annotations":    func(u *v1alpha1.User) { u.Annotations = map[string]string{"note": "x"} },
+		}
+		for field, change := range changes {
+			changed := existing()
+			change(changed)
+			assert.False(t, userMutableFieldsEqual(existing(), changed), field)
+		}
+		assert.True(t, userMutableFieldsEqual(existing(), existing()))
+
+		// 같은 시각을 가리키는 다른 포인터는 변경이 아님
+		a, b := existing(), existing()
+		sameInstant := metav1.NewTime(activation.Time.In(time.FixedZone("KST", 9*60*60)))
+		a.Spec.ActivationTime, b.Spec.ActivationTime = &activation, &sameInstant
+		assert.True(t, userMutableFieldsEqual(a, b))
+	})
+}
+
+func TestAuthController_DeleteUsersBatch(t *testing.T) {
+	t.Run("all succeed", func(t *testing.T) {
+		mockStore := mocks.NewMockStore()
+		mockStore.ExpectListRoleBindings([]*v1alpha1.RoleBinding{}, nil)
+		mockStore.ExpectListClusterRoleBindings([]*v1alpha1.ClusterRoleBinding{}, nil)
+		mockStore.ExpectGetUser("alice", &v1alpha1.User{ObjectMeta: metav1.ObjectMeta{Name: "alice"}}, nil)
+		mockStore.ExpectGetUser("bob", &v1alpha1.User{ObjectMeta: metav1.ObjectMeta{Name: "bob"}}, nil)
+		mockStore.ExpectDeleteUser("alice", nil)
+		mockStore.ExpectDeleteUser("bob", nil)
+
+		controller := NewAuthController(mockStore)
+		failed, err := controller.DeleteUsersBatch(context.Background(), []string{"alice", "bob", "alice"})
+
+		assert.NoError(t, err)
+		assert.Empty(t, failed)
+		mockStore.AssertNumberOfCalls(t, "DeleteUser", 2)
+		mockStore.AssertNotCalled(t, "UpdateRoleBinding", mock.Anything, mock.Anything)
+		mockStore.AssertNotCalled(t, "DeleteRoleBinding", mock.Anything, mock.Anything)
+	})
+
+	t.Run("missing user does not stop the rest", func(t *testing.T) {
+		mockStore := mocks.NewMockStore()
+		mockStore.ExpectGetUser("alice", &v1alpha1.User{ObjectMeta: metav1.ObjectMeta{Name: "alice"}}, nil)
+		mockStore.ExpectGetUser("ghost", nil, errors.ErrUserNotFound)
+		mockStore.ExpectGetUser("bob", &v1alpha1.User{ObjectMeta: metav1.ObjectMeta{Name: "bob"}}, nil)
+		mockStore.ExpectDeleteUser("alice", nil)
+		mockStore.ExpectDeleteUser("bob", nil)
+		mockStore.ExpectListRoleBindings([]*v1alpha1.RoleBinding{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "shared"},
+				RoleRef:    v1alpha1.RoleRef{Kind: "Role", Name: "reader"},
+				Subjects: []v1alpha1.Subject{
+					{Kind: v1alpha1.SubjectKindUser, Name: "alice"},
+					{Kind: v1alpha1.SubjectKindUser, Name: "carol"},
+				},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "bob-only"},
+				RoleRef:    v1alpha1.RoleRef{Kind: "Role", Name: "reader"},
+				Subjects:   []v1alpha1.Subject{{Kind: v1alpha1.SubjectKindUser, Name: "bob"}},
+			},
+		}, nil)
+		mockStore.On("UpdateRoleBinding", mock.Anything, mock.MatchedBy(func(b *v1alpha1.RoleBinding) bool {
+			return b.Name == "shared" && len(b.Subjects) == 1 && b.Subjects[0].Name == "carol"
+		})).Return(nil)
+		mockStore.ExpectDeleteRoleBinding("bob-only", nil)
+		mockStore.ExpectListClusterRoleBindings([]*v1alpha1.ClusterRoleBinding{}, nil)
+		mockStore.ExpectGetRole("reader", &v1alpha1.Role{
+			ObjectMeta: metav1.ObjectMeta{Name: "reader"},
+			Rules:      []v1alpha1.PolicyRule{{Verbs: []string{"get"}, Resources: []string{"users"}, APIGroups: []string{"auth.service"}}},
+		}, nil)
+
+		controller := NewAuthController(mockStore)
+		failed, err := controller.DeleteUsersBatch(WithCascadeDelete(context.Background()), []string{"alice", "ghost", "bob"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]error{"ghost": errors.ErrUserNotFound}, failed)
+		mockStore.AssertExpectations(t)
+		mockStore.AssertNotCalled(t, "DeleteUser", mock.Anything, "ghost")
+	})
+
+	t.Run("failed cascade keeps the user", func(t *testing.T) {
+		mockStore := mocks.NewMockStore()
+		mockStore.ExpectGetUser("alice", &v1alpha1.User{ObjectMeta: metav1.ObjectMeta{Name: "alice"}}, nil)
+		mockStore.ExpectListRoleBindings([]*v1alpha1.RoleBinding{{
+			ObjectMeta: metav1.ObjectMeta{Name: "alice-only"},
+			RoleRef:    v1alpha1.RoleRef{Kind: "Role", Name: "reader"},
+			Subjects:   []v1alpha1.Subject{{Kind: v1alpha1.SubjectKindUser, Name: "alice"}},
+		}}, nil)
+		mockStore.ExpectListClusterRoleBindings([]*v1alpha1.ClusterRoleBinding{}, nil)
+		mockStore.ExpectGetRole("reader", &v1alpha1.Role{
+			ObjectMeta: metav1.ObjectMeta{Name: "reader"},
+			Rules:      []v1alpha1.PolicyRule{{Verbs: []string{"get"}, Resources: []string{"users"}, APIGroups: []string{"auth.service"}}},
+		}, nil)
+		mockStore.ExpectDeleteRoleBinding("alice-only", errors.ErrInternal)
+
+		failed, err := NewAuthController(mockStore).DeleteUsersBatch(WithCascadeDelete(context.Background()), []string{"alice"})
+
+		// 바인딩 정리가 실패하면 사용자는 삭제되지 않으므로 deleted=false가 실제 상태와 일치
+		assert.NoError(t, err)
+		assert.ErrorIs(t, failed["alice"], errors.ErrInternal)
+		mockStore.AssertNotCalled(t, "DeleteUser", mock.Anything, mock.Anything)
+	})
+
+	t.Run("empty request", func(t *testing.T) {
+		controller := NewAuthController(mocks.NewMockStore())
+
+		_, err := controller.DeleteUsersBatch(context.Background(), nil)
+		assert.Error(t, err)
+
+		_, err = controller.DeleteUsersBatch(context.Background(), []string{"alice", ""})
+		assert.Error(t, err)
+	})
+}
+
+func TestAuthController_CreateUserDefaultRoles(t *testing.T) {
+	newUser := func(roles ...string) *v1alpha1.User {
+		return &v1alpha1.User{
+			ObjectMeta: metav1.ObjectMeta{Name: "testuser"},
+			Spec: v1alpha1.UserSpec{
+				Username:     "testuser",
+				Email:        "test@example.com",
+				PasswordHash: "password123",
+				Roles:        roles,
+			},
+		}
+	}
+	cfg := AuthControllerConfig{DefaultRoles: []string{"viewer"}}
+
+	t.Run("user without roles gets the defaults", func(t *testing.T) {
+		mockStore := mocks.NewMockStore()
+		mockStore.ExpectGetRole("viewer", &v1alpha1.Role{ObjectMeta: metav1.ObjectMeta{Name: "viewer"}}, nil)
+		mockStore.On("CreateUser", mock.Anything, mock.MatchedBy(func(u *v1alpha1.User) bool {
+			return assert.ObjectsAreEqual([]string{"viewer"}, u.Spec.Roles)
+		})).Return(nil)
+
+		controller := NewAuthControllerWithConfig(mockStore, cfg)
+		result, err := controller.CreateUser(context.Background(), newUser())
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"viewer"}, result.Spec.Roles)
+		mockStore.AssertExpectations(t)
+	})
+
+	t.Run("explicit roles are untouched", func(t *testing.T) {
+		mockStore := mocks.NewMockStore()
+		mockStore.On("CreateUser", mock.Anything, mock.Anything).Return(nil)
+
+		controller := NewAuthControllerWithConfig(mockStore, cfg)
+		result, err := controller.CreateUser(context.Background(), newUser("admin"))
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"admin"}, result.Spec.Roles)
+		mockStore.AssertNotCalled(t, "GetRole", mock.Anything, mock.Anything)
+	})
+
+	t.Run("defaults can be skipped per request", func(t *testing.T) {
+		mockStore := mocks.NewMockStore()
+		mockStore.On("CreateUser", mock.Anything, mock.Anything).Return(nil)
+
+		controller := NewAuthControllerWithConfig(mockStore, cfg)
+		result, err := controller.CreateUser(WithoutDefaultRoles(context.Background()), newUser())
+		assert.NoError(t, err)
+		assert.Empty(t, result.Spec.Roles)
+	})
+
+	t.Run("missing default role is a configuration error", func(t *testing.T) {
+		mockStore := mocks.NewMockStore()
+		mockStore.ExpectGetRole("viewer", nil, errors.ErrRoleNotFound)
+
+		controller := NewAuthControllerWithConfig(mockStore, cfg)
+		_, err := controller.CreateUser(context.Background(), newUser())
+		statusErr, ok := err.(*errors.StatusError)
+		if assert.True(t, ok) {
+			assert.Equal(t, http.StatusInternalServerError, statusErr.Code)
+			assert.Contains(t, statusErr.Reason, `"viewer"`)
+		}
+		mockStore.AssertNotCalled(t, "CreateUser", mock.Anything, mock.Anything)
+	})
+}
+
+func TestAuthController_AddRemoveRoles(t *testing.T) {
+	t.Cleanup(func() { errors.ErrRoleNotFound.Reason = "" })
+
+	newUser := func() *v1alpha1.User {
+		return &v1alpha1.User{
+			ObjectMeta: metav1.ObjectMeta{Name: "testuser"},
+			Spec:       v1alpha1.UserSpec{Username: "testuser", Roles: []string{"user", "editor"}},
+		}
+	}
+	rolesAre := func(want ...string) interface{} {
+		if want == nil {
+			want = []string{}
+		}
+		return mock.MatchedBy(func(u *v1alpha1.User) bool {
+			return assert.ObjectsAreEqual(want, u.Spec.Roles)
+		})
+	}
+
+	t.Run("add merges without duplicates", func(t *testing.T) {
+		ms := mocks.NewMockStore()
+		ms.ExpectGetRole("admin", &v1alpha1.Role{ObjectMeta: metav1.ObjectMeta{Name: "admin"}}, nil)
+		ms.ExpectGetRole("user", &v1alpha1.Role{ObjectMeta: metav1.ObjectMeta{Name: "user"}}, nil)
+		ms.ExpectGetUser("testuser", newUser(), nil)
+		ms.On("UpdateUser", mock.Anything, rolesAre("user", "editor", "admin")).Return(nil)
+
+		assert.NoError(t, NewAuthController(ms).AddRoles(context.Background(), "testuser", []string{"admin", "user"}))
+		ms.AssertExpectations(t)
+	})
+
+	t.Run("add rejects unknown role", func(t *testing.T) {
+		ms := mocks.NewMockStore()
+		ms.ExpectGetRole("ghost", nil, errors.ErrRoleNotFound)
+
+		err := NewAuthController(ms).AddRoles(context.Background(), "testuser", []string{"ghost"})
+		assert.Equal(t, "status 404: role not found: unknown roles: ghost", err.Error())
+		ms.AssertNotCalled(t, "UpdateUser", mock.Anything, mock.Anything)
+	})
+
+	t.Run("remove subtracts", func(t *testing.T) {
+		ms := mocks.NewMockStore()
+		ms.ExpectGetUser("testuser", newUser(), nil)
+		ms.On("UpdateUser", mock.Anything, rolesAre("editor")).Return(nil)
+
+		// 삭제된 역할(stale)도 조회 없이 해제 가능
+		assert.NoError(t, NewAuthController(ms).RemoveRoles(context.Background(), "testuser", []string{"user", "deleted-role"}))
+		ms.AssertExpectations(t)
+		ms.AssertNotCalled(t, "GetRole", mock.Anything, mock.Anything)
+	})
+
+	t.Run("remove all leaves empty roles", func(t *testing.T) {
+		ms := mocks.NewMockStore()
+		ms.ExpectGetUser("testuser", newUser(), nil)
+		ms.On("UpdateUser", mock.Anything, rolesAre()).Return(nil)
+
+		assert.NoError(t, NewAuthController(ms).RemoveRoles(context.Background(), "testuser", []string{"user", "editor"}))
+		ms.AssertExpectations(t)
+	})
+}
+
+func TestAuthController_AddRolesValidatesExistence(t *testing.T) {
+	t.Cleanup(func() { errors.ErrRoleNotFound.Reason = "" })
+
+	t.Run("existing role", func(t *testing.T) {
+		ms := mocks.NewMockStore()
+		ms.ExpectGetRole("viewer", &v1alpha1.Role{ObjectMeta: metav1.ObjectMeta{Name: "viewer"}}, nil)
+		ms.ExpectGetUser("alice", &v1alpha1.User{ObjectMeta: metav1.ObjectMeta{Name: "alice"}}, nil)
+		ms.On("UpdateUser", mock.Anything, mock.Anything).Return(nil)
+
+		assert.NoError(t, NewAuthController(ms).AddRoles(context.Background(), "alice", []string{"viewer"}))
+		ms.AssertExpectations(t)
+	})
+
+	t.Run("every unknown role is named", func(t *testing.T) {
+		ms := mocks.NewMockStore()
+		ms.ExpectGetRole("viewer", &v1alpha1.Role{ObjectMeta: metav1.ObjectMeta{Name: "viewer"}}, nil)
+		ms.ExpectGetRole("veiwer", nil, errors.ErrRoleNotFound)
+		ms.ExpectGetRole("admn", nil, errors.ErrRoleNotFound)
+
+		err := NewAuthController(ms).AddRoles(context.Background(), "alice", []string{"veiwer", "viewer", "admn"})
+		assert.ErrorIs(t, err, errors.ErrRoleNotFound)
+		assert.Equal(t, "status 404: role not found: unknown roles: veiwer, admn", err.Error())
+		ms.AssertNotCalled(t, "GetUser", mock.Anything, mock.Anything)
+	})
+}
+
+func TestAuthController_DeleteLastAdminUser(t *testing.T) {
+	mockStore := mocks.NewMockStore()
+	mockStore.ExpectListRoleBindings([]*v1alpha1.RoleBinding{{
+		ObjectMeta: metav1.ObjectMeta{Name: "alice-admin"},
+		RoleRef:    v1alpha1.RoleRef{Kind: "Role", Name: "admin"},
+		Subjects:   []v1alpha1.Subject{{Kind: v1alpha1.SubjectKindUser, Name: "alice"}},
+	}}, nil)
+	mockStore.ExpectListClusterRoleBindings([]*v1alpha1.ClusterRoleBinding{}, nil)
+	mockStore.ExpectGetRole("admin", &v1alpha1.Role{
+		ObjectMeta: metav1.ObjectMeta{Name: "admin"},
+		Rules:      []v1alpha1.PolicyRule{{Verbs: []string{"*"}, Resources: []string{"*"}, APIGroups: []string{"*"}}},
+	}, nil)
+
+	err := NewAuthController(mockStore).DeleteUser(context.Background(), "alice")
+	assert.ErrorIs(t, err, errors.ErrLastAdmin)
+	mockStore.AssertNotCalled(t, "DeleteUser", mock.Anything, mock.Anything)
+}
+
+func TestAuthController_LoginInactiveUser(t *testing.T) {
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	mockStore := mocks.NewMockStore()
+	mockStore.ExpectGetUser("frank", &v1alpha1.User{
+		ObjectMeta: metav1.ObjectMeta{Name: "frank"},
+		Spec:       v1alpha1.UserSpec{Username: "frank", Email: "frank@example.com", PasswordHash: string(hashedPassword)},
+		Status:     v1alpha1.UserStatus{Active: false},
+	}, nil)
+
+	// 비활성 사용자는 refresh뿐 아니라 로그인도 거부되며 마지막 로그인 기록도 남지 않음
+	_, err := NewAuthController(mockStore).Login(context.Background(), "frank", "password123")
+	assert.ErrorIs(t, err, errors.ErrAccountInactive)
+	mockStore.AssertNotCalled(t, "UpdateUser", mock.Anything, mock.Anything)
+}
+
+func TestAuthController_LoginActivationTime(t *testing.T) {
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	activation := time.Date(2030, 1, 1, 9, 0, 0, 0, time.UTC)
+	user := &v1alpha1.User{
+		ObjectMeta: metav1.ObjectMeta{Name: "newhire"},
+		Spec: v1alpha1.UserSpec{
+			Username:       "newhire",
+			PasswordHash:   string(hashedPassword),
+			ActivationTime: &metav1.Time{Time: activation},
+		},
+		Status: v1alpha1.UserStatus{Active: true},
+	}
+
+	fakeClock := clock.NewFake(activation.Add(-time.Hour))
+	mockStore := new(mocks.MockStore)
+	controller := NewAuthControllerWithConfig(mockStore, AuthControllerConfig{Clock: fakeClock})
+	mockStore.On("GetUser", mock.Anything, "newhire").Return(user, nil)
+
+	_, err := controller.Login(context.Background(), "newhire", "password123")
+	assert.ErrorIs(t, err, errors.ErrAccountNotYetActive)
+	mockStore.AssertNotCalled(t, "UpdateUser", mock.Anything, mock.Anything)
+
+	// 활성화 시각이 지나면 로그인 가능
+	fakeClock.Advance(time.Hour + time.Minute)
+	mockStore.On("UpdateUser", mock.Anything, mock.Anything).Return(nil)
+
+	loggedIn, err := controller.Login(context.Background(), "newhire", "password123")
+	assert.NoError(t, err)
+	assert.True(t, loggedIn.Status.LastLogin.Time.Equal(fakeClock.Now()))
+}
+
+func TestAuthController_RenameUser(t *testing.T) {
+	newStore := func() (*mocks.MockStore, *v1alpha1.RoleBinding) {
+		ms := mocks.NewMockStore()
+		binding := &v1alpha1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "editor-binding"},
+			Subjects: []v1alpha1.Subject{
+				{Kind: v1alpha1.SubjectKindUser, Name: "alice"},
+				{Kind: v1alpha1.SubjectKindUser, Name: "bob"},
+			},
+			RoleRef: v1alpha1.RoleRef{Kind: "Role", Name: "editor"},
+		}
+		ms.ExpectListRoleBindings([]*v1alpha1.RoleBinding{binding}, nil)
+		ms.ExpectListClusterRoleBindings([]*v1alpha1.ClusterRoleBinding{}, nil)
+		ms.ExpectGetRole("editor", &v1alpha1.Role{
+			ObjectMeta: metav1.ObjectMeta{Name: "editor"},
+			Rules: []v1alpha1.PolicyRule{{
+				Verbs:     []string{"update"},
+				Resources: []string{"users"},
+				APIGroups: []string{"auth.service"},
+			}},
+		}, nil)
+		return ms, binding
+	}
+
+	t.Run("rename updates user and binding subject", func(t *testing.T) {
+		ms, binding := newStore()
+		renamed := &v1alpha1.User{
+			ObjectMeta: metav1.ObjectMeta{Name: "alice2"},
+			Spec:       v1alpha1.UserSpec{Username: "alice2"},
+		}
+		ms.ExpectGetUser("alice", &v1alpha1.User{ObjectMeta: metav1.ObjectMeta{Name: "alice"}}, nil)
+		ms.ExpectGetUser("alice2", nil, errors.ErrUserNotFound).Once()
+		ms.ExpectRenameUser("alice", "alice2", nil)
+		ms.On("UpdateRoleBinding", mock.Anything, binding).Return(nil)
+		ms.ExpectGetUser("alice2", renamed, nil)
+
+		controller := NewAuthController(ms)
+		user, err := controller.RenameUser(context.Background(), "alice", "alice2")
+		assert.NoError(t, err)
+		assert.Equal(t, "alice2", user.Name)
+		assert.Equal(t, []v1alpha1.Subject{
+			{Kind: v1alpha1.SubjectKindUser, Name: "alice2"},
+			{Kind: v1alpha1.SubjectKindUser, Name: "bob"},
+		}, binding.Subjects)
+
+		// 새 이름으로 기존 권한이 유지되어야 함
+		allowed, err := NewRBACController(ms).CheckAccess(context.Background(), user, "update", "users", "auth.service")
+		assert.NoError(t, err)
+		assert.True(t, allowed)
+		ms.AssertExpectations(t)
+	})
+
+	t.Run("rename to an existing name is rejected", func(t *testing.T) {
+		ms, _ := newStore()
+		ms.ExpectGetUser("alice", &v1alpha1.User{ObjectMeta: metav1.ObjectMeta{Name: "alice"}}, nil)
+		ms.ExpectGetUser("bob", &v1alpha1.User{ObjectMeta: metav1.ObjectMeta{Name: "bob"}}, nil)
+
+		_, err := NewAuthController(ms).RenameUser(context.Background(), "alice", "bob")
+		assert.ErrorIs(t, err, errors.ErrUserExists)
+		ms.AssertNotCalled(t, "RenameUser", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("rename of a cluster role binding subject is rejected", func(t *testing.T) {
+		ms := mocks.NewMockStore()
+		ms.ExpectGetUser("alice", &v1alpha1.User{ObjectMeta: metav1.ObjectMeta{Name: "alice", Namespace: "tenant-a"}}, nil)
+		ms.ExpectGetUser("alice2", nil, errors.ErrUserNotFound)
+		ms.ExpectListClusterRoleBindings([]*v1alpha1.ClusterRoleBinding{{
+			ObjectMeta: metav1.ObjectMeta{Name: "alice-viewer"},
+			Subjects:   []v1alpha1.Subject{{Kind: v1alpha1.SubjectKindUser, Name: "alice"}},
+			RoleRef:    v1alpha1.RoleRef{Kind: v1alpha1.RoleRefKindClusterRole, Name: "viewer"},
+		}}, nil)
+
+		// 다른 테넌트의 같은 이름 사용자에게도 적용되는 바인딩이므로 수정하지 않음
+		_, err := NewAuthController(ms).RenameUser(context.Background(), "alice", "alice2")
+		assert.ErrorIs(t, err, errors.ErrInvalidInput)
+		ms.AssertNotCalled(t, "RenameUser", mock.Anything, mock.Anything, mock.Anything)
+		ms.AssertNotCalled(t, "DeleteClusterRoleBinding", mock.Anything, mock.Anything)
+	})
+}
+
+func TestAuthController_RefreshUser(t *testing.T) {
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password-1"), bcrypt.MinCost)
+	user := &v1alpha1.User{
+		ObjectMeta: metav1.ObjectMeta{Name: "alice"},
+		Spec:       v1alpha1.UserSpec{Username: "alice", PasswordHash: string(hashedPassword)},
+		Status:     v1alpha1.UserStatus{Active: true},
+	}
+	now := time.Date(2030, 1, 1, 9, 0, 0, 0, time.UTC)
+	fakeClock := clock.NewFake(now)
+	mockStore := new(mocks.MockStore)
+	mockStore.ExpectGetUser("alice", user, nil)
+	mockStore.ExpectUpdateUser(user, nil)
+	controller := NewAuthControllerWithConfig(mockStore, AuthControllerConfig{Clock: fakeClock})
+	ctx := context.Background()
+
+	got, err := controller.RefreshUser(ctx, "alice", now.Add(-time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, user, got)
+
+	t.Run("inactive user is rejected", func(t *testing.T) {
+		user.Status.Active = false
+		defer func() { user.Status.Active = true }()
+
+		_, err := controller.RefreshUser(ctx, "alice", now)
+		assert.ErrorIs(t, err, errors.ErrAccountInactive)
+	})
+
+	t.Run("user before activation is rejected", func(t *testing.T) {
+		user.Spec.ActivationTime = &metav1.Time{Time: now.Add(time.Hour)}
+		defer func() { user.Spec.ActivationTime = nil }()
+
+		_, err := controller.RefreshUser(ctx, "alice", now)
+		assert.ErrorIs(t, err, errors.ErrAccountNotYetActive)
+	})
+
+	t.Run("tokens issued before a password change are rejected", func(t *testing.T) {
+		issuedAt := now.Add(-time.Minute)
+		fakeClock.Advance(500 * time.Millisecond)
+		assert.NoError(t, controller.ChangePassword(ctx, "alice", "password-1", "password-2"))
+		if assert.NotNil(t, user.Status.PasswordChangedAt) {
+			assert.True(t, user.Status.PasswordChangedAt.Time.Equal(fakeClock.Now()))
+		}
+
+		_, err := controller.RefreshUser(ctx, "alice", issuedAt)
+		assert.ErrorIs(t, err, errors.ErrInvalidToken)
+
+		// iat는 초 단위이므로 변경과 같은 초에 발급된 토큰은 허용
+		_, err = controller.RefreshUser(ctx, "alice", now)
+		assert.NoError(t, err)
+	})
+
+	t.Run("missing user is rejected", func(t *testing.T) {
+		mockStore.ExpectGetUser("bob", nil, errors.ErrUserNotFound)
+
+		_, err := controller.RefreshUser(ctx, "bob", now)
+		assert.ErrorIs(t, err, errors.ErrInvalidToken)
+	})
+}
+
+func TestAuthController_ChangePasswordHistory(t *testing.T) {
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password-1"), bcrypt.MinCost)
+	user := &v1alpha1.User{
+		ObjectMeta: metav1.ObjectMeta{Name: "alice"},
+		Spec:       v1alpha1.UserSpec{Username: "alice", PasswordHash: string(hashedPassword)},
+	}
+	mockStore := new(mocks.MockStore)
+	mockStore.ExpectGetUser("alice", user, nil)
+	mockStore.ExpectUpdateUser(user, nil)
+
+	controller := NewAuthControllerWithConfig(mockStore, AuthControllerConfig{PasswordHistorySize: 2})
+	ctx := context.Background()
+
+	assert.NoError(t, controller.ChangePassword(ctx, "alice", "password-1", "password-2"))
+
+	// 바로 이전 비밀번호와 현재 비밀번호는 재사용 불가
+	err := controller.ChangePassword(ctx, "alice", "password-2", "password-1")
+	assert.ErrorIs(t, err, errors.ErrPasswordReused)
+	err = controller.ChangePassword(ctx, "alice", "password-2", "password-2")
+	assert.ErrorIs(t, err, errors.ErrPasswordReused)
+
+	assert.NoError(t, controller.ChangePassword(ctx, "alice", "password-2", "password-3"))
+	assert.NoError(t, controller.ChangePassword(ctx, "alice", "password-3", "password-4"))
+	assert.Len(t, user.Status.PasswordHistory, 2)
+
+	// 기록 범위를 벗어난 비밀번호는 다시 사용할 수 있음
+	assert.NoError(t, controller.ChangePassword(ctx, "alice", "password-4", "password-1"))
+}
+
+func TestAuthController_RegisterUserEmailVerification(t *testing.T) {
+	mockStore := mocks.NewMockStore()
+	sender := &recordingEmailSender{}
+	controller := NewAuthControllerWithConfig(mockStore, AuthControllerConfig{
+		AllowSelfRegistration:    true,
+		RequireEmailVerification: true,
+		EmailChangeSender:        sender,
+	})
+
+	var stored *v1alpha1.User
+	mockStore.On("FindUserByEmail", mock.Anything, "carol@example.com").Return(nil, errors.ErrUserNotFound)
+	mockStore.On("CreateUser", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		stored = args.Get(1).(*v1alpha1.User)
+	}).Return(nil)
+
+	// 이메일 확인이 필요하면 이메일 필수
+	_, err := controller.RegisterUser(context.Background(), Registration{Name: "carol", Password: "correct-horse"})
+	assert.IsType(t, &errors.ValidationError{}, err)
+
+	user, err := controller.RegisterUser(context.Background(), Registration{
+		Name: "carol", Email: "carol@example.com", Password: "correct-horse",
+	})
+	assert.NoError(t, err)
+	assert.False(t, user.Status.Active)
+	assert.Empty(t, user.Spec.Email)
+	assert.Equal(t, "carol@example.com", sender.newEmail)
+
+	// 확인 전에는 로그인 불가
+	mockStore.ExpectGetUser("carol", stored, nil)
+	_, err = controller.Login(context.Background(), "carol", "correct-horse")
+	assert.ErrorIs(t, err, errors.ErrEmailNotVerified)
+
+	// 일반 이메일 변경 토큰으로는 가입 확인 불가
+	mockStore.On("FindUserByEmailChangeToken", mock.Anything, hashEmailChangeToken("other")).
+		Return(&v1alpha1.User{Spec: v1alpha1.UserSpec{Email: "x@example.com"}, Status: v1alpha1.UserStatus{Active: true, PendingEmail: "y@example.com"}}, nil)
+	_, err = controller.VerifyRegistration(context.Background(), "other")
+	assert.ErrorIs(t, err, errors.ErrInvalidToken)
+
+	mockStore.On("FindUserByEmailChangeToken", mock.Anything, stored.Status.EmailChangeTokenHash).Return(stored, nil)
+	mockStore.On("UpdateUser", mock.Anything, mock.Anything).Return(nil)
+	verified, err := controller.VerifyRegistration(context.Background(), sender.token)
+	assert.NoError(t, err)
+	assert.True(t, verified.Status.Active)
+	assert.Equal(t, "carol@example.com", verified.Spec.Email)
+	assert.Empty(t, verified.Status.PendingEmail)
+}
+
+func TestAuthController_LoginIdentifiers(t *testing.T) {
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	newStore := func() *mocks.MockStore {
+		ms := mocks.NewMockStore()
+		user := &v1alpha1.User{
+			ObjectMeta: metav1.ObjectMeta{Name: "dave"},
+			Spec: v1alpha1.UserSpec{
+				Username:     "dave",
+				Email:        "dave@example.com",
+				PasswordHash: string(hashedPassword),
+			},
+			Status: v1alpha1.UserStatus{Active: true},
+		}
+		ms.On("GetUser", mock.Anything, "dave").Return(user, nil)
+		ms.On("GetUser", mock.Anything, mock.Anything).Return(nil, errors.ErrUserNotFound)
+		ms.On("FindUserByEmail", mock.Anything, "dave@example.com").Return(user, nil)
+		ms.On("FindUserByEmail", mock.Anything, mock.Anything).Return(nil, errors.ErrUserNotFound)
+		ms.On("UpdateUser", mock.Anything, mock.Anything).Return(nil)
+		return ms
+	}
+
+	t.Run("username and email both succeed", func(t *testing.T) {
+		controller := NewAuthController(newStore())
+		for _, identifier := range []string{"dave", "dave@example.com"} {
+			user, err := controller.Login(context.Background(), identifier, "password123")
+			if assert.NoError(t, err, identifier) {
+				assert.Equal(t, "dave", user.Name)
+			}
+		}
+	})
+
+	t.Run("failures are indistinguishable", func(t *testing.T) {
+		controller := NewAuthController(newStore())
+		var messages []string
+		for _, tc := range []struct{ identifier, password string }{
+			{"nobody", "password123"},
+			{"nobody@example.com", "password123"},
+			{"dave", "wrong-password"},
+			{"dave@example.com", "wrong-password"},
+		} {
+			_, err := controller.Login(context.Background(), tc.identifier, tc.password)
+			assert.ErrorIs(t, err, errors.ErrInvalidCredentials, tc.identifier)
+			messages = append(messages, err.Error())
+		}
+		for _, msg := range messages {
+			assert.Equal(t, messages[0], msg)
+		}
+	})
+
+	t.Run("email login can be disabled", func(t *testing.T) {
+		ms := newStore()
+		controller := NewAuthControllerWithConfig(ms, AuthControllerConfig{LoginIdentifiers: []LoginIdentifier{LoginByName}})
+		_, err := controller.Login(context.Background(), "dave@example.com", "password123")
+		assert.ErrorIs(t, err, errors.ErrInvalidCredentials)
+		ms.AssertNotCalled(t, "FindUserByEmail", mock.Anything, mock.Anything)
+	})
+}
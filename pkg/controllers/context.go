@@ -0,0 +1,76 @@
+package controllers
+
+import "context"
+
+type clientInfoKey struct{}
+
+type dryRunKey struct{}
+
+type cascadeDeleteKey struct{}
+
+type withoutDefaultRolesKey struct{}
+
+type selfServiceKey struct{}
+
+// ClientInfo carries request metadata that controllers record (e.g. login history)
+type ClientInfo struct {
+	IP        string
+	UserAgent string
+}
+
+// WithClientInfo returns a copy of ctx carrying the client information
+func WithClientInfo(ctx context.Context, info ClientInfo) context.Context {
+	return context.WithValue(ctx, clientInfoKey{}, info)
+}
+
+// ClientInfoFromContext extracts the client information stored by WithClientInfo
+func ClientInfoFromContext(ctx context.Context) ClientInfo {
+	info, _ := ctx.Value(clientInfoKey{}).(ClientInfo)
+	return info
+}
+
+// WithDryRun marks ctx so create operations run validation without persisting
+func WithDryRun(ctx context.Context) context.Context {
+	return context.WithValue(ctx, dryRunKey{}, true)
+}
+
+// IsDryRun reports whether ctx was marked by WithDryRun
+func IsDryRun(ctx context.Context) bool {
+	dryRun, _ := ctx.Value(dryRunKey{}).(bool)
+	return dryRun
+}
+
+// WithCascadeDelete marks ctx so user deletion also removes the user from role bindings,
+// and role deletion removes the role bindings referencing the role instead of failing
+func WithCascadeDelete(ctx context.Context) context.Context {
+	return context.WithValue(ctx, cascadeDeleteKey{}, true)
+}
+
+// IsCascadeDelete reports whether ctx was marked by WithCascadeDelete
+func IsCascadeDelete(ctx context.Context) bool {
+	cascade, _ := ctx.Value(cascadeDeleteKey{}).(bool)
+	return cascade
+}
+
+// WithoutDefaultRoles marks ctx so CreateUser does not assign the configured default roles
+func WithoutDefaultRoles(ctx context.Context) context.Context {
+	return context.WithValue(ctx, withoutDefaultRolesKey{}, true)
+}
+
+// IsWithoutDefaultRoles reports whether ctx was marked by WithoutDefaultRoles
+func IsWithoutDefaultRoles(ctx context.Context) bool {
+	skip, _ := ctx.Value(withoutDefaultRolesKey{}).(bool)
+	return skip
+}
+
+// WithSelfService marks ctx as a user acting on their own account without the update permission.
+// UpdateUser는 이 경우 본인이 변경할 수 있는 필드(displayName, profile)만 반영함
+func WithSelfService(ctx context.Context) context.Context {
+	return context.WithValue(ctx, selfServiceKey{}, true)
+}
+
+// IsSelfService reports whether ctx was marked by WithSelfService
+func IsSelfService(ctx context.Context) bool {
+	self, _ := ctx.Value(selfServiceKey{}).(bool)
+	return self
+}
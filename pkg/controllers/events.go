@@ -0,0 +1,38 @@
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/sukryu/pAuth/pkg/apis/auth/v1alpha1"
+	"github.com/sukryu/pAuth/pkg/events"
+	"github.com/sukryu/pAuth/pkg/utils/namespace"
+)
+
+// publish sends a change event when bus is configured. 알림은 best-effort이므로
+// 발행 실패가 이미 완료된 변경을 실패로 만들지 않음. ns가 비어 있으면(삭제 이벤트 등)
+// 요청의 namespace가 사용되어 watch가 namespace별로 이벤트를 거를 수 있음
+func publish(ctx context.Context, bus events.Bus, eventType events.EventType, kind, name, ns string, obj interface{}) {
+	if bus == nil || IsDryRun(ctx) {
+		return
+	}
+	if ns == "" {
+		ns = namespace.FromContext(ctx)
+	}
+	_ = bus.Publish(ctx, events.Event{
+		Type:      eventType,
+		Kind:      kind,
+		Name:      name,
+		Namespace: ns,
+		Object:    obj,
+		Timestamp: time.Now(),
+	})
+}
+
+// publishUser publishes a User event without the password hash
+func (c *authController) publishUser(ctx context.Context, eventType events.EventType, user *v1alpha1.User) {
+	redacted := *user
+	redacted.Spec.PasswordHash = ""
+	redacted.Status.PasswordHistory = nil
+	publish(ctx, c.config.Events, eventType, "User", user.Name, user.Namespace, &redacted)
+}
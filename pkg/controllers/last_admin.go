@@ -0,0 +1,127 @@
+package controllers
+
+import (
+	"context"
+
+	"github.com/sukryu/pAuth/pkg/apis/auth/v1alpha1"
+	"github.com/sukryu/pAuth/pkg/errors"
+)
+
+// adminWildcard is the verb, resource and API group an admin is allowed (*/*/*)
+const adminWildcard = "*"
+
+// pendingRemoval hides the objects a deletion is about to remove, so access can be
+// evaluated as if the deletion had already happened. 비어 있는 필드는 무시됨
+type pendingRemoval struct {
+	Store
+	user               string
+	role               string // 이 Role과 RoleBinding의 참조를 모두 숨김
+	roleBinding        string
+	clusterRoleBinding string
+	updatedBinding     *v1alpha1.RoleBinding // 같은 이름의 RoleBinding을 수정 후 내용으로 대체
+	updatedRole        *v1alpha1.Role        // 같은 이름의 Role을 수정 후 내용으로 대체
+}
+
+func (s *pendingRemoval) GetRole(ctx context.Context, name string) (*v1alpha1.Role, error) {
+	if s.role != "" && name == s.role {
+		return nil, errors.ErrRoleNotFound
+	}
+	if s.updatedRole != nil && name == s.updatedRole.Name {
+		return s.updatedRole, nil
+	}
+	return s.Store.GetRole(ctx, name)
+}
+
+func (s *pendingRemoval) ListRoleBindings(ctx context.Context) ([]*v1alpha1.RoleBinding, error) {
+	bindings, err := s.Store.ListRoleBindings(ctx)
+	if err != nil || (s.roleBinding == "" && s.role == "" && s.updatedBinding == nil) {
+		return bindings, err
+	}
+	kept := make([]*v1alpha1.RoleBinding, 0, len(bindings))
+	for _, binding := range bindings {
+		if binding.Name == s.roleBinding {
+			continue
+		}
+		if s.updatedBinding != nil && binding.Name == s.updatedBinding.Name {
+			binding = s.updatedBinding
+		}
+		if s.role != "" && binding.ReferencesRole(s.role) {
+			if binding = withoutRoleRef(binding, s.role); binding == nil {
+				continue
+			}
+		}
+		kept = append(kept, binding)
+	}
+	return kept, nil
+}
+
+func (s *pendingRemoval) ListClusterRoleBindings(ctx context.Context) ([]*v1alpha1.ClusterRoleBinding, error) {
+	bindings, err := s.Store.ListClusterRoleBindings(ctx)
+	if err != nil || s.clusterRoleBinding == "" {
+		return bindings, err
+	}
+	kept := make([]*v1alpha1.ClusterRoleBinding, 0, len(bindings))
+	for _, binding := range bindings {
+		if binding.Name != s.clusterRoleBinding {
+			kept = append(kept, binding)
+		}
+	}
+	return kept, nil
+}
+
+// ensureAdminRemains rejects removal with ErrLastAdmin when it would leave no subject with */*/* access.
+// 처음부터 admin이 없던 경우는 막지 않음
+func ensureAdminRemains(ctx context.Context, store Store, removal pendingRemoval) error {
+	before, err := hasAdmin(ctx, store, "")
+	if err != nil || !before {
+		return err
+	}
+
+	removal.Store = store
+	after, err := hasAdmin(ctx, &removal, removal.user)
+	if err != nil {
+		return err
+	}
+	if !after {
+		return errors.ErrLastAdmin
+	}
+	return nil
+}
+
+// hasAdmin reports whether any bound subject other than the user excludeUser has */*/* access
+func hasAdmin(ctx context.Context, store Store, excludeUser string) (bool, error) {
+	bindings, err := store.ListRoleBindings(ctx)
+	if err != nil {
+		return false, errors.ErrInternal.WithReason("failed to list role bindings")
+	}
+	clusterBindings, err := store.ListClusterRoleBindings(ctx)
+	if err != nil {
+		return false, errors.ErrInternal.WithReason("failed to list cluster role bindings")
+	}
+
+	var subjects []v1alpha1.Subject
+	for _, binding := range bindings {
+		subjects = append(subjects, binding.Subjects...)
+	}
+	for _, binding := range clusterBindings {
+		subjects = append(subjects, binding.Subjects...)
+	}
+
+	rbac := &rbacController{store: store}
+	seen := make(map[v1alpha1.Subject]bool, len(subjects))
+	for _, subject := range subjects {
+		if seen[subject] || (subject.Kind == v1alpha1.SubjectKindUser && subject.Name == excludeUser) {
+			continue
+		}
+		seen[subject] = true
+
+		allowed, err := rbac.CheckSubjectAccess(ctx, subject, adminWildcard, adminWildcard, adminWildcard)
+		if err != nil {
+			return false, err
+		}
+		if allowed {
+			return true, nil
+		}
+	}
+	return false, nil
+}
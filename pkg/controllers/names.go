@@ -0,0 +1,45 @@
+package controllers
+
+import "fmt"
+
+// DefaultMaxNameLength is the resource name limit when NamePolicy.MaxLength is 0 (DNS label 길이)
+const DefaultMaxNameLength = 63
+
+// NamePolicy configures the names accepted for users, roles and role bindings.
+// 이름은 저장소의 primary key이므로 생성/이름 변경 시에만 검증됨 (기존 이름은 계속 수정 가능)
+type NamePolicy struct {
+	// MaxLength caps the name length in bytes; 0이면 DefaultMaxNameLength
+	MaxLength int
+	// AllowUppercase also accepts A-Z. 기본값은 DNS label처럼 소문자만 허용
+	AllowUppercase bool
+}
+
+// validateResourceName checks that name is a DNS-label-like identifier: alphanumerics and '-',
+// starting and ending with an alphanumeric, within policy.MaxLength
+func validateResourceName(name string, policy NamePolicy) error {
+	maxLength := policy.MaxLength
+	if maxLength <= 0 {
+		maxLength = DefaultMaxNameLength
+	}
+
+	if name == "" {
+		return fmt.Errorf("name cannot be empty")
+	}
+	if len(name) > maxLength {
+		return fmt.Errorf("name must be at most %d characters", maxLength)
+	}
+
+	alnum := func(r byte) bool {
+		return r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || policy.AllowUppercase && r >= 'A' && r <= 'Z'
+	}
+	for i := 0; i < len(name); i++ {
+		if alnum(name[i]) || name[i] == '-' && i > 0 && i < len(name)-1 {
+			continue
+		}
+		if policy.AllowUppercase {
+			return fmt.Errorf("name %q must consist of alphanumeric characters or '-', and start and end with an alphanumeric character", name)
+		}
+		return fmt.Errorf("name %q must consist of lowercase alphanumeric characters or '-', and start and end with an alphanumeric character", name)
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package controllers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateResourceName(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		policy  NamePolicy
+		wantErr string
+	}{
+		{name: "simple", input: "alice"},
+		{name: "dashes and digits", input: "ci-bot-2"},
+		{name: "single character", input: "a"},
+		{name: "max length", input: strings.Repeat("a", DefaultMaxNameLength)},
+		{name: "empty", input: "", wantErr: "name cannot be empty"},
+		{name: "too long", input: strings.Repeat("a", DefaultMaxNameLength+1), wantErr: "at most 63 characters"},
+		{name: "10KB", input: strings.Repeat("a", 10*1024), wantErr: "at most 63 characters"},
+		{name: "newline", input: "alice\nadmin", wantErr: "must consist of lowercase alphanumeric"},
+		{name: "space", input: "alice admin", wantErr: "must consist of lowercase alphanumeric"},
+		{name: "slash", input: "../admin", wantErr: "must consist of lowercase alphanumeric"},
+		{name: "leading dash", input: "-alice", wantErr: "must consist of lowercase alphanumeric"},
+		{name: "trailing dash", input: "alice-", wantErr: "must consist of lowercase alphanumeric"},
+		{name: "uppercase rejected by default", input: "Alice", wantErr: "must consist of lowercase alphanumeric"},
+		{name: "uppercase allowed", input: "Alice", policy: NamePolicy{AllowUppercase: true}},
+		{name: "custom max length", input: "alice", policy: NamePolicy{MaxLength: 4}, wantErr: "at most 4 characters"},
+		{name: "custom max length raised", input: strings.Repeat("a", 100), policy: NamePolicy{MaxLength: 253}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateResourceName(tt.input, tt.policy)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tt.wantErr)
+			}
+		})
+	}
+}
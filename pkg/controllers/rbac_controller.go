@@ -3,58 +3,206 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"strings"
+	"time"
 
+	"github.com/sukryu/pAuth/internal/store/cache"
 	"github.com/sukryu/pAuth/pkg/apis/auth/v1alpha1"
 	"github.com/sukryu/pAuth/pkg/errors"
+	"github.com/sukryu/pAuth/pkg/events"
+	"github.com/sukryu/pAuth/pkg/utils/cursor"
+	"github.com/sukryu/pAuth/pkg/utils/namespace"
 )
 
 type RBACController interface {
 	CreateRole(ctx context.Context, role *v1alpha1.Role) error
 	GetRole(ctx context.Context, name string) (*v1alpha1.Role, error)
 	ListRoles(ctx context.Context) ([]*v1alpha1.Role, error)
+	ListRolesPaged(ctx context.Context, limit, offset int) ([]*v1alpha1.Role, int, error)
+	ListRolesWithCursor(ctx context.Context, limit int, next string) ([]*v1alpha1.Role, string, error)
+	UpdateRole(ctx context.Context, role *v1alpha1.Role) (*v1alpha1.Role, error)
 	DeleteRole(ctx context.Context, name string) error
 
 	CreateRoleBinding(ctx context.Context, binding *v1alpha1.RoleBinding) error
 	GetRoleBinding(ctx context.Context, name string) (*v1alpha1.RoleBinding, error)
 	ListRoleBindings(ctx context.Context) ([]*v1alpha1.RoleBinding, error)
+	ListRoleBindingsPaged(ctx context.Context, limit, offset int) ([]*v1alpha1.RoleBinding, int, error)
+	ListRoleBindingsWithCursor(ctx context.Context, limit int, next string) ([]*v1alpha1.RoleBinding, string, error)
+	UpdateRoleBinding(ctx context.Context, binding *v1alpha1.RoleBinding) error
 	DeleteRoleBinding(ctx context.Context, name string) error
+	AddRoleBindingSubject(ctx context.Context, name string, subject v1alpha1.Subject) error
+
+	CreateClusterRole(ctx context.Context, role *v1alpha1.ClusterRole) error
+	GetClusterRole(ctx context.Context, name string) (*v1alpha1.ClusterRole, error)
+	ListClusterRoles(ctx context.Context) ([]*v1alpha1.ClusterRole, error)
+	DeleteClusterRole(ctx context.Context, name string) error
+
+	CreateClusterRoleBinding(ctx context.Context, binding *v1alpha1.ClusterRoleBinding) error
+	GetClusterRoleBinding(ctx context.Context, name string) (*v1alpha1.ClusterRoleBinding, error)
+	ListClusterRoleBindings(ctx context.Context) ([]*v1alpha1.ClusterRoleBinding, error)
+	DeleteClusterRoleBinding(ctx context.Context, name string) error
 
 	CheckAccess(ctx context.Context, user *v1alpha1.User, verb, resource, apiGroup string) (bool, error)
+	CheckSubjectAccess(ctx context.Context, subject v1alpha1.Subject, verb, resource, apiGroup string) (bool, error)
+	CheckClusterAccess(ctx context.Context, subject v1alpha1.Subject, verb, resource, apiGroup string) (bool, error)
+	CheckAccessBatch(ctx context.Context, subject v1alpha1.Subject, checks []v1alpha1.AccessCheck) ([]v1alpha1.AccessCheckResult, error)
+	ListEffectivePermissions(ctx context.Context, subject v1alpha1.Subject) (*v1alpha1.EffectivePermissions, error)
+	GetRolesForUser(ctx context.Context, username string) ([]*v1alpha1.Role, error)
+}
+
+// RBACControllerConfig holds optional RBAC controller settings
+type RBACControllerConfig struct {
+	// AllowRemovingLastAdmin disables the guard that rejects deleting the last binding granting */*/*.
+	// 장애 복구 시에만 사용
+	AllowRemovingLastAdmin bool
+
+	// AllowedVerbs are the verbs accepted in PolicyRules; 비어 있으면 DefaultAllowedVerbs. "*"는 항상 허용
+	AllowedVerbs []string
+	// AllowUnknownVerbs accepts verbs outside AllowedVerbs (strict=false). 대소문자 정규화는 그대로 적용
+	AllowUnknownVerbs bool
+
+	// MaxSubjectsPerBinding caps the subjects of a single (cluster) role binding; 0이면 제한 없음.
+	// 구성원이 많으면 Group subject 하나로 바인딩하는 것을 권장
+	MaxSubjectsPerBinding int
+
+	// Names restricts the length and characters of role and (cluster) role binding names
+	Names NamePolicy
+
+	// Events receives role change notifications; nil이면 발행하지 않음
+	Events events.Bus
+
+	// AccessCache caches CheckAccess/CheckSubjectAccess decisions for AccessCacheTTL
+	// (0이면 DefaultAccessCacheTTL); nil이면 캐시하지 않음. AuthControllerConfig.AccessCache와 같은 cache를
+	// 사용해야 사용자 역할 변경도 캐시를 무효화함
+	AccessCache    cache.Cache
+	AccessCacheTTL time.Duration
 }
 
+// DefaultAllowedVerbs are the PolicyRule verbs accepted when RBACControllerConfig.AllowedVerbs is empty
+var DefaultAllowedVerbs = []string{"get", "list", "create", "update", "delete", "watch", "*"}
+
 type rbacController struct {
-	store Store
+	store  Store
+	config RBACControllerConfig
+
+	allowedVerbs map[string]bool
+	access       *accessCache
 }
 
 func NewRBACController(store Store) RBACController {
-	return &rbacController{store: store}
+	return NewRBACControllerWithConfig(store, RBACControllerConfig{})
+}
+
+func NewRBACControllerWithConfig(store Store, cfg RBACControllerConfig) RBACController {
+	if len(cfg.AllowedVerbs) == 0 {
+		cfg.AllowedVerbs = DefaultAllowedVerbs
+	}
+
+	allowedVerbs := map[string]bool{"*": true}
+	for _, verb := range cfg.AllowedVerbs {
+		allowedVerbs[normalizeRuleValue(verb)] = true
+	}
+	return &rbacController{
+		store:        store,
+		config:       cfg,
+		allowedVerbs: allowedVerbs,
+		access:       newAccessCache(cfg.AccessCache, cfg.AccessCacheTTL),
+	}
 }
 
 func (c *rbacController) CreateRole(ctx context.Context, role *v1alpha1.Role) error {
 	if role == nil {
 		return errors.ErrInvalidInput.WithReason("role cannot be nil")
 	}
+
+	// 모든 필드 오류를 모아서 한 번에 반환
+	verr := &errors.ValidationError{}
 	if role.Name == "" {
-		return errors.ErrInvalidInput.WithReason("role name is required")
+		verr.Add("metadata.name", "role name is required")
+	} else if err := validateResourceName(role.Name, c.config.Names); err != nil {
+		verr.Add("metadata.name", err.Error())
+	}
+	c.addRuleErrors(verr, role.Rules)
+	for i, parent := range role.Inherits {
+		if parent == "" {
+			verr.Add(fmt.Sprintf("inherits[%d]", i), "inherited role name is required")
+		}
 	}
-	if len(role.Rules) == 0 {
-		return errors.ErrInvalidInput.WithReason("at least one rule is required")
+	if err := verr.OrNil(); err != nil {
+		return err
 	}
 
-	// 각 rule의 유효성 검사
-	for i, rule := range role.Rules {
-		if len(rule.Verbs) == 0 {
-			return errors.ErrInvalidInput.WithReason(fmt.Sprintf("verbs are required in rule %d", i))
+	// 요청 namespace 밖에는 생성할 수 없음
+	if _, err := namespace.Resolve(ctx, role.Namespace); err != nil {
+		return err
+	}
+
+	if err := c.checkInherits(ctx, role); err != nil {
+		return err
+	}
+
+	if IsDryRun(ctx) {
+		return nil
+	}
+
+	if err := c.store.CreateRole(ctx, role); err != nil {
+		return err
+	}
+	c.access.invalidate(ctx)
+	publish(ctx, c.config.Events, events.Added, "Role", role.Name, role.Namespace, role)
+	return nil
+}
+
+// checkInherits verifies every role named in role.Inherits exists and that none of them
+// inherits role back, directly or transitively
+func (c *rbacController) checkInherits(ctx context.Context, role *v1alpha1.Role) error {
+	visited := make(map[string]bool)
+	var walk func(name string, path []string) error
+	walk = func(name string, path []string) error {
+		path = append(path, name)
+		if name == role.Name {
+			return errors.ErrInvalidInput.WithReason("role inheritance cycle: " + strings.Join(path, " -> "))
 		}
-		if len(rule.Resources) == 0 {
-			return errors.ErrInvalidInput.WithReason(fmt.Sprintf("resources are required in rule %d", i))
+		if visited[name] {
+			return nil
 		}
-		if len(rule.APIGroups) == 0 {
-			return errors.ErrInvalidInput.WithReason(fmt.Sprintf("apiGroups are required in rule %d", i))
+		visited[name] = true
+
+		parent, err := c.store.GetRole(ctx, name)
+		if err != nil {
+			return err
+		}
+		for _, next := range parent.Inherits {
+			if err := walk(next, path); err != nil {
+				return err
+			}
 		}
+		return nil
 	}
 
-	return c.store.CreateRole(ctx, role)
+	for _, name := range role.Inherits {
+		if err := walk(name, []string{role.Name}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkNotInherited fails with ErrInvalidInput when another role inherits the role name
+func (c *rbacController) checkNotInherited(ctx context.Context, name string) error {
+	roles, err := c.store.ListRoles(ctx)
+	if err != nil {
+		return errors.ErrInternal.WithReason("failed to list roles")
+	}
+	for _, role := range roles {
+		for _, inherited := range role.Inherits {
+			if inherited == name {
+				return errors.ErrInvalidInput.WithReason(fmt.Sprintf("role %s is inherited by role %s", name, role.Name))
+			}
+		}
+	}
+	return nil
 }
 
 func (c *rbacController) GetRole(ctx context.Context, name string) (*v1alpha1.Role, error) {
@@ -69,8 +217,104 @@ func (c *rbacController) GetRole(ctx context.Context, name string) (*v1alpha1.Ro
 	return role, nil
 }
 
+// ListRoles returns every role in the namespace; ListRolesPaged의 전체 조회 버전
 func (c *rbacController) ListRoles(ctx context.Context) ([]*v1alpha1.Role, error) {
-	return c.store.ListRoles(ctx)
+	roles, _, err := c.ListRolesPaged(ctx, 0, 0)
+	return roles, err
+}
+
+func (c *rbacController) ListRolesPaged(ctx context.Context, limit, offset int) ([]*v1alpha1.Role, int, error) {
+	if limit < 0 || offset < 0 {
+		return nil, 0, errors.ErrInvalidInput.WithReason("limit and offset must not be negative")
+	}
+
+	roles, total, err := c.store.ListRolesPaged(ctx, limit, offset)
+	if err != nil {
+		return nil, 0, errors.ErrInternal.WithReason("failed to list roles")
+	}
+	return roles, total, nil
+}
+
+// ListRolesWithCursor returns a page of roles after the cursor next; next가 비어 있으면 첫 페이지
+func (c *rbacController) ListRolesWithCursor(ctx context.Context, limit int, next string) ([]*v1alpha1.Role, string, error) {
+	if limit < 0 {
+		return nil, "", errors.ErrInvalidInput.WithReason("limit must not be negative")
+	}
+	if next != "" {
+		if _, err := cursor.Decode(next); err != nil {
+			return nil, "", errors.ErrInvalidInput.WithReason("invalid cursor")
+		}
+	}
+
+	roles, continueToken, err := c.store.ListRolesWithCursor(ctx, limit, next)
+	if err != nil {
+		return nil, "", errors.ErrInternal.WithReason("failed to list roles")
+	}
+	return roles, continueToken, nil
+}
+
+// UpdateRole replaces the rules, inherited roles and annotations of an existing role.
+// 변경 사항이 없으면 쓰기를 생략하고 (updated_at도 갱신하지 않음) 기존 Role을 반환
+func (c *rbacController) UpdateRole(ctx context.Context, role *v1alpha1.Role) (*v1alpha1.Role, error) {
+	if role == nil {
+		return nil, errors.ErrInvalidInput.WithReason("role cannot be nil")
+	}
+
+	// 이름 형식은 생성 시에만 검증. 기존 Role은 이름이 정책에 맞지 않아도 수정 가능
+	verr := &errors.ValidationError{}
+	if role.Name == "" {
+		verr.Add("metadata.name", "role name is required")
+	}
+	c.addRuleErrors(verr, role.Rules)
+	for i, parent := range role.Inherits {
+		if parent == "" {
+			verr.Add(fmt.Sprintf("inherits[%d]", i), "inherited role name is required")
+		}
+	}
+	if err := verr.OrNil(); err != nil {
+		return nil, err
+	}
+
+	existing, err := c.store.GetRole(ctx, role.Name)
+	if err != nil {
+		return nil, err
+	}
+	role.Namespace = existing.Namespace
+	role.CreationTimestamp = existing.CreationTimestamp
+
+	if roleMutableFieldsEqual(existing, role) {
+		return existing, nil
+	}
+
+	if err := c.checkInherits(ctx, role); err != nil {
+		return nil, err
+	}
+
+	// 규칙을 줄여 마지막 admin 권한이 사라지는 수정도 삭제와 같이 거부
+	if !c.config.AllowRemovingLastAdmin {
+		if err := ensureAdminRemains(ctx, c.store, pendingRemoval{updatedRole: role}); err != nil {
+			return nil, err
+		}
+	}
+
+	if IsDryRun(ctx) {
+		return role, nil
+	}
+
+	if err := c.store.UpdateRole(ctx, role); err != nil {
+		return nil, err
+	}
+	c.access.invalidate(ctx)
+	publish(ctx, c.config.Events, events.Modified, "Role", role.Name, role.Namespace, role)
+	return role, nil
+}
+
+// roleMutableFieldsEqual reports whether UpdateRole would persist nothing new.
+// userMutableFieldsEqual과 같이 nil과 빈 값은 동일하게 취급
+func roleMutableFieldsEqual(a, b *v1alpha1.Role) bool {
+	return (len(a.Rules) == 0 && len(b.Rules) == 0 || reflect.DeepEqual(a.Rules, b.Rules)) &&
+		reflect.DeepEqual(nonNilStrings(a.Inherits), nonNilStrings(b.Inherits)) &&
+		reflect.DeepEqual(nonNilMap(a.Annotations), nonNilMap(b.Annotations))
 }
 
 func (c *rbacController) DeleteRole(ctx context.Context, name string) error {
@@ -84,42 +328,103 @@ func (c *rbacController) DeleteRole(ctx context.Context, name string) error {
 		return err
 	}
 
+	// 다른 Role이 상속하는 Role은 cascade 여부와 무관하게 삭제할 수 없음.
+	// 삭제하면 상속한 Role에 끊어진 참조가 남고 그 Role로 부여된 권한이 조용히 사라짐
+	if err := c.checkNotInherited(ctx, name); err != nil {
+		return err
+	}
+
 	// 이 Role을 참조하는 RoleBinding이 있는지 확인
 	bindings, err := c.store.ListRoleBindings(ctx)
 	if err != nil {
 		return errors.ErrInternal.WithReason("failed to list role bindings")
 	}
 
+	var referencing []*v1alpha1.RoleBinding
 	for _, binding := range bindings {
-		if binding.RoleRef.Name == name {
-			return errors.ErrInvalidInput.WithReason(fmt.Sprintf("role %s is still referenced by role binding %s", name, binding.Name))
+		if binding.ReferencesRole(name) {
+			referencing = append(referencing, binding)
 		}
 	}
+	if len(referencing) == 0 {
+		if err := c.store.DeleteRole(ctx, name); err != nil {
+			return err
+		}
+		c.access.invalidate(ctx)
+		publish(ctx, c.config.Events, events.Deleted, "Role", name, "", nil)
+		return nil
+	}
+	if !IsCascadeDelete(ctx) {
+		return errors.ErrInvalidInput.WithReason(fmt.Sprintf("role %s is still referenced by role binding %s", name, referencing[0].Name))
+	}
 
-	return c.store.DeleteRole(ctx, name)
+	if !c.config.AllowRemovingLastAdmin {
+		if err := ensureAdminRemains(ctx, c.store, pendingRemoval{role: name}); err != nil {
+			return err
+		}
+	}
+
+	// store가 Transactor를 구현하면 바인딩과 Role이 한 트랜잭션에서 삭제됨.
+	// 그렇지 않은 store에서도 Role을 마지막에 삭제하므로, 중간에 실패하면 Role과 남은 바인딩이
+	// 그대로 남아 같은 요청을 재시도하여 복구할 수 있음
+	err = inTransaction(ctx, c.store, func(ctx context.Context) error {
+		for _, binding := range referencing {
+			// 다른 Role도 바인딩하는 경우 이 Role에 대한 참조만 제거
+			if remaining := withoutRoleRef(binding, name); remaining != nil {
+				if err := c.store.UpdateRoleBinding(ctx, remaining); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := c.store.DeleteRoleBinding(ctx, binding.Name); err != nil {
+				return err
+			}
+		}
+		return c.store.DeleteRole(ctx, name)
+	})
+	if err != nil {
+		return err
+	}
+	// 트랜잭션이 커밋된 뒤에 무효화해야 이전 상태로 다시 캐시되지 않음
+	c.access.invalidate(ctx)
+	publish(ctx, c.config.Events, events.Deleted, "Role", name, "", nil)
+	return nil
 }
 
 func (c *rbacController) CreateRoleBinding(ctx context.Context, binding *v1alpha1.RoleBinding) error {
 	if binding == nil {
 		return errors.ErrInvalidInput.WithReason("role binding cannot be nil")
 	}
-	if binding.Name == "" {
-		return errors.ErrInvalidInput.WithReason("role binding name is required")
-	}
-	if binding.RoleRef.Name == "" {
-		return errors.ErrInvalidInput.WithReason("role reference name is required")
+	if err := validateNewRoleBinding(binding, c.config.Names, c.config.MaxSubjectsPerBinding); err != nil {
+		return err
 	}
-	if len(binding.Subjects) == 0 {
-		return errors.ErrInvalidInput.WithReason("at least one subject is required")
+	normalizeRoleRefs(binding)
+
+	// 요청 namespace 밖에는 생성할 수 없음
+	if _, err := namespace.Resolve(ctx, binding.Namespace); err != nil {
+		return err
 	}
 
 	// 참조된 Role이 존재하는지 확인
-	_, err := c.store.GetRole(ctx, binding.RoleRef.Name)
-	if err != nil {
+	if err := c.checkRolesExist(ctx, binding); err != nil {
 		return err
 	}
 
-	return c.store.CreateRoleBinding(ctx, binding)
+	if err := c.store.CreateRoleBinding(ctx, binding); err != nil {
+		return err
+	}
+	c.access.invalidate(ctx)
+	return nil
+}
+
+// checkRolesExist returns the store error for the first role referenced by binding that does not exist
+func (c *rbacController) checkRolesExist(ctx context.Context, binding *v1alpha1.RoleBinding) error {
+	for _, ref := range binding.AllRoleRefs() {
+		if _, err := c.store.GetRole(ctx, ref.Name); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (c *rbacController) GetRoleBinding(ctx context.Context, name string) (*v1alpha1.RoleBinding, error) {
@@ -130,12 +435,83 @@ func (c *rbacController) GetRoleBinding(ctx context.Context, name string) (*v1al
 	return c.store.GetRoleBinding(ctx, name)
 }
 
+// ListRoleBindings returns every role binding in the namespace; ListRoleBindingsPaged의 전체 조회 버전
 func (c *rbacController) ListRoleBindings(ctx context.Context) ([]*v1alpha1.RoleBinding, error) {
-	bindings, err := c.store.ListRoleBindings(ctx)
+	bindings, _, err := c.ListRoleBindingsPaged(ctx, 0, 0)
+	return bindings, err
+}
+
+func (c *rbacController) ListRoleBindingsPaged(ctx context.Context, limit, offset int) ([]*v1alpha1.RoleBinding, int, error) {
+	if limit < 0 || offset < 0 {
+		return nil, 0, errors.ErrInvalidInput.WithReason("limit and offset must not be negative")
+	}
+
+	bindings, total, err := c.store.ListRoleBindingsPaged(ctx, limit, offset)
 	if err != nil {
-		return nil, errors.ErrInternal.WithReason("failed to list role bindings")
+		return nil, 0, errors.ErrInternal.WithReason("failed to list role bindings")
+	}
+	return bindings, total, nil
+}
+
+// ListRoleBindingsWithCursor returns a page of role bindings after the cursor next; next가 비어 있으면 첫 페이지
+func (c *rbacController) ListRoleBindingsWithCursor(ctx context.Context, limit int, next string) ([]*v1alpha1.RoleBinding, string, error) {
+	if limit < 0 {
+		return nil, "", errors.ErrInvalidInput.WithReason("limit must not be negative")
+	}
+	if next != "" {
+		if _, err := cursor.Decode(next); err != nil {
+			return nil, "", errors.ErrInvalidInput.WithReason("invalid cursor")
+		}
+	}
+
+	bindings, continueToken, err := c.store.ListRoleBindingsWithCursor(ctx, limit, next)
+	if err != nil {
+		return nil, "", errors.ErrInternal.WithReason("failed to list role bindings")
+	}
+	return bindings, continueToken, nil
+}
+
+func (c *rbacController) UpdateRoleBinding(ctx context.Context, binding *v1alpha1.RoleBinding) error {
+	if binding == nil {
+		return errors.ErrInvalidInput.WithReason("role binding cannot be nil")
+	}
+	verr := &errors.ValidationError{}
+	if binding.Name == "" {
+		verr.Add("metadata.name", "role binding name is required")
+	}
+	// 이름 형식은 생성 시에만 검증. 기존 바인딩은 이름이 정책에 맞지 않아도 수정 가능
+	addRoleRefErrors(verr, binding)
+	addSubjectErrors(verr, binding.Subjects)
+	if err := verr.OrNil(); err != nil {
+		return err
+	}
+	if err := c.checkSubjectLimit(len(binding.Subjects)); err != nil {
+		return err
+	}
+	normalizeRoleRefs(binding)
+
+	// RoleBinding이 존재하는지 확인
+	if _, err := c.store.GetRoleBinding(ctx, binding.Name); err != nil {
+		return err
+	}
+
+	// 참조된 Role이 여전히 존재하는지 확인
+	if err := c.checkRolesExist(ctx, binding); err != nil {
+		return err
+	}
+
+	// subject나 Role을 바꿔 마지막 admin 권한이 사라지는 수정도 삭제와 같이 거부
+	if !c.config.AllowRemovingLastAdmin {
+		if err := ensureAdminRemains(ctx, c.store, pendingRemoval{updatedBinding: binding}); err != nil {
+			return err
+		}
+	}
+
+	if err := c.store.UpdateRoleBinding(ctx, binding); err != nil {
+		return err
 	}
-	return bindings, nil
+	c.access.invalidate(ctx)
+	return nil
 }
 
 func (c *rbacController) DeleteRoleBinding(ctx context.Context, name string) error {
@@ -148,8 +524,188 @@ func (c *rbacController) DeleteRoleBinding(ctx context.Context, name string) err
 	if err != nil {
 		return err
 	}
+	if !c.config.AllowRemovingLastAdmin {
+		if err := ensureAdminRemains(ctx, c.store, pendingRemoval{roleBinding: name}); err != nil {
+			return err
+		}
+	}
+
+	if err := c.store.DeleteRoleBinding(ctx, name); err != nil {
+		return err
+	}
+	c.access.invalidate(ctx)
+	return nil
+}
+
+// AddRoleBindingSubject appends a subject to an existing role binding.
+// 이미 포함된 subject는 그대로 두고, MaxSubjectsPerBinding을 넘으면 거부
+func (c *rbacController) AddRoleBindingSubject(ctx context.Context, name string, subject v1alpha1.Subject) error {
+	if name == "" {
+		return errors.ErrInvalidInput.WithReason("role binding name is required")
+	}
+	if err := validateSubjects([]v1alpha1.Subject{subject}); err != nil {
+		return err
+	}
+
+	binding, err := c.store.GetRoleBinding(ctx, name)
+	if err != nil {
+		return err
+	}
+	if hasSubject(binding.Subjects, subject) {
+		return nil
+	}
+	if err := c.checkSubjectLimit(len(binding.Subjects) + 1); err != nil {
+		return err
+	}
+
+	binding.Subjects = append(binding.Subjects, subject)
+	if IsDryRun(ctx) {
+		return nil
+	}
+	if err := c.store.UpdateRoleBinding(ctx, binding); err != nil {
+		return err
+	}
+	c.access.invalidate(ctx)
+	return nil
+}
+
+func (c *rbacController) CreateClusterRole(ctx context.Context, role *v1alpha1.ClusterRole) error {
+	if role == nil {
+		return errors.ErrInvalidInput.WithReason("cluster role cannot be nil")
+	}
+
+	verr := &errors.ValidationError{}
+	if role.Name == "" {
+		verr.Add("metadata.name", "cluster role name is required")
+	} else if err := validateResourceName(role.Name, c.config.Names); err != nil {
+		verr.Add("metadata.name", err.Error())
+	}
+	c.addRuleErrors(verr, role.Rules)
+	if err := verr.OrNil(); err != nil {
+		return err
+	}
+
+	if IsDryRun(ctx) {
+		return nil
+	}
+
+	if err := c.store.CreateClusterRole(ctx, role); err != nil {
+		return err
+	}
+	c.access.invalidate(ctx)
+	return nil
+}
+
+func (c *rbacController) GetClusterRole(ctx context.Context, name string) (*v1alpha1.ClusterRole, error) {
+	if name == "" {
+		return nil, errors.ErrInvalidInput.WithReason("cluster role name is required")
+	}
+	return c.store.GetClusterRole(ctx, name)
+}
+
+func (c *rbacController) ListClusterRoles(ctx context.Context) ([]*v1alpha1.ClusterRole, error) {
+	return c.store.ListClusterRoles(ctx)
+}
+
+func (c *rbacController) DeleteClusterRole(ctx context.Context, name string) error {
+	if name == "" {
+		return errors.ErrInvalidInput.WithReason("cluster role name is required")
+	}
+
+	if _, err := c.store.GetClusterRole(ctx, name); err != nil {
+		return err
+	}
+
+	// 이 ClusterRole을 참조하는 ClusterRoleBinding이 있는지 확인
+	bindings, err := c.store.ListClusterRoleBindings(ctx)
+	if err != nil {
+		return errors.ErrInternal.WithReason("failed to list cluster role bindings")
+	}
+	for _, binding := range bindings {
+		if binding.RoleRef.Name == name {
+			return errors.ErrInvalidInput.WithReason(fmt.Sprintf("cluster role %s is still referenced by cluster role binding %s", name, binding.Name))
+		}
+	}
+
+	if err := c.store.DeleteClusterRole(ctx, name); err != nil {
+		return err
+	}
+	c.access.invalidate(ctx)
+	return nil
+}
+
+func (c *rbacController) CreateClusterRoleBinding(ctx context.Context, binding *v1alpha1.ClusterRoleBinding) error {
+	if binding == nil {
+		return errors.ErrInvalidInput.WithReason("cluster role binding cannot be nil")
+	}
+
+	verr := &errors.ValidationError{}
+	if binding.Name == "" {
+		verr.Add("metadata.name", "cluster role binding name is required")
+	} else if err := validateResourceName(binding.Name, c.config.Names); err != nil {
+		verr.Add("metadata.name", err.Error())
+	}
+	if binding.RoleRef.Name == "" {
+		verr.Add("roleRef.name", "role reference name is required")
+	}
+	if binding.RoleRef.Kind != "" && binding.RoleRef.Kind != v1alpha1.RoleRefKindClusterRole {
+		verr.Add("roleRef.kind", "cluster role bindings can only reference a ClusterRole")
+	}
+	addSubjectErrors(verr, binding.Subjects)
+	if err := verr.OrNil(); err != nil {
+		return err
+	}
+	if err := c.checkSubjectLimit(len(binding.Subjects)); err != nil {
+		return err
+	}
+	binding.RoleRef.Kind = v1alpha1.RoleRefKindClusterRole
+
+	// 참조된 ClusterRole이 존재하는지 확인
+	if _, err := c.store.GetClusterRole(ctx, binding.RoleRef.Name); err != nil {
+		return err
+	}
 
-	return c.store.DeleteRoleBinding(ctx, name)
+	if IsDryRun(ctx) {
+		return nil
+	}
+
+	if err := c.store.CreateClusterRoleBinding(ctx, binding); err != nil {
+		return err
+	}
+	c.access.invalidate(ctx)
+	return nil
+}
+
+func (c *rbacController) GetClusterRoleBinding(ctx context.Context, name string) (*v1alpha1.ClusterRoleBinding, error) {
+	if name == "" {
+		return nil, errors.ErrInvalidInput.WithReason("cluster role binding name is required")
+	}
+	return c.store.GetClusterRoleBinding(ctx, name)
+}
+
+func (c *rbacController) ListClusterRoleBindings(ctx context.Context) ([]*v1alpha1.ClusterRoleBinding, error) {
+	return c.store.ListClusterRoleBindings(ctx)
+}
+
+func (c *rbacController) DeleteClusterRoleBinding(ctx context.Context, name string) error {
+	if name == "" {
+		return errors.ErrInvalidInput.WithReason("cluster role binding name is required")
+	}
+
+	if _, err := c.store.GetClusterRoleBinding(ctx, name); err != nil {
+		return err
+	}
+	if !c.config.AllowRemovingLastAdmin {
+		if err := ensureAdminRemains(ctx, c.store, pendingRemoval{clusterRoleBinding: name}); err != nil {
+			return err
+		}
+	}
+
+	if err := c.store.DeleteClusterRoleBinding(ctx, name); err != nil {
+		return err
+	}
+	c.access.invalidate(ctx)
+	return nil
 }
 
 func (c *rbacController) CheckAccess(ctx context.Context, user *v1alpha1.User, verb, resource, apiGroup string) (bool, error) {
@@ -157,48 +713,449 @@ func (c *rbacController) CheckAccess(ctx context.Context, user *v1alpha1.User, v
 		return false, errors.ErrInvalidInput.WithReason("user cannot be nil")
 	}
 
+	// 사용자의 바인딩은 사용자가 속한 namespace에서만 평가
+	if user.Namespace != "" {
+		ctx = namespace.WithNamespace(ctx, user.Namespace)
+	}
+
+	return c.CheckSubjectAccess(ctx, v1alpha1.Subject{Kind: v1alpha1.SubjectKindUser, Name: user.Name}, verb, resource, apiGroup)
+}
+
+// CheckSubjectAccess evaluates access for any binding subject (User, ServiceAccount)
+func (c *rbacController) CheckSubjectAccess(ctx context.Context, subject v1alpha1.Subject, verb, resource, apiGroup string) (bool, error) {
+	key, cacheable := c.access.key(ctx, subject, verb, resource, apiGroup)
+	if cacheable {
+		if allowed, found := c.access.get(ctx, key); found {
+			return allowed, nil
+		}
+	}
+
+	allowed, err := c.checkSubjectAccess(ctx, subject, verb, resource, apiGroup)
+	if err == nil && cacheable {
+		c.access.set(ctx, key, allowed)
+	}
+	return allowed, err
+}
+
+// checkSubjectAccess evaluates access from the stored bindings and roles
+func (c *rbacController) checkSubjectAccess(ctx context.Context, subject v1alpha1.Subject, verb, resource, apiGroup string) (bool, error) {
+	// 삭제된 ServiceAccount의 토큰은 더 이상 권한을 갖지 않음
+	if subject.Kind == v1alpha1.SubjectKindServiceAccount {
+		if _, err := c.store.GetServiceAccount(ctx, subject.Name); err != nil {
+			return false, nil
+		}
+	}
+
 	bindings, err := c.store.ListRoleBindings(ctx)
 	if err != nil {
 		return false, errors.ErrInternal.WithReason("failed to list role bindings")
 	}
 
-	// Find user's role bindings
+	// Find subject's role bindings
 	userBindings := make([]*v1alpha1.RoleBinding, 0)
 	for _, binding := range bindings {
-		for _, subject := range binding.Subjects {
-			if subject.Kind == "User" && subject.Name == user.Name {
+		for _, s := range binding.Subjects {
+			if s.Kind == subject.Kind && s.Name == subject.Name {
 				userBindings = append(userBindings, binding)
 			}
 		}
 	}
 
-	// Check permissions from each role
+	// Check permissions from each role, including the roles it inherits
+	var roles []*v1alpha1.Role
+	seen := make(map[string]bool)
 	for _, binding := range userBindings {
-		role, err := c.store.GetRole(ctx, binding.RoleRef.Name)
-		if err != nil {
-			continue // Skip if role not found
+		for _, ref := range binding.AllRoleRefs() {
+			roles = c.collectRoles(ctx, ref.Name, seen, roles)
+		}
+	}
+	for _, role := range roles {
+		for _, rule := range role.Rules {
+			if ruleAllows(rule, verb, resource, apiGroup) {
+				return true, nil
+			}
 		}
+	}
+
+	// ClusterRoleBinding은 요청 namespace와 무관하게 평가
+	return c.clusterAllows(ctx, subject, verb, resource, apiGroup)
+}
 
-		// Check rules
+// clusterScopedResources are authorized only through ClusterRoleBindings
+var clusterScopedResources = map[string]bool{
+	"clusterroles":        true,
+	"clusterrolebindings": true,
+}
+
+// IsClusterScoped reports whether resource must be checked with CheckClusterAccess instead of CheckSubjectAccess
+func IsClusterScoped(resource string) bool {
+	return clusterScopedResources[resource]
+}
+
+// CheckClusterAccess evaluates access for cluster-scoped resources using only ClusterRoleBindings.
+// namespace의 RoleBinding으로 부여된 권한은 해당 테넌트 밖으로 확장되지 않아야 하므로 무시됨
+func (c *rbacController) CheckClusterAccess(ctx context.Context, subject v1alpha1.Subject, verb, resource, apiGroup string) (bool, error) {
+	if subject.Kind == v1alpha1.SubjectKindServiceAccount {
+		if _, err := c.store.GetServiceAccount(ctx, subject.Name); err != nil {
+			return false, nil
+		}
+	}
+	return c.clusterAllows(ctx, subject, verb, resource, apiGroup)
+}
+
+// clusterAllows reports whether a ClusterRole bound to subject grants verb on resource
+func (c *rbacController) clusterAllows(ctx context.Context, subject v1alpha1.Subject, verb, resource, apiGroup string) (bool, error) {
+	clusterRoles, err := c.boundClusterRoles(ctx, subject)
+	if err != nil {
+		return false, err
+	}
+	for _, role := range clusterRoles {
 		for _, rule := range role.Rules {
-			// Check API Group
-			if !contains(rule.APIGroups, apiGroup) && !contains(rule.APIGroups, "*") {
-				continue
+			if ruleAllows(rule, verb, resource, apiGroup) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// CheckAccessBatch evaluates several checks for one subject, loading its roles only once.
+// cluster-scoped 리소스는 CheckClusterAccess와 같이 ClusterRole 규칙만으로 판정
+func (c *rbacController) CheckAccessBatch(ctx context.Context, subject v1alpha1.Subject, checks []v1alpha1.AccessCheck) ([]v1alpha1.AccessCheckResult, error) {
+	results := make([]v1alpha1.AccessCheckResult, len(checks))
+	for i, check := range checks {
+		results[i].AccessCheck = check
+	}
+	if len(checks) == 0 {
+		return results, nil
+	}
+
+	perms, clusterRules, err := c.effectivePermissions(ctx, subject)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, check := range checks {
+		rules := perms.Rules
+		if IsClusterScoped(check.Resource) {
+			rules = clusterRules
+		}
+		for _, rule := range rules {
+			if ruleAllows(rule, check.Verb, check.Resource, check.APIGroup) {
+				results[i].Allowed = true
+				break
 			}
+		}
+	}
+	return results, nil
+}
+
+// ListEffectivePermissions returns the roles bound to subject in the request namespace,
+// its ClusterRoles, and the union of their rules
+func (c *rbacController) ListEffectivePermissions(ctx context.Context, subject v1alpha1.Subject) (*v1alpha1.EffectivePermissions, error) {
+	perms, _, err := c.effectivePermissions(ctx, subject)
+	return perms, err
+}
+
+// effectivePermissions builds ListEffectivePermissions and also returns the ClusterRole rules on their own
+func (c *rbacController) effectivePermissions(ctx context.Context, subject v1alpha1.Subject) (*v1alpha1.EffectivePermissions, []v1alpha1.PolicyRule, error) {
+	perms := &v1alpha1.EffectivePermissions{
+		Roles: []string{},
+		Rules: []v1alpha1.PolicyRule{},
+	}
+
+	// 삭제된 ServiceAccount의 토큰은 더 이상 권한을 갖지 않음
+	if subject.Kind == v1alpha1.SubjectKindServiceAccount {
+		if _, err := c.store.GetServiceAccount(ctx, subject.Name); err != nil {
+			return perms, nil, nil
+		}
+	}
+
+	bindings, err := c.store.ListRoleBindings(ctx)
+	if err != nil {
+		return nil, nil, errors.ErrInternal.WithReason("failed to list role bindings")
+	}
 
-			// Check Resource
-			if !contains(rule.Resources, resource) && !contains(rule.Resources, "*") {
+	seen := make(map[string]bool)
+	for _, binding := range bindings {
+		if !hasSubject(binding.Subjects, subject) {
+			continue
+		}
+		// 상속된 role도 Roles에 포함
+		for _, ref := range binding.AllRoleRefs() {
+			for _, role := range c.collectRoles(ctx, ref.Name, seen, nil) {
+				perms.Roles = append(perms.Roles, role.Name)
+				perms.Rules = append(perms.Rules, role.Rules...)
+			}
+		}
+	}
+
+	clusterRoles, err := c.boundClusterRoles(ctx, subject)
+	if err != nil {
+		return nil, nil, err
+	}
+	var clusterRules []v1alpha1.PolicyRule
+	for _, role := range clusterRoles {
+		perms.ClusterRoles = append(perms.ClusterRoles, role.Name)
+		clusterRules = append(clusterRules, role.Rules...)
+	}
+	perms.Rules = append(perms.Rules, clusterRules...)
+
+	return perms, clusterRules, nil
+}
+
+// GetRolesForUser returns the distinct roles bound to the user through RoleBindings.
+// 존재하지 않는 role은 건너뜀. group 주체가 생기면 사용자가 속한 group의 바인딩도 여기서 포함해야 함
+func (c *rbacController) GetRolesForUser(ctx context.Context, username string) ([]*v1alpha1.Role, error) {
+	if username == "" {
+		return nil, errors.ErrInvalidInput.WithReason("user name is required")
+	}
+
+	bindings, err := c.store.ListRoleBindings(ctx)
+	if err != nil {
+		return nil, errors.ErrInternal.WithReason("failed to list role bindings")
+	}
+
+	subject := v1alpha1.Subject{Kind: v1alpha1.SubjectKindUser, Name: username}
+	roles := make([]*v1alpha1.Role, 0)
+	seen := make(map[string]bool)
+	for _, binding := range bindings {
+		if !hasSubject(binding.Subjects, subject) {
+			continue
+		}
+		for _, ref := range binding.AllRoleRefs() {
+			if seen[ref.Name] {
 				continue
 			}
+			seen[ref.Name] = true
 
-			// Check Verb
-			if contains(rule.Verbs, verb) || contains(rule.Verbs, "*") {
-				return true, nil
+			role, err := c.store.GetRole(ctx, ref.Name)
+			if err != nil {
+				continue // Skip if role not found
 			}
+			roles = append(roles, role)
 		}
 	}
 
-	return false, nil
+	return roles, nil
+}
+
+// collectRoles appends the role name and the roles it inherits, transitively, to roles.
+// seen에 있는 role은 건너뛰므로 저장된 상속 관계에 순환이 있어도 종료됨. 존재하지 않는 role은 무시
+func (c *rbacController) collectRoles(ctx context.Context, name string, seen map[string]bool, roles []*v1alpha1.Role) []*v1alpha1.Role {
+	if seen[name] {
+		return roles
+	}
+	seen[name] = true
+
+	role, err := c.store.GetRole(ctx, name)
+	if err != nil {
+		return roles // Skip if role not found
+	}
+	roles = append(roles, role)
+	for _, parent := range role.Inherits {
+		roles = c.collectRoles(ctx, parent, seen, roles)
+	}
+	return roles
+}
+
+// boundClusterRoles returns the ClusterRoles bound to subject, which apply in every namespace
+func (c *rbacController) boundClusterRoles(ctx context.Context, subject v1alpha1.Subject) ([]*v1alpha1.ClusterRole, error) {
+	bindings, err := c.store.ListClusterRoleBindings(ctx)
+	if err != nil {
+		return nil, errors.ErrInternal.WithReason("failed to list cluster role bindings")
+	}
+
+	var roles []*v1alpha1.ClusterRole
+	seen := make(map[string]bool)
+	for _, binding := range bindings {
+		if seen[binding.RoleRef.Name] || !hasSubject(binding.Subjects, subject) {
+			continue
+		}
+		seen[binding.RoleRef.Name] = true
+
+		role, err := c.store.GetClusterRole(ctx, binding.RoleRef.Name)
+		if err != nil {
+			continue // Skip if cluster role not found
+		}
+		roles = append(roles, role)
+	}
+	return roles, nil
+}
+
+// ruleAllows reports whether rule grants verb on resource in apiGroup
+func ruleAllows(rule v1alpha1.PolicyRule, verb, resource, apiGroup string) bool {
+	// Check API Group
+	if !contains(rule.APIGroups, apiGroup) && !contains(rule.APIGroups, "*") {
+		return false
+	}
+
+	// Check Resource
+	if !contains(rule.Resources, resource) && !contains(rule.Resources, "*") {
+		return false
+	}
+
+	// Check Verb
+	return contains(rule.Verbs, verb) || contains(rule.Verbs, "*")
+}
+
+func hasSubject(subjects []v1alpha1.Subject, subject v1alpha1.Subject) bool {
+	for _, s := range subjects {
+		if s.Kind == subject.Kind && s.Name == subject.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// checkSubjectLimit rejects bindings with more than MaxSubjectsPerBinding subjects
+func (c *rbacController) checkSubjectLimit(count int) error {
+	return subjectLimitError(count, c.config.MaxSubjectsPerBinding)
+}
+
+// subjectLimitError rejects a binding with more than max subjects (max <= 0: 제한 없음)
+func subjectLimitError(count, max int) error {
+	if max <= 0 || count <= max {
+		return nil
+	}
+	return errors.ErrInvalidInput.WithReason(fmt.Sprintf(
+		"role binding has %d subjects, more than the maximum of %d; bind a Group subject for large memberships",
+		count, max))
+}
+
+// validateNewRoleBinding checks the name, role references, subjects and subject count of a binding to be created.
+// CreateUserWithBindings가 생성하는 바인딩도 같은 검증을 거침
+func validateNewRoleBinding(binding *v1alpha1.RoleBinding, names NamePolicy, maxSubjects int) error {
+	verr := &errors.ValidationError{}
+	if binding.Name == "" {
+		verr.Add("metadata.name", "role binding name is required")
+	} else if err := validateResourceName(binding.Name, names); err != nil {
+		verr.Add("metadata.name", err.Error())
+	}
+	addRoleRefErrors(verr, binding)
+	addSubjectErrors(verr, binding.Subjects)
+	if err := verr.OrNil(); err != nil {
+		return err
+	}
+	return subjectLimitError(len(binding.Subjects), maxSubjects)
+}
+
+// validateSubjects rejects empty subject lists, unknown kinds and empty names
+func validateSubjects(subjects []v1alpha1.Subject) error {
+	verr := &errors.ValidationError{}
+	addSubjectErrors(verr, subjects)
+	return verr.OrNil()
+}
+
+// addRuleErrors validates rules, normalizing verbs and resources to lower case in place.
+// CheckAccess는 대소문자를 구분하므로 저장 전에 정규화하여 매칭되지 않는 rule이 남지 않도록 함
+func (c *rbacController) addRuleErrors(verr *errors.ValidationError, rules []v1alpha1.PolicyRule) {
+	if len(rules) == 0 {
+		verr.Add("rules", "at least one rule is required")
+	}
+	// 각 rule의 유효성 검사
+	for i, rule := range rules {
+		if len(rule.Verbs) == 0 {
+			verr.Add(fmt.Sprintf("rules[%d].verbs", i), fmt.Sprintf("verbs are required in rule %d", i))
+		}
+		for j, verb := range rule.Verbs {
+			verb = normalizeRuleValue(verb)
+			rule.Verbs[j] = verb
+			if !c.config.AllowUnknownVerbs && !c.allowedVerbs[verb] {
+				verr.Add(fmt.Sprintf("rules[%d].verbs[%d]", i, j), fmt.Sprintf("unknown verb %q in rule %d", verb, i))
+			}
+		}
+		for j, resource := range rule.Resources {
+			rule.Resources[j] = normalizeRuleValue(resource)
+		}
+		if len(rule.Resources) == 0 {
+			verr.Add(fmt.Sprintf("rules[%d].resources", i), fmt.Sprintf("resources are required in rule %d", i))
+		}
+		if len(rule.APIGroups) == 0 {
+			verr.Add(fmt.Sprintf("rules[%d].apiGroups", i), fmt.Sprintf("apiGroups are required in rule %d", i))
+		}
+	}
+}
+
+// normalizeRuleValue trims and lower-cases a PolicyRule verb or resource
+func normalizeRuleValue(value string) string {
+	return strings.ToLower(strings.TrimSpace(value))
+}
+
+// addRoleRefErrors validates RoleRef and every entry of RoleRefs; 둘 중 하나에는 role이 지정되어야 함
+func addRoleRefErrors(verr *errors.ValidationError, binding *v1alpha1.RoleBinding) {
+	if binding.RoleRef.Name == "" && len(binding.RoleRefs) == 0 {
+		verr.Add("roleRef.name", "role reference name is required")
+	}
+	addRoleRefKindError(verr, "roleRef.kind", binding.RoleRef)
+	for i, ref := range binding.RoleRefs {
+		if ref.Name == "" {
+			verr.Add(fmt.Sprintf("roleRefs[%d].name", i), "role reference name is required")
+		}
+		addRoleRefKindError(verr, fmt.Sprintf("roleRefs[%d].kind", i), ref)
+	}
+}
+
+// normalizeRoleRefs defaults every reference kind to Role and fills an empty RoleRef with the
+// first of RoleRefs, so the role_ref column and older clients always see a role
+func normalizeRoleRefs(binding *v1alpha1.RoleBinding) {
+	if binding.RoleRef.Name == "" && len(binding.RoleRefs) > 0 {
+		binding.RoleRef = binding.RoleRefs[0]
+	}
+	binding.RoleRef.Kind = v1alpha1.RoleRefKindRole
+	for i := range binding.RoleRefs {
+		binding.RoleRefs[i].Kind = v1alpha1.RoleRefKindRole
+	}
+}
+
+// withoutRoleRef returns a copy of binding without its reference to role,
+// or nil when role was the only role the binding referenced
+func withoutRoleRef(binding *v1alpha1.RoleBinding, role string) *v1alpha1.RoleBinding {
+	var refs []v1alpha1.RoleRef
+	for _, ref := range binding.AllRoleRefs() {
+		if ref.Name != role {
+			refs = append(refs, ref)
+		}
+	}
+	if len(refs) == 0 {
+		return nil
+	}
+
+	out := binding.DeepCopy()
+	out.RoleRef = refs[0]
+	out.RoleRefs = nil
+	if len(refs) > 1 {
+		out.RoleRefs = refs[1:]
+	}
+	return out
+}
+
+// addRoleRefKindError rejects RoleBinding references to anything but a Role.
+// 권한 평가 시 RoleBinding은 Role로만 해석되므로 다른 kind를 허용하면 아무 권한도 주지 않는 바인딩이 생김
+func addRoleRefKindError(verr *errors.ValidationError, field string, ref v1alpha1.RoleRef) {
+	switch ref.Kind {
+	case "", v1alpha1.RoleRefKindRole:
+	case v1alpha1.RoleRefKindClusterRole:
+		verr.Add(field, "role bindings cannot reference a ClusterRole; use a cluster role binding")
+	default:
+		verr.Add(field, fmt.Sprintf("unsupported roleRef kind %q", ref.Kind))
+	}
+}
+
+func addSubjectErrors(verr *errors.ValidationError, subjects []v1alpha1.Subject) {
+	if len(subjects) == 0 {
+		verr.Add("subjects", "at least one subject is required")
+	}
+	for i, subject := range subjects {
+		switch subject.Kind {
+		case v1alpha1.SubjectKindUser, v1alpha1.SubjectKindGroup, v1alpha1.SubjectKindServiceAccount:
+		default:
+			verr.Add(fmt.Sprintf("subjects[%d].kind", i), fmt.Sprintf("unsupported kind %q in subject %d", subject.Kind, i))
+		}
+		if subject.Name == "" {
+			verr.Add(fmt.Sprintf("subjects[%d].name", i), fmt.Sprintf("name is required in subject %d", i))
+		}
+	}
 }
 
 // Helper function
@@ -2,13 +2,16 @@ package controllers
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/sukryu/pAuth/internal/store/cache"
 	"github.com/sukryu/pAuth/pkg/apis/auth/v1alpha1"
 	"github.com/sukryu/pAuth/pkg/errors"
 	"github.com/sukryu/pAuth/pkg/mocks"
+	"github.com/sukryu/pAuth/pkg/utils/namespace"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -124,6 +127,7 @@ func TestRBACController_CheckAccess(t *testing.T) {
 				}
 				ms.On("ListRoleBindings", mock.Anything).Return([]*v1alpha1.RoleBinding{binding}, nil)
 				ms.On("GetRole", mock.Anything, "reader").Return(role, nil)
+				ms.ExpectListClusterRoleBindings(nil, nil)
 			},
 			want:    false,
 			wantErr: "",
@@ -269,6 +273,7 @@ func TestRBACController_DeleteRole(t *testing.T) {
 				ms.On("GetRole", mock.Anything, "test-role").Return(&v1alpha1.Role{
 					ObjectMeta: metav1.ObjectMeta{Name: "test-role"},
 				}, nil)
+				ms.ExpectListRoles([]*v1alpha1.Role{{ObjectMeta: metav1.ObjectMeta{Name: "test-role"}}}, nil)
 				ms.On("ListRoleBindings", mock.Anything).Return([]*v1alpha1.RoleBinding{}, nil)
 				ms.On("DeleteRole", mock.Anything, "test-role").Return(nil)
 			},
@@ -281,6 +286,7 @@ func TestRBACController_DeleteRole(t *testing.T) {
 				ms.On("GetRole", mock.Anything, "test-role").Return(&v1alpha1.Role{
 					ObjectMeta: metav1.ObjectMeta{Name: "test-role"},
 				}, nil)
+				ms.ExpectListRoles([]*v1alpha1.Role{{ObjectMeta: metav1.ObjectMeta{Name: "test-role"}}}, nil)
 				ms.On("ListRoleBindings", mock.Anything).Return([]*v1alpha1.RoleBinding{
 					{
 						ObjectMeta: metav1.ObjectMeta{Name: "test-binding"},
@@ -290,6 +296,20 @@ func TestRBACController_DeleteRole(t *testing.T) {
 			},
 			wantErr: "status 400: invalid input: role test-role is still referenced by role binding test-binding",
 		},
+		{
+			name:     "role is inherited by another role",
+			roleName: "test-role",
+			setupMock: func(ms *mocks.MockStore) {
+				ms.On("GetRole", mock.Anything, "test-role").Return(&v1alpha1.Role{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-role"},
+				}, nil)
+				ms.ExpectListRoles([]*v1alpha1.Role{
+					{ObjectMeta: metav1.ObjectMeta{Name: "test-role"}},
+					{ObjectMeta: metav1.ObjectMeta{Name: "editor"}, Inherits: []string{"test-role"}},
+				}, nil)
+			},
+			wantErr: "status 400: invalid input: role test-role is inherited by role editor",
+		},
 		{
 			name:      "empty role name",
 			roleName:  "",
@@ -410,7 +430,7 @@ func TestRBACController_ListRoles(t *testing.T) {
 						}},
 					},
 				}
-				ms.On("ListRoles", mock.Anything).Return(roles, nil)
+				ms.On("ListRolesPaged", mock.Anything, 0, 0).Return(roles, len(roles), nil)
 			},
 			wantLen: 2,
 			wantErr: "",
@@ -418,7 +438,7 @@ func TestRBACController_ListRoles(t *testing.T) {
 		{
 			name: "empty list",
 			setupMock: func(ms *mocks.MockStore) {
-				ms.On("ListRoles", mock.Anything).Return([]*v1alpha1.Role{}, nil)
+				ms.On("ListRolesPaged", mock.Anything, 0, 0).Return([]*v1alpha1.Role{}, 0, nil)
 			},
 			wantLen: 0,
 			wantErr: "",
@@ -426,10 +446,10 @@ func TestRBACController_ListRoles(t *testing.T) {
 		{
 			name: "internal error",
 			setupMock: func(ms *mocks.MockStore) {
-				ms.On("ListRoles", mock.Anything).Return(nil, errors.ErrInternal)
+				ms.On("ListRolesPaged", mock.Anything, 0, 0).Return(nil, 0, errors.ErrInternal)
 			},
 			wantLen: 0,
-			wantErr: "status 500: internal server error: failed to list role bindings",
+			wantErr: "status 500: internal server error: failed to list roles",
 		},
 	}
 
@@ -633,7 +653,7 @@ func TestRBACController_ListRoleBindings(t *testing.T) {
 						},
 					},
 				}
-				ms.On("ListRoleBindings", mock.Anything).Return(bindings, nil)
+				ms.On("ListRoleBindingsPaged", mock.Anything, 0, 0).Return(bindings, len(bindings), nil)
 			},
 			wantLen: 2,
 			wantErr: "",
@@ -641,7 +661,7 @@ func TestRBACController_ListRoleBindings(t *testing.T) {
 		{
 			name: "empty list",
 			setupMock: func(ms *mocks.MockStore) {
-				ms.On("ListRoleBindings", mock.Anything).Return([]*v1alpha1.RoleBinding{}, nil)
+				ms.On("ListRoleBindingsPaged", mock.Anything, 0, 0).Return([]*v1alpha1.RoleBinding{}, 0, nil)
 			},
 			wantLen: 0,
 			wantErr: "",
@@ -649,7 +669,7 @@ func TestRBACController_ListRoleBindings(t *testing.T) {
 		{
 			name: "store error",
 			setupMock: func(ms *mocks.MockStore) {
-				ms.On("ListRoleBindings", mock.Anything).Return(nil, errors.ErrInternal)
+				ms.On("ListRoleBindingsPaged", mock.Anything, 0, 0).Return(nil, 0, errors.ErrInternal)
 			},
 			wantLen: 0,
 			wantErr: "status 500: internal server error: failed to list role bindings",
@@ -692,6 +712,8 @@ func TestRBACController_DeleteRoleBinding(t *testing.T) {
 				ms.On("GetRoleBinding", mock.Anything, "test-binding").Return(&v1alpha1.RoleBinding{
 					ObjectMeta: metav1.ObjectMeta{Name: "test-binding"},
 				}, nil)
+				ms.ExpectListRoleBindings([]*v1alpha1.RoleBinding{}, nil)
+				ms.ExpectListClusterRoleBindings([]*v1alpha1.ClusterRoleBinding{}, nil)
 				ms.On("DeleteRoleBinding", mock.Anything, "test-binding").Return(nil)
 			},
 			wantErr: "",
@@ -717,6 +739,8 @@ func TestRBACController_DeleteRoleBinding(t *testing.T) {
 				ms.On("GetRoleBinding", mock.Anything, "test-binding").Return(&v1alpha1.RoleBinding{
 					ObjectMeta: metav1.ObjectMeta{Name: "test-binding"},
 				}, nil)
+				ms.ExpectListRoleBindings([]*v1alpha1.RoleBinding{}, nil)
+				ms.ExpectListClusterRoleBindings([]*v1alpha1.ClusterRoleBinding{}, nil)
 				ms.On("DeleteRoleBinding", mock.Anything, "test-binding").Return(errors.ErrInternal)
 			},
 			wantErr: "status 500: internal server error: failed to list role bindings",
@@ -741,3 +765,1224 @@ func TestRBACController_DeleteRoleBinding(t *testing.T) {
 		})
 	}
 }
+
+func TestRBACController_ListRolesPaged(t *testing.T) {
+	roles := []*v1alpha1.Role{
+		{ObjectMeta: metav1.ObjectMeta{Name: "role-c"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "role-d"}},
+	}
+
+	t.Run("returns page and total", func(t *testing.T) {
+		mockStore := mocks.NewMockStore()
+		mockStore.On("ListRolesPaged", mock.Anything, 2, 2).Return(roles, 5, nil)
+
+		controller := NewRBACController(mockStore)
+		result, total, err := controller.ListRolesPaged(context.Background(), 2, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, 5, total)
+		assert.Equal(t, roles, result)
+		mockStore.AssertExpectations(t)
+	})
+
+	t.Run("negative offset", func(t *testing.T) {
+		mockStore := mocks.NewMockStore()
+
+		controller := NewRBACController(mockStore)
+		_, _, err := controller.ListRolesPaged(context.Background(), 2, -1)
+		assert.Error(t, err)
+		mockStore.AssertNotCalled(t, "ListRolesPaged", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("store error", func(t *testing.T) {
+		mockStore := mocks.NewMockStore()
+		mockStore.On("ListRolesPaged", mock.Anything, 2, 0).Return(nil, 0, errors.ErrInternal)
+
+		controller := NewRBACController(mockStore)
+		result, _, err := controller.ListRolesPaged(context.Background(), 2, 0)
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+}
+
+func TestRBACController_CreateRoleDryRun(t *testing.T) {
+	t.Run("dry run skips store write", func(t *testing.T) {
+		mockStore := mocks.NewMockStore()
+
+		controller := NewRBACController(mockStore)
+		role := &v1alpha1.Role{
+			ObjectMeta: metav1.ObjectMeta{Name: "reader"},
+			Rules: []v1alpha1.PolicyRule{{
+				Verbs:     []string{"get"},
+				Resources: []string{"users"},
+				APIGroups: []string{"auth.service"},
+			}},
+		}
+		err := controller.CreateRole(WithDryRun(context.Background()), role)
+		assert.NoError(t, err)
+		mockStore.AssertNotCalled(t, "CreateRole", mock.Anything, mock.Anything)
+	})
+
+	t.Run("dry run still catches invalid rules", func(t *testing.T) {
+		mockStore := mocks.NewMockStore()
+
+		controller := NewRBACController(mockStore)
+		role := &v1alpha1.Role{
+			ObjectMeta: metav1.ObjectMeta{Name: "broken"},
+			Rules: []v1alpha1.PolicyRule{{
+				Resources: []string{"users"},
+				APIGroups: []string{"auth.service"},
+			}},
+		}
+		err := controller.CreateRole(WithDryRun(context.Background()), role)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "verbs are required in rule 0")
+		mockStore.AssertNotCalled(t, "CreateRole", mock.Anything, mock.Anything)
+	})
+}
+
+func TestRBACController_CreateRoleBindingSubjects(t *testing.T) {
+	tests := []struct {
+		name     string
+		subjects []v1alpha1.Subject
+		wantMsg  string
+	}{
+		{
+			name:     "empty subject name",
+			subjects: []v1alpha1.Subject{{Kind: "User", Name: ""}},
+			wantMsg:  "name is required in subject 0",
+		},
+		{
+			name:     "unknown subject kind",
+			subjects: []v1alpha1.Subject{{Kind: "User", Name: "alice"}, {Kind: "Robot", Name: "r2d2"}},
+			wantMsg:  `unsupported kind "Robot" in subject 1`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockStore := mocks.NewMockStore()
+
+			controller := NewRBACController(mockStore)
+			err := controller.CreateRoleBinding(context.Background(), &v1alpha1.RoleBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-binding"},
+				RoleRef:    v1alpha1.RoleRef{Kind: "Role", Name: "reader"},
+				Subjects:   tt.subjects,
+			})
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantMsg)
+			mockStore.AssertNotCalled(t, "CreateRoleBinding", mock.Anything, mock.Anything)
+		})
+	}
+
+	t.Run("all supported kinds", func(t *testing.T) {
+		assert.NoError(t, validateSubjects([]v1alpha1.Subject{
+			{Kind: "User", Name: "alice"},
+			{Kind: "Group", Name: "admins"},
+			{Kind: "ServiceAccount", Name: "ci"},
+		}))
+	})
+}
+
+func TestRBACController_ValidationAccumulatesErrors(t *testing.T) {
+	t.Run("role", func(t *testing.T) {
+		controller := NewRBACController(mocks.NewMockStore())
+		err := controller.CreateRole(context.Background(), &v1alpha1.Role{
+			Rules: []v1alpha1.PolicyRule{{APIGroups: []string{"*"}}},
+		})
+
+		verr, ok := err.(*errors.ValidationError)
+		if assert.True(t, ok, "expected ValidationError, got %T", err) {
+			assert.Equal(t, []errors.FieldError{
+				{Field: "metadata.name", Message: "role name is required"},
+				{Field: "rules[0].verbs", Message: "verbs are required in rule 0"},
+				{Field: "rules[0].resources", Message: "resources are required in rule 0"},
+			}, verr.Errors)
+		}
+	})
+
+	t.Run("role binding", func(t *testing.T) {
+		controller := NewRBACController(mocks.NewMockStore())
+		err := controller.CreateRoleBinding(context.Background(), &v1alpha1.RoleBinding{
+			Subjects: []v1alpha1.Subject{{Kind: "Robot"}},
+		})
+
+		verr, ok := err.(*errors.ValidationError)
+		if assert.True(t, ok, "expected ValidationError, got %T", err) {
+			fields := make([]string, 0, len(verr.Errors))
+			for _, fe := range verr.Errors {
+				fields = append(fields, fe.Field)
+			}
+			assert.Equal(t, []string{"metadata.name", "roleRef.name", "subjects[0].kind", "subjects[0].name"}, fields)
+		}
+	})
+}
+
+func TestRBACController_UpdateRole(t *testing.T) {
+	existing := func() *v1alpha1.Role {
+		return &v1alpha1.Role{
+			ObjectMeta: metav1.ObjectMeta{Name: "reader", Namespace: "default"},
+			Rules:      []v1alpha1.PolicyRule{{Verbs: []string{"get"}, Resources: []string{"users"}, APIGroups: []string{"auth.service"}}},
+			Inherits:   []string{"base"},
+		}
+	}
+
+	t.Run("identical role performs no store write", func(t *testing.T) {
+		mockStore := mocks.NewMockStore()
+		controller := NewRBACController(mockStore)
+		stored := existing()
+		mockStore.ExpectGetRole("reader", stored, nil)
+
+		got, err := controller.UpdateRole(context.Background(), existing())
+		assert.NoError(t, err)
+		assert.Same(t, stored, got)
+		mockStore.AssertNotCalled(t, "UpdateRole", mock.Anything, mock.Anything)
+	})
+
+	t.Run("changed rules are written", func(t *testing.T) {
+		mockStore := mocks.NewMockStore()
+		controller := NewRBACController(mockStore)
+		mockStore.ExpectGetRole("reader", existing(), nil)
+		mockStore.ExpectGetRole("base", &v1alpha1.Role{ObjectMeta: metav1.ObjectMeta{Name: "base"}}, nil)
+		mockStore.ExpectListRoleBindings(nil, nil)
+		mockStore.ExpectListClusterRoleBindings(nil, nil)
+
+		updated := existing()
+		updated.Namespace = ""
+		updated.Rules[0].Verbs = []string{"get", "list"}
+		mockStore.ExpectUpdateRole(updated, nil)
+
+		got, err := controller.UpdateRole(context.Background(), updated)
+		assert.NoError(t, err)
+		assert.Equal(t, "default", got.Namespace)
+		mockStore.AssertExpectations(t)
+	})
+
+	t.Run("clearing inherited roles is a change", func(t *testing.T) {
+		mockStore := mocks.NewMockStore()
+		controller := NewRBACController(mockStore)
+		mockStore.ExpectGetRole("reader", existing(), nil)
+		mockStore.ExpectListRoleBindings(nil, nil)
+		mockStore.ExpectListClusterRoleBindings(nil, nil)
+
+		updated := existing()
+		updated.Inherits = nil
+		mockStore.ExpectUpdateRole(updated, nil)
+
+		_, err := controller.UpdateRole(context.Background(), updated)
+		assert.NoError(t, err)
+		mockStore.AssertExpectations(t)
+	})
+
+	t.Run("narrowing the last admin role", func(t *testing.T) {
+		mockStore := mocks.NewMockStore()
+		controller := NewRBACController(mockStore)
+		admin := &v1alpha1.Role{
+			ObjectMeta: metav1.ObjectMeta{Name: "admin"},
+			Rules:      []v1alpha1.PolicyRule{{Verbs: []string{"*"}, Resources: []string{"*"}, APIGroups: []string{"*"}}},
+		}
+		mockStore.ExpectGetRole("admin", admin, nil)
+		mockStore.ExpectListRoleBindings([]*v1alpha1.RoleBinding{{
+			ObjectMeta: metav1.ObjectMeta{Name: "admins"},
+			RoleRef:    v1alpha1.RoleRef{Kind: "Role", Name: "admin"},
+			Subjects:   []v1alpha1.Subject{{Kind: "User", Name: "alice"}},
+		}}, nil)
+		mockStore.ExpectListClusterRoleBindings(nil, nil)
+
+		_, err := controller.UpdateRole(context.Background(), &v1alpha1.Role{
+			ObjectMeta: metav1.ObjectMeta{Name: "admin"},
+			Rules:      []v1alpha1.PolicyRule{{Verbs: []string{"get"}, Resources: []string{"*"}, APIGroups: []string{"*"}}},
+		})
+		assert.ErrorIs(t, err, errors.ErrLastAdmin)
+		mockStore.AssertNotCalled(t, "UpdateRole", mock.Anything, mock.Anything)
+	})
+
+	t.Run("invalid rules", func(t *testing.T) {
+		mockStore := mocks.NewMockStore()
+		controller := NewRBACController(mockStore)
+
+		_, err := controller.UpdateRole(context.Background(), &v1alpha1.Role{
+			ObjectMeta: metav1.ObjectMeta{Name: "reader"},
+			Rules:      []v1alpha1.PolicyRule{{}},
+		})
+		assert.Error(t, err)
+		mockStore.AssertNotCalled(t, "GetRole", mock.Anything, mock.Anything)
+	})
+}
+
+func TestRBACController_UpdateRoleBinding(t *testing.T) {
+	reader := &v1alpha1.Role{ObjectMeta: metav1.ObjectMeta{Name: "reader"}}
+
+	t.Run("create then update subjects", func(t *testing.T) {
+		mockStore := mocks.NewMockStore()
+		controller := NewRBACController(mockStore)
+
+		binding := &v1alpha1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "reader-binding"},
+			RoleRef:    v1alpha1.RoleRef{Kind: "Role", Name: "reader"},
+			Subjects:   []v1alpha1.Subject{{Kind: "User", Name: "alice"}},
+		}
+		mockStore.ExpectGetRole("reader", reader, nil)
+		mockStore.ExpectCreateRoleBinding(binding, nil)
+		assert.NoError(t, controller.CreateRoleBinding(context.Background(), binding))
+
+		updated := &v1alpha1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "reader-binding"},
+			RoleRef:    v1alpha1.RoleRef{Kind: "Role", Name: "reader"},
+			Subjects: []v1alpha1.Subject{
+				{Kind: "User", Name: "alice"},
+				{Kind: "Group", Name: "auditors"},
+			},
+		}
+		mockStore.ExpectGetRoleBinding("reader-binding", binding, nil)
+		mockStore.ExpectListRoleBindings([]*v1alpha1.RoleBinding{binding}, nil)
+		mockStore.ExpectListClusterRoleBindings(nil, nil)
+		mockStore.ExpectUpdateRoleBinding(updated, nil)
+		assert.NoError(t, controller.UpdateRoleBinding(context.Background(), updated))
+		mockStore.AssertExpectations(t)
+	})
+
+	t.Run("update that removes the last admin", func(t *testing.T) {
+		mockStore := mocks.NewMockStore()
+		controller := NewRBACController(mockStore)
+
+		admin := &v1alpha1.Role{
+			ObjectMeta: metav1.ObjectMeta{Name: "admin"},
+			Rules:      []v1alpha1.PolicyRule{{Verbs: []string{"*"}, Resources: []string{"*"}, APIGroups: []string{"*"}}},
+		}
+		existing := &v1alpha1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "admins"},
+			RoleRef:    v1alpha1.RoleRef{Kind: "Role", Name: "admin"},
+			Subjects:   []v1alpha1.Subject{{Kind: "User", Name: "alice"}},
+		}
+		mockStore.ExpectGetRoleBinding("admins", existing, nil)
+		mockStore.ExpectGetRole("admin", admin, nil)
+		mockStore.ExpectGetRole("reader", reader, nil)
+		mockStore.ExpectListRoleBindings([]*v1alpha1.RoleBinding{existing}, nil)
+		mockStore.ExpectListClusterRoleBindings(nil, nil)
+
+		// admin Role을 reader로 바꾸면 alice가 마지막 admin 권한을 잃음
+		err := controller.UpdateRoleBinding(context.Background(), &v1alpha1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "admins"},
+			RoleRef:    v1alpha1.RoleRef{Kind: "Role", Name: "reader"},
+			Subjects:   []v1alpha1.Subject{{Kind: "User", Name: "alice"}},
+		})
+		assert.ErrorIs(t, err, errors.ErrLastAdmin)
+		mockStore.AssertNotCalled(t, "UpdateRoleBinding", mock.Anything, mock.Anything)
+	})
+
+	t.Run("role ref no longer exists", func(t *testing.T) {
+		mockStore := mocks.NewMockStore()
+		controller := NewRBACController(mockStore)
+
+		mockStore.ExpectGetRoleBinding("reader-binding", &v1alpha1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "reader-binding"},
+		}, nil)
+		mockStore.ExpectGetRole("deleted", nil, errors.ErrRoleNotFound)
+
+		err := controller.UpdateRoleBinding(context.Background(), &v1alpha1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "reader-binding"},
+			RoleRef:    v1alpha1.RoleRef{Kind: "Role", Name: "deleted"},
+			Subjects:   []v1alpha1.Subject{{Kind: "User", Name: "alice"}},
+		})
+		assert.ErrorIs(t, err, errors.ErrRoleNotFound)
+		mockStore.AssertNotCalled(t, "UpdateRoleBinding", mock.Anything, mock.Anything)
+	})
+
+	t.Run("binding not found", func(t *testing.T) {
+		mockStore := mocks.NewMockStore()
+		controller := NewRBACController(mockStore)
+
+		mockStore.ExpectGetRoleBinding("missing", nil, errors.ErrRoleBindingNotFound)
+
+		err := controller.UpdateRoleBinding(context.Background(), &v1alpha1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "missing"},
+			RoleRef:    v1alpha1.RoleRef{Kind: "Role", Name: "reader"},
+			Subjects:   []v1alpha1.Subject{{Kind: "User", Name: "alice"}},
+		})
+		assert.ErrorIs(t, err, errors.ErrRoleBindingNotFound)
+		mockStore.AssertNotCalled(t, "UpdateRoleBinding", mock.Anything, mock.Anything)
+	})
+
+	t.Run("invalid subjects", func(t *testing.T) {
+		mockStore := mocks.NewMockStore()
+		controller := NewRBACController(mockStore)
+
+		err := controller.UpdateRoleBinding(context.Background(), &v1alpha1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "reader-binding"},
+			RoleRef:    v1alpha1.RoleRef{Kind: "Role", Name: "reader"},
+			Subjects:   []v1alpha1.Subject{{Kind: "Robot", Name: "r2d2"}},
+		})
+		_, ok := err.(*errors.ValidationError)
+		assert.True(t, ok, "expected ValidationError, got %T", err)
+		mockStore.AssertNotCalled(t, "GetRoleBinding", mock.Anything, mock.Anything)
+	})
+}
+
+func TestRBACController_CheckAccessBatch(t *testing.T) {
+	mockStore := mocks.NewMockStore()
+	mockStore.ExpectListRoleBindings([]*v1alpha1.RoleBinding{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "b1"},
+			RoleRef:    v1alpha1.RoleRef{Kind: "Role", Name: "reader"},
+			Subjects:   []v1alpha1.Subject{{Kind: v1alpha1.SubjectKindUser, Name: "alice"}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "b2"},
+			RoleRef:    v1alpha1.RoleRef{Kind: "Role", Name: "reader"},
+			Subjects:   []v1alpha1.Subject{{Kind: v1alpha1.SubjectKindUser, Name: "alice"}},
+		},
+	}, nil)
+	mockStore.ExpectGetRole("reader", &v1alpha1.Role{
+		ObjectMeta: metav1.ObjectMeta{Name: "reader"},
+		Rules: []v1alpha1.PolicyRule{{
+			Verbs:     []string{"get", "list"},
+			Resources: []string{"*"},
+			APIGroups: []string{"auth.service"},
+		}},
+	}, nil)
+	mockStore.ExpectListClusterRoleBindings(nil, nil)
+
+	controller := NewRBACController(mockStore)
+	results, err := controller.CheckAccessBatch(context.Background(),
+		v1alpha1.Subject{Kind: v1alpha1.SubjectKindUser, Name: "alice"},
+		[]v1alpha1.AccessCheck{
+			{Verb: "get", Resource: "roles", APIGroup: "auth.service"},
+			{Verb: "delete", Resource: "roles", APIGroup: "auth.service"},
+			{Verb: "list", Resource: "users", APIGroup: "other.service"},
+		})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []bool{true, false, false}, []bool{results[0].Allowed, results[1].Allowed, results[2].Allowed})
+	// 동일한 Role은 한 번만 조회
+	mockStore.AssertNumberOfCalls(t, "GetRole", 1)
+}
+
+func TestRBACController_CheckAccessBatchClusterScoped(t *testing.T) {
+	alice := v1alpha1.Subject{Kind: v1alpha1.SubjectKindUser, Name: "alice"}
+	mockStore := mocks.NewMockStore()
+	mockStore.ExpectListRoleBindings([]*v1alpha1.RoleBinding{{
+		ObjectMeta: metav1.ObjectMeta{Name: "tenant-admin"},
+		RoleRef:    v1alpha1.RoleRef{Kind: "Role", Name: "tenant-admin"},
+		Subjects:   []v1alpha1.Subject{alice},
+	}}, nil)
+	mockStore.ExpectGetRole("tenant-admin", &v1alpha1.Role{
+		ObjectMeta: metav1.ObjectMeta{Name: "tenant-admin"},
+		Rules: []v1alpha1.PolicyRule{{
+			Verbs:     []string{"*"},
+			Resources: []string{"users", "clusterroles"},
+			APIGroups: []string{"auth.service"},
+		}},
+	}, nil)
+	mockStore.ExpectListClusterRoleBindings([]*v1alpha1.ClusterRoleBinding{{
+		ObjectMeta: metav1.ObjectMeta{Name: "binding-viewer"},
+		RoleRef:    v1alpha1.RoleRef{Kind: v1alpha1.RoleRefKindClusterRole, Name: "binding-viewer"},
+		Subjects:   []v1alpha1.Subject{alice},
+	}}, nil)
+	mockStore.ExpectGetClusterRole("binding-viewer", &v1alpha1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{Name: "binding-viewer"},
+		Rules: []v1alpha1.PolicyRule{{
+			Verbs:     []string{"get"},
+			Resources: []string{"clusterrolebindings"},
+			APIGroups: []string{"auth.service"},
+		}},
+	}, nil)
+
+	results, err := NewRBACController(mockStore).CheckAccessBatch(context.Background(), alice, []v1alpha1.AccessCheck{
+		{Verb: "create", Resource: "users", APIGroup: "auth.service"},
+		{Verb: "create", Resource: "clusterroles", APIGroup: "auth.service"},
+		{Verb: "get", Resource: "clusterrolebindings", APIGroup: "auth.service"},
+	})
+
+	// namespace Role은 cluster-scoped 리소스에 대한 권한을 부여하지 않음 (middleware와 같은 판정)
+	assert.NoError(t, err)
+	assert.Equal(t, []bool{true, false, true}, []bool{results[0].Allowed, results[1].Allowed, results[2].Allowed})
+}
+
+func TestRBACController_NamespaceIsolation(t *testing.T) {
+	inNamespace := func(ns string) interface{} {
+		return mock.MatchedBy(func(ctx context.Context) bool { return namespace.FromContext(ctx) == ns })
+	}
+
+	ms := mocks.NewMockStore()
+	// tenant-b에만 alice에 대한 바인딩이 존재
+	ms.On("ListRoleBindings", inNamespace("tenant-b")).Return([]*v1alpha1.RoleBinding{{
+		ObjectMeta: metav1.ObjectMeta{Name: "reader-binding", Namespace: "tenant-b"},
+		RoleRef:    v1alpha1.RoleRef{Kind: "Role", Name: "reader"},
+		Subjects:   []v1alpha1.Subject{{Kind: v1alpha1.SubjectKindUser, Name: "alice"}},
+	}}, nil)
+	ms.On("ListRoleBindings", inNamespace("tenant-a")).Return([]*v1alpha1.RoleBinding{}, nil)
+	ms.ExpectListClusterRoleBindings(nil, nil)
+	ms.ExpectGetRole("reader", &v1alpha1.Role{
+		ObjectMeta: metav1.ObjectMeta{Name: "reader", Namespace: "tenant-b"},
+		Rules: []v1alpha1.PolicyRule{{
+			Verbs:     []string{"get"},
+			Resources: []string{"users"},
+			APIGroups: []string{"auth.service"},
+		}},
+	}, nil)
+	controller := NewRBACController(ms)
+
+	t.Run("binding in another namespace does not grant access", func(t *testing.T) {
+		user := &v1alpha1.User{ObjectMeta: metav1.ObjectMeta{Name: "alice", Namespace: "tenant-a"}}
+		allowed, err := controller.CheckAccess(context.Background(), user, "get", "users", "auth.service")
+		assert.NoError(t, err)
+		assert.False(t, allowed)
+	})
+
+	t.Run("binding in the user's namespace grants access", func(t *testing.T) {
+		user := &v1alpha1.User{ObjectMeta: metav1.ObjectMeta{Name: "alice", Namespace: "tenant-b"}}
+		allowed, err := controller.CheckAccess(context.Background(), user, "get", "users", "auth.service")
+		assert.NoError(t, err)
+		assert.True(t, allowed)
+	})
+
+	t.Run("cannot create objects in another namespace", func(t *testing.T) {
+		ctx := namespace.WithNamespace(context.Background(), "tenant-a")
+		err := controller.CreateRole(ctx, &v1alpha1.Role{
+			ObjectMeta: metav1.ObjectMeta{Name: "writer", Namespace: "tenant-b"},
+			Rules: []v1alpha1.PolicyRule{{
+				Verbs:     []string{"update"},
+				Resources: []string{"users"},
+				APIGroups: []string{"auth.service"},
+			}},
+		})
+		assert.Error(t, err)
+		ms.AssertNotCalled(t, "CreateRole", mock.Anything, mock.Anything)
+	})
+}
+
+func TestRBACController_ClusterRoleBindings(t *testing.T) {
+	inNamespace := func(ns string) interface{} {
+		return mock.MatchedBy(func(ctx context.Context) bool { return namespace.FromContext(ctx) == ns })
+	}
+	reader := []v1alpha1.PolicyRule{{
+		Verbs:     []string{"get"},
+		Resources: []string{"users"},
+		APIGroups: []string{"auth.service"},
+	}}
+
+	ms := mocks.NewMockStore()
+	// bob은 tenant-a의 RoleBinding만, alice는 ClusterRoleBinding을 가짐
+	ms.On("ListRoleBindings", inNamespace("tenant-a")).Return([]*v1alpha1.RoleBinding{{
+		ObjectMeta: metav1.ObjectMeta{Name: "bob-reader", Namespace: "tenant-a"},
+		RoleRef:    v1alpha1.RoleRef{Kind: "Role", Name: "reader"},
+		Subjects:   []v1alpha1.Subject{{Kind: v1alpha1.SubjectKindUser, Name: "bob"}},
+	}}, nil)
+	ms.On("ListRoleBindings", inNamespace("tenant-b")).Return([]*v1alpha1.RoleBinding{}, nil)
+	ms.ExpectGetRole("reader", &v1alpha1.Role{ObjectMeta: metav1.ObjectMeta{Name: "reader"}, Rules: reader}, nil)
+	ms.ExpectListClusterRoleBindings([]*v1alpha1.ClusterRoleBinding{{
+		ObjectMeta: metav1.ObjectMeta{Name: "alice-cluster-reader"},
+		RoleRef:    v1alpha1.RoleRef{Kind: v1alpha1.RoleRefKindClusterRole, Name: "cluster-reader"},
+		Subjects:   []v1alpha1.Subject{{Kind: v1alpha1.SubjectKindUser, Name: "alice"}},
+	}}, nil)
+	ms.ExpectGetClusterRole("cluster-reader", &v1alpha1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "cluster-reader"}, Rules: reader}, nil)
+	controller := NewRBACController(ms)
+
+	tests := []struct {
+		user      string
+		namespace string
+		want      bool
+	}{
+		{"alice", "tenant-a", true},
+		{"alice", "tenant-b", true},
+		{"bob", "tenant-a", true},
+		{"bob", "tenant-b", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.user+"/"+tt.namespace, func(t *testing.T) {
+			user := &v1alpha1.User{ObjectMeta: metav1.ObjectMeta{Name: tt.user, Namespace: tt.namespace}}
+			allowed, err := controller.CheckAccess(context.Background(), user, "get", "users", "auth.service")
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, allowed)
+		})
+	}
+
+	t.Run("batch includes cluster rules", func(t *testing.T) {
+		ctx := namespace.WithNamespace(context.Background(), "tenant-b")
+		results, err := controller.CheckAccessBatch(ctx, v1alpha1.Subject{Kind: v1alpha1.SubjectKindUser, Name: "alice"},
+			[]v1alpha1.AccessCheck{{Verb: "get", Resource: "users", APIGroup: "auth.service"}})
+		assert.NoError(t, err)
+		assert.True(t, results[0].Allowed)
+	})
+}
+
+func TestRBACController_CreateClusterRoleBinding(t *testing.T) {
+	ms := mocks.NewMockStore()
+	ms.ExpectGetClusterRole("cluster-reader", &v1alpha1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "cluster-reader"}}, nil)
+	ms.ExpectGetClusterRole("missing", nil, errors.ErrClusterRoleNotFound)
+	ms.On("CreateClusterRoleBinding", mock.Anything, mock.Anything).Return(nil)
+	controller := NewRBACController(ms)
+
+	binding := &v1alpha1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "alice-cluster-reader"},
+		RoleRef:    v1alpha1.RoleRef{Name: "cluster-reader"},
+		Subjects:   []v1alpha1.Subject{{Kind: v1alpha1.SubjectKindUser, Name: "alice"}},
+	}
+	assert.NoError(t, controller.CreateClusterRoleBinding(context.Background(), binding))
+	assert.Equal(t, v1alpha1.RoleRefKindClusterRole, binding.RoleRef.Kind)
+
+	err := controller.CreateClusterRoleBinding(context.Background(), &v1alpha1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "bad"},
+		RoleRef:    v1alpha1.RoleRef{Kind: "Role", Name: "cluster-reader"},
+		Subjects:   []v1alpha1.Subject{{Kind: v1alpha1.SubjectKindUser, Name: "alice"}},
+	})
+	var verr *errors.ValidationError
+	assert.ErrorAs(t, err, &verr)
+
+	err = controller.CreateClusterRoleBinding(context.Background(), &v1alpha1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "dangling"},
+		RoleRef:    v1alpha1.RoleRef{Name: "missing"},
+		Subjects:   []v1alpha1.Subject{{Kind: v1alpha1.SubjectKindUser, Name: "alice"}},
+	})
+	assert.ErrorIs(t, err, errors.ErrClusterRoleNotFound)
+	ms.AssertNumberOfCalls(t, "CreateClusterRoleBinding", 1)
+}
+
+func TestRBACController_DeleteLastAdminBinding(t *testing.T) {
+	adminRole := &v1alpha1.Role{
+		ObjectMeta: metav1.ObjectMeta{Name: "admin"},
+		Rules:      []v1alpha1.PolicyRule{{Verbs: []string{"*"}, Resources: []string{"*"}, APIGroups: []string{"*"}}},
+	}
+	adminBinding := func(name, user string) *v1alpha1.RoleBinding {
+		return &v1alpha1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			RoleRef:    v1alpha1.RoleRef{Kind: "Role", Name: "admin"},
+			Subjects:   []v1alpha1.Subject{{Kind: v1alpha1.SubjectKindUser, Name: user}},
+		}
+	}
+	newStore := func(bindings ...*v1alpha1.RoleBinding) *mocks.MockStore {
+		ms := mocks.NewMockStore()
+		ms.ExpectGetRoleBinding("alice-admin", bindings[0], nil)
+		ms.ExpectListRoleBindings(bindings, nil)
+		ms.ExpectListClusterRoleBindings([]*v1alpha1.ClusterRoleBinding{}, nil)
+		ms.ExpectGetRole("admin", adminRole, nil)
+		return ms
+	}
+
+	t.Run("sole admin binding is rejected", func(t *testing.T) {
+		ms := newStore(adminBinding("alice-admin", "alice"))
+		err := NewRBACController(ms).DeleteRoleBinding(context.Background(), "alice-admin")
+
+		assert.ErrorIs(t, err, errors.ErrLastAdmin)
+		ms.AssertNotCalled(t, "DeleteRoleBinding", mock.Anything, mock.Anything)
+	})
+
+	t.Run("one of two admins can be removed", func(t *testing.T) {
+		ms := newStore(adminBinding("alice-admin", "alice"), adminBinding("bob-admin", "bob"))
+		ms.ExpectDeleteRoleBinding("alice-admin", nil)
+
+		assert.NoError(t, NewRBACController(ms).DeleteRoleBinding(context.Background(), "alice-admin"))
+		ms.AssertExpectations(t)
+	})
+
+	t.Run("guard can be disabled for recovery", func(t *testing.T) {
+		ms := mocks.NewMockStore()
+		ms.ExpectGetRoleBinding("alice-admin", adminBinding("alice-admin", "alice"), nil)
+		ms.ExpectDeleteRoleBinding("alice-admin", nil)
+
+		controller := NewRBACControllerWithConfig(ms, RBACControllerConfig{AllowRemovingLastAdmin: true})
+		assert.NoError(t, controller.DeleteRoleBinding(context.Background(), "alice-admin"))
+		ms.AssertNotCalled(t, "ListRoleBindings", mock.Anything)
+	})
+}
+
+func TestRBACController_GetRolesForUser(t *testing.T) {
+	reader := &v1alpha1.Role{ObjectMeta: metav1.ObjectMeta{Name: "reader"}}
+	writer := &v1alpha1.Role{ObjectMeta: metav1.ObjectMeta{Name: "writer"}}
+	alice := v1alpha1.Subject{Kind: v1alpha1.SubjectKindUser, Name: "alice"}
+	binding := func(name, role string, subjects ...v1alpha1.Subject) *v1alpha1.RoleBinding {
+		return &v1alpha1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			RoleRef:    v1alpha1.RoleRef{Kind: "Role", Name: role},
+			Subjects:   subjects,
+		}
+	}
+
+	ms := mocks.NewMockStore()
+	ms.ExpectListRoleBindings([]*v1alpha1.RoleBinding{
+		binding("alice-reader", "reader", alice),
+		binding("team-writer", "writer", alice, v1alpha1.Subject{Kind: v1alpha1.SubjectKindUser, Name: "bob"}),
+		binding("alice-reader-again", "reader", alice),
+		binding("alice-stale", "deleted", alice),
+		binding("bob-admin", "admin", v1alpha1.Subject{Kind: v1alpha1.SubjectKindUser, Name: "bob"}),
+	}, nil)
+	ms.ExpectGetRole("reader", reader, nil)
+	ms.ExpectGetRole("writer", writer, nil)
+	ms.ExpectGetRole("deleted", nil, errors.ErrRoleNotFound)
+	controller := NewRBACController(ms)
+
+	roles, err := controller.GetRolesForUser(context.Background(), "alice")
+	assert.NoError(t, err)
+	assert.Equal(t, []*v1alpha1.Role{reader, writer}, roles)
+	ms.AssertNumberOfCalls(t, "GetRole", 3)
+	ms.AssertNotCalled(t, "GetRole", mock.Anything, "admin")
+
+	roles, err = controller.GetRolesForUser(context.Background(), "carol")
+	assert.NoError(t, err)
+	assert.Empty(t, roles)
+	assert.NotNil(t, roles)
+
+	_, err = controller.GetRolesForUser(context.Background(), "")
+	assert.ErrorIs(t, err, errors.ErrInvalidInput)
+}
+
+// transactionalStore counts InTransaction calls; 롤백 자체는 실제 store 구현의 책임
+type transactionalStore struct {
+	*mocks.MockStore
+	transactions int
+}
+
+func (s *transactionalStore) InTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	s.transactions++
+	return fn(ctx)
+}
+
+func TestRBACController_ForceDeleteRole(t *testing.T) {
+	reader := &v1alpha1.Role{
+		ObjectMeta: metav1.ObjectMeta{Name: "reader"},
+		Rules: []v1alpha1.PolicyRule{{
+			Verbs:     []string{"get"},
+			Resources: []string{"users"},
+			APIGroups: []string{"auth.service"},
+		}},
+	}
+	bindings := []*v1alpha1.RoleBinding{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "reader-alice"},
+			Subjects:   []v1alpha1.Subject{{Kind: v1alpha1.SubjectKindUser, Name: "alice"}},
+			RoleRef:    v1alpha1.RoleRef{Kind: "Role", Name: "reader"},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "reader-bob"},
+			Subjects:   []v1alpha1.Subject{{Kind: v1alpha1.SubjectKindUser, Name: "bob"}},
+			RoleRef:    v1alpha1.RoleRef{Kind: "Role", Name: "reader"},
+		},
+	}
+	newStore := func() *transactionalStore {
+		ms := mocks.NewMockStore()
+		ms.ExpectGetRole("reader", reader, nil)
+		ms.ExpectListRoles([]*v1alpha1.Role{reader}, nil)
+		ms.ExpectListRoleBindings(bindings, nil)
+		ms.ExpectListClusterRoleBindings([]*v1alpha1.ClusterRoleBinding{}, nil)
+		return &transactionalStore{MockStore: ms}
+	}
+
+	t.Run("force keeps a role inherited by another role", func(t *testing.T) {
+		ms := mocks.NewMockStore()
+		ms.ExpectGetRole("reader", reader, nil)
+		ms.ExpectListRoles([]*v1alpha1.Role{reader, {ObjectMeta: metav1.ObjectMeta{Name: "editor"}, Inherits: []string{"reader"}}}, nil)
+		store := &transactionalStore{MockStore: ms}
+
+		err := NewRBACController(store).DeleteRole(WithCascadeDelete(context.Background()), "reader")
+
+		assert.ErrorIs(t, err, errors.ErrInvalidInput)
+		assert.Zero(t, store.transactions)
+		store.AssertNotCalled(t, "DeleteRoleBinding", mock.Anything, mock.Anything)
+		store.AssertNotCalled(t, "DeleteRole", mock.Anything, mock.Anything)
+	})
+
+	t.Run("without force the referenced role is kept", func(t *testing.T) {
+		store := newStore()
+		err := NewRBACController(store).DeleteRole(context.Background(), "reader")
+
+		assert.ErrorIs(t, err, errors.ErrInvalidInput)
+		store.AssertNotCalled(t, "DeleteRoleBinding", mock.Anything, mock.Anything)
+		store.AssertNotCalled(t, "DeleteRole", mock.Anything, mock.Anything)
+	})
+
+	t.Run("force removes bindings and role in one transaction", func(t *testing.T) {
+		store := newStore()
+		store.ExpectDeleteRoleBinding("reader-alice", nil)
+		store.ExpectDeleteRoleBinding("reader-bob", nil)
+		store.ExpectDeleteRole("reader", nil)
+
+		err := NewRBACController(store).DeleteRole(WithCascadeDelete(context.Background()), "reader")
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, store.transactions)
+		store.AssertExpectations(t)
+	})
+
+	t.Run("force fails as a whole when the role cannot be deleted", func(t *testing.T) {
+		store := newStore()
+		store.ExpectDeleteRoleBinding("reader-alice", nil)
+		store.ExpectDeleteRoleBinding("reader-bob", nil)
+		store.ExpectDeleteRole("reader", errors.ErrInternal)
+
+		err := NewRBACController(store).DeleteRole(WithCascadeDelete(context.Background()), "reader")
+
+		// 트랜잭션 안에서 실패하므로 store가 바인딩 삭제를 롤백함
+		assert.ErrorIs(t, err, errors.ErrInternal)
+		assert.Equal(t, 1, store.transactions)
+	})
+
+	t.Run("without transactions the role is deleted last", func(t *testing.T) {
+		ms := newStore().MockStore
+		ms.ExpectDeleteRoleBinding("reader-alice", nil)
+		ms.ExpectDeleteRoleBinding("reader-bob", errors.ErrInternal)
+
+		err := NewRBACController(ms).DeleteRole(WithCascadeDelete(context.Background()), "reader")
+
+		// Role이 남아 있으므로 같은 요청을 재시도하면 나머지 바인딩과 함께 삭제됨
+		assert.ErrorIs(t, err, errors.ErrInternal)
+		ms.AssertNotCalled(t, "DeleteRole", mock.Anything, mock.Anything)
+	})
+}
+
+func TestRBACController_CreateRoleVerbValidation(t *testing.T) {
+	newRole := func(verbs ...string) *v1alpha1.Role {
+		return &v1alpha1.Role{
+			ObjectMeta: metav1.ObjectMeta{Name: "reader"},
+			Rules: []v1alpha1.PolicyRule{{
+				Verbs:     verbs,
+				Resources: []string{"Users"},
+				APIGroups: []string{"auth.service"},
+			}},
+		}
+	}
+
+	t.Run("unknown verb is rejected in strict mode", func(t *testing.T) {
+		ms := mocks.NewMockStore()
+		err := NewRBACController(ms).CreateRole(context.Background(), newRole("get", "gett"))
+
+		var verr *errors.ValidationError
+		if assert.ErrorAs(t, err, &verr) {
+			assert.Contains(t, err.Error(), `unknown verb "gett"`)
+		}
+		ms.AssertNotCalled(t, "CreateRole", mock.Anything, mock.Anything)
+	})
+
+	t.Run("verbs and resources are normalized", func(t *testing.T) {
+		ms := mocks.NewMockStore()
+		role := newRole(" GET ", "List")
+		ms.ExpectCreateRole(role, nil)
+
+		assert.NoError(t, NewRBACController(ms).CreateRole(context.Background(), role))
+		assert.Equal(t, []string{"get", "list"}, role.Rules[0].Verbs)
+		assert.Equal(t, []string{"users"}, role.Rules[0].Resources)
+	})
+
+	t.Run("wildcard is always allowed", func(t *testing.T) {
+		ms := mocks.NewMockStore()
+		role := newRole("*")
+		ms.ExpectCreateRole(role, nil)
+
+		controller := NewRBACControllerWithConfig(ms, RBACControllerConfig{AllowedVerbs: []string{"get"}})
+		assert.NoError(t, controller.CreateRole(context.Background(), role))
+	})
+
+	t.Run("unknown verb is accepted when strict mode is off", func(t *testing.T) {
+		ms := mocks.NewMockStore()
+		role := newRole("Impersonate")
+		ms.ExpectCreateRole(role, nil)
+
+		controller := NewRBACControllerWithConfig(ms, RBACControllerConfig{AllowUnknownVerbs: true})
+		assert.NoError(t, controller.CreateRole(context.Background(), role))
+		assert.Equal(t, []string{"impersonate"}, role.Rules[0].Verbs)
+	})
+}
+
+func TestRBACController_CreateRoleBindingRoleRefKind(t *testing.T) {
+	newBinding := func(kind string) *v1alpha1.RoleBinding {
+		return &v1alpha1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "alice-reader"},
+			Subjects:   []v1alpha1.Subject{{Kind: v1alpha1.SubjectKindUser, Name: "alice"}},
+			RoleRef:    v1alpha1.RoleRef{Kind: kind, Name: "reader"},
+		}
+	}
+
+	t.Run("unsupported kind is rejected", func(t *testing.T) {
+		ms := mocks.NewMockStore()
+		err := NewRBACController(ms).CreateRoleBinding(context.Background(), newBinding("ClusterRole"))
+
+		var verr *errors.ValidationError
+		assert.ErrorAs(t, err, &verr)
+		ms.AssertNotCalled(t, "CreateRoleBinding", mock.Anything, mock.Anything)
+	})
+
+	t.Run("existing role of a valid kind is bound", func(t *testing.T) {
+		ms := mocks.NewMockStore()
+		ms.ExpectGetRole("reader", &v1alpha1.Role{ObjectMeta: metav1.ObjectMeta{Name: "reader"}}, nil)
+		binding := newBinding("")
+		ms.ExpectCreateRoleBinding(binding, nil)
+
+		assert.NoError(t, NewRBACController(ms).CreateRoleBinding(context.Background(), binding))
+		assert.Equal(t, v1alpha1.RoleRefKindRole, binding.RoleRef.Kind)
+		ms.AssertExpectations(t)
+	})
+
+	t.Run("missing role is rejected", func(t *testing.T) {
+		ms := mocks.NewMockStore()
+		ms.ExpectGetRole("reader", nil, errors.ErrRoleNotFound)
+
+		err := NewRBACController(ms).CreateRoleBinding(context.Background(), newBinding("Role"))
+		assert.ErrorIs(t, err, errors.ErrRoleNotFound)
+	})
+}
+
+func TestRBACController_MaxSubjectsPerBinding(t *testing.T) {
+	newController := func(ms *mocks.MockStore) RBACController {
+		return NewRBACControllerWithConfig(ms, RBACControllerConfig{MaxSubjectsPerBinding: 2})
+	}
+	existing := func() *v1alpha1.RoleBinding {
+		return &v1alpha1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "readers"},
+			Subjects:   []v1alpha1.Subject{{Kind: v1alpha1.SubjectKindUser, Name: "alice"}},
+			RoleRef:    v1alpha1.RoleRef{Kind: v1alpha1.RoleRefKindRole, Name: "reader"},
+		}
+	}
+
+	t.Run("adding within the limit succeeds", func(t *testing.T) {
+		ms := mocks.NewMockStore()
+		binding := existing()
+		ms.ExpectGetRoleBinding("readers", binding, nil)
+		ms.ExpectUpdateRoleBinding(binding, nil)
+
+		err := newController(ms).AddRoleBindingSubject(context.Background(), "readers", v1alpha1.Subject{Kind: v1alpha1.SubjectKindUser, Name: "bob"})
+		assert.NoError(t, err)
+		assert.Len(t, binding.Subjects, 2)
+		ms.AssertExpectations(t)
+	})
+
+	t.Run("adding beyond the limit is rejected", func(t *testing.T) {
+		ms := mocks.NewMockStore()
+		binding := existing()
+		binding.Subjects = append(binding.Subjects, v1alpha1.Subject{Kind: v1alpha1.SubjectKindUser, Name: "bob"})
+		ms.ExpectGetRoleBinding("readers", binding, nil)
+
+		err := newController(ms).AddRoleBindingSubject(context.Background(), "readers", v1alpha1.Subject{Kind: v1alpha1.SubjectKindUser, Name: "carol"})
+		assert.ErrorIs(t, err, errors.ErrInvalidInput)
+		ms.AssertNotCalled(t, "UpdateRoleBinding", mock.Anything, mock.Anything)
+	})
+
+	t.Run("creating with too many subjects is rejected", func(t *testing.T) {
+		ms := mocks.NewMockStore()
+		binding := existing()
+		binding.Subjects = append(binding.Subjects,
+			v1alpha1.Subject{Kind: v1alpha1.SubjectKindUser, Name: "bob"},
+			v1alpha1.Subject{Kind: v1alpha1.SubjectKindUser, Name: "carol"})
+
+		err := newController(ms).CreateRoleBinding(context.Background(), binding)
+		assert.ErrorIs(t, err, errors.ErrInvalidInput)
+		ms.AssertNotCalled(t, "CreateRoleBinding", mock.Anything, mock.Anything)
+	})
+}
+
+func TestRBACController_ResourceNames(t *testing.T) {
+	rules := []v1alpha1.PolicyRule{{APIGroups: []string{"*"}, Resources: []string{"users"}, Verbs: []string{"get"}}}
+	binding := func(name string) *v1alpha1.RoleBinding {
+		return &v1alpha1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Subjects:   []v1alpha1.Subject{{Kind: v1alpha1.SubjectKindUser, Name: "alice"}},
+			RoleRef:    v1alpha1.RoleRef{Kind: v1alpha1.RoleRefKindRole, Name: "reader"},
+		}
+	}
+	assertNameError := func(t *testing.T, err error) {
+		var verr *errors.ValidationError
+		if assert.ErrorAs(t, err, &verr) && assert.NotEmpty(t, verr.Errors) {
+			assert.Equal(t, "metadata.name", verr.Errors[0].Field)
+		}
+	}
+
+	t.Run("invalid names are rejected", func(t *testing.T) {
+		ms := mocks.NewMockStore()
+		controller := NewRBACController(ms)
+
+		for _, name := range []string{strings.Repeat("r", 64), "", "reader\n", "read er"} {
+			assertNameError(t, controller.CreateRole(context.Background(), &v1alpha1.Role{ObjectMeta: metav1.ObjectMeta{Name: name}, Rules: rules}))
+			assertNameError(t, controller.CreateRoleBinding(context.Background(), binding(name)))
+		}
+		ms.AssertNotCalled(t, "CreateRole", mock.Anything, mock.Anything)
+		ms.AssertNotCalled(t, "CreateRoleBinding", mock.Anything, mock.Anything)
+	})
+
+	t.Run("existing bindings with legacy names can be updated", func(t *testing.T) {
+		ms := mocks.NewMockStore()
+		controller := NewRBACControllerWithConfig(ms, RBACControllerConfig{AllowRemovingLastAdmin: true})
+
+		legacy := binding("Legacy_Binding")
+		ms.ExpectGetRoleBinding("Legacy_Binding", legacy, nil)
+		ms.ExpectGetRole("reader", &v1alpha1.Role{ObjectMeta: metav1.ObjectMeta{Name: "reader"}}, nil)
+		ms.ExpectUpdateRoleBinding(legacy, nil)
+		assert.NoError(t, controller.UpdateRoleBinding(context.Background(), legacy))
+		ms.AssertExpectations(t)
+	})
+
+	t.Run("normal names are accepted", func(t *testing.T) {
+		ms := mocks.NewMockStore()
+		ms.On("CreateRole", mock.Anything, mock.Anything).Return(nil)
+
+		err := NewRBACController(ms).CreateRole(context.Background(), &v1alpha1.Role{ObjectMeta: metav1.ObjectMeta{Name: "notes-editor-2"}, Rules: rules})
+		assert.NoError(t, err)
+		ms.AssertExpectations(t)
+	})
+
+	t.Run("configured max length", func(t *testing.T) {
+		ms := mocks.NewMockStore()
+		ms.On("CreateRole", mock.Anything, mock.Anything).Return(nil)
+		controller := NewRBACControllerWithConfig(ms, RBACControllerConfig{Names: NamePolicy{MaxLength: 100}})
+
+		err := controller.CreateRole(context.Background(), &v1alpha1.Role{ObjectMeta: metav1.ObjectMeta{Name: strings.Repeat("r", 100)}, Rules: rules})
+		assert.NoError(t, err)
+		err = controller.CreateRole(context.Background(), &v1alpha1.Role{ObjectMeta: metav1.ObjectMeta{Name: strings.Repeat("r", 101)}, Rules: rules})
+		assertNameError(t, err)
+	})
+}
+
+func TestRBACController_RoleRefs(t *testing.T) {
+	reader := &v1alpha1.Role{
+		ObjectMeta: metav1.ObjectMeta{Name: "reader"},
+		Rules: []v1alpha1.PolicyRule{{
+			Verbs:     []string{"get", "list"},
+			Resources: []string{"users"},
+			APIGroups: []string{"auth.service"},
+		}},
+	}
+	writer := &v1alpha1.Role{
+		ObjectMeta: metav1.ObjectMeta{Name: "writer"},
+		Rules: []v1alpha1.PolicyRule{{
+			Verbs:     []string{"update"},
+			Resources: []string{"roles"},
+			APIGroups: []string{"auth.service"},
+		}},
+	}
+	newBinding := func() *v1alpha1.RoleBinding {
+		return &v1alpha1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "alice-roles"},
+			Subjects:   []v1alpha1.Subject{{Kind: v1alpha1.SubjectKindUser, Name: "alice"}},
+			RoleRefs: []v1alpha1.RoleRef{
+				{Kind: v1alpha1.RoleRefKindRole, Name: "reader"},
+				{Name: "writer"},
+			},
+		}
+	}
+
+	t.Run("binding grants the union of its roles", func(t *testing.T) {
+		ms := mocks.NewMockStore()
+		ms.ExpectListRoleBindings([]*v1alpha1.RoleBinding{newBinding()}, nil)
+		ms.ExpectGetRole("reader", reader, nil)
+		ms.ExpectGetRole("writer", writer, nil)
+		ms.ExpectListClusterRoleBindings(nil, nil)
+		controller := NewRBACController(ms)
+		user := &v1alpha1.User{ObjectMeta: metav1.ObjectMeta{Name: "alice"}}
+
+		allowed, err := controller.CheckAccess(context.Background(), user, "list", "users", "auth.service")
+		assert.NoError(t, err)
+		assert.True(t, allowed)
+
+		allowed, err = controller.CheckAccess(context.Background(), user, "update", "roles", "auth.service")
+		assert.NoError(t, err)
+		assert.True(t, allowed)
+
+		allowed, err = controller.CheckAccess(context.Background(), user, "delete", "users", "auth.service")
+		assert.NoError(t, err)
+		assert.False(t, allowed)
+
+		roles, err := controller.GetRolesForUser(context.Background(), "alice")
+		assert.NoError(t, err)
+		assert.Len(t, roles, 2)
+	})
+
+	t.Run("create checks every referenced role", func(t *testing.T) {
+		ms := mocks.NewMockStore()
+		ms.ExpectGetRole("reader", reader, nil)
+		ms.ExpectGetRole("writer", nil, errors.ErrRoleNotFound)
+
+		err := NewRBACController(ms).CreateRoleBinding(context.Background(), newBinding())
+		assert.ErrorIs(t, err, errors.ErrRoleNotFound)
+		ms.AssertNotCalled(t, "CreateRoleBinding", mock.Anything, mock.Anything)
+	})
+
+	t.Run("create fills roleRef from roleRefs", func(t *testing.T) {
+		ms := mocks.NewMockStore()
+		ms.ExpectGetRole("reader", reader, nil)
+		ms.ExpectGetRole("writer", writer, nil)
+		binding := newBinding()
+		ms.ExpectCreateRoleBinding(binding, nil)
+
+		assert.NoError(t, NewRBACController(ms).CreateRoleBinding(context.Background(), binding))
+		assert.Equal(t, v1alpha1.RoleRef{Kind: v1alpha1.RoleRefKindRole, Name: "reader"}, binding.RoleRef)
+		assert.Equal(t, v1alpha1.RoleRefKindRole, binding.RoleRefs[1].Kind)
+		ms.AssertExpectations(t)
+	})
+
+	t.Run("invalid entries are reported by index", func(t *testing.T) {
+		ms := mocks.NewMockStore()
+		binding := newBinding()
+		binding.RoleRefs = append(binding.RoleRefs, v1alpha1.RoleRef{Kind: v1alpha1.RoleRefKindClusterRole})
+
+		err := NewRBACController(ms).CreateRoleBinding(context.Background(), binding)
+		var verr *errors.ValidationError
+		assert.ErrorAs(t, err, &verr)
+		assert.Len(t, verr.Errors, 2)
+		assert.Equal(t, "roleRefs[2].name", verr.Errors[0].Field)
+		assert.Equal(t, "roleRefs[2].kind", verr.Errors[1].Field)
+	})
+
+	t.Run("force delete keeps the binding's other roles", func(t *testing.T) {
+		ms := mocks.NewMockStore()
+		binding := newBinding()
+		binding.RoleRef = binding.RoleRefs[0]
+		ms.ExpectGetRole("reader", reader, nil)
+		ms.ExpectGetRole("writer", writer, nil)
+		ms.ExpectListRoles([]*v1alpha1.Role{reader, writer}, nil)
+		ms.ExpectListRoleBindings([]*v1alpha1.RoleBinding{binding}, nil)
+		ms.ExpectListClusterRoleBindings(nil, nil)
+		ms.On("UpdateRoleBinding", mock.Anything, mock.MatchedBy(func(b *v1alpha1.RoleBinding) bool {
+			return b.RoleRef.Name == "writer" && len(b.RoleRefs) == 0
+		})).Return(nil)
+		ms.ExpectDeleteRole("reader", nil)
+		store := &transactionalStore{MockStore: ms}
+
+		err := NewRBACController(store).DeleteRole(WithCascadeDelete(context.Background()), "reader")
+		assert.NoError(t, err)
+		store.AssertExpectations(t)
+		store.AssertNotCalled(t, "DeleteRoleBinding", mock.Anything, mock.Anything)
+	})
+}
+
+func TestRBACController_RoleInheritance(t *testing.T) {
+	viewer := &v1alpha1.Role{
+		ObjectMeta: metav1.ObjectMeta{Name: "viewer"},
+		Rules: []v1alpha1.PolicyRule{{
+			Verbs:     []string{"get", "list"},
+			Resources: []string{"users"},
+			APIGroups: []string{"auth.service"},
+		}},
+	}
+	editor := &v1alpha1.Role{
+		ObjectMeta: metav1.ObjectMeta{Name: "editor"},
+		Rules: []v1alpha1.PolicyRule{{
+			Verbs:     []string{"update"},
+			Resources: []string{"users"},
+			APIGroups: []string{"auth.service"},
+		}},
+		Inherits: []string{"viewer"},
+	}
+
+	t.Run("inheriting role grants the parent's verbs", func(t *testing.T) {
+		ms := mocks.NewMockStore()
+		ms.ExpectListRoleBindings([]*v1alpha1.RoleBinding{{
+			ObjectMeta: metav1.ObjectMeta{Name: "alice-editor"},
+			Subjects:   []v1alpha1.Subject{{Kind: v1alpha1.SubjectKindUser, Name: "alice"}},
+			RoleRef:    v1alpha1.RoleRef{Kind: v1alpha1.RoleRefKindRole, Name: "editor"},
+		}}, nil)
+		ms.ExpectGetRole("editor", editor, nil)
+		ms.ExpectGetRole("viewer", viewer, nil)
+		ms.ExpectListClusterRoleBindings(nil, nil)
+		controller := NewRBACController(ms)
+		alice := v1alpha1.Subject{Kind: v1alpha1.SubjectKindUser, Name: "alice"}
+
+		allowed, err := controller.CheckSubjectAccess(context.Background(), alice, "list", "users", "auth.service")
+		assert.NoError(t, err)
+		assert.True(t, allowed)
+
+		allowed, err = controller.CheckSubjectAccess(context.Background(), alice, "delete", "users", "auth.service")
+		assert.NoError(t, err)
+		assert.False(t, allowed)
+
+		perms, err := controller.ListEffectivePermissions(context.Background(), alice)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"editor", "viewer"}, perms.Roles)
+		assert.Len(t, perms.Rules, 2)
+	})
+
+	t.Run("create checks inherited roles exist", func(t *testing.T) {
+		ms := mocks.NewMockStore()
+		ms.ExpectGetRole("viewer", nil, errors.ErrRoleNotFound)
+
+		err := NewRBACController(ms).CreateRole(context.Background(), editor.DeepCopy())
+		assert.ErrorIs(t, err, errors.ErrRoleNotFound)
+		ms.AssertNotCalled(t, "CreateRole", mock.Anything, mock.Anything)
+	})
+
+	t.Run("self inheritance is rejected", func(t *testing.T) {
+		ms := mocks.NewMockStore()
+		role := viewer.DeepCopy()
+		role.Inherits = []string{"viewer"}
+
+		err := NewRBACController(ms).CreateRole(context.Background(), role)
+		assert.ErrorIs(t, err, errors.ErrInvalidInput)
+		ms.AssertNotCalled(t, "CreateRole", mock.Anything, mock.Anything)
+	})
+
+	t.Run("cyclic inheritance is rejected", func(t *testing.T) {
+		// viewer가 삭제 후 editor를 상속하도록 다시 만들어지는 경우: viewer -> editor -> viewer
+		ms := mocks.NewMockStore()
+		ms.ExpectGetRole("editor", editor, nil)
+		role := viewer.DeepCopy()
+		role.Inherits = []string{"editor"}
+
+		err := NewRBACController(ms).CreateRole(context.Background(), role)
+		assert.ErrorIs(t, err, errors.ErrInvalidInput)
+		assert.Contains(t, err.Error(), "viewer -> editor -> viewer")
+		ms.AssertNotCalled(t, "CreateRole", mock.Anything, mock.Anything)
+	})
+
+	t.Run("stored cycles do not loop during evaluation", func(t *testing.T) {
+		cyclicViewer := viewer.DeepCopy()
+		cyclicViewer.Inherits = []string{"editor"}
+		ms := mocks.NewMockStore()
+		ms.ExpectListRoleBindings([]*v1alpha1.RoleBinding{{
+			ObjectMeta: metav1.ObjectMeta{Name: "alice-editor"},
+			Subjects:   []v1alpha1.Subject{{Kind: v1alpha1.SubjectKindUser, Name: "alice"}},
+			RoleRef:    v1alpha1.RoleRef{Kind: v1alpha1.RoleRefKindRole, Name: "editor"},
+		}}, nil)
+		ms.ExpectGetRole("editor", editor, nil)
+		ms.ExpectGetRole("viewer", cyclicViewer, nil)
+		ms.ExpectListClusterRoleBindings(nil, nil)
+
+		allowed, err := NewRBACController(ms).CheckSubjectAccess(context.Background(),
+			v1alpha1.Subject{Kind: v1alpha1.SubjectKindUser, Name: "alice"}, "get", "users", "auth.service")
+		assert.NoError(t, err)
+		assert.True(t, allowed)
+	})
+}
+
+func TestRBACController_AccessCache(t *testing.T) {
+	reader := &v1alpha1.Role{
+		ObjectMeta: metav1.ObjectMeta{Name: "reader"},
+		Rules:      []v1alpha1.PolicyRule{{Verbs: []string{"get"}, Resources: []string{"users"}, APIGroups: []string{"auth.service"}}},
+	}
+	binding := &v1alpha1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "alice-reader"},
+		RoleRef:    v1alpha1.RoleRef{Kind: "Role", Name: "reader"},
+		Subjects:   []v1alpha1.Subject{{Kind: v1alpha1.SubjectKindUser, Name: "alice"}},
+	}
+	alice := v1alpha1.Subject{Kind: v1alpha1.SubjectKindUser, Name: "alice"}
+
+	mockStore := mocks.NewMockStore()
+	mockStore.ExpectListRoleBindings([]*v1alpha1.RoleBinding{binding}, nil)
+	mockStore.ExpectListClusterRoleBindings(nil, nil)
+	mockStore.ExpectGetRole("reader", reader, nil)
+	controller := NewRBACControllerWithConfig(mockStore, RBACControllerConfig{AccessCache: cache.NewMemoryCache()})
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		allowed, err := controller.CheckSubjectAccess(ctx, alice, "get", "users", "auth.service")
+		assert.NoError(t, err)
+		assert.True(t, allowed)
+		denied, err := controller.CheckSubjectAccess(ctx, alice, "delete", "users", "auth.service")
+		assert.NoError(t, err)
+		assert.False(t, denied)
+	}
+	// 같은 판정은 저장소를 다시 조회하지 않음
+	mockStore.AssertNumberOfCalls(t, "ListRoleBindings", 2)
+
+	// namespace가 다르면 별도로 평가
+	_, err := controller.CheckSubjectAccess(namespace.WithNamespace(ctx, "tenant-a"), alice, "get", "users", "auth.service")
+	assert.NoError(t, err)
+	mockStore.AssertNumberOfCalls(t, "ListRoleBindings", 3)
+
+	// 바인딩 변경은 캐시된 판정을 무효화
+	created := &v1alpha1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "bob-reader"},
+		RoleRef:    v1alpha1.RoleRef{Kind: "Role", Name: "reader"},
+		Subjects:   []v1alpha1.Subject{{Kind: v1alpha1.SubjectKindUser, Name: "bob"}},
+	}
+	mockStore.ExpectCreateRoleBinding(created, nil)
+	assert.NoError(t, controller.CreateRoleBinding(ctx, created))
+	_, err = controller.CheckSubjectAccess(ctx, alice, "get", "users", "auth.service")
+	assert.NoError(t, err)
+	mockStore.AssertNumberOfCalls(t, "ListRoleBindings", 4)
+}
@@ -0,0 +1,87 @@
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/sukryu/pAuth/pkg/apis/auth/v1alpha1"
+	"github.com/sukryu/pAuth/pkg/errors"
+	"github.com/sukryu/pAuth/pkg/utils/jwt"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ServiceAccountController manages non-interactive identities and their tokens
+type ServiceAccountController interface {
+	CreateServiceAccount(ctx context.Context, name string) (*v1alpha1.ServiceAccount, error)
+	GetServiceAccount(ctx context.Context, name string) (*v1alpha1.ServiceAccount, error)
+	IssueServiceAccountToken(ctx context.Context, name string, ttl time.Duration) (string, error)
+}
+
+type serviceAccountController struct {
+	store      Store
+	jwtManager *jwt.JWTManager
+}
+
+func NewServiceAccountController(store Store, jwtManager *jwt.JWTManager) ServiceAccountController {
+	return &serviceAccountController{
+		store:      store,
+		jwtManager: jwtManager,
+	}
+}
+
+func (c *serviceAccountController) CreateServiceAccount(ctx context.Context, name string) (*v1alpha1.ServiceAccount, error) {
+	if name == "" {
+		return nil, errors.ErrInvalidInput.WithReason("service account name is required")
+	}
+	if err := validateResourceName(name, NamePolicy{}); err != nil {
+		return nil, errors.ErrInvalidInput.WithReason(err.Error())
+	}
+
+	if _, err := c.store.GetServiceAccount(ctx, name); err == nil {
+		return nil, errors.ErrAlreadyExists.WithReason("service account already exists")
+	}
+
+	sa := &v1alpha1.ServiceAccount{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "auth.service/v1alpha1",
+			Kind:       "ServiceAccount",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			CreationTimestamp: metav1.Now(),
+		},
+	}
+
+	if err := c.store.CreateServiceAccount(ctx, sa); err != nil {
+		return nil, err
+	}
+
+	return sa, nil
+}
+
+func (c *serviceAccountController) GetServiceAccount(ctx context.Context, name string) (*v1alpha1.ServiceAccount, error) {
+	if name == "" {
+		return nil, errors.ErrInvalidInput.WithReason("service account name is required")
+	}
+
+	return c.store.GetServiceAccount(ctx, name)
+}
+
+// IssueServiceAccountToken mints a JWT whose sub is the service account name
+func (c *serviceAccountController) IssueServiceAccountToken(ctx context.Context, name string, ttl time.Duration) (string, error) {
+	if ttl < 0 {
+		return "", errors.ErrInvalidInput.WithReason("ttl must not be negative")
+	}
+
+	sa, err := c.GetServiceAccount(ctx, name)
+	if err != nil {
+		return "", err
+	}
+
+	token, err := c.jwtManager.GenerateServiceAccountToken(sa.Name, ttl)
+	if err != nil {
+		return "", errors.ErrInternal.WithReason("failed to generate token")
+	}
+
+	return token, nil
+}
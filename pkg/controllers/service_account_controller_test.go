@@ -0,0 +1,113 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/sukryu/pAuth/pkg/apis/auth/v1alpha1"
+	"github.com/sukryu/pAuth/pkg/errors"
+	"github.com/sukryu/pAuth/pkg/mocks"
+	"github.com/sukryu/pAuth/pkg/utils/jwt"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestServiceAccountController_CreateServiceAccount(t *testing.T) {
+	t.Run("successful creation", func(t *testing.T) {
+		mockStore := mocks.NewMockStore()
+		mockStore.On("GetServiceAccount", mock.Anything, "ci-bot").Return(nil, errors.ErrNotFound)
+		mockStore.On("CreateServiceAccount", mock.Anything, mock.MatchedBy(func(sa *v1alpha1.ServiceAccount) bool {
+			return sa.Name == "ci-bot" && sa.Kind == "ServiceAccount"
+		})).Return(nil)
+
+		controller := NewServiceAccountController(mockStore, jwt.NewJWTManager("test-secret", time.Hour))
+		sa, err := controller.CreateServiceAccount(context.Background(), "ci-bot")
+		assert.NoError(t, err)
+		assert.Equal(t, "ci-bot", sa.Name)
+		mockStore.AssertExpectations(t)
+	})
+
+	t.Run("already exists", func(t *testing.T) {
+		mockStore := mocks.NewMockStore()
+		mockStore.On("GetServiceAccount", mock.Anything, "ci-bot").Return(&v1alpha1.ServiceAccount{
+			ObjectMeta: metav1.ObjectMeta{Name: "ci-bot"},
+		}, nil)
+
+		controller := NewServiceAccountController(mockStore, jwt.NewJWTManager("test-secret", time.Hour))
+		_, err := controller.CreateServiceAccount(context.Background(), "ci-bot")
+		assert.Error(t, err)
+		mockStore.AssertNotCalled(t, "CreateServiceAccount", mock.Anything, mock.Anything)
+	})
+
+	t.Run("empty name", func(t *testing.T) {
+		controller := NewServiceAccountController(mocks.NewMockStore(), jwt.NewJWTManager("test-secret", time.Hour))
+		_, err := controller.CreateServiceAccount(context.Background(), "")
+		assert.Error(t, err)
+	})
+}
+
+func TestServiceAccountController_IssueToken(t *testing.T) {
+	jwtManager := jwt.NewJWTManager("test-secret", time.Hour)
+	sa := &v1alpha1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "ci-bot"}}
+
+	mockStore := mocks.NewMockStore()
+	mockStore.On("GetServiceAccount", mock.Anything, "ci-bot").Return(sa, nil)
+	mockStore.On("GetServiceAccount", mock.Anything, "ghost").Return(nil, errors.ErrNotFound)
+	mockStore.On("ListRoleBindings", mock.Anything).Return([]*v1alpha1.RoleBinding{{
+		ObjectMeta: metav1.ObjectMeta{Name: "ci-reader"},
+		RoleRef:    v1alpha1.RoleRef{Kind: "Role", Name: "reader"},
+		Subjects:   []v1alpha1.Subject{{Kind: "ServiceAccount", Name: "ci-bot"}},
+	}}, nil)
+	mockStore.On("GetRole", mock.Anything, "reader").Return(&v1alpha1.Role{
+		ObjectMeta: metav1.ObjectMeta{Name: "reader"},
+		Rules: []v1alpha1.PolicyRule{{
+			Verbs:     []string{"get", "list"},
+			Resources: []string{"users"},
+			APIGroups: []string{"auth.service"},
+		}},
+	}, nil)
+	mockStore.ExpectListClusterRoleBindings(nil, nil)
+
+	controller := NewServiceAccountController(mockStore, jwtManager)
+	rbac := NewRBACController(mockStore)
+
+	t.Run("token carries service account claims", func(t *testing.T) {
+		token, err := controller.IssueServiceAccountToken(context.Background(), "ci-bot", 10*time.Minute)
+		assert.NoError(t, err)
+
+		claims, err := jwtManager.ValidateToken(token)
+		assert.NoError(t, err)
+		assert.Equal(t, "ci-bot", claims.Subject)
+		assert.Equal(t, jwt.TokenTypeServiceAccount, claims.Type)
+		assert.Empty(t, claims.UserID)
+		assert.WithinDuration(t, time.Now().Add(10*time.Minute), claims.ExpiresAt.Time, 5*time.Second)
+
+		subject := v1alpha1.Subject{Kind: v1alpha1.SubjectKindServiceAccount, Name: claims.Subject}
+		allowed, err := rbac.CheckSubjectAccess(context.Background(), subject, "get", "users", "auth.service")
+		assert.NoError(t, err)
+		assert.True(t, allowed)
+
+		allowed, err = rbac.CheckSubjectAccess(context.Background(), subject, "delete", "users", "auth.service")
+		assert.NoError(t, err)
+		assert.False(t, allowed)
+	})
+
+	t.Run("user with the same name does not inherit the binding", func(t *testing.T) {
+		user := &v1alpha1.User{ObjectMeta: metav1.ObjectMeta{Name: "ci-bot"}}
+		allowed, err := rbac.CheckAccess(context.Background(), user, "get", "users", "auth.service")
+		assert.NoError(t, err)
+		assert.False(t, allowed)
+	})
+
+	t.Run("unknown service account", func(t *testing.T) {
+		_, err := controller.IssueServiceAccountToken(context.Background(), "ghost", time.Minute)
+		assert.Error(t, err)
+
+		allowed, err := rbac.CheckSubjectAccess(context.Background(),
+			v1alpha1.Subject{Kind: v1alpha1.SubjectKindServiceAccount, Name: "ghost"}, "get", "users", "auth.service")
+		assert.NoError(t, err)
+		assert.False(t, allowed)
+	})
+}
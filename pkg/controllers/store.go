@@ -13,6 +13,11 @@ type Store interface {
 	UpdateUser(ctx context.Context, user *v1alpha1.User) error
 	DeleteUser(ctx context.Context, name string) error
 	ListUsers(ctx context.Context) (*v1alpha1.UserList, error)
+	ListUsersWithOptions(ctx context.Context, opts v1alpha1.ListOptions) (*v1alpha1.UserList, error)
+	FindUserByEmail(ctx context.Context, email string) (*v1alpha1.User, error)
+	FindUserByEmailChangeToken(ctx context.Context, tokenHash string) (*v1alpha1.User, error)
+	// RenameUser changes the user's name; username도 이전 이름과 같았다면 함께 변경됨
+	RenameUser(ctx context.Context, oldName, newName string) error
 
 	// Role operations
 	CreateRole(ctx context.Context, role *v1alpha1.Role) error
@@ -20,6 +25,8 @@ type Store interface {
 	UpdateRole(ctx context.Context, role *v1alpha1.Role) error
 	DeleteRole(ctx context.Context, name string) error
 	ListRoles(ctx context.Context) ([]*v1alpha1.Role, error)
+	ListRolesPaged(ctx context.Context, limit, offset int) ([]*v1alpha1.Role, int, error)
+	ListRolesWithCursor(ctx context.Context, limit int, next string) ([]*v1alpha1.Role, string, error)
 
 	// RoleBinding operations
 	CreateRoleBinding(ctx context.Context, binding *v1alpha1.RoleBinding) error
@@ -27,4 +34,36 @@ type Store interface {
 	UpdateRoleBinding(ctx context.Context, binding *v1alpha1.RoleBinding) error
 	DeleteRoleBinding(ctx context.Context, name string) error
 	ListRoleBindings(ctx context.Context) ([]*v1alpha1.RoleBinding, error)
+	ListRoleBindingsPaged(ctx context.Context, limit, offset int) ([]*v1alpha1.RoleBinding, int, error)
+	ListRoleBindingsWithCursor(ctx context.Context, limit int, next string) ([]*v1alpha1.RoleBinding, string, error)
+
+	// ClusterRole operations
+	CreateClusterRole(ctx context.Context, role *v1alpha1.ClusterRole) error
+	GetClusterRole(ctx context.Context, name string) (*v1alpha1.ClusterRole, error)
+	DeleteClusterRole(ctx context.Context, name string) error
+	ListClusterRoles(ctx context.Context) ([]*v1alpha1.ClusterRole, error)
+
+	// ClusterRoleBinding operations
+	CreateClusterRoleBinding(ctx context.Context, binding *v1alpha1.ClusterRoleBinding) error
+	GetClusterRoleBinding(ctx context.Context, name string) (*v1alpha1.ClusterRoleBinding, error)
+	DeleteClusterRoleBinding(ctx context.Context, name string) error
+	ListClusterRoleBindings(ctx context.Context) ([]*v1alpha1.ClusterRoleBinding, error)
+
+	// ServiceAccount operations
+	CreateServiceAccount(ctx context.Context, sa *v1alpha1.ServiceAccount) error
+	GetServiceAccount(ctx context.Context, name string) (*v1alpha1.ServiceAccount, error)
+}
+
+// Transactor is implemented by stores that can run several operations atomically.
+// 구현하지 않는 저장소에서는 각 연산이 개별적으로 적용됨
+type Transactor interface {
+	InTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// inTransaction runs fn in a transaction when store supports it
+func inTransaction(ctx context.Context, store Store, fn func(ctx context.Context) error) error {
+	if tx, ok := store.(Transactor); ok {
+		return tx.InTransaction(ctx, fn)
+	}
+	return fn(ctx)
 }
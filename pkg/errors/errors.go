@@ -10,6 +10,8 @@ type StatusError struct {
 	Message    string `json:"message"`
 	Reason     string `json:"reason,omitempty"`
 	RetryAfter int    `json:"retryAfter,omitempty"`
+	// RequestID identifies the failed request in server logs; 응답에서 복원된 오류에만 채워짐
+	RequestID string `json:"requestId,omitempty"`
 }
 
 func (e *StatusError) Error() string {
@@ -33,13 +35,18 @@ func (e *StatusError) WithReason(reason string) *StatusError {
 
 var (
 	// Authentication errors
-	ErrInvalidCredentials = NewStatusError(http.StatusUnauthorized, "invalid credentials")
-	ErrTokenExpired       = NewStatusError(http.StatusUnauthorized, "token expired")
-	ErrInvalidToken       = NewStatusError(http.StatusUnauthorized, "invalid token")
+	ErrInvalidCredentials  = NewStatusError(http.StatusUnauthorized, "invalid credentials")
+	ErrTokenExpired        = NewStatusError(http.StatusUnauthorized, "token expired")
+	ErrInvalidToken        = NewStatusError(http.StatusUnauthorized, "invalid token")
+	ErrUnauthorized        = NewStatusError(http.StatusUnauthorized, "unauthorized")
+	ErrAccountNotYetActive = NewStatusError(http.StatusForbidden, "account is not yet active")
+	ErrEmailNotVerified    = NewStatusError(http.StatusForbidden, "email address is not verified")
+	ErrAccountInactive     = NewStatusError(http.StatusForbidden, "account is inactive")
 
 	// Authorization errors
 	ErrForbidden        = NewStatusError(http.StatusForbidden, "forbidden")
 	ErrPermissionDenied = NewStatusError(http.StatusForbidden, "permission denied")
+	ErrLastAdmin        = NewStatusError(http.StatusConflict, "operation would remove the last admin")
 
 	// Resource errors
 	ErrUserNotFound = NewStatusError(http.StatusNotFound, "user not found")
@@ -50,15 +57,21 @@ var (
 	// Validation errors
 	ErrInvalidRequest = NewStatusError(http.StatusBadRequest, "invalid request")
 	ErrInvalidInput   = NewStatusError(http.StatusBadRequest, "invalid input")
+	ErrPasswordReused = NewStatusError(http.StatusBadRequest, "password was used recently")
 
 	// Server errors
 	ErrInternal       = NewStatusError(http.StatusInternalServerError, "internal server error")
 	ErrNotImplemented = NewStatusError(http.StatusNotImplemented, "not implemented")
+	ErrInvalidConfig  = NewStatusError(http.StatusInternalServerError, "invalid server configuration")
 
 	// Binding errors
 	ErrRoleBindingExists   = NewStatusError(http.StatusConflict, "role binding already exists")
 	ErrRoleBindingNotFound = NewStatusError(http.StatusNotFound, "role binding not found")
 
+	// Cluster-scoped RBAC errors
+	ErrClusterRoleNotFound        = NewStatusError(http.StatusNotFound, "cluster role not found")
+	ErrClusterRoleBindingNotFound = NewStatusError(http.StatusNotFound, "cluster role binding not found")
+
 	// Generic Store errors
 	ErrNotFound      = NewStatusError(http.StatusNotFound, "resource not found")
 	ErrAlreadyExists = NewStatusError(http.StatusConflict, "resource already exists")
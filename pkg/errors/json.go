@@ -0,0 +1,82 @@
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Response is the JSON body written for failed requests: {"error": {...}, "errors": [...]}.
+// Errors는 ValidationError일 때만 채워짐
+type Response struct {
+	Error  *StatusError `json:"error"`
+	Errors []FieldError `json:"errors,omitempty"`
+}
+
+// maxErrorBodySize bounds how much of an error response FromResponse reads
+const maxErrorBodySize = 1 << 20
+
+// UnmarshalJSON decodes either a bare StatusError object or the {"error": {...}} response envelope
+func (e *StatusError) UnmarshalJSON(data []byte) error {
+	type plain StatusError // UnmarshalJSON 재귀 호출 방지
+
+	var envelope struct {
+		Error *plain `json:"error"`
+	}
+	if err := json.Unmarshal(data, &envelope); err == nil && envelope.Error != nil {
+		*e = StatusError(*envelope.Error)
+		return nil
+	}
+
+	var p plain
+	if err := json.Unmarshal(data, &p); err != nil {
+		return err
+	}
+	*e = StatusError(p)
+	return nil
+}
+
+// Is matches StatusErrors by code and message, so an error decoded from a response
+// satisfies errors.Is against the predefined value (e.g. ErrUserNotFound)
+func (e *StatusError) Is(target error) bool {
+	t, ok := target.(*StatusError)
+	if !ok || e == nil || t == nil {
+		return false
+	}
+	return e.Code == t.Code && e.Message == t.Message
+}
+
+// FromResponse reconstructs the typed error of a failed pAuth response.
+// 2xx 응답이면 nil, 필드 오류가 있으면 *ValidationError, 그 밖에는 *StatusError를 반환.
+// 본문을 해석할 수 없으면 상태 코드만으로 StatusError를 만듦. 본문은 호출자가 닫아야 함
+func FromResponse(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+
+	fallback := NewStatusError(resp.StatusCode, http.StatusText(resp.StatusCode))
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	if err != nil {
+		return fallback.WithReason(fmt.Sprintf("failed to read error response: %v", err))
+	}
+
+	var decoded Response
+	if err := json.Unmarshal(body, &decoded); err != nil || decoded.Error == nil {
+		// 일부 미들웨어는 {"error": "message"} 형식으로 응답함
+		var legacy struct {
+			Error string `json:"error"`
+		}
+		if err := json.Unmarshal(body, &legacy); err == nil && legacy.Error != "" {
+			return NewStatusError(resp.StatusCode, legacy.Error)
+		}
+		return fallback
+	}
+	if decoded.Error.Code == 0 {
+		decoded.Error.Code = resp.StatusCode
+	}
+	if len(decoded.Errors) > 0 {
+		return NewValidationError(decoded.Errors...)
+	}
+	return decoded.Error
+}
@@ -0,0 +1,104 @@
+package errors
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var predefinedErrors = []*StatusError{
+	ErrInvalidCredentials, ErrTokenExpired, ErrInvalidToken, ErrUnauthorized, ErrAccountNotYetActive, ErrEmailNotVerified,
+	ErrAccountInactive,
+	ErrForbidden, ErrPermissionDenied, ErrLastAdmin,
+	ErrUserNotFound, ErrRoleNotFound, ErrUserExists, ErrRoleExists,
+	ErrInvalidRequest, ErrInvalidInput, ErrPasswordReused,
+	ErrInternal, ErrNotImplemented, ErrInvalidConfig,
+	ErrRoleBindingExists, ErrRoleBindingNotFound,
+	ErrClusterRoleNotFound, ErrClusterRoleBindingNotFound,
+	ErrNotFound, ErrAlreadyExists,
+	ErrStorageOperation, ErrTransactionFailed,
+	ErrUniqueViolation, ErrDatabaseConnection,
+	ErrInvalidFieldType, ErrInvalidJSON, ErrInvalidTimestamp,
+}
+
+func errorResponse(code int, body string) *http.Response {
+	return &http.Response{StatusCode: code, Body: io.NopCloser(strings.NewReader(body))}
+}
+
+func TestStatusError_JSONRoundTrip(t *testing.T) {
+	for _, want := range predefinedErrors {
+		t.Run(want.Message, func(t *testing.T) {
+			sent := *want
+			sent.Reason = "details"
+
+			data, err := json.Marshal(Response{Error: &sent})
+			require.NoError(t, err)
+
+			// 봉투 형식과 단독 객체 모두 복원되어야 함
+			var fromEnvelope StatusError
+			require.NoError(t, json.Unmarshal(data, &fromEnvelope))
+			assert.Equal(t, sent, fromEnvelope)
+
+			bare, err := json.Marshal(&sent)
+			require.NoError(t, err)
+			var fromBare StatusError
+			require.NoError(t, json.Unmarshal(bare, &fromBare))
+			assert.Equal(t, sent, fromBare)
+
+			got := FromResponse(errorResponse(want.Code, string(data)))
+			var statusErr *StatusError
+			require.True(t, stderrors.As(got, &statusErr))
+			assert.Equal(t, want.Code, statusErr.Code)
+			assert.Equal(t, "details", statusErr.Reason)
+			assert.ErrorIs(t, got, want)
+		})
+	}
+}
+
+func TestFromResponse(t *testing.T) {
+	t.Run("success is nil", func(t *testing.T) {
+		assert.NoError(t, FromResponse(errorResponse(http.StatusOK, `{}`)))
+	})
+
+	t.Run("validation errors", func(t *testing.T) {
+		got := FromResponse(errorResponse(http.StatusBadRequest,
+			`{"error":{"code":400,"message":"invalid input"},"errors":[{"field":"email","message":"email is required"}]}`))
+
+		var verr *ValidationError
+		require.True(t, stderrors.As(got, &verr))
+		assert.Equal(t, []FieldError{{Field: "email", Message: "email is required"}}, verr.Errors)
+	})
+
+	t.Run("request id and retry after", func(t *testing.T) {
+		got := FromResponse(errorResponse(http.StatusTooManyRequests,
+			`{"error":{"code":429,"message":"too many requests","retryAfter":30,"requestId":"req-1"}}`))
+
+		var statusErr *StatusError
+		require.True(t, stderrors.As(got, &statusErr))
+		assert.Equal(t, 30, statusErr.RetryAfter)
+		assert.Equal(t, "req-1", statusErr.RequestID)
+	})
+
+	t.Run("unparseable body falls back to status", func(t *testing.T) {
+		got := FromResponse(errorResponse(http.StatusBadGateway, `<html>bad gateway</html>`))
+		assert.Equal(t, NewStatusError(http.StatusBadGateway, "Bad Gateway"), got)
+	})
+
+	t.Run("plain error message", func(t *testing.T) {
+		got := FromResponse(errorResponse(http.StatusForbidden, `{"error":"forbidden"}`))
+		assert.Equal(t, NewStatusError(http.StatusForbidden, "forbidden"), got)
+		assert.ErrorIs(t, got, ErrForbidden)
+	})
+
+	t.Run("different errors do not match", func(t *testing.T) {
+		got := FromResponse(errorResponse(http.StatusNotFound, `{"error":{"code":404,"message":"user not found"}}`))
+		assert.ErrorIs(t, got, ErrUserNotFound)
+		assert.NotErrorIs(t, got, ErrRoleNotFound)
+	})
+}
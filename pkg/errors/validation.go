@@ -0,0 +1,55 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// FieldError describes a validation failure of a single request field
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ValidationError collects field-level validation failures so they can be reported together
+type ValidationError struct {
+	Errors []FieldError `json:"errors"`
+}
+
+// NewValidationError creates a ValidationError with the given entries
+func NewValidationError(errs ...FieldError) *ValidationError {
+	return &ValidationError{Errors: errs}
+}
+
+// Add records a failure for field
+func (e *ValidationError) Add(field, message string) {
+	e.Errors = append(e.Errors, FieldError{Field: field, Message: message})
+}
+
+// HasErrors reports whether any failure was recorded
+func (e *ValidationError) HasErrors() bool {
+	return len(e.Errors) > 0
+}
+
+// OrNil returns e as an error when failures were recorded, otherwise nil.
+// typed nil이 error로 반환되는 것을 막기 위해 사용
+func (e *ValidationError) OrNil() error {
+	if !e.HasErrors() {
+		return nil
+	}
+	return e
+}
+
+// Code returns the HTTP status code of validation failures
+func (e *ValidationError) Code() int {
+	return http.StatusBadRequest
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, 0, len(e.Errors))
+	for _, fe := range e.Errors {
+		messages = append(messages, fe.Message)
+	}
+	return fmt.Sprintf("status %d: %s: %s", e.Code(), ErrInvalidInput.Message, strings.Join(messages, "; "))
+}
@@ -0,0 +1,40 @@
+package events
+
+import (
+	"context"
+	"time"
+)
+
+// EventType describes what happened to the object of an Event (Kubernetes watch와 동일한 이름)
+type EventType string
+
+const (
+	Added    EventType = "ADDED"
+	Modified EventType = "MODIFIED"
+	Deleted  EventType = "DELETED"
+)
+
+// Event is a change notification published by the controllers
+type Event struct {
+	Type      EventType `json:"type"`
+	Kind      string    `json:"kind"`
+	Name      string    `json:"name"`
+	Namespace string    `json:"namespace,omitempty"`
+	// Object is the object after the change; 삭제 이벤트에서는 nil일 수 있음
+	Object    interface{} `json:"object,omitempty"`
+	Timestamp time.Time   `json:"timestamp"`
+}
+
+// Bus delivers events from publishers to every current subscriber.
+// 기본 구현은 프로세스 내 MemoryBus이며, 여러 인스턴스 간 전달이 필요하면 Redis 등으로 교체
+type Bus interface {
+	// Publish delivers event to the current subscribers without blocking on slow ones
+	Publish(ctx context.Context, event Event) error
+
+	// Subscribe returns a channel receiving events published after the call.
+	// ctx가 종료되면 구독이 해제되고 채널이 닫힘
+	Subscribe(ctx context.Context) (<-chan Event, error)
+
+	// Close closes every subscription
+	Close() error
+}
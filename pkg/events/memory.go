@@ -0,0 +1,89 @@
+package events
+
+import (
+	"context"
+	"sync"
+)
+
+// DefaultSubscriberBuffer is the number of events queued per subscriber before events are dropped
+const DefaultSubscriberBuffer = 64
+
+// MemoryBus implements Bus in process memory.
+// 단일 인스턴스 배포나 테스트 용도로 사용
+type MemoryBus struct {
+	mu     sync.Mutex
+	subs   map[chan Event]struct{}
+	buffer int
+	closed bool
+}
+
+// NewMemoryBus creates a MemoryBus; buffer <= 0이면 DefaultSubscriberBuffer
+func NewMemoryBus(buffer int) *MemoryBus {
+	if buffer <= 0 {
+		buffer = DefaultSubscriberBuffer
+	}
+	return &MemoryBus{
+		subs:   make(map[chan Event]struct{}),
+		buffer: buffer,
+	}
+}
+
+var _ Bus = (*MemoryBus)(nil)
+
+// Publish sends event to every subscriber. 버퍼가 가득 찬 구독자에게는 이벤트를 버려
+// 느린 구독자가 컨트롤러를 막지 않도록 함
+func (b *MemoryBus) Publish(ctx context.Context, event Event) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for ch := range b.subs {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+	return nil
+}
+
+// Subscribe registers a subscriber until ctx is done
+func (b *MemoryBus) Subscribe(ctx context.Context) (<-chan Event, error) {
+	ch := make(chan Event, b.buffer)
+
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		close(ch)
+		return ch, nil
+	}
+	b.subs[ch] = struct{}{}
+	b.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		b.unsubscribe(ch)
+	}()
+	return ch, nil
+}
+
+func (b *MemoryBus) unsubscribe(ch chan Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.subs[ch]; ok {
+		delete(b.subs, ch)
+		close(ch)
+	}
+}
+
+// Close closes every subscription; 이후 Subscribe는 닫힌 채널을 반환
+func (b *MemoryBus) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for ch := range b.subs {
+		delete(b.subs, ch)
+		close(ch)
+	}
+	b.closed = true
+	return nil
+}
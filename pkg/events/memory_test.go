@@ -0,0 +1,43 @@
+package events
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryBus(t *testing.T) {
+	bus := NewMemoryBus(1)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	ch, err := bus.Subscribe(ctx)
+	assert.NoError(t, err)
+
+	assert.NoError(t, bus.Publish(context.Background(), Event{Type: Added, Kind: "User", Name: "alice"}))
+	// 버퍼가 가득 차면 버려지고 Publish는 막히지 않음
+	assert.NoError(t, bus.Publish(context.Background(), Event{Type: Added, Kind: "User", Name: "bob"}))
+
+	select {
+	case event := <-ch:
+		assert.Equal(t, "alice", event.Name)
+	case <-time.After(time.Second):
+		t.Fatal("event not delivered")
+	}
+
+	// 구독 해제 후 채널이 닫힘
+	cancel()
+	select {
+	case _, ok := <-ch:
+		assert.False(t, ok)
+	case <-time.After(time.Second):
+		t.Fatal("channel not closed after cancel")
+	}
+
+	assert.NoError(t, bus.Close())
+	closed, err := bus.Subscribe(context.Background())
+	assert.NoError(t, err)
+	_, ok := <-closed
+	assert.False(t, ok)
+}
@@ -0,0 +1,51 @@
+package mail
+
+import (
+	"context"
+	"net/smtp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sukryu/pAuth/pkg/apis/auth/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSMTPSender(t *testing.T) {
+	_, err := NewSMTPSender(SMTPConfig{From: "noreply@example.com"})
+	assert.Error(t, err, "host is required")
+	_, err = NewSMTPSender(SMTPConfig{Host: "smtp.example.com"})
+	assert.Error(t, err, "from is required")
+
+	sender, err := NewSMTPSender(SMTPConfig{
+		Host:      "smtp.example.com",
+		Username:  "mailer",
+		Password:  "secret",
+		From:      "noreply@example.com",
+		VerifyURL: "https://auth.example.com/verify",
+	})
+	require.NoError(t, err)
+
+	var gotAddr string
+	var gotTo []string
+	var gotMsg string
+	sender.send = func(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+		gotAddr, gotTo, gotMsg = addr, to, string(msg)
+		assert.NotNil(t, a)
+		assert.Equal(t, "noreply@example.com", from)
+		return nil
+	}
+
+	user := &v1alpha1.User{ObjectMeta: metav1.ObjectMeta{Name: "carol"}}
+	require.NoError(t, sender.SendEmailChangeVerification(context.Background(), user, "carol@example.com", "tok+en"))
+	assert.Equal(t, "smtp.example.com:587", gotAddr)
+	assert.Equal(t, []string{"carol@example.com"}, gotTo)
+	assert.Contains(t, gotMsg, "To: carol@example.com\r\n")
+	assert.Contains(t, gotMsg, "Token: tok+en\r\n")
+	assert.Contains(t, gotMsg, "https://auth.example.com/verify?token=tok%2Ben")
+
+	t.Run("rejects header injection", func(t *testing.T) {
+		err := sender.SendEmailChangeVerification(context.Background(), user, "carol@example.com\r\nBcc: x@example.com", "token")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,148 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	// DefaultCORSMethods are allowed in preflight responses when CORSConfig.AllowedMethods is empty
+	DefaultCORSMethods = []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete}
+	// DefaultCORSHeaders are allowed in preflight responses when CORSConfig.AllowedHeaders is empty
+	DefaultCORSHeaders = []string{"Authorization", "Content-Type", DefaultCSRFHeaderName, IdempotencyKeyHeader, RequestIDHeader}
+)
+
+// CORSConfig is a cross-origin policy
+type CORSConfig struct {
+	// Enabled turns on CORS headers; false이면 브라우저의 same-origin 정책이 그대로 적용됨
+	Enabled bool
+
+	// AllowedOrigins lists the origins allowed to call the API. "*"이면 모든 origin, 비어 있으면 허용 없음
+	AllowedOrigins []string
+	AllowedMethods []string // 비어 있으면 DefaultCORSMethods
+	AllowedHeaders []string // 비어 있으면 DefaultCORSHeaders
+
+	// AllowCredentials lets browsers send cookies to the explicitly listed origins.
+	// "*"와 함께 쓸 수 없으며 (Validate), "*"로 허용된 origin에는 자격 증명 헤더를 보내지 않음
+	AllowCredentials bool
+	// MaxAge is how long browsers may cache a preflight response. 0이면 헤더를 보내지 않음
+	MaxAge time.Duration
+}
+
+// Validate rejects a policy that would let every origin make credentialed requests
+func (cfg CORSConfig) Validate() error {
+	if !cfg.Enabled || !cfg.AllowCredentials {
+		return nil
+	}
+	for _, o := range cfg.AllowedOrigins {
+		if o == "*" {
+			return fmt.Errorf("cors: allowCredentials cannot be combined with allowedOrigins \"*\"; list the trusted origins explicitly")
+		}
+	}
+	return nil
+}
+
+// CORSRoute applies Config instead of the default policy to paths under PathPrefix
+type CORSRoute struct {
+	// PathPrefix matches the path itself and everything below it (e.g. "/api/v1/auth/roles")
+	PathPrefix string
+	Config     CORSConfig
+}
+
+// CORS applies cfg to cross-origin requests, or the config of the longest matching route.
+// 경로로 정책을 고르므로 라우트가 등록되지 않은 OPTIONS preflight에도 해당 그룹의 정책이 적용됨
+func CORS(cfg CORSConfig, routes ...CORSRoute) gin.HandlerFunc {
+	routes = append([]CORSRoute(nil), routes...)
+	sort.SliceStable(routes, func(i, j int) bool {
+		return len(routes[i].PathPrefix) > len(routes[j].PathPrefix)
+	})
+
+	return func(c *gin.Context) {
+		policy := cfg
+		for _, route := range routes {
+			if hasPathPrefix(c.Request.URL.Path, route.PathPrefix) {
+				policy = route.Config
+				break
+			}
+		}
+		policy.apply(c)
+	}
+}
+
+func (cfg CORSConfig) apply(c *gin.Context) {
+	origin := c.GetHeader("Origin")
+	if !cfg.Enabled || origin == "" {
+		c.Next()
+		return
+	}
+
+	// 응답이 Origin에 따라 달라지므로 캐시에 알림
+	c.Writer.Header().Add("Vary", "Origin")
+	preflight := c.Request.Method == http.MethodOptions && c.GetHeader("Access-Control-Request-Method") != ""
+
+	allowed, wildcard := cfg.allowsOrigin(origin)
+	if !allowed {
+		if preflight {
+			c.AbortWithStatus(http.StatusForbidden)
+			return
+		}
+		// CORS 헤더 없이 처리하여 브라우저가 응답을 차단하도록 함
+		c.Next()
+		return
+	}
+
+	// "*"와 일치한 origin은 자격 증명 없이만 허용 (임의 origin의 쿠키 요청을 허용하지 않음)
+	if wildcard {
+		c.Header("Access-Control-Allow-Origin", "*")
+	} else {
+		c.Header("Access-Control-Allow-Origin", origin)
+		if cfg.AllowCredentials {
+			c.Header("Access-Control-Allow-Credentials", "true")
+		}
+	}
+
+	if !preflight {
+		c.Header("Access-Control-Expose-Headers", RequestIDHeader)
+		c.Next()
+		return
+	}
+
+	methods, headers := cfg.AllowedMethods, cfg.AllowedHeaders
+	if len(methods) == 0 {
+		methods = DefaultCORSMethods
+	}
+	if len(headers) == 0 {
+		headers = DefaultCORSHeaders
+	}
+	c.Header("Access-Control-Allow-Methods", strings.Join(methods, ", "))
+	c.Header("Access-Control-Allow-Headers", strings.Join(headers, ", "))
+	if cfg.MaxAge > 0 {
+		c.Header("Access-Control-Max-Age", strconv.Itoa(int(cfg.MaxAge.Seconds())))
+	}
+	c.AbortWithStatus(http.StatusNoContent)
+}
+
+// allowsOrigin reports whether origin is allowed and whether it matched "*"
+func (cfg CORSConfig) allowsOrigin(origin string) (allowed, wildcard bool) {
+	for _, o := range cfg.AllowedOrigins {
+		if o == "*" {
+			return true, true
+		}
+		if strings.EqualFold(o, origin) {
+			return true, false
+		}
+	}
+	return false, false
+}
+
+// hasPathPrefix matches prefix on path segment boundaries (/roles는 /rolebindings와 일치하지 않음)
+func hasPathPrefix(path, prefix string) bool {
+	prefix = strings.TrimSuffix(prefix, "/")
+	return path == prefix || strings.HasPrefix(path, prefix+"/")
+}
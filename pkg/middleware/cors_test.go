@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCORS_WildcardCredentials(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cfg := CORSConfig{Enabled: true, AllowedOrigins: []string{"https://app.example.com", "*"}, AllowCredentials: true}
+	assert.Error(t, cfg.Validate())
+	assert.NoError(t, CORSConfig{Enabled: true, AllowedOrigins: []string{"*"}}.Validate())
+	assert.NoError(t, CORSConfig{Enabled: true, AllowedOrigins: []string{"https://app.example.com"}, AllowCredentials: true}.Validate())
+
+	// 검증을 거치지 않은 설정이어도 "*"로 허용된 origin에는 자격 증명을 허용하지 않음
+	router := gin.New()
+	router.Use(CORS(cfg))
+	router.GET("/me", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	get := func(origin string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/me", nil)
+		req.Header.Set("Origin", origin)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := get("https://evil.example.com")
+	assert.Equal(t, "*", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Credentials"))
+
+	w = get("https://app.example.com")
+	assert.Equal(t, "https://app.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "true", w.Header().Get("Access-Control-Allow-Credentials"))
+}
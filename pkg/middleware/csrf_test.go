@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCSRF(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(CSRF(CSRFConfig{Enabled: true}))
+	router.GET("/me", func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.POST("/things", func(c *gin.Context) { c.Status(http.StatusCreated) })
+
+	// 안전한 요청에서 토큰 쿠키를 발급받음
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/me", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	var csrfCookie *http.Cookie
+	for _, c := range w.Result().Cookies() {
+		if c.Name == DefaultCSRFCookieName {
+			csrfCookie = c
+		}
+	}
+	if !assert.NotNil(t, csrfCookie) {
+		return
+	}
+	assert.False(t, csrfCookie.HttpOnly)
+	assert.Equal(t, csrfCookie.Value, w.Header().Get(DefaultCSRFHeaderName))
+
+	session := &http.Cookie{Name: DefaultSessionCookieName, Value: "jwt"}
+	post := func(header string, cookies ...*http.Cookie) int {
+		req := httptest.NewRequest(http.MethodPost, "/things", nil)
+		for _, c := range cookies {
+			req.AddCookie(c)
+		}
+		if header != "" {
+			req.Header.Set(DefaultCSRFHeaderName, header)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	t.Run("cookie session without header is rejected", func(t *testing.T) {
+		assert.Equal(t, http.StatusForbidden, post("", session, csrfCookie))
+	})
+
+	t.Run("mismatched header is rejected", func(t *testing.T) {
+		assert.Equal(t, http.StatusForbidden, post("other", session, csrfCookie))
+	})
+
+	t.Run("matching token passes", func(t *testing.T) {
+		assert.Equal(t, http.StatusCreated, post(csrfCookie.Value, session, csrfCookie))
+	})
+
+	t.Run("bearer request is unaffected", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/things", nil)
+		req.Header.Set("Authorization", "Bearer token")
+		req.AddCookie(session)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		router := gin.New()
+		router.Use(CSRF(CSRFConfig{}))
+		router.POST("/things", func(c *gin.Context) { c.Status(http.StatusCreated) })
+
+		req := httptest.NewRequest(http.MethodPost, "/things", nil)
+		req.AddCookie(session)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+}
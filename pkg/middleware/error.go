@@ -6,6 +6,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/sukryu/pAuth/pkg/errors"
+	"github.com/sukryu/pAuth/pkg/utils/requestid"
 )
 
 func ErrorMiddleware() gin.HandlerFunc {
@@ -14,23 +15,24 @@ func ErrorMiddleware() gin.HandlerFunc {
 
 		if len(c.Errors) > 0 {
 			err := c.Errors.Last().Err
-			log.Printf("Error: %v", err)
+			id := requestid.FromContext(c.Request.Context())
+			if id != "" {
+				log.Printf("Error: request_id=%s %v", id, err)
+			} else {
+				log.Printf("Error: %v", err)
+			}
 
 			switch e := err.(type) {
+			case *errors.ValidationError:
+				c.JSON(e.Code(), errors.Response{
+					Error:  errors.NewStatusError(e.Code(), errors.ErrInvalidInput.Message),
+					Errors: e.Errors,
+				})
 			case *errors.StatusError:
-				response := gin.H{
-					"error": gin.H{
-						"code":    e.Code,
-						"message": e.Message,
-					},
-				}
-				if e.Reason != "" {
-					response["error"].(gin.H)["reason"] = e.Reason
-				}
-				if e.RetryAfter > 0 {
-					response["error"].(gin.H)["retryAfter"] = e.RetryAfter
-				}
-				c.JSON(e.Code, response)
+				// 전역 오류 값을 변경하지 않도록 복사본에 request ID 기록
+				body := *e
+				body.RequestID = id
+				c.JSON(e.Code, errors.Response{Error: &body})
 			default:
 				c.JSON(http.StatusInternalServerError, gin.H{
 					"error": gin.H{
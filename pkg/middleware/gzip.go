@@ -0,0 +1,180 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultGzipMinSize is the smallest body compressed when GzipConfig.MinSize is not set.
+// 작은 응답은 gzip 헤더 오버헤드 때문에 오히려 커질 수 있음
+const DefaultGzipMinSize = 1024
+
+// GzipConfig configures the Gzip middleware
+type GzipConfig struct {
+	// Enabled turns compression on; false이면 미들웨어는 아무 것도 하지 않음
+	Enabled bool
+	// MinSize is the minimum body size in bytes to compress. 0이면 DefaultGzipMinSize
+	MinSize int
+}
+
+// gzipWriter buffers the start of the response until MinSize bytes are written, then picks the
+// encoding from the response headers and streams the rest. Flush나 WriteHeaderNow가 먼저 호출되면
+// 그 시점까지의 본문으로 결정하므로 SSE 같은 스트리밍 응답도 지연 없이 전달됨
+type gzipWriter struct {
+	gin.ResponseWriter
+	minSize int
+	buf     bytes.Buffer
+	status  int
+	size    int
+
+	decided bool
+	gz      *gzip.Writer
+}
+
+func (w *gzipWriter) WriteHeader(code int) {
+	if !w.decided {
+		w.status = code
+	}
+}
+
+// WriteHeaderNow sends the headers; 이후 본문은 압축하지 않음
+func (w *gzipWriter) WriteHeaderNow() {
+	if !w.decided {
+		w.decide(false)
+	}
+}
+
+func (w *gzipWriter) Write(data []byte) (int, error) {
+	w.size += len(data)
+	if w.decided {
+		if w.gz != nil {
+			return w.gz.Write(data)
+		}
+		return w.ResponseWriter.Write(data)
+	}
+
+	if !w.compressible() {
+		w.decide(false)
+		return w.ResponseWriter.Write(data)
+	}
+	n, err := w.buf.Write(data)
+	if w.buf.Len() >= w.minSize {
+		w.decide(true)
+	}
+	return n, err
+}
+
+func (w *gzipWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// Flush sends what was written so far, deciding the encoding first if needed
+func (w *gzipWriter) Flush() {
+	if !w.decided {
+		w.decide(w.buf.Len() >= w.minSize && w.compressible())
+	}
+	if w.gz != nil {
+		_ = w.gz.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+func (w *gzipWriter) Status() int {
+	return w.status
+}
+
+func (w *gzipWriter) Size() int {
+	return w.size
+}
+
+func (w *gzipWriter) Written() bool {
+	return w.decided || w.size > 0
+}
+
+// compressible reports whether the response headers allow compressing the body.
+// 이벤트 스트림은 압축 버퍼 때문에 이벤트가 늦게 전달되므로 제외
+func (w *gzipWriter) compressible() bool {
+	header := w.Header()
+	return bodyAllowed(w.status) && header.Get("Content-Encoding") == "" &&
+		!strings.HasPrefix(header.Get("Content-Type"), "text/event-stream")
+}
+
+// decide writes the headers with or without gzip encoding and the buffered body
+func (w *gzipWriter) decide(compress bool) {
+	w.decided = true
+	// 응답 내용이 Accept-Encoding에 따라 달라지므로 캐시에 알림
+	w.Header().Add("Vary", "Accept-Encoding")
+	if compress {
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Header().Del("Content-Length")
+	}
+	w.ResponseWriter.WriteHeader(w.status)
+	w.ResponseWriter.WriteHeaderNow()
+
+	if compress {
+		w.gz = gzip.NewWriter(w.ResponseWriter)
+		_, _ = w.gz.Write(w.buf.Bytes())
+	} else if w.buf.Len() > 0 {
+		_, _ = w.ResponseWriter.Write(w.buf.Bytes())
+	}
+	w.buf.Reset()
+}
+
+// close finishes the response after the handlers ran
+func (w *gzipWriter) close() {
+	if !w.decided {
+		w.decide(w.buf.Len() >= w.minSize && w.compressible())
+	}
+	if w.gz != nil {
+		_ = w.gz.Close()
+	}
+}
+
+// Gzip compresses responses of at least MinSize bytes for clients sending Accept-Encoding: gzip
+func Gzip(cfg GzipConfig) gin.HandlerFunc {
+	minSize := cfg.MinSize
+	if minSize <= 0 {
+		minSize = DefaultGzipMinSize
+	}
+
+	return func(c *gin.Context) {
+		if !cfg.Enabled || !acceptsGzip(c.GetHeader("Accept-Encoding")) || c.Request.Method == http.MethodHead {
+			c.Next()
+			return
+		}
+
+		original := c.Writer
+		writer := &gzipWriter{ResponseWriter: original, minSize: minSize, status: http.StatusOK}
+		c.Writer = writer
+		defer func() {
+			c.Writer = original
+		}()
+		c.Next()
+		writer.close()
+	}
+}
+
+// acceptsGzip reports whether the Accept-Encoding header allows gzip (q=0이면 거부)
+func acceptsGzip(header string) bool {
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+		if !strings.EqualFold(strings.TrimSpace(fields[0]), "gzip") {
+			continue
+		}
+		for _, param := range fields[1:] {
+			if strings.ReplaceAll(strings.TrimSpace(param), " ", "") == "q=0" {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+func bodyAllowed(status int) bool {
+	return status >= http.StatusOK && status != http.StatusNoContent && status != http.StatusNotModified
+}
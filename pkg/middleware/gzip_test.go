@@ -0,0 +1,101 @@
+package middleware
+
+import (
+	"bufio"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGzip_Streaming(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	chunk := strings.Repeat("a", 2048)
+	release := make(chan struct{})
+	router := gin.New()
+	router.Use(Gzip(GzipConfig{Enabled: true, MinSize: 1024}))
+	router.GET("/chunks", func(c *gin.Context) {
+		c.Header("Content-Type", "text/plain")
+		_, _ = c.Writer.WriteString(chunk)
+		c.Writer.Flush()
+		<-release
+		_, _ = c.Writer.WriteString(chunk)
+	})
+	router.GET("/events", func(c *gin.Context) {
+		c.Header("Content-Type", "text/event-stream")
+		c.SSEvent("ping", "1")
+		c.Writer.Flush()
+		<-release
+	})
+
+	server := httptest.NewServer(router)
+	defer server.Close()
+	defer close(release)
+
+	get := func(path string) *http.Response {
+		req, err := http.NewRequest(http.MethodGet, server.URL+path, nil)
+		require.NoError(t, err)
+		req.Header.Set("Accept-Encoding", "gzip")
+		// DefaultClient는 gzip을 투명하게 해제하므로 Transport를 직접 사용
+		resp, err := http.DefaultTransport.RoundTrip(req)
+		require.NoError(t, err)
+		return resp
+	}
+
+	t.Run("flushed chunks are compressed and delivered", func(t *testing.T) {
+		resp := get("/chunks")
+		defer resp.Body.Close()
+		assert.Equal(t, "gzip", resp.Header.Get("Content-Encoding"))
+
+		// 핸들러가 끝나기 전에 첫 번째 조각을 읽을 수 있어야 함
+		reader, err := gzip.NewReader(resp.Body)
+		require.NoError(t, err)
+		first := make([]byte, len(chunk))
+		_, err = io.ReadFull(reader, first)
+		require.NoError(t, err)
+		assert.Equal(t, chunk, string(first))
+	})
+
+	t.Run("event streams are not compressed", func(t *testing.T) {
+		resp := get("/events")
+		defer resp.Body.Close()
+		assert.Empty(t, resp.Header.Get("Content-Encoding"))
+
+		line, err := bufio.NewReader(resp.Body).ReadString('\n')
+		require.NoError(t, err)
+		assert.Equal(t, "event:ping\n", line)
+	})
+}
+
+func TestGzip_SmallAndEmptyResponses(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(Gzip(GzipConfig{Enabled: true}))
+	router.GET("/small", func(c *gin.Context) { c.String(http.StatusOK, "ok") })
+	router.DELETE("/empty", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+
+	req := httptest.NewRequest(http.MethodGet, "/small", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("Content-Encoding"))
+	assert.Equal(t, "Accept-Encoding", w.Header().Get("Vary"))
+	assert.Equal(t, "ok", w.Body.String())
+
+	req = httptest.NewRequest(http.MethodDelete, "/empty", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Empty(t, w.Header().Get("Content-Encoding"))
+	assert.Zero(t, w.Body.Len())
+}
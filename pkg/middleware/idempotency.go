@@ -0,0 +1,150 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sukryu/pAuth/internal/store/cache"
+)
+
+// IdempotencyKeyHeader is the request header clients use to make POST requests safe to retry
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// IdempotencyReplayedHeader is set on responses served from the idempotency cache
+const IdempotencyReplayedHeader = "Idempotent-Replayed"
+
+// DefaultIdempotencyTTL is how long a cached response is replayed when no TTL is configured
+const DefaultIdempotencyTTL = 24 * time.Hour
+
+// IdempotencyConfig configures the Idempotency middleware
+type IdempotencyConfig struct {
+	// Cache stores responses; nil disables idempotency handling
+	Cache cache.Cache
+	TTL   time.Duration
+}
+
+// idempotentResponse is the cached form of a completed request
+type idempotentResponse struct {
+	RequestHash string `json:"requestHash"`
+	Status      int    `json:"status"`
+	ContentType string `json:"contentType"`
+	Body        []byte `json:"body"`
+}
+
+// responseRecorder captures the response body while still writing it to the client
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// Idempotency replays the cached response for POST requests that repeat an Idempotency-Key.
+// 키는 주체, 경로 단위로 구분되며 성공한(2xx) 응답만 캐시하므로 실패한 요청은 재시도 가능.
+func Idempotency(cfg IdempotencyConfig) gin.HandlerFunc {
+	ttl := cfg.TTL
+	if ttl <= 0 {
+		ttl = DefaultIdempotencyTTL
+	}
+
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if cfg.Cache == nil || key == "" || c.Request.Method != http.MethodPost {
+			c.Next()
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "failed to read request body"})
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		ctx := c.Request.Context()
+		cacheKey := idempotencyCacheKey(c, key)
+		requestHash := hashBytes(body)
+
+		if cached, found, err := cfg.Cache.Get(ctx, cacheKey); err == nil && found {
+			var resp idempotentResponse
+			if err := json.Unmarshal([]byte(cached), &resp); err == nil {
+				// 같은 키를 다른 요청에 재사용하는 것은 클라이언트 오류
+				if resp.RequestHash != requestHash {
+					c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "idempotency key was already used with a different request"})
+					c.Abort()
+					return
+				}
+
+				c.Header(IdempotencyReplayedHeader, "true")
+				c.Data(resp.Status, resp.ContentType, resp.Body)
+				c.Abort()
+				return
+			}
+		}
+
+		// 동일 키의 요청이 동시에 처리되지 않도록 잠금
+		lockKey := cacheKey + ":lock"
+		n, err := cfg.Cache.Incr(ctx, lockKey, time.Minute)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to acquire idempotency lock"})
+			c.Abort()
+			return
+		}
+		defer cfg.Cache.Del(ctx, lockKey)
+		if n > 1 {
+			c.JSON(http.StatusConflict, gin.H{"error": "a request with this idempotency key is already in progress"})
+			c.Abort()
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Next()
+		c.Writer = recorder.ResponseWriter
+
+		status := recorder.Status()
+		if len(c.Errors) > 0 || status < 200 || status >= 300 {
+			return
+		}
+
+		data, err := json.Marshal(idempotentResponse{
+			RequestHash: requestHash,
+			Status:      status,
+			ContentType: recorder.Header().Get("Content-Type"),
+			Body:        recorder.body.Bytes(),
+		})
+		if err != nil {
+			return
+		}
+		if err := cfg.Cache.Set(ctx, cacheKey, string(data), ttl); err != nil {
+			log.Printf("Failed to cache idempotent response: %v", err)
+		}
+	}
+}
+
+// idempotencyCacheKey scopes the client key to the authenticated subject and route
+func idempotencyCacheKey(c *gin.Context, key string) string {
+	subject, _ := SubjectFromContext(c)
+	return "idempotency:" + hashBytes([]byte(subject.Kind+"/"+subject.Name+"|"+c.Request.URL.Path+"|"+key))
+}
+
+func hashBytes(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
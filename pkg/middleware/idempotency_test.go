@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/sukryu/pAuth/internal/store/cache"
+	"github.com/sukryu/pAuth/pkg/errors"
+)
+
+func TestIdempotency(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	calls := 0
+	fail := true
+	router := gin.New()
+	router.Use(ErrorMiddleware())
+	router.POST("/things", Idempotency(IdempotencyConfig{Cache: cache.NewMemoryCache()}), func(c *gin.Context) {
+		calls++
+		if fail {
+			c.Error(errors.ErrInternal)
+			return
+		}
+		c.JSON(http.StatusCreated, gin.H{"call": calls})
+	})
+
+	do := func(key string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/things", nil)
+		if key != "" {
+			req.Header.Set(IdempotencyKeyHeader, key)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// 실패한 응답은 캐시되지 않아 같은 키로 재시도 가능
+	assert.Equal(t, http.StatusInternalServerError, do("k1").Code)
+	fail = false
+	first := do("k1")
+	assert.Equal(t, http.StatusCreated, first.Code)
+	assert.Equal(t, 2, calls)
+
+	replayed := do("k1")
+	assert.Equal(t, first.Body.String(), replayed.Body.String())
+	assert.Equal(t, 2, calls)
+
+	// 키가 없으면 매번 실행
+	do("")
+	do("")
+	assert.Equal(t, 4, calls)
+}
@@ -5,7 +5,9 @@ import (
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/sukryu/pAuth/pkg/utils/actor"
 	"github.com/sukryu/pAuth/pkg/utils/jwt"
+	"github.com/sukryu/pAuth/pkg/utils/namespace"
 )
 
 func JWTAuth(jwtManager *jwt.JWTManager) gin.HandlerFunc {
@@ -31,9 +33,27 @@ func JWTAuth(jwtManager *jwt.JWTManager) gin.HandlerFunc {
 			return
 		}
 
+		// refresh token은 새 access token 발급에만 사용 가능
+		if claims.Type == jwt.TokenTypeRefresh {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "refresh token cannot be used for authentication"})
+			c.Abort()
+			return
+		}
+
 		// 클레임 정보를 컨텍스트에 저장
-		c.Set("userID", claims.UserID)
-		c.Set("roles", claims.Roles)
+		if claims.Type == jwt.TokenTypeServiceAccount {
+			// ServiceAccount 토큰은 userID를 설정하지 않아 사용자 본인 확인 경로에 사용될 수 없음
+			c.Set("serviceAccount", claims.Subject)
+			c.Request = c.Request.WithContext(actor.WithActor(c.Request.Context(), "serviceaccount:"+claims.Subject))
+		} else {
+			c.Set("userID", claims.UserID)
+			c.Set("roles", claims.Roles)
+			c.Request = c.Request.WithContext(actor.WithActor(c.Request.Context(), claims.UserID))
+		}
+		// 토큰의 namespace로 이후 조회 범위를 제한
+		if claims.Namespace != "" {
+			c.Request = c.Request.WithContext(namespace.WithNamespace(c.Request.Context(), claims.Namespace))
+		}
 		c.Next()
 	}
 }
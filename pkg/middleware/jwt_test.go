@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/sukryu/pAuth/pkg/utils/jwt"
+)
+
+func TestJWTAuth_RefreshToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	manager := jwt.NewJWTManagerWithConfig(jwt.Config{SecretKey: "secret", Expiry: time.Hour, RefreshExpiry: 24 * time.Hour})
+
+	router := gin.New()
+	router.Use(JWTAuth(manager))
+	router.GET("/me", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	request := func(token string) int {
+		req := httptest.NewRequest(http.MethodGet, "/me", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	access, err := manager.GenerateToken("alice", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, request(access))
+
+	refresh, err := manager.GenerateRefreshToken("alice", "", false)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, request(refresh))
+}
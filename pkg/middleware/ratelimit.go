@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sukryu/pAuth/internal/store/cache"
+	"github.com/sukryu/pAuth/pkg/errors"
+)
+
+// DefaultRateLimitWindow is the counting window when RateLimitConfig.Window is not set
+const DefaultRateLimitWindow = time.Minute
+
+// RateLimitConfig configures the RateLimit middleware
+type RateLimitConfig struct {
+	// Cache counts requests; nil or Requests <= 0 disables rate limiting.
+	// 여러 인스턴스가 한도를 공유하려면 Redis cache를 사용해야 함
+	Cache cache.Cache
+	// Requests is the number of requests a client may make per Window
+	Requests int
+	Window   time.Duration
+}
+
+// RateLimit rejects a client's requests with 429 once it exceeds cfg.Requests within a fixed window.
+// 클라이언트는 IP와 경로로 구분되며, cache 장애 시에는 요청을 막지 않고 통과시킴
+func RateLimit(cfg RateLimitConfig) gin.HandlerFunc {
+	window := cfg.Window
+	if window <= 0 {
+		window = DefaultRateLimitWindow
+	}
+
+	return func(c *gin.Context) {
+		if cfg.Cache == nil || cfg.Requests <= 0 {
+			c.Next()
+			return
+		}
+
+		key := "ratelimit:" + hashBytes([]byte(c.ClientIP()+"|"+c.FullPath()))
+		count, err := cfg.Cache.Incr(c.Request.Context(), key, window)
+		if err != nil {
+			log.Printf("Rate limit check failed: %v", err)
+			c.Next()
+			return
+		}
+		if count > int64(cfg.Requests) {
+			retryAfter := int(window.Seconds())
+			c.Header("Retry-After", strconv.Itoa(retryAfter))
+			err := errors.NewStatusError(http.StatusTooManyRequests, "too many requests")
+			err.RetryAfter = retryAfter
+			c.Error(err)
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
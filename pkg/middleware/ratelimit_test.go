@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sukryu/pAuth/internal/store/cache"
+	"github.com/sukryu/pAuth/pkg/errors"
+)
+
+func TestRateLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(ErrorMiddleware())
+	limit := RateLimit(RateLimitConfig{Cache: cache.NewMemoryCache(), Requests: 2, Window: time.Minute})
+	router.POST("/login", limit, func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.POST("/register", limit, func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	post := func(path, ip string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, path, nil)
+		req.RemoteAddr = ip + ":1234"
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	assert.Equal(t, http.StatusOK, post("/login", "10.0.0.1").Code)
+	assert.Equal(t, http.StatusOK, post("/login", "10.0.0.1").Code)
+
+	w := post("/login", "10.0.0.1")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "60", w.Header().Get("Retry-After"))
+	var body errors.Response
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, 60, body.Error.RetryAfter)
+
+	// 한도는 클라이언트와 경로별로 따로 계산
+	assert.Equal(t, http.StatusOK, post("/login", "10.0.0.2").Code)
+	assert.Equal(t, http.StatusOK, post("/register", "10.0.0.1").Code)
+
+	t.Run("disabled without a cache", func(t *testing.T) {
+		router := gin.New()
+		router.POST("/login", RateLimit(RateLimitConfig{Requests: 1}), func(c *gin.Context) { c.Status(http.StatusOK) })
+		for i := 0; i < 3; i++ {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/login", nil))
+			assert.Equal(t, http.StatusOK, w.Code)
+		}
+	})
+}
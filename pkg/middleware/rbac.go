@@ -1,18 +1,21 @@
 package middleware
 
 import (
+	"context"
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/sukryu/pAuth/pkg/apis/auth/v1alpha1"
 	"github.com/sukryu/pAuth/pkg/controllers"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+const apiPrefix = "/api/v1/auth"
+
 func RBACMiddleware(rbacController controllers.RBACController) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// JWT 미들웨어에서 설정한 사용자 정보 가져오기
-		userID, exists := c.Get("userID")
+		// JWT 미들웨어에서 설정한 주체 정보 가져오기
+		subject, exists := SubjectFromContext(c)
 		if !exists {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
 			c.Abort()
@@ -24,15 +27,8 @@ func RBACMiddleware(rbacController controllers.RBACController) gin.HandlerFunc {
 		resource := getResource(c.FullPath())
 		apiGroup := "auth.service"
 
-		// 사용자 정보 가져오기
-		user := &v1alpha1.User{
-			ObjectMeta: metav1.ObjectMeta{
-				Name: userID.(string),
-			},
-		}
-
 		// 접근 권한 확인
-		allowed, err := rbacController.CheckAccess(c.Request.Context(), user, verb, resource, apiGroup)
+		allowed, err := checkAccess(c.Request.Context(), rbacController, subject, verb, resource, apiGroup)
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to check access"})
 			c.Abort()
@@ -49,6 +45,130 @@ func RBACMiddleware(rbacController controllers.RBACController) gin.HandlerFunc {
 	}
 }
 
+// RequireSelfOrPermission allows the request when the path's :name is the authenticated user,
+// otherwise it requires the given permission via RBAC. 권한 없이 본인으로 허용된 요청은
+// controllers.WithSelfService로 표시되어 컨트롤러가 변경 가능한 필드를 제한함
+func RequireSelfOrPermission(rbacController controllers.RBACController, verb, resource, apiGroup string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		subject, exists := SubjectFromContext(c)
+		if !exists {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+			c.Abort()
+			return
+		}
+
+		// 본인 리소스에 대한 요청은 역할 없이 허용 (사용자 토큰만 해당)
+		if name := c.Param("name"); name != "" && subject.Kind == v1alpha1.SubjectKindUser && name == subject.Name {
+			allowed, err := checkAccess(c.Request.Context(), rbacController, subject, verb, resource, apiGroup)
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to check access"})
+				c.Abort()
+				return
+			}
+			if !allowed {
+				c.Request = c.Request.WithContext(controllers.WithSelfService(c.Request.Context()))
+			}
+			c.Next()
+			return
+		}
+
+		authorize(c, rbacController, subject, verb, resource, apiGroup)
+	}
+}
+
+// RequirePermission requires the given permission via RBAC regardless of the route path.
+// 경로에서 리소스를 추론할 수 없는 라우트(예: /users:batchDelete)에 사용
+func RequirePermission(rbacController controllers.RBACController, verb, resource, apiGroup string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		subject, exists := SubjectFromContext(c)
+		if !exists {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+			c.Abort()
+			return
+		}
+
+		authorize(c, rbacController, subject, verb, resource, apiGroup)
+	}
+}
+
+// RequirePermissionForQuery additionally requires the given permission when ?query=true is set.
+// 영향 범위가 큰 옵션(예: ?force=true)에만 추가 권한을 요구할 때 사용
+func RequirePermissionForQuery(rbacController controllers.RBACController, query, verb, resource, apiGroup string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Query(query) != "true" {
+			c.Next()
+			return
+		}
+
+		subject, exists := SubjectFromContext(c)
+		if !exists {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+			c.Abort()
+			return
+		}
+
+		authorize(c, rbacController, subject, verb, resource, apiGroup)
+	}
+}
+
+// RequirePermissionForParam requires the permission whose resource is the path parameter param
+// and whose verb follows the HTTP method. 컬렉션 경로(param으로 끝나는 경로)의 GET은 list로 판단
+// 예: GET /api/v1/dynamic/:table -> list <table>, DELETE /api/v1/dynamic/:table/:id -> delete <table>
+func RequirePermissionForParam(rbacController controllers.RBACController, param, apiGroup string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		subject, exists := SubjectFromContext(c)
+		if !exists {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+			c.Abort()
+			return
+		}
+
+		verb := getVerb(c.Request.Method)
+		if verb == "get" && strings.HasSuffix(c.FullPath(), "/:"+param) {
+			verb = "list"
+		}
+		authorize(c, rbacController, subject, verb, c.Param(param), apiGroup)
+	}
+}
+
+// authorize continues the chain when subject holds the permission, otherwise aborts
+func authorize(c *gin.Context, rbacController controllers.RBACController, subject v1alpha1.Subject, verb, resource, apiGroup string) {
+	allowed, err := checkAccess(c.Request.Context(), rbacController, subject, verb, resource, apiGroup)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to check access"})
+		c.Abort()
+		return
+	}
+
+	if !allowed {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		c.Abort()
+		return
+	}
+
+	c.Next()
+}
+
+// checkAccess evaluates the permission, ignoring namespaced RoleBindings for cluster-scoped resources.
+// namespace 관리자가 ClusterRole을 만들어 자신을 바인딩하는 방식으로 테넌트를 벗어나는 것을 막음
+func checkAccess(ctx context.Context, rbacController controllers.RBACController, subject v1alpha1.Subject, verb, resource, apiGroup string) (bool, error) {
+	if controllers.IsClusterScoped(resource) {
+		return rbacController.CheckClusterAccess(ctx, subject, verb, resource, apiGroup)
+	}
+	return rbacController.CheckSubjectAccess(ctx, subject, verb, resource, apiGroup)
+}
+
+// SubjectFromContext returns the authenticated principal set by JWTAuth
+func SubjectFromContext(c *gin.Context) (v1alpha1.Subject, bool) {
+	if name, ok := c.Get("serviceAccount"); ok {
+		return v1alpha1.Subject{Kind: v1alpha1.SubjectKindServiceAccount, Name: name.(string)}, true
+	}
+	if userID, ok := c.Get("userID"); ok {
+		return v1alpha1.Subject{Kind: v1alpha1.SubjectKindUser, Name: userID.(string)}, true
+	}
+	return v1alpha1.Subject{}, false
+}
+
 func getVerb(method string) string {
 	switch method {
 	case "GET":
@@ -64,8 +184,14 @@ func getVerb(method string) string {
 	}
 }
 
+// getResource extracts the resource from the route path.
+// 예: /api/v1/auth/users/:name -> users, /api/v1/auth/rolebindings -> rolebindings
 func getResource(path string) string {
-	// path에서 리소스 추출 로직 구현
-	// 예: /api/v1/auth/users -> users
-	return "users" // 실제 구현은 더 복잡할 수 있음
+	path = strings.TrimPrefix(path, apiPrefix)
+	for _, segment := range strings.Split(path, "/") {
+		if segment != "" {
+			return segment
+		}
+	}
+	return ""
 }
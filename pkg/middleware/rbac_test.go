@@ -0,0 +1,232 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/sukryu/pAuth/pkg/apis/auth/v1alpha1"
+	"github.com/sukryu/pAuth/pkg/controllers"
+	"github.com/sukryu/pAuth/pkg/mocks"
+	"github.com/sukryu/pAuth/pkg/utils/jwt"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// withUser simulates JWTAuth by placing the authenticated user in the context
+func withUser(userID string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set("userID", userID)
+		c.Next()
+	}
+}
+
+func setupSelfServiceRouter(ms *mocks.MockStore, userID string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.PUT("/users/:name/password",
+		withUser(userID),
+		RequireSelfOrPermission(controllers.NewRBACController(ms), "update", "users", "auth.service"),
+		func(c *gin.Context) {
+			c.Header("X-Self-Service", strconv.FormatBool(controllers.IsSelfService(c.Request.Context())))
+			c.Status(http.StatusOK)
+		},
+	)
+	return router
+}
+
+func TestRequireSelfOrPermission(t *testing.T) {
+	t.Run("user changes own password without any role", func(t *testing.T) {
+		ms := mocks.NewMockStore()
+		ms.On("ListRoleBindings", mock.Anything).Return([]*v1alpha1.RoleBinding{}, nil)
+		ms.ExpectListClusterRoleBindings(nil, nil)
+		router := setupSelfServiceRouter(ms, "alice")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/users/alice/password", nil))
+
+		// 권한 없이 본인으로 허용되었으므로 변경 가능한 필드가 제한됨
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "true", w.Header().Get("X-Self-Service"))
+	})
+
+	t.Run("user without permission cannot change another's password", func(t *testing.T) {
+		ms := mocks.NewMockStore()
+		ms.On("ListRoleBindings", mock.Anything).Return([]*v1alpha1.RoleBinding{}, nil)
+		ms.ExpectListClusterRoleBindings(nil, nil)
+		router := setupSelfServiceRouter(ms, "alice")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/users/bob/password", nil))
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		ms.AssertExpectations(t)
+	})
+
+	t.Run("user with permission can change another's password", func(t *testing.T) {
+		ms := mocks.NewMockStore()
+		ms.On("ListRoleBindings", mock.Anything).Return([]*v1alpha1.RoleBinding{{
+			Subjects: []v1alpha1.Subject{{Kind: "User", Name: "admin"}},
+			RoleRef:  v1alpha1.RoleRef{Kind: "Role", Name: "user-admin"},
+		}}, nil)
+		ms.On("GetRole", mock.Anything, "user-admin").Return(&v1alpha1.Role{
+			ObjectMeta: metav1.ObjectMeta{Name: "user-admin"},
+			Rules: []v1alpha1.PolicyRule{{
+				Verbs:     []string{"update"},
+				Resources: []string{"users"},
+				APIGroups: []string{"auth.service"},
+			}},
+		}, nil)
+		router := setupSelfServiceRouter(ms, "admin")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/users/bob/password", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		ms.AssertExpectations(t)
+
+		// 권한이 있으면 본인 요청도 제한되지 않음
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/users/admin/password", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "false", w.Header().Get("X-Self-Service"))
+	})
+
+	t.Run("unauthenticated request", func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		router.PUT("/users/:name/password",
+			RequireSelfOrPermission(controllers.NewRBACController(mocks.NewMockStore()), "update", "users", "auth.service"),
+			func(c *gin.Context) { c.Status(http.StatusOK) },
+		)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/users/alice/password", nil))
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
+
+func TestRBACMiddleware_ServiceAccountToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	jwtManager := jwt.NewJWTManager("test-secret", time.Hour)
+
+	ms := mocks.NewMockStore()
+	ms.On("GetServiceAccount", mock.Anything, "ci-bot").Return(&v1alpha1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{Name: "ci-bot"},
+	}, nil)
+	ms.On("ListRoleBindings", mock.Anything).Return([]*v1alpha1.RoleBinding{{
+		ObjectMeta: metav1.ObjectMeta{Name: "ci-reader"},
+		RoleRef:    v1alpha1.RoleRef{Kind: "Role", Name: "reader"},
+		Subjects:   []v1alpha1.Subject{{Kind: "ServiceAccount", Name: "ci-bot"}},
+	}}, nil)
+	ms.On("GetRole", mock.Anything, "reader").Return(&v1alpha1.Role{
+		ObjectMeta: metav1.ObjectMeta{Name: "reader"},
+		Rules: []v1alpha1.PolicyRule{{
+			Verbs:     []string{"get"},
+			Resources: []string{"users"},
+			APIGroups: []string{"auth.service"},
+		}},
+	}, nil)
+	ms.ExpectListClusterRoleBindings(nil, nil)
+
+	saController := controllers.NewServiceAccountController(ms, jwtManager)
+	token, err := saController.IssueServiceAccountToken(context.Background(), "ci-bot", time.Minute)
+	assert.NoError(t, err)
+
+	rbacController := controllers.NewRBACController(ms)
+	router := gin.New()
+	protected := router.Group("/api/v1/auth", JWTAuth(jwtManager), RBACMiddleware(rbacController))
+	protected.GET("/users/:name", func(c *gin.Context) { c.Status(http.StatusOK) })
+	protected.DELETE("/users/:name", func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.PUT("/users/:name/password", JWTAuth(jwtManager),
+		RequireSelfOrPermission(rbacController, "update", "users", "auth.service"),
+		func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	do := func(method, path string) int {
+		req := httptest.NewRequest(method, path, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	assert.Equal(t, http.StatusOK, do(http.MethodGet, "/api/v1/auth/users/alice"))
+	assert.Equal(t, http.StatusForbidden, do(http.MethodDelete, "/api/v1/auth/users/alice"))
+	// ServiceAccount는 같은 이름의 사용자로 취급되지 않음
+	assert.Equal(t, http.StatusForbidden, do(http.MethodPut, "/users/ci-bot/password"))
+}
+
+func TestRBACMiddleware_ClusterScopedResources(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// tenant-a의 namespace 관리자: RoleBinding으로 모든 권한을 가짐
+	ms := mocks.NewMockStore()
+	ms.On("ListRoleBindings", mock.Anything).Return([]*v1alpha1.RoleBinding{{
+		ObjectMeta: metav1.ObjectMeta{Name: "tenant-admin", Namespace: "tenant-a"},
+		RoleRef:    v1alpha1.RoleRef{Kind: "Role", Name: "admin"},
+		Subjects:   []v1alpha1.Subject{{Kind: v1alpha1.SubjectKindUser, Name: "alice"}},
+	}}, nil)
+	ms.On("GetRole", mock.Anything, "admin").Return(&v1alpha1.Role{
+		ObjectMeta: metav1.ObjectMeta{Name: "admin"},
+		Rules: []v1alpha1.PolicyRule{{
+			Verbs:     []string{"*"},
+			Resources: []string{"*"},
+			APIGroups: []string{"*"},
+		}},
+	}, nil)
+	ms.ExpectListClusterRoleBindings([]*v1alpha1.ClusterRoleBinding{}, nil)
+
+	router := gin.New()
+	protected := router.Group("/api/v1/auth", withUser("alice"), RBACMiddleware(controllers.NewRBACController(ms)))
+	protected.POST("/roles", func(c *gin.Context) { c.Status(http.StatusCreated) })
+	protected.POST("/clusterroles", func(c *gin.Context) { c.Status(http.StatusCreated) })
+	protected.POST("/clusterrolebindings", func(c *gin.Context) { c.Status(http.StatusCreated) })
+
+	do := func(path string) int {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, path, nil))
+		return w.Code
+	}
+
+	assert.Equal(t, http.StatusCreated, do("/api/v1/auth/roles"))
+	// namespace의 RoleBinding은 클러스터 범위 리소스에 대한 권한을 부여하지 않음
+	assert.Equal(t, http.StatusForbidden, do("/api/v1/auth/clusterroles"))
+	assert.Equal(t, http.StatusForbidden, do("/api/v1/auth/clusterrolebindings"))
+}
+
+func TestGetResource(t *testing.T) {
+	assert.Equal(t, "users", getResource("/api/v1/auth/users/:name"))
+	assert.Equal(t, "users", getResource("/api/v1/auth/users/:name/login-history"))
+	assert.Equal(t, "roles", getResource("/api/v1/auth/roles"))
+	assert.Equal(t, "rolebindings", getResource("/api/v1/auth/rolebindings/:name"))
+	assert.Equal(t, "", getResource(""))
+}
+
+func TestRequirePermissionForQuery(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ms := mocks.NewMockStore()
+	ms.On("ListRoleBindings", mock.Anything).Return([]*v1alpha1.RoleBinding{}, nil)
+	ms.ExpectListClusterRoleBindings(nil, nil)
+
+	router := gin.New()
+	router.DELETE("/roles/:name",
+		withUser("alice"),
+		RequirePermissionForQuery(controllers.NewRBACController(ms), "force", "delete", "rolebindings", "auth.service"),
+		func(c *gin.Context) { c.Status(http.StatusNoContent) },
+	)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/roles/reader", nil))
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	ms.AssertNotCalled(t, "ListRoleBindings", mock.Anything)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/roles/reader?force=true", nil))
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
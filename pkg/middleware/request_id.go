@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"log"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sukryu/pAuth/pkg/utils/requestid"
+)
+
+// RequestIDHeader carries the correlation ID of a request in both directions
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength bounds client supplied IDs so they cannot flood the logs
+const maxRequestIDLength = 128
+
+// RequestID reads X-Request-ID or generates one, stores it in the request context,
+// echoes it in the response and logs one line per request with it.
+// logger가 nil이면 log.Default() 사용
+func RequestID(logger *log.Logger) gin.HandlerFunc {
+	if logger == nil {
+		logger = log.Default()
+	}
+
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+		if !isValidRequestID(id) {
+			id = newRequestID()
+		}
+
+		c.Set("requestID", id)
+		c.Request = c.Request.WithContext(requestid.WithRequestID(c.Request.Context(), id))
+		c.Header(RequestIDHeader, id)
+
+		start := time.Now()
+		c.Next()
+
+		logger.Printf("request_id=%s method=%s path=%s status=%d duration=%s",
+			id, c.Request.Method, c.Request.URL.Path, c.Writer.Status(), time.Since(start))
+	}
+}
+
+// isValidRequestID accepts short IDs made of printable ASCII without spaces
+func isValidRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		// 난수 생성 실패는 요청을 막을 이유가 아니므로 시간 기반 ID로 대체
+		return hex.EncodeToString([]byte(time.Now().Format(time.RFC3339Nano)))
+	}
+	return hex.EncodeToString(b)
+}
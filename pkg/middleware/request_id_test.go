@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/sukryu/pAuth/pkg/errors"
+	"github.com/sukryu/pAuth/pkg/utils/requestid"
+)
+
+func TestRequestID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var buf bytes.Buffer
+	var seen string
+	router := gin.New()
+	router.Use(RequestID(log.New(&buf, "", 0)), ErrorMiddleware())
+	router.GET("/ok", func(c *gin.Context) {
+		seen = requestid.FromContext(c.Request.Context())
+		c.Status(http.StatusOK)
+	})
+	router.GET("/fail", func(c *gin.Context) {
+		c.Error(errors.ErrForbidden)
+	})
+
+	t.Run("generated", func(t *testing.T) {
+		buf.Reset()
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ok", nil))
+
+		id := w.Header().Get(RequestIDHeader)
+		assert.Len(t, id, 32)
+		assert.Equal(t, id, seen)
+		assert.Contains(t, buf.String(), "request_id="+id+" method=GET path=/ok status=200")
+	})
+
+	t.Run("propagated from client", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/ok", nil)
+		req.Header.Set(RequestIDHeader, "trace-123")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, "trace-123", w.Header().Get(RequestIDHeader))
+	})
+
+	t.Run("invalid client ID is replaced", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/ok", nil)
+		req.Header.Set(RequestIDHeader, "bad id\twith spaces")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Len(t, w.Header().Get(RequestIDHeader), 32)
+	})
+
+	t.Run("included in error response", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/fail", nil))
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		var body struct {
+			Error struct {
+				RequestID string `json:"requestId"`
+			} `json:"error"`
+		}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Equal(t, w.Header().Get(RequestIDHeader), body.Error.RequestID)
+	})
+}
@@ -49,6 +49,35 @@ func (m *MockStore) ListUsers(ctx context.Context) (*v1alpha1.UserList, error) {
 	return nil, args.Error(1)
 }
 
+func (m *MockStore) ListUsersWithOptions(ctx context.Context, opts v1alpha1.ListOptions) (*v1alpha1.UserList, error) {
+	args := m.Called(ctx, opts)
+	if list, ok := args.Get(0).(*v1alpha1.UserList); ok {
+		return list, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockStore) RenameUser(ctx context.Context, oldName, newName string) error {
+	args := m.Called(ctx, oldName, newName)
+	return args.Error(0)
+}
+
+func (m *MockStore) FindUserByEmail(ctx context.Context, email string) (*v1alpha1.User, error) {
+	args := m.Called(ctx, email)
+	if user, ok := args.Get(0).(*v1alpha1.User); ok {
+		return user, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockStore) FindUserByEmailChangeToken(ctx context.Context, tokenHash string) (*v1alpha1.User, error) {
+	args := m.Called(ctx, tokenHash)
+	if user, ok := args.Get(0).(*v1alpha1.User); ok {
+		return user, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
 // Role 관련 메서드
 func (m *MockStore) CreateRole(ctx context.Context, role *v1alpha1.Role) error {
 	args := m.Called(ctx, role)
@@ -81,6 +110,22 @@ func (m *MockStore) ListRoles(ctx context.Context) ([]*v1alpha1.Role, error) {
 	return nil, args.Error(1)
 }
 
+func (m *MockStore) ListRolesPaged(ctx context.Context, limit, offset int) ([]*v1alpha1.Role, int, error) {
+	args := m.Called(ctx, limit, offset)
+	if roles, ok := args.Get(0).([]*v1alpha1.Role); ok {
+		return roles, args.Int(1), args.Error(2)
+	}
+	return nil, args.Int(1), args.Error(2)
+}
+
+func (m *MockStore) ListRolesWithCursor(ctx context.Context, limit int, next string) ([]*v1alpha1.Role, string, error) {
+	args := m.Called(ctx, limit, next)
+	if roles, ok := args.Get(0).([]*v1alpha1.Role); ok {
+		return roles, args.String(1), args.Error(2)
+	}
+	return nil, args.String(1), args.Error(2)
+}
+
 // RoleBinding 관련 메서드
 func (m *MockStore) CreateRoleBinding(ctx context.Context, binding *v1alpha1.RoleBinding) error {
 	args := m.Called(ctx, binding)
@@ -113,6 +158,22 @@ func (m *MockStore) ListRoleBindings(ctx context.Context) ([]*v1alpha1.RoleBindi
 	return nil, args.Error(1)
 }
 
+func (m *MockStore) ListRoleBindingsPaged(ctx context.Context, limit, offset int) ([]*v1alpha1.RoleBinding, int, error) {
+	args := m.Called(ctx, limit, offset)
+	if bindings, ok := args.Get(0).([]*v1alpha1.RoleBinding); ok {
+		return bindings, args.Int(1), args.Error(2)
+	}
+	return nil, args.Int(1), args.Error(2)
+}
+
+func (m *MockStore) ListRoleBindingsWithCursor(ctx context.Context, limit int, next string) ([]*v1alpha1.RoleBinding, string, error) {
+	args := m.Called(ctx, limit, next)
+	if bindings, ok := args.Get(0).([]*v1alpha1.RoleBinding); ok {
+		return bindings, args.String(1), args.Error(2)
+	}
+	return nil, args.String(1), args.Error(2)
+}
+
 // Helper 메서드들
 func (m *MockStore) ExpectCreateUser(user *v1alpha1.User, err error) *mock.Call {
 	return m.On("CreateUser", mock.Anything, user).Return(err)
@@ -130,10 +191,18 @@ func (m *MockStore) ExpectDeleteUser(name string, err error) *mock.Call {
 	return m.On("DeleteUser", mock.Anything, name).Return(err)
 }
 
+func (m *MockStore) ExpectRenameUser(oldName, newName string, err error) *mock.Call {
+	return m.On("RenameUser", mock.Anything, oldName, newName).Return(err)
+}
+
 func (m *MockStore) ExpectListUsers(list *v1alpha1.UserList, err error) *mock.Call {
 	return m.On("ListUsers", mock.Anything).Return(list, err)
 }
 
+func (m *MockStore) ExpectListUsersWithOptions(opts v1alpha1.ListOptions, list *v1alpha1.UserList, err error) *mock.Call {
+	return m.On("ListUsersWithOptions", mock.Anything, opts).Return(list, err)
+}
+
 func (m *MockStore) ExpectCreateRole(role *v1alpha1.Role, err error) *mock.Call {
 	return m.On("CreateRole", mock.Anything, role).Return(err)
 }
@@ -173,3 +242,79 @@ func (m *MockStore) ExpectDeleteRoleBinding(name string, err error) *mock.Call {
 func (m *MockStore) ExpectListRoleBindings(bindings []*v1alpha1.RoleBinding, err error) *mock.Call {
 	return m.On("ListRoleBindings", mock.Anything).Return(bindings, err)
 }
+
+// ClusterRole 관련 메서드
+func (m *MockStore) CreateClusterRole(ctx context.Context, role *v1alpha1.ClusterRole) error {
+	args := m.Called(ctx, role)
+	return args.Error(0)
+}
+
+func (m *MockStore) GetClusterRole(ctx context.Context, name string) (*v1alpha1.ClusterRole, error) {
+	args := m.Called(ctx, name)
+	if role, ok := args.Get(0).(*v1alpha1.ClusterRole); ok {
+		return role, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockStore) DeleteClusterRole(ctx context.Context, name string) error {
+	args := m.Called(ctx, name)
+	return args.Error(0)
+}
+
+func (m *MockStore) ListClusterRoles(ctx context.Context) ([]*v1alpha1.ClusterRole, error) {
+	args := m.Called(ctx)
+	if roles, ok := args.Get(0).([]*v1alpha1.ClusterRole); ok {
+		return roles, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// ClusterRoleBinding 관련 메서드
+func (m *MockStore) CreateClusterRoleBinding(ctx context.Context, binding *v1alpha1.ClusterRoleBinding) error {
+	args := m.Called(ctx, binding)
+	return args.Error(0)
+}
+
+func (m *MockStore) GetClusterRoleBinding(ctx context.Context, name string) (*v1alpha1.ClusterRoleBinding, error) {
+	args := m.Called(ctx, name)
+	if binding, ok := args.Get(0).(*v1alpha1.ClusterRoleBinding); ok {
+		return binding, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockStore) DeleteClusterRoleBinding(ctx context.Context, name string) error {
+	args := m.Called(ctx, name)
+	return args.Error(0)
+}
+
+func (m *MockStore) ListClusterRoleBindings(ctx context.Context) ([]*v1alpha1.ClusterRoleBinding, error) {
+	args := m.Called(ctx)
+	if bindings, ok := args.Get(0).([]*v1alpha1.ClusterRoleBinding); ok {
+		return bindings, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockStore) ExpectGetClusterRole(name string, role *v1alpha1.ClusterRole, err error) *mock.Call {
+	return m.On("GetClusterRole", mock.Anything, name).Return(role, err)
+}
+
+func (m *MockStore) ExpectListClusterRoleBindings(bindings []*v1alpha1.ClusterRoleBinding, err error) *mock.Call {
+	return m.On("ListClusterRoleBindings", mock.Anything).Return(bindings, err)
+}
+
+// ServiceAccount 관련 메서드
+func (m *MockStore) CreateServiceAccount(ctx context.Context, sa *v1alpha1.ServiceAccount) error {
+	args := m.Called(ctx, sa)
+	return args.Error(0)
+}
+
+func (m *MockStore) GetServiceAccount(ctx context.Context, name string) (*v1alpha1.ServiceAccount, error) {
+	args := m.Called(ctx, name)
+	if sa, ok := args.Get(0).(*v1alpha1.ServiceAccount); ok {
+		return sa, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
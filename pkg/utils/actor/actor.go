@@ -0,0 +1,21 @@
+package actor
+
+import "context"
+
+// System is recorded when no authenticated actor is present (bootstrap, migrations, background jobs)
+const System = "system"
+
+type actorKey struct{}
+
+// WithActor returns a context carrying the name of the principal performing the request
+func WithActor(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, actorKey{}, name)
+}
+
+// FromContext returns the actor stored by WithActor, or System when there is none
+func FromContext(ctx context.Context) string {
+	if name, ok := ctx.Value(actorKey{}).(string); ok && name != "" {
+		return name
+	}
+	return System
+}
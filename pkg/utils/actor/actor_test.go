@@ -0,0 +1,14 @@
+package actor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromContext(t *testing.T) {
+	assert.Equal(t, System, FromContext(context.Background()))
+	assert.Equal(t, System, FromContext(WithActor(context.Background(), "")))
+	assert.Equal(t, "alice", FromContext(WithActor(context.Background(), "alice")))
+}
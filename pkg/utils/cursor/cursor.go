@@ -0,0 +1,37 @@
+package cursor
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+// ErrInvalidCursor is returned by Decode for strings that were not produced by Encode
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor marks the last row of a page for keyset pagination.
+// 목록은 primary key 순으로 정렬되며, 다음 페이지는 ID가 이 값보다 큰 행부터 시작하므로
+// 페이지 사이에 행이 추가되어도 누락/중복이 없음
+type Cursor struct {
+	// ID is the primary key of the last row returned
+	ID string `json:"id"`
+}
+
+// Encode returns c as an opaque, URL safe string
+func Encode(c Cursor) string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// Decode parses a string produced by Encode
+func Decode(s string) (Cursor, error) {
+	var c Cursor
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+	if err := json.Unmarshal(data, &c); err != nil || c.ID == "" {
+		return Cursor{}, ErrInvalidCursor
+	}
+	return c, nil
+}
@@ -0,0 +1,23 @@
+package cursor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	for _, c := range []Cursor{{ID: "alice"}, {ID: "user/42"}} {
+		encoded := Encode(c)
+		assert.NotContains(t, encoded, "alice")
+
+		decoded, err := Decode(encoded)
+		assert.NoError(t, err)
+		assert.Equal(t, c, decoded)
+	}
+
+	for _, bad := range []string{"", "not base64!", Encode(Cursor{}), "bnVsbA"} {
+		_, err := Decode(bad)
+		assert.ErrorIs(t, err, ErrInvalidCursor, bad)
+	}
+}
@@ -1,60 +1,489 @@
 package jwt
 
 import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/golang-jwt/jwt/v4"
+	"github.com/sukryu/pAuth/internal/store/cache"
+	"github.com/sukryu/pAuth/pkg/apis/auth/v1alpha1"
+	"github.com/sukryu/pAuth/pkg/utils/clock"
 )
 
+// TokenTypeServiceAccount marks tokens issued to service accounts. 사용자 토큰은 typ이 비어 있음
+const TokenTypeServiceAccount = "service-account"
+
+// TokenTypeRefresh marks refresh tokens, which are only accepted for issuing new access tokens
+const TokenTypeRefresh = "refresh"
+
+// ErrRefreshDisabled is returned when a refresh token is requested but RefreshExpiry is not set
+var ErrRefreshDisabled = errors.New("refresh tokens are disabled")
+
+// ErrTokenRevoked is returned by ValidateToken for tokens whose jti was revoked
+var ErrTokenRevoked = errors.New("token has been revoked")
+
+// ErrReservedClaim is returned when a ClaimsEnricher sets a claim the manager itself issues
+var ErrReservedClaim = errors.New("claim name is reserved")
+
+// ClaimsEnricher returns extra claims to add to a user's access token (e.g. tenant, email).
+// 반환된 키는 registeredClaimNames와 겹칠 수 없음
+type ClaimsEnricher func(user *v1alpha1.User) map[string]interface{}
+
+// registeredClaimNames are the claims set by JWTManager, which Extra cannot override
+var registeredClaimNames = map[string]bool{
+	"iss": true, "sub": true, "aud": true, "exp": true, "nbf": true, "iat": true, "jti": true,
+	"user_id": true, "roles": true, "typ": true, "ns": true,
+}
+
+// revokedKeyPrefix namespaces revoked jti entries in the revocation cache
+const revokedKeyPrefix = "jwt:revoked:"
+
 type Claims struct {
 	UserID string   `json:"user_id"`
 	Roles  []string `json:"roles"`
+	Type   string   `json:"typ,omitempty"`
+
+	// Namespace is the tenant the user belongs to. 비어 있으면 기본 namespace
+	Namespace string `json:"ns,omitempty"`
 	jwt.RegisteredClaims
+
+	// Extra holds the claims added by a ClaimsEnricher. 토큰에는 최상위 클레임으로 기록됨
+	Extra map[string]interface{} `json:"-"`
+}
+
+// claimsJSON has the fields of Claims without its JSON methods
+type claimsJSON Claims
+
+// MarshalJSON writes Extra next to the registered claims
+func (c Claims) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(claimsJSON(c))
+	if err != nil || len(c.Extra) == 0 {
+		return data, err
+	}
+
+	merged := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(data, &merged); err != nil {
+		return nil, err
+	}
+	for name, value := range c.Extra {
+		if registeredClaimNames[name] {
+			return nil, fmt.Errorf("%w: %s", ErrReservedClaim, name)
+		}
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("claim %s: %w", name, err)
+		}
+		merged[name] = raw
+	}
+	return json.Marshal(merged)
+}
+
+// UnmarshalJSON collects the claims that are not registered into Extra
+func (c *Claims) UnmarshalJSON(data []byte) error {
+	var base claimsJSON
+	if err := json.Unmarshal(data, &base); err != nil {
+		return err
+	}
+	var all map[string]interface{}
+	if err := json.Unmarshal(data, &all); err != nil {
+		return err
+	}
+	for name := range registeredClaimNames {
+		delete(all, name)
+	}
+
+	*c = Claims(base)
+	if len(all) > 0 {
+		c.Extra = all
+	}
+	return nil
+}
+
+// SigningKey is a previous HMAC secret that is still accepted for validation after a rotation
+type SigningKey struct {
+	// ID matches the kid header of tokens signed with Secret. 비어 있으면 kid 없는 토큰에만 시도
+	ID     string
+	Secret string
+	// Sunset is when tokens signed with Secret stop validating. zero이면 제한 없음
+	Sunset time.Time
+}
+
+// Config holds the settings used to issue and validate tokens
+type Config struct {
+	SecretKey string
+	Expiry    time.Duration
+
+	// KeyID is written to the kid header of issued tokens. 비어 있으면 kid를 쓰지 않음
+	KeyID string
+	// PreviousKeys are accepted by ValidateToken so rotating SecretKey does not log everyone out
+	PreviousKeys []SigningKey
+
+	// Issuer and Audience are set on issued tokens and, when non-empty, required on validation
+	Issuer   string
+	Audience string
+
+	// Leeway tolerates clock skew between servers when checking exp/nbf/iat
+	Leeway time.Duration
+
+	// Revocations stores revoked token IDs (jti) until the token expires; nil disables revocation
+	Revocations cache.Cache
+
+	// RefreshExpiry is the lifetime of refresh tokens issued at login. 0이면 refresh token 비활성화
+	RefreshExpiry time.Duration
+	// RememberMeExpiry is the refresh token lifetime for "remember me" logins. 0이면 RefreshExpiry
+	RememberMeExpiry time.Duration
+
+	// ClaimsEnricher adds custom claims to tokens issued by GenerateUserToken; nil이면 추가하지 않음
+	ClaimsEnricher ClaimsEnricher
+
+	// Clock is used for issuing and validating time-based claims; nil이면 clock.Real
+	Clock clock.Clock
 }
 
 type JWTManager struct {
 	secretKey string
 	expiry    time.Duration
+	issuer    string
+	audience  string
+	leeway    time.Duration
+
+	revocations cache.Cache
+
+	refreshExpiry    time.Duration
+	rememberMeExpiry time.Duration
+
+	keyID        string
+	previousKeys []SigningKey
+
+	enricher ClaimsEnricher
+
+	clock clock.Clock
 }
 
 func NewJWTManager(secretKey string, expiry time.Duration) *JWTManager {
+	return NewJWTManagerWithConfig(Config{
+		SecretKey: secretKey,
+		Expiry:    expiry,
+	})
+}
+
+func NewJWTManagerWithConfig(cfg Config) *JWTManager {
+	if cfg.Clock == nil {
+		cfg.Clock = clock.Real{}
+	}
+
 	return &JWTManager{
-		secretKey: secretKey,
-		expiry:    expiry,
+		secretKey: cfg.SecretKey,
+		expiry:    cfg.Expiry,
+		issuer:    cfg.Issuer,
+		audience:  cfg.Audience,
+		leeway:    cfg.Leeway,
+
+		revocations: cfg.Revocations,
+
+		refreshExpiry:    cfg.RefreshExpiry,
+		rememberMeExpiry: cfg.RememberMeExpiry,
+
+		keyID:        cfg.KeyID,
+		previousKeys: cfg.PreviousKeys,
+
+		enricher: cfg.ClaimsEnricher,
+
+		clock: cfg.Clock,
 	}
 }
 
 func (m *JWTManager) GenerateToken(userID string, roles []string) (string, error) {
+	return m.GenerateNamespacedToken(userID, "", roles)
+}
+
+// GenerateNamespacedToken issues a user token scoped to the given namespace
+func (m *JWTManager) GenerateNamespacedToken(userID, namespace string, roles []string) (string, error) {
+	claims, err := m.userClaims(userID, namespace, roles)
+	if err != nil {
+		return "", err
+	}
+	return m.sign(claims)
+}
+
+// GenerateUserToken issues a token for user scoped to its namespace, with the claims of the
+// configured ClaimsEnricher. enricher가 예약된 클레임을 반환하면 ErrReservedClaim
+func (m *JWTManager) GenerateUserToken(user *v1alpha1.User) (string, error) {
+	claims, err := m.userClaims(user.Name, user.Namespace, user.Spec.Roles)
+	if err != nil {
+		return "", err
+	}
+
+	if m.enricher != nil {
+		extra := m.enricher(user)
+		for name := range extra {
+			if registeredClaimNames[name] {
+				return "", fmt.Errorf("%w: %s", ErrReservedClaim, name)
+			}
+		}
+		if len(extra) > 0 {
+			claims.Extra = extra
+		}
+	}
+	return m.sign(claims)
+}
+
+// userClaims returns the claims of a user access token
+func (m *JWTManager) userClaims(userID, namespace string, roles []string) (Claims, error) {
+	jti, err := newTokenID()
+	if err != nil {
+		return Claims{}, err
+	}
+
+	now := m.clock.Now()
+	claims := Claims{
+		UserID:    userID,
+		Roles:     roles,
+		Namespace: namespace,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        jti,
+			Issuer:    m.issuer,
+			ExpiresAt: jwt.NewNumericDate(now.Add(m.expiry)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+		},
+	}
+	if m.audience != "" {
+		claims.Audience = jwt.ClaimStrings{m.audience}
+	}
+	return claims, nil
+}
+
+// GenerateServiceAccountToken issues a token for a service account with sub set to name.
+// ttl이 0 이하이면 기본 만료 시간을 사용
+func (m *JWTManager) GenerateServiceAccountToken(name string, ttl time.Duration) (string, error) {
+	if ttl <= 0 {
+		ttl = m.expiry
+	}
+
+	jti, err := newTokenID()
+	if err != nil {
+		return "", err
+	}
+
+	now := m.clock.Now()
+	claims := Claims{
+		Type: TokenTypeServiceAccount,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   name,
+			ID:        jti,
+			Issuer:    m.issuer,
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+		},
+	}
+	if m.audience != "" {
+		claims.Audience = jwt.ClaimStrings{m.audience}
+	}
+
+	return m.sign(claims)
+}
+
+// RefreshEnabled reports whether refresh tokens are issued
+func (m *JWTManager) RefreshEnabled() bool {
+	return m.refreshExpiry > 0
+}
+
+// GenerateRefreshToken issues a refresh token for the user. rememberMe이면 RememberMeExpiry 사용.
+// roles는 담지 않으며, 갱신 시 사용자를 다시 조회해 최신 역할로 access token을 발급함
+func (m *JWTManager) GenerateRefreshToken(userID, namespace string, rememberMe bool) (string, error) {
+	if !m.RefreshEnabled() {
+		return "", ErrRefreshDisabled
+	}
+	ttl := m.refreshExpiry
+	if rememberMe && m.rememberMeExpiry > 0 {
+		ttl = m.rememberMeExpiry
+	}
+
+	jti, err := newTokenID()
+	if err != nil {
+		return "", err
+	}
+
+	now := m.clock.Now()
 	claims := Claims{
-		UserID: userID,
-		Roles:  roles,
+		UserID:    userID,
+		Type:      TokenTypeRefresh,
+		Namespace: namespace,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(m.expiry)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-			NotBefore: jwt.NewNumericDate(time.Now()),
+			ID:        jti,
+			Issuer:    m.issuer,
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
 		},
 	}
+	if m.audience != "" {
+		claims.Audience = jwt.ClaimStrings{m.audience}
+	}
 
+	return m.sign(claims)
+}
+
+// ValidateRefreshToken validates tokenStr and requires it to be a refresh token
+func (m *JWTManager) ValidateRefreshToken(tokenStr string) (*Claims, error) {
+	claims, err := m.ValidateToken(tokenStr)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Type != TokenTypeRefresh {
+		return nil, fmt.Errorf("not a refresh token")
+	}
+	return claims, nil
+}
+
+// sign signs claims with the primary secret, setting kid when KeyID is configured
+func (m *JWTManager) sign(claims Claims) (string, error) {
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	if m.keyID != "" {
+		token.Header["kid"] = m.keyID
+	}
 	return token.SignedString([]byte(m.secretKey))
 }
 
+// verificationKeys returns the secrets a token with the given kid may be signed with, primary first.
+// kid가 있으면 같은 ID의 키만, 없으면 primary와 ID 없는 이전 키를 시도. KeyID 미설정 시 primary는 항상 시도.
+// sunset이 지난 키는 제외
+func (m *JWTManager) verificationKeys(kid string) [][]byte {
+	var keys [][]byte
+	if kid == "" || m.keyID == "" || kid == m.keyID {
+		keys = append(keys, []byte(m.secretKey))
+	}
+	now := m.clock.Now()
+	for _, key := range m.previousKeys {
+		if key.ID != kid || (!key.Sunset.IsZero() && now.After(key.Sunset)) {
+			continue
+		}
+		keys = append(keys, []byte(key.Secret))
+	}
+	return keys
+}
+
 func (m *JWTManager) ValidateToken(tokenStr string) (*Claims, error) {
-	token, err := jwt.ParseWithClaims(tokenStr, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	// 시간 관련 클레임은 leeway를 적용하기 위해 직접 검증
+	parser := jwt.NewParser(jwt.WithoutClaimsValidation())
+
+	// kid로 후보 키를 고른 뒤 primary부터 차례로 서명 검증
+	var kid string
+	if unverified, _, err := parser.ParseUnverified(tokenStr, &Claims{}); err == nil {
+		kid, _ = unverified.Header["kid"].(string)
+	}
+	keys := m.verificationKeys(kid)
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("unknown signing key: %s", kid)
+	}
+
+	var (
+		token *jwt.Token
+		err   error
+	)
+	for _, key := range keys {
+		token, err = parser.ParseWithClaims(tokenStr, &Claims{}, func(token *jwt.Token) (interface{}, error) {
+			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+			}
+			return key, nil
+		})
+		if !errors.Is(err, jwt.ErrSignatureInvalid) {
+			break
 		}
-		return []byte(m.secretKey), nil
-	})
+	}
 
 	if err != nil {
 		return nil, err
 	}
 
-	if claims, ok := token.Claims.(*Claims); ok && token.Valid {
-		return claims, nil
+	claims, ok := token.Claims.(*Claims)
+	if !ok || !token.Valid {
+		return nil, fmt.Errorf("invalid token")
+	}
+
+	if err := m.validateClaims(claims); err != nil {
+		return nil, err
+	}
+
+	revoked, err := m.IsRevoked(context.Background(), claims.ID)
+	if err != nil {
+		return nil, err
+	}
+	if revoked {
+		return nil, ErrTokenRevoked
+	}
+
+	return claims, nil
+}
+
+// Revoke marks the token identified by claims.ID as revoked until it expires
+func (m *JWTManager) Revoke(ctx context.Context, claims *Claims) error {
+	if m.revocations == nil {
+		return fmt.Errorf("token revocation is not configured")
+	}
+	if claims.ID == "" {
+		return fmt.Errorf("token has no jti")
+	}
+
+	// 만료된 토큰은 어차피 거부되므로 만료 시각까지만 보관 (leeway 포함)
+	var ttl time.Duration
+	if claims.ExpiresAt != nil {
+		ttl = claims.ExpiresAt.Sub(m.clock.Now()) + m.leeway
+		if ttl <= 0 {
+			return nil
+		}
+	}
+	return m.revocations.Set(ctx, revokedKeyPrefix+claims.ID, "1", ttl)
+}
+
+// IsRevoked reports whether jti was revoked. 캐시 조회 실패 시 에러를 반환하여 fail-closed로 처리
+func (m *JWTManager) IsRevoked(ctx context.Context, jti string) (bool, error) {
+	if m.revocations == nil || jti == "" {
+		return false, nil
+	}
+	_, found, err := m.revocations.Get(ctx, revokedKeyPrefix+jti)
+	if err != nil {
+		return false, fmt.Errorf("failed to check token revocation: %w", err)
+	}
+	return found, nil
+}
+
+// newTokenID returns a random token ID used as the jti claim
+func newTokenID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate token id: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// validateClaims checks time-based claims with leeway and the configured issuer/audience
+func (m *JWTManager) validateClaims(claims *Claims) error {
+	now := m.clock.Now()
+
+	if !claims.VerifyExpiresAt(now.Add(-m.leeway), false) {
+		return jwt.ErrTokenExpired
+	}
+	if !claims.VerifyIssuedAt(now.Add(m.leeway), false) {
+		return jwt.ErrTokenUsedBeforeIssued
+	}
+	if !claims.VerifyNotBefore(now.Add(m.leeway), false) {
+		return jwt.ErrTokenNotValidYet
+	}
+	if m.issuer != "" && !claims.VerifyIssuer(m.issuer, true) {
+		return jwt.ErrTokenInvalidIssuer
+	}
+	if m.audience != "" && !claims.VerifyAudience(m.audience, true) {
+		return jwt.ErrTokenInvalidAudience
 	}
 
-	return nil, fmt.Errorf("invalid token")
+	return nil
 }
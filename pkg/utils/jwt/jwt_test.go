@@ -1,10 +1,16 @@
 package jwt
 
 import (
+	"context"
 	"testing"
 	"time"
 
+	"github.com/golang-jwt/jwt/v4"
 	"github.com/stretchr/testify/assert"
+	"github.com/sukryu/pAuth/internal/store/cache"
+	"github.com/sukryu/pAuth/pkg/apis/auth/v1alpha1"
+	"github.com/sukryu/pAuth/pkg/utils/clock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestJWTManager(t *testing.T) {
@@ -38,14 +44,17 @@ func TestJWTManager(t *testing.T) {
 	})
 
 	t.Run("Expired Token", func(t *testing.T) {
-		// Create manager with very short expiry
-		shortManager := NewJWTManager(secretKey, 1*time.Nanosecond)
+		fakeClock := clock.NewFake(time.Now())
+		shortManager := NewJWTManagerWithConfig(Config{
+			SecretKey: secretKey,
+			Expiry:    time.Minute,
+			Clock:     fakeClock,
+		})
 
 		token, err := shortManager.GenerateToken("user", []string{"role"})
 		assert.NoError(t, err)
 
-		// Wait for token to expire
-		time.Sleep(1 * time.Millisecond)
+		fakeClock.Advance(time.Minute + time.Second)
 
 		claims, err := shortManager.ValidateToken(token)
 		assert.Error(t, err)
@@ -104,6 +113,92 @@ func TestJWTManager(t *testing.T) {
 	})
 }
 
+func TestJWTManager_IssuerAudience(t *testing.T) {
+	cfg := Config{
+		SecretKey: "test-secret-key",
+		Expiry:    time.Hour,
+		Issuer:    "pauth",
+		Audience:  "api.example.com",
+	}
+	manager := NewJWTManagerWithConfig(cfg)
+
+	t.Run("Matching issuer and audience", func(t *testing.T) {
+		token, err := manager.GenerateToken("user", nil)
+		assert.NoError(t, err)
+
+		claims, err := manager.ValidateToken(token)
+		assert.NoError(t, err)
+		assert.Equal(t, "pauth", claims.Issuer)
+		assert.Contains(t, claims.Audience, "api.example.com")
+	})
+
+	t.Run("Wrong audience is rejected", func(t *testing.T) {
+		otherCfg := cfg
+		otherCfg.Audience = "other.example.com"
+		token, err := NewJWTManagerWithConfig(otherCfg).GenerateToken("user", nil)
+		assert.NoError(t, err)
+
+		claims, err := manager.ValidateToken(token)
+		assert.Error(t, err)
+		assert.Nil(t, claims)
+		assert.Contains(t, err.Error(), "invalid audience")
+	})
+
+	t.Run("Wrong issuer is rejected", func(t *testing.T) {
+		otherCfg := cfg
+		otherCfg.Issuer = "someone-else"
+		token, err := NewJWTManagerWithConfig(otherCfg).GenerateToken("user", nil)
+		assert.NoError(t, err)
+
+		_, err = manager.ValidateToken(token)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid issuer")
+	})
+
+	t.Run("Unconfigured manager accepts any issuer and audience", func(t *testing.T) {
+		token, err := manager.GenerateToken("user", nil)
+		assert.NoError(t, err)
+
+		_, err = NewJWTManager(cfg.SecretKey, time.Hour).ValidateToken(token)
+		assert.NoError(t, err)
+	})
+}
+
+func TestJWTManager_Leeway(t *testing.T) {
+	t.Run("Expired token within leeway is accepted", func(t *testing.T) {
+		fakeClock := clock.NewFake(time.Now())
+		manager := NewJWTManagerWithConfig(Config{
+			SecretKey: "test-secret-key",
+			Expiry:    time.Second,
+			Leeway:    time.Minute,
+			Clock:     fakeClock,
+		})
+
+		token, err := manager.GenerateToken("user", nil)
+		assert.NoError(t, err)
+		fakeClock.Advance(30 * time.Second)
+
+		claims, err := manager.ValidateToken(token)
+		assert.NoError(t, err)
+		assert.Equal(t, "user", claims.UserID)
+	})
+
+	t.Run("Expired token beyond leeway is rejected", func(t *testing.T) {
+		manager := NewJWTManagerWithConfig(Config{
+			SecretKey: "test-secret-key",
+			Expiry:    -2 * time.Minute,
+			Leeway:    time.Minute,
+		})
+
+		token, err := manager.GenerateToken("user", nil)
+		assert.NoError(t, err)
+
+		_, err = manager.ValidateToken(token)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "token is expired")
+	})
+}
+
 func TestClaimsType(t *testing.T) {
 	claims := &Claims{
 		UserID: "test-user",
@@ -115,3 +210,200 @@ func TestClaimsType(t *testing.T) {
 		assert.Equal(t, []string{"admin"}, claims.Roles)
 	})
 }
+
+func TestJWTManager_GenerateNamespacedToken(t *testing.T) {
+	manager := NewJWTManager("test-secret", time.Hour)
+
+	token, err := manager.GenerateNamespacedToken("alice", "tenant-a", []string{"reader"})
+	assert.NoError(t, err)
+
+	claims, err := manager.ValidateToken(token)
+	assert.NoError(t, err)
+	assert.Equal(t, "alice", claims.UserID)
+	assert.Equal(t, "tenant-a", claims.Namespace)
+
+	// 기존 토큰은 namespace 클레임이 없음
+	token, err = manager.GenerateToken("alice", nil)
+	assert.NoError(t, err)
+	claims, err = manager.ValidateToken(token)
+	assert.NoError(t, err)
+	assert.Empty(t, claims.Namespace)
+}
+
+func TestJWTManager_Revoke(t *testing.T) {
+	manager := NewJWTManagerWithConfig(Config{SecretKey: "secret", Expiry: time.Hour, Revocations: cache.NewMemoryCache()})
+	ctx := context.Background()
+
+	token, err := manager.GenerateToken("alice", nil)
+	assert.NoError(t, err)
+	other, err := manager.GenerateToken("alice", nil)
+	assert.NoError(t, err)
+
+	claims, err := manager.ValidateToken(token)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, claims.ID)
+
+	assert.NoError(t, manager.Revoke(ctx, claims))
+	_, err = manager.ValidateToken(token)
+	assert.ErrorIs(t, err, ErrTokenRevoked)
+
+	// 같은 사용자의 다른 토큰은 영향 없음
+	_, err = manager.ValidateToken(other)
+	assert.NoError(t, err)
+
+	// 폐기 저장소가 없으면 Revoke는 실패
+	assert.Error(t, NewJWTManager("secret", time.Hour).Revoke(ctx, claims))
+}
+
+func TestJWTManager_RefreshToken(t *testing.T) {
+	manager := NewJWTManagerWithConfig(Config{
+		SecretKey:        "secret",
+		Expiry:           15 * time.Minute,
+		RefreshExpiry:    24 * time.Hour,
+		RememberMeExpiry: 30 * 24 * time.Hour,
+	})
+	assert.True(t, manager.RefreshEnabled())
+
+	ttl := func(token string) time.Duration {
+		claims, err := manager.ValidateRefreshToken(token)
+		if !assert.NoError(t, err) {
+			return 0
+		}
+		assert.Equal(t, TokenTypeRefresh, claims.Type)
+		assert.Equal(t, "alice", claims.UserID)
+		assert.Equal(t, "tenant-a", claims.Namespace)
+		return claims.ExpiresAt.Sub(claims.IssuedAt.Time)
+	}
+
+	standard, err := manager.GenerateRefreshToken("alice", "tenant-a", false)
+	assert.NoError(t, err)
+	assert.Equal(t, 24*time.Hour, ttl(standard))
+
+	remembered, err := manager.GenerateRefreshToken("alice", "tenant-a", true)
+	assert.NoError(t, err)
+	assert.Equal(t, 30*24*time.Hour, ttl(remembered))
+
+	// access token은 refresh token으로 사용할 수 없음
+	access, err := manager.GenerateToken("alice", nil)
+	assert.NoError(t, err)
+	_, err = manager.ValidateRefreshToken(access)
+	assert.Error(t, err)
+
+	disabled := NewJWTManager("secret", time.Hour)
+	assert.False(t, disabled.RefreshEnabled())
+	_, err = disabled.GenerateRefreshToken("alice", "", true)
+	assert.ErrorIs(t, err, ErrRefreshDisabled)
+}
+
+func TestJWTManager_SecretRotation(t *testing.T) {
+	oldWithKid := NewJWTManagerWithConfig(Config{SecretKey: "old-secret", KeyID: "2023", Expiry: time.Hour})
+	oldWithoutKid := NewJWTManager("old-secret", time.Hour)
+	unknown := NewJWTManager("unknown-secret", time.Hour)
+
+	rotated := func(sunset time.Time) *JWTManager {
+		return NewJWTManagerWithConfig(Config{
+			SecretKey: "new-secret",
+			KeyID:     "2024",
+			Expiry:    time.Hour,
+			PreviousKeys: []SigningKey{
+				{ID: "2023", Secret: "old-secret", Sunset: sunset},
+				{Secret: "old-secret", Sunset: sunset},
+			},
+		})
+	}
+	manager := rotated(time.Now().Add(time.Hour))
+
+	// 새 토큰은 primary로 서명되고 kid가 설정됨
+	token, err := manager.GenerateToken("alice", nil)
+	assert.NoError(t, err)
+	_, err = NewJWTManager("new-secret", time.Hour).ValidateToken(token)
+	assert.NoError(t, err)
+	_, err = manager.ValidateToken(token)
+	assert.NoError(t, err)
+
+	// overlap 기간에는 이전 secret으로 서명된 토큰도 유효 (kid 유무와 무관)
+	for _, old := range []*JWTManager{oldWithKid, oldWithoutKid} {
+		token, err := old.GenerateToken("bob", nil)
+		assert.NoError(t, err)
+		claims, err := manager.ValidateToken(token)
+		if assert.NoError(t, err) {
+			assert.Equal(t, "bob", claims.UserID)
+		}
+	}
+
+	// 알 수 없는 secret이나 kid는 거부
+	token, err = unknown.GenerateToken("mallory", nil)
+	assert.NoError(t, err)
+	_, err = manager.ValidateToken(token)
+	assert.Error(t, err)
+
+	token, err = NewJWTManagerWithConfig(Config{SecretKey: "old-secret", KeyID: "2022", Expiry: time.Hour}).GenerateToken("bob", nil)
+	assert.NoError(t, err)
+	_, err = manager.ValidateToken(token)
+	assert.Error(t, err)
+
+	// sunset이 지나면 이전 secret은 더 이상 허용되지 않음
+	token, err = oldWithKid.GenerateToken("bob", nil)
+	assert.NoError(t, err)
+	_, err = rotated(time.Now().Add(-time.Minute)).ValidateToken(token)
+	assert.Error(t, err)
+}
+
+func TestJWTManager_ClaimsEnricher(t *testing.T) {
+	user := &v1alpha1.User{
+		ObjectMeta: metav1.ObjectMeta{Name: "alice", Namespace: "acme"},
+		Spec:       v1alpha1.UserSpec{Email: "alice@example.com", Roles: []string{"reader"}},
+	}
+
+	t.Run("extra claims are issued and validated", func(t *testing.T) {
+		manager := NewJWTManagerWithConfig(Config{
+			SecretKey: "test-secret-key",
+			Expiry:    time.Hour,
+			ClaimsEnricher: func(u *v1alpha1.User) map[string]interface{} {
+				return map[string]interface{}{"tenant": u.Namespace, "email": u.Spec.Email}
+			},
+		})
+
+		token, err := manager.GenerateUserToken(user)
+		assert.NoError(t, err)
+
+		// 확장 클레임은 최상위 클레임으로 기록됨
+		raw := jwt.MapClaims{}
+		_, _, err = jwt.NewParser().ParseUnverified(token, raw)
+		assert.NoError(t, err)
+		assert.Equal(t, "acme", raw["tenant"])
+		assert.Equal(t, "alice", raw["user_id"])
+
+		claims, err := manager.ValidateToken(token)
+		assert.NoError(t, err)
+		assert.Equal(t, "alice", claims.UserID)
+		assert.Equal(t, "acme", claims.Namespace)
+		assert.Equal(t, []string{"reader"}, claims.Roles)
+		assert.Equal(t, map[string]interface{}{"tenant": "acme", "email": "alice@example.com"}, claims.Extra)
+	})
+
+	t.Run("reserved claims are rejected", func(t *testing.T) {
+		for _, name := range []string{"roles", "exp", "sub", "ns"} {
+			manager := NewJWTManagerWithConfig(Config{
+				SecretKey: "test-secret-key",
+				Expiry:    time.Hour,
+				ClaimsEnricher: func(*v1alpha1.User) map[string]interface{} {
+					return map[string]interface{}{name: "admin"}
+				},
+			})
+			_, err := manager.GenerateUserToken(user)
+			assert.ErrorIs(t, err, ErrReservedClaim, name)
+		}
+	})
+
+	t.Run("without enricher", func(t *testing.T) {
+		manager := NewJWTManager("test-secret-key", time.Hour)
+		token, err := manager.GenerateUserToken(user)
+		assert.NoError(t, err)
+
+		claims, err := manager.ValidateToken(token)
+		assert.NoError(t, err)
+		assert.Equal(t, "alice", claims.UserID)
+		assert.Nil(t, claims.Extra)
+	})
+}
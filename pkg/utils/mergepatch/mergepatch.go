@@ -0,0 +1,47 @@
+package mergepatch
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ContentType is the media type of an RFC 7386 JSON Merge Patch
+const ContentType = "application/merge-patch+json"
+
+// Apply applies the RFC 7386 JSON Merge Patch patch to the JSON document original.
+// 객체는 재귀적으로 병합되고, null은 해당 필드를 제거하며, 그 외 값(배열 포함)은 통째로 교체됨
+func Apply(original, patch []byte) ([]byte, error) {
+	var patchValue interface{}
+	if err := json.Unmarshal(patch, &patchValue); err != nil {
+		return nil, fmt.Errorf("invalid merge patch: %w", err)
+	}
+
+	var originalValue interface{}
+	if len(original) > 0 {
+		if err := json.Unmarshal(original, &originalValue); err != nil {
+			return nil, fmt.Errorf("invalid original document: %w", err)
+		}
+	}
+
+	return json.Marshal(merge(originalValue, patchValue))
+}
+
+func merge(target, patch interface{}) interface{} {
+	patchObj, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	targetObj, ok := target.(map[string]interface{})
+	if !ok {
+		targetObj = make(map[string]interface{})
+	}
+	for key, value := range patchObj {
+		if value == nil {
+			delete(targetObj, key)
+			continue
+		}
+		targetObj[key] = merge(targetObj[key], value)
+	}
+	return targetObj
+}
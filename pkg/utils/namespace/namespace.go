@@ -0,0 +1,48 @@
+package namespace
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sukryu/pAuth/pkg/errors"
+)
+
+// Default is the namespace used when a request or object does not specify one
+const Default = "default"
+
+type namespaceKey struct{}
+
+// WithNamespace returns a context scoped to the given namespace
+func WithNamespace(ctx context.Context, ns string) context.Context {
+	return context.WithValue(ctx, namespaceKey{}, ns)
+}
+
+// Lookup returns the namespace set by WithNamespace and whether one was set
+func Lookup(ctx context.Context) (string, bool) {
+	ns, ok := ctx.Value(namespaceKey{}).(string)
+	if !ok || ns == "" {
+		return "", false
+	}
+	return ns, true
+}
+
+// FromContext returns the namespace of ctx, or Default when none was set
+func FromContext(ctx context.Context) string {
+	if ns, ok := Lookup(ctx); ok {
+		return ns
+	}
+	return Default
+}
+
+// Resolve returns the namespace an object should be stored in.
+// 객체에 namespace가 없으면 요청의 namespace를 사용하고, 요청이 namespace로 한정된 경우
+// 다른 namespace의 객체는 생성할 수 없음 (테넌트 격리).
+func Resolve(ctx context.Context, objectNamespace string) (string, error) {
+	if objectNamespace == "" {
+		return FromContext(ctx), nil
+	}
+	if ns, ok := Lookup(ctx); ok && ns != objectNamespace {
+		return "", errors.ErrForbidden.WithReason(fmt.Sprintf("cannot access namespace %q from namespace %q", objectNamespace, ns))
+	}
+	return objectNamespace, nil
+}
@@ -0,0 +1,39 @@
+package namespace
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromContext(t *testing.T) {
+	assert.Equal(t, Default, FromContext(context.Background()))
+
+	_, ok := Lookup(context.Background())
+	assert.False(t, ok)
+
+	ctx := WithNamespace(context.Background(), "tenant-a")
+	assert.Equal(t, "tenant-a", FromContext(ctx))
+	ns, ok := Lookup(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, "tenant-a", ns)
+}
+
+func TestResolve(t *testing.T) {
+	ns, err := Resolve(context.Background(), "")
+	assert.NoError(t, err)
+	assert.Equal(t, Default, ns)
+
+	ns, err = Resolve(context.Background(), "tenant-b")
+	assert.NoError(t, err)
+	assert.Equal(t, "tenant-b", ns)
+
+	ctx := WithNamespace(context.Background(), "tenant-a")
+	ns, err = Resolve(ctx, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "tenant-a", ns)
+
+	_, err = Resolve(ctx, "tenant-b")
+	assert.Error(t, err)
+}
@@ -0,0 +1,16 @@
+package requestid
+
+import "context"
+
+type requestIDKey struct{}
+
+// WithRequestID returns a context carrying the correlation ID of the current request
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// FromContext returns the ID stored by WithRequestID, or "" outside of a request
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}